@@ -4,21 +4,38 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
+	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
 
+	"github.com/maxime/k8s-tui/internal/config"
 	"github.com/maxime/k8s-tui/internal/k8s"
 	"github.com/maxime/k8s-tui/internal/model"
 	"github.com/maxime/k8s-tui/internal/ui"
 )
 
-// Log streaming message types
+// Log streaming message types carry the id of the stream they came from
+// so messages from a replaced stream are dropped
 type logLineMsg struct {
+	id   int
 	line k8s.LogLine
 }
 
@@ -27,16 +44,56 @@ type logStreamStartedMsg struct {
 }
 
 type logStreamErrorMsg struct {
+	id  int
 	err error
 }
 
-type logStreamEndedMsg struct{}
+type logStreamEndedMsg struct {
+	id int
+}
+
+// logReconnectMsg reopens a followed stream that ended on its own
+type logReconnectMsg struct {
+	id int
+}
+
+// splitLogsLoadedMsg carries the previous instance's logs for the split
+// pane; key is the logCacheKey of the logs they were loaded next to
+type splitLogsLoadedMsg struct {
+	key   string
+	lines []string
+	err   error
+}
 
 // Exec message types
+// Exec messages carry the id of the run they belong to so output that
+// arrives after the command was interrupted is dropped
+
+// execStreamMsg carries the output channel of a command that started
+type execStreamMsg struct {
+	id     int
+	chunks <-chan k8s.ExecChunk
+}
+
+// execOutputMsg carries a line of output from a running command
+type execOutputMsg struct {
+	id    int
+	chunk k8s.ExecChunk
+}
+
+// execResultMsg reports how a command ended; its output was already
+// streamed
 type execResultMsg struct {
+	id     int
 	result k8s.ExecResult
 }
 
+type debugContainerMsg struct {
+	id        int
+	container string
+	err       error
+}
+
 // File browser message types
 type dirLoadedMsg struct {
 	entries []k8s.FileInfo
@@ -44,28 +101,279 @@ type dirLoadedMsg struct {
 	err     error
 }
 
+// gotoPathCheckedMsg reports whether a go-to-path target is a directory
+type gotoPathCheckedMsg struct {
+	path string
+	err  error
+}
+
+// pathCompletionMsg carries the listing used to tab-complete input in the
+// go-to-path prompt
+type pathCompletionMsg struct {
+	input   string
+	dir     string
+	prefix  string
+	entries []k8s.FileInfo
+	err     error
+}
+
+// fileContentMsg carries a chunk of a viewed file; the chunk at offset 0
+// opens it
 type fileContentMsg struct {
+	chunk    k8s.FileChunk
+	offset   int64
+	filename string
+	err      error
+}
+
+// fileEditLoadedMsg carries the full contents of a file to be edited
+type fileEditLoadedMsg struct {
+	path     string
+	filename string
 	content  string
+	err      error
+}
+
+// fileEditedMsg reports that the editor exited, leaving its result in tmpPath
+type fileEditedMsg struct {
+	path     string
+	filename string
+	tmpPath  string
+	original string
+	err      error
+}
+
+// pagerClosedMsg reports that the pager showing the log buffer exited
+type pagerClosedMsg struct {
+	tmpPath string
+	err     error
+}
+
+// fileWrittenMsg reports the result of writing an edited file back
+type fileWrittenMsg struct {
+	path     string
 	filename string
 	err      error
 }
 
+// bookmarksSavedMsg reports the result of saving the file browser bookmarks
+type bookmarksSavedMsg struct {
+	err error
+}
+
+// YAML view message types
+type podYAMLMsg struct {
+	content string
+	err     error
+}
+
+// containerRef names a container of a pod
+type containerRef struct {
+	namespace, pod, container string
+}
+
+// String formats the container as namespace/pod/container
+func (r containerRef) String() string {
+	return r.namespace + "/" + r.pod + "/" + r.container
+}
+
+// envLoadedMsg carries a container's environment. declared tells which
+// one was loaded, requested which one was asked for, so a fallback can be
+// told apart from a result for a replaced mode.
+type envLoadedMsg struct {
+	target    containerRef
+	requested bool
+	declared  bool
+	vars      []k8s.EnvVar
+	err       error
+}
+
+// eventsLoadedMsg carries events for the events view; all marks a
+// namespace-wide listing so results for a replaced mode are dropped
+type eventsLoadedMsg struct {
+	all    bool
+	events []k8s.EventInfo
+	err    error
+}
+
+// dataItemsLoadedMsg carries ConfigMaps or Secrets for the data browser;
+// kind drops results for a browser that was reopened on the other kind
+type dataItemsLoadedMsg struct {
+	kind  string
+	items []ui.DataItem
+	err   error
+}
+
 // Messages for async operations
 type k8sClientReadyMsg struct {
 	client *k8s.Client
 	err    error
 }
 
+// podsLoadedMsg carries the pods listed by the load with the given id, so a
+// list started before a namespace or context switch is dropped
 type podsLoadedMsg struct {
-	pods []k8s.PodInfo
-	err  error
+	id            int
+	pods          []k8s.PodInfo
+	allNamespaces bool // Listed across all namespaces
+	err           error
 }
 
+// namespacesLoadedMsg carries the namespaces listed by the load with the
+// given id, so a list from the cluster before a context switch is dropped
 type namespacesLoadedMsg struct {
+	id         int
 	namespaces []k8s.NamespaceInfo
 	err        error
 }
 
+// namespaceSummaryMsg carries the pod count and quotas of the namespace at
+// index, from the summary run with the given id
+type namespaceSummaryMsg struct {
+	id      int
+	index   int
+	summary k8s.NamespaceSummary
+	err     error
+}
+
+// workloadRestartedMsg reports a rolling restart started from the pod list
+type workloadRestartedMsg struct {
+	status string
+	err    error
+}
+
+// Deployment message types
+type deploymentsLoadedMsg struct {
+	deployments []k8s.DeploymentInfo
+	err         error
+}
+
+// topMetricsLoadedMsg carries pod usage for the top view; id guards results
+// of an earlier visit
+type topMetricsLoadedMsg struct {
+	id      int
+	metrics []k8s.PodMetrics
+	err     error
+}
+
+// topTickMsg refreshes the top view; id guards ticks of an earlier visit
+type topTickMsg struct {
+	id int
+}
+
+// servicesLoadedMsg carries the services of the current namespace
+type servicesLoadedMsg struct {
+	services []k8s.ServiceInfo
+	err      error
+}
+
+// deploymentActionMsg reports the result of scaling or restarting a deployment
+type deploymentActionMsg struct {
+	status string
+	err    error
+}
+
+// contextSwitchedMsg carries a client built for another context; id drops
+// the result of a switch superseded by a newer one
+type contextSwitchedMsg struct {
+	id      int
+	name    string
+	client  *k8s.Client
+	persist bool
+	err     error
+}
+
+// contextSwitchRequestedMsg asks for a context switch once the question
+// whether to make it the kubeconfig default is answered
+type contextSwitchRequestedMsg struct {
+	name    string
+	persist bool
+}
+
+// contextPersistedMsg reports the result of writing the current context
+// back to the kubeconfig
+type contextPersistedMsg struct {
+	name string
+	err  error
+}
+
+// clipboardCopiedMsg reports the result of copying to the clipboard
+type clipboardCopiedMsg struct {
+	what string
+	err  error
+}
+
+// bundleSavedMsg reports the result of writing a troubleshooting bundle
+type bundleSavedMsg struct {
+	path string
+	err  error
+}
+
+// configReloadedMsg carries a client rebuilt from a freshly read kubeconfig
+type configReloadedMsg struct {
+	client *k8s.Client
+	err    error
+}
+
+// Retry message types carry the attempt they were scheduled for so that a
+// retry superseded by a newer attempt or a successful load is dropped
+type retryPodsMsg struct {
+	attempt int
+}
+
+type retryNamespacesMsg struct {
+	attempt int
+}
+
+// clearStatusMsg clears the status message unless a newer one replaced it
+type clearStatusMsg struct {
+	id int
+}
+
+// restartFlashMsg clears restart markers that have been shown long enough
+type restartFlashMsg struct{}
+
+// gotoTimeoutMsg ends jump-to-pod mode unless a newer keystroke restarted it
+type gotoTimeoutMsg struct {
+	id int
+}
+
+// Pod watch message types carry the watch ID so that messages from a
+// replaced watch are dropped
+type podWatchStartedMsg struct {
+	id     int
+	events <-chan k8s.PodEvent
+	err    error
+}
+
+type podEventMsg struct {
+	id     int
+	events <-chan k8s.PodEvent
+	event  k8s.PodEvent
+}
+
+type podWatchEndedMsg struct {
+	id  int
+	err error
+}
+
+// podWatchResyncMsg relists pods after a watch ended
+type podWatchResyncMsg struct {
+	id int
+}
+
+// Connection check message types carry the check ID so that checks of a
+// replaced client are dropped
+type pingTickMsg struct {
+	id int
+}
+
+type pingResultMsg struct {
+	id      int
+	latency time.Duration
+	err     error
+}
+
 type contextsLoadedMsg struct {
 	contexts       []k8s.ContextInfo
 	currentContext string
@@ -78,12 +386,22 @@ type Model struct {
 	view     model.ViewState
 	prevView model.ViewState // For returning from overlays
 
+	// Startup options, kept for clients built before any context switch
+	opts Options
+
 	// Keybindings
 	keys ui.KeyMap
 
+	// User configuration
+	config config.Config
+
+	// Styling
+	styles ui.Styles
+
 	// Help component
-	help     help.Model
-	showHelp bool
+	help           help.Model
+	showHelp       bool
+	helpReturnView model.ViewState // View the help overlay was opened from
 
 	// Window dimensions
 	width  int
@@ -97,19 +415,87 @@ type Model struct {
 	k8sErr    error
 
 	// Data
-	pods       []k8s.PodInfo
-	namespaces []k8s.NamespaceInfo
-	contexts   []k8s.ContextInfo
+	pods        []k8s.PodInfo
+	podsID      int       // Bumped when the namespace or context changes under a pod list
+	lastRefresh time.Time // When pods were last listed; zero before the first list
+	namespaces  []k8s.NamespaceInfo
+	contexts    []k8s.ContextInfo
+
+	// Pods as last seen, to tell restarts and failures, and when a pod was
+	// last seen restarting so its row can be marked for a while
+	seenPods    map[string]k8s.PodInfo
+	restartedAt map[string]time.Time
+
+	// Receives the terminal bell. BEL doesn't move the cursor, so it can be
+	// written alongside the renderer's output.
+	bellOut io.Writer
+
+	// Context being switched to in the background, empty when idle
+	switchingContext string
+	contextSwitchID  int
+
+	// Deployment list state
+	deployments    []k8s.DeploymentInfo
+	deploymentsErr error
+	scaleTarget    k8s.DeploymentInfo // Deployment the replica prompt applies to
+
+	// Top view state: pod usage refreshed every topRefreshInterval while open
+	topMetrics       []k8s.PodMetrics
+	topErr           error
+	topSortMemory    bool // Sort by memory instead of CPU
+	topID            int
+	selectedTopIndex int
+
+	// Service list state
+	services    []k8s.ServiceInfo
+	servicesErr error
 
 	// Loading states
-	loadingK8s        bool
-	loadingPods       bool
-	loadingNamespaces bool
+	loadingK8s         bool
+	loadingPods        bool
+	loadingNamespaces  bool
+	loadingDeployments bool
+	loadingTop         bool
+	loadingServices    bool
+
+	// Guards namespace lists, which may still be loading from the old
+	// cluster after a context switch. namespacesErr is kept apart from
+	// k8sErr so the pod list and the selector each name their own failure.
+	namespacesID  int
+	namespacesErr error
+
+	// Guards namespace summaries, which load after the selector opens
+	namespaceSummaryID int
+
+	// Filters
+	hideCompleted bool
+	allNamespaces bool            // List pods across all namespaces
+	nodeFilter    string          // Only list pods on this node when set
+	serviceFilter k8s.ServiceInfo // Only list pods backing this service when Name is set
+	labelSelector string          // Server-side label selector for the pod list and watch
+
+	// Pod list display options
+	showOwner    bool
+	showImage    bool
+	showNodeIP   bool
+	groupByOwner bool
+	podExpanded  bool // Show the selected pod's containers below its row
+
+	// Workload to reselect once the next namespace's pods load (sticky selection)
+	stickyWorkload string
 
 	// Selected indices
-	selectedPodIndex       int
-	selectedNamespaceIndex int
-	selectedContextIndex   int
+	selectedPodIndex        int
+	selectedNamespaceIndex  int
+	selectedContextIndex    int
+	selectedDeploymentIndex int
+	selectedServiceIndex    int
+
+	// Container selector state: the view to open once a container is
+	// chosen, and the container last chosen per pod
+	selectedContainerIndex int
+	containerTarget        model.ViewState
+	containerChoices       map[string]string
 
 	// Log streaming state
 	logView           ui.LogViewModel
@@ -117,29 +503,291 @@ type Model struct {
 	logChan           <-chan k8s.LogLine
 	logStreamActive   bool
 	selectedContainer string
+	logAllContainers  bool  // Merge every container's logs into one stream
+	logTailLines      int64 // How many existing lines a new stream starts with
+	logStreamID       int
+	logNamespace      string
+	logPod            string
+	logSelector       string    // Label selector of a workload whose pods' logs merge; empty for one pod
+	logLastLineAt     time.Time // Server timestamp of the newest line; a reconnect resumes from it
+	logResumeAfter    time.Time // Lines stamped at or before this were already shown before a resume
+	logReconnects     int       // Reconnect attempts since the last line arrived
+	pagerPaused       bool      // The log view was paused for the pager and resumes after it
+	logSearchBefore   string    // Search term when the search prompt opened, restored by esc
+
+	// Log views left this session, restored when the same logs are
+	// reopened; logCacheOrder lists their keys oldest first
+	logCache      map[string]logCacheEntry
+	logCacheOrder []string
 
 	// Exec state
 	execView    ui.ExecViewModel
 	execCancel  context.CancelFunc
+	execChunks  <-chan k8s.ExecChunk
 	execRunning bool
+	execID      int
+
+	// Pod watch state
+	podWatchCancel context.CancelFunc
+	podWatchID     int
+
+	// Automatic reconnect attempts after transient errors (0 when healthy)
+	podsRetry       int
+	namespacesRetry int
 
 	// File browser state
-	filesView   ui.FileBrowserModel
-	filesCancel context.CancelFunc
+	filesView     ui.FileBrowserModel
+	filesCancel   context.CancelFunc
+	bookmarksPath string // Where bookmarks are saved; empty disables saving
+
+	// YAML manifest state
+	yamlView ui.TextViewModel
+
+	// Environment state; envTarget names the container shown
+	envView   ui.EnvViewModel
+	envTarget containerRef
+
+	// Events state; eventsAll shows the whole namespace instead of eventsPod
+	eventsView ui.TextViewModel
+	eventsPod  k8s.PodInfo
+	eventsAll  bool
+
+	// ConfigMap and Secret browser
+	dataView ui.DataBrowserModel
+
+	// Cross-view search overlay
+	search ui.SearchModel
+
+	// Text prompt state; promptAction decides what a submitted value does
+	prompt       ui.PromptModel
+	promptAction promptAction
+	bundlePod    k8s.PodInfo
+
+	// Yes/no question guarding a destructive action
+	confirmation ui.ConfirmModel
+
+	// Connection health from the periodic ping; pingID drops the checks of
+	// a replaced client
+	pingID       int
+	pingChecked  bool
+	pingLatency  time.Duration
+	pingFailures int
+
+	// Transient notification shown below the current view
+	statusMessage string
+	statusID      int
+	statusAlert   bool // The notification is a failure alert and stands out
+
+	// Jump-to-pod mode: typed characters collect in gotoBuffer and move the
+	// selection to the first pod whose name starts with them
+	gotoActive bool
+	gotoBuffer string
+	gotoID     int
+}
+
+// promptAction identifies what the text prompt was opened for
+type promptAction int
+
+const (
+	promptNone promptAction = iota
+	promptBundlePath
+	promptLogSearch
+	promptDebugImage
+	promptScaleReplicas
+	promptLogTail
+	promptLabelSelector
+	promptGotoPath
+)
+
+// allContainersLabel stands in for the container name in combined log mode
+const allContainersLabel = "(all containers)"
+
+// allPodsLabel stands in for the container name when tailing a workload
+const allPodsLabel = "(all pods)"
+
+// statusMessageTTL is how long a status notification stays visible
+const statusMessageTTL = 3 * time.Second
+
+// alertMessageTTL is how long a failure alert stays visible, longer than a
+// notification so it is noticed while looking away
+const alertMessageTTL = 10 * time.Second
+
+// restartFlashTTL is how long a pod that just restarted stays marked
+const restartFlashTTL = 10 * time.Second
+
+// gotoTimeout ends jump-to-pod mode after a pause in typing
+const gotoTimeout = 2 * time.Second
+
+// topRefreshInterval is the time between top view refreshes, matching
+// metrics-server's default resolution
+const topRefreshInterval = 15 * time.Second
+
+// pingInterval is the time between connection checks, independent of pod
+// refreshes
+const pingInterval = 15 * time.Second
+
+// disconnectedAfter is how many connection checks in a row must fail
+// before the header reports the cluster as disconnected
+const disconnectedAfter = 3
+
+// podWatchResyncDelay is the pause before relisting pods after a watch ends
+const podWatchResyncDelay = 2 * time.Second
+
+// restartRefreshDelay is the pause before relisting pods after restarting
+// a workload, giving the rollout time to replace the first pod
+const restartRefreshDelay = 2 * time.Second
+
+// maxLogCacheEntries bounds how many log views are kept to restore. Each
+// holds up to the log view's line limit.
+const maxLogCacheEntries = 8
+
+// logCacheEntry is a log view that was left and the server timestamp of
+// its newest line, which a restored stream resumes from
+type logCacheEntry struct {
+	snapshot   ui.LogSnapshot
+	lastLineAt time.Time
+}
+
+// maxLogReconnects bounds how often a followed log stream that keeps
+// ending without output (e.g. an exited container) is reopened
+const maxLogReconnects = 5
+
+// Reconnect backoff doubles from retryBaseDelay up to retryMaxDelay
+const (
+	retryBaseDelay = time.Second
+	retryMaxDelay  = 30 * time.Second
+)
+
+// retryDelay returns the backoff before the given retry attempt (1-based)
+func retryDelay(attempt int) time.Duration {
+	delay := retryBaseDelay
+	for i := 1; i < attempt && delay < retryMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, retryMaxDelay)
+}
+
+// Options configures the application at startup
+type Options struct {
+	// Plain disables colors and spells out status indicators for screen readers
+	Plain bool
+
+	// Kubeconfig, Context and Namespace override the kubeconfig defaults
+	// for the first client, like kubectl's flags of the same names
+	Kubeconfig string
+	Context    string
+	Namespace  string
+
+	// AllNamespaces starts with pods listed across every namespace, as
+	// does a Namespace of "all"
+	AllNamespaces bool
+
+	// ReadOnly refuses every change to the cluster and the kubeconfig, and
+	// hides the keys that would make one
+	ReadOnly bool
+}
+
+// allNamespacesName is the --namespace value that lists every namespace
+const allNamespacesName = "all"
+
+// Validate checks the options before the program starts
+func (o Options) Validate() error {
+	if o.Namespace != "" && o.Namespace != allNamespacesName {
+		if errs := validation.IsDNS1123Label(o.Namespace); len(errs) > 0 {
+			return fmt.Errorf("invalid namespace %q: %s", o.Namespace, strings.Join(errs, "; "))
+		}
+	}
+	if o.Kubeconfig != "" {
+		if _, err := os.Stat(o.Kubeconfig); err != nil {
+			return fmt.Errorf("failed to read kubeconfig: %w", err)
+		}
+	}
+	return nil
 }
 
 // New creates a new application model with default state
 func New() Model {
+	return NewWithOptions(Options{})
+}
+
+// NewWithOptions creates a new application model with the given options
+func NewWithOptions(opts Options) Model {
+	// Fall back to defaults if the config file is missing or invalid
+	cfg, keys := config.Default(), ui.DefaultKeyMap()
+	if path, err := config.DefaultPath(); err == nil {
+		if loaded, err := config.Load(path); err == nil {
+			cfg = loaded
+		}
+		if loaded, err := ui.LoadKeyMap(path); err == nil {
+			keys = loaded
+		}
+	}
+
+	styles := ui.DefaultStyles()
+	helpView := help.New()
+	if opts.Plain {
+		styles = ui.AccessibleStyles()
+		keys = keys.ASCIIHelp()
+		helpView.ShortSeparator = " | "
+		helpView.FullSeparator = " | "
+		helpView.Ellipsis = "..."
+		helpView.Styles = help.Styles{}
+	}
+	if opts.ReadOnly {
+		keys = keys.ReadOnly()
+	}
+
+	logView := ui.NewLogViewModel()
+	logView.SetStyles(styles)
+	logView.SetLevelRules(cfg.LogLevels)
+	execView := ui.NewExecViewModel()
+	execView.SetPresets(cfg.ExecPresets)
+	execView.SetStyles(styles)
+	filesView := ui.NewFileBrowserModel()
+	filesView.SetStyles(styles)
+	bookmarksPath, err := config.BookmarksPath()
+	if err == nil {
+		if bookmarks, err := config.LoadBookmarks(bookmarksPath); err == nil {
+			filesView.SetBookmarks(bookmarks)
+		}
+	}
+	prompt := ui.NewPromptModel()
+	prompt.SetStyles(styles)
+	confirmation := ui.NewConfirmModel()
+	confirmation.SetStyles(styles)
+	search := ui.NewSearchModel()
+	search.SetStyles(styles)
+	dataView := ui.NewDataBrowserModel()
+	dataView.SetStyles(styles)
+
 	return Model{
-		view:       model.ViewPodList,
-		prevView:   model.ViewPodList,
-		keys:       ui.DefaultKeyMap(),
-		help:       help.New(),
-		showHelp:   false,
-		loadingK8s: true,
-		logView:    ui.NewLogViewModel(),
-		execView:   ui.NewExecViewModel(),
-		filesView:  ui.NewFileBrowserModel(),
+		view:          model.ViewPodList,
+		prevView:      model.ViewPodList,
+		opts:          opts,
+		allNamespaces: opts.AllNamespaces || opts.Namespace == allNamespacesName,
+		keys:          keys,
+		config:        cfg,
+		hideCompleted: cfg.HideCompleted,
+		logTailLines:  cfg.LogTailLines,
+		styles:        styles,
+		help:          helpView,
+		showHelp:      false,
+		loadingK8s:    true,
+		logView:       logView,
+		execView:      execView,
+		filesView:     filesView,
+		bookmarksPath: bookmarksPath,
+		yamlView:      ui.NewTextViewModel(),
+		envView:       ui.NewEnvViewModel(),
+		eventsView:    ui.NewTextViewModel(),
+		dataView:      dataView,
+		prompt:        prompt,
+		confirmation:  confirmation,
+		seenPods:      make(map[string]k8s.PodInfo),
+		logCache:      make(map[string]logCacheEntry),
+		restartedAt:   make(map[string]time.Time),
+		bellOut:       os.Stdout,
+		search:        search,
 	}
 }
 
@@ -148,856 +796,4362 @@ func (m Model) Init() tea.Cmd {
 	return m.initK8sClient
 }
 
-// initK8sClient initializes the Kubernetes client
+// initK8sClient initializes the Kubernetes client. Listing all namespaces
+// keeps the context's namespace as the one to return to.
 func (m Model) initK8sClient() tea.Msg {
-	client, err := k8s.NewClient()
+	namespace := m.opts.Namespace
+	if namespace == allNamespacesName {
+		namespace = ""
+	}
+	client, err := k8s.NewClient(m.clientOptions(
+		k8s.WithContext(m.opts.Context),
+		k8s.WithNamespace(namespace),
+	)...)
 	return k8sClientReadyMsg{client: client, err: err}
 }
 
+// clientOptions returns the options for a new client from the command line
+// and config file, followed by extra
+func (m Model) clientOptions(extra ...k8s.ClientOption) []k8s.ClientOption {
+	opts := []k8s.ClientOption{
+		k8s.WithKubeconfig(m.opts.Kubeconfig),
+		k8s.WithTimeout(m.config.Timeout.Duration),
+		k8s.WithExecTimeout(m.config.ExecTimeout.Duration),
+	}
+	if m.opts.ReadOnly {
+		opts = append(opts, k8s.WithReadOnly())
+	}
+	return append(opts, extra...)
+}
+
 // loadPods fetches pods from the current namespace
 func (m Model) loadPods() tea.Msg {
 	if m.k8sClient == nil {
-		return podsLoadedMsg{err: fmt.Errorf("k8s client not initialized")}
+		return podsLoadedMsg{id: m.podsID, err: fmt.Errorf("k8s client not initialized")}
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := m.k8sClient.RequestContext()
 	defer cancel()
 
-	pods, err := m.k8sClient.ListPods(ctx, "")
-	return podsLoadedMsg{pods: pods, err: err}
+	if m.allNamespaces {
+		pods, err := m.k8sClient.ListAllPods(ctx, m.labelSelector)
+		return podsLoadedMsg{id: m.podsID, pods: pods, allNamespaces: true, err: err}
+	}
+	pods, err := m.k8sClient.ListPods(ctx, "", m.labelSelector)
+	return podsLoadedMsg{id: m.podsID, pods: pods, err: err}
 }
 
 // loadNamespaces fetches namespaces from the cluster
 func (m Model) loadNamespaces() tea.Msg {
 	if m.k8sClient == nil {
-		return namespacesLoadedMsg{err: fmt.Errorf("k8s client not initialized")}
+		return namespacesLoadedMsg{id: m.namespacesID, err: fmt.Errorf("k8s client not initialized")}
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := m.k8sClient.RequestContext()
 	defer cancel()
 
 	namespaces, err := m.k8sClient.ListNamespaces(ctx)
-	return namespacesLoadedMsg{namespaces: namespaces, err: err}
+	return namespacesLoadedMsg{id: m.namespacesID, namespaces: namespaces, err: err}
 }
 
-// loadContexts loads available contexts
-func (m Model) loadContexts() tea.Msg {
-	if m.k8sClient == nil {
-		return contextsLoadedMsg{err: fmt.Errorf("k8s client not initialized")}
+// namespaceSummaryWorkers bounds how many namespace summaries load at once
+const namespaceSummaryWorkers = 4
+
+// loadNamespaceSummaries starts filling in pod counts and quotas for the
+// listed namespaces. Each worker walks every namespaceSummaryWorkers-th
+// namespace, so the selector opens at once and counts arrive gradually
+// without an API call burst per namespace.
+func (m *Model) loadNamespaceSummaries() tea.Cmd {
+	m.namespaceSummaryID++
+	cmds := make([]tea.Cmd, 0, namespaceSummaryWorkers)
+	for i := 0; i < namespaceSummaryWorkers && i < len(m.namespaces); i++ {
+		cmds = append(cmds, m.loadNamespaceSummary(i))
 	}
+	return tea.Batch(cmds...)
+}
 
-	contexts := m.k8sClient.ListContexts()
-	return contextsLoadedMsg{
-		contexts:       contexts,
-		currentContext: m.k8sClient.CurrentContext(),
+// loadNamespaceSummary fetches the summary of the namespace at index
+func (m Model) loadNamespaceSummary(index int) tea.Cmd {
+	if m.k8sClient == nil || index >= len(m.namespaces) {
+		return nil
 	}
-}
 
-// logStreamChanMsg carries the log channel after stream creation
-type logStreamChanMsg struct {
-	logChan <-chan k8s.LogLine
+	client := m.k8sClient
+	id := m.namespaceSummaryID
+	name := m.namespaces[index].Name
+
+	return func() tea.Msg {
+		ctx, cancel := client.RequestContext()
+		defer cancel()
+
+		summary, err := client.GetNamespaceSummary(ctx, name)
+		return namespaceSummaryMsg{id: id, index: index, summary: summary, err: err}
+	}
 }
 
-// initLogStream prepares and starts log streaming for the selected pod
-func (m *Model) initLogStream() tea.Cmd {
+// loadDeployments fetches deployments from the current namespace
+func (m Model) loadDeployments() tea.Msg {
 	if m.k8sClient == nil {
-		return func() tea.Msg {
-			return logStreamErrorMsg{err: fmt.Errorf("k8s client not initialized")}
-		}
+		return deploymentsLoadedMsg{err: fmt.Errorf("k8s client not initialized")}
 	}
 
-	if m.selectedPodIndex >= len(m.pods) {
-		return func() tea.Msg {
-			return logStreamErrorMsg{err: fmt.Errorf("no pod selected")}
-		}
+	ctx, cancel := m.k8sClient.RequestContext()
+	defer cancel()
+
+	deployments, err := m.k8sClient.ListDeployments(ctx, "")
+	return deploymentsLoadedMsg{deployments: deployments, err: err}
+}
+
+// loadTopMetrics fetches pod usage in the current namespace
+func (m Model) loadTopMetrics() tea.Msg {
+	if m.k8sClient == nil {
+		return topMetricsLoadedMsg{id: m.topID, err: fmt.Errorf("k8s client not initialized")}
 	}
 
-	pod := m.pods[m.selectedPodIndex]
+	ctx, cancel := m.k8sClient.RequestContext()
+	defer cancel()
 
-	// Stop any existing stream
-	m.stopLogStream()
+	metrics, err := m.k8sClient.GetPodMetrics(ctx, "")
+	return topMetricsLoadedMsg{id: m.topID, metrics: metrics, err: err}
+}
 
-	// Determine container to use
-	container := m.selectedContainer
-	if container == "" && len(pod.Containers) > 0 {
-		container = pod.Containers[0].Name
+// openTop switches to the top view and starts refreshing it
+func (m *Model) openTop() tea.Cmd {
+	m.view = model.ViewTop
+	m.loadingTop = true
+	m.topID++
+	return m.loadTopMetrics
+}
+
+// loadServices fetches services from the current namespace
+func (m Model) loadServices() tea.Msg {
+	if m.k8sClient == nil {
+		return servicesLoadedMsg{err: fmt.Errorf("k8s client not initialized")}
 	}
 
-	// Set up log view
-	m.logView.Clear()
-	m.logView.SetPodInfo(pod.Namespace, pod.Name, container)
-	m.logView.SetState(ui.LogViewStateStreaming)
-	m.selectedContainer = container
+	ctx, cancel := m.k8sClient.RequestContext()
+	defer cancel()
 
-	// Create context for this stream
-	ctx, cancel := context.WithCancel(context.Background())
-	m.logCancel = cancel
-	m.logStreamActive = true
+	services, err := m.k8sClient.ListServices(ctx, "")
+	return servicesLoadedMsg{services: services, err: err}
+}
 
-	// Capture values for closure
-	namespace := pod.Namespace
-	podName := pod.Name
-	client := m.k8sClient
+// loadContexts loads available contexts
+func (m Model) loadContexts() tea.Msg {
+	if m.k8sClient == nil {
+		// Without a client, e.g. when no context is set, read the kubeconfig
+		contexts, _, err := k8s.ListContextsFromConfig(m.opts.Kubeconfig)
+		return contextsLoadedMsg{contexts: contexts, err: err}
+	}
 
-	return func() tea.Msg {
-		opts := k8s.LogOptions{
-			Namespace: namespace,
-			Pod:       podName,
-			Container: container,
-			Follow:    true,
-			TailLines: 100, // Start with last 100 lines
-		}
+	contexts := m.k8sClient.ListContexts()
+	return contextsLoadedMsg{
+		contexts:       contexts,
+		currentContext: m.k8sClient.CurrentContext(),
+	}
+}
 
-		logChan, err := client.StreamLogs(ctx, opts)
-		if err != nil {
-			return logStreamErrorMsg{err: err}
-		}
+// reloadConfig re-reads the kubeconfig into a new client
+func (m Model) reloadConfig() tea.Msg {
+	client, err := m.k8sClient.ReloadConfig()
+	return configReloadedMsg{client: client, err: err}
+}
 
-		// Return the channel so we can store it
-		return logStreamChanMsg{logChan: logChan}
+// clipboardWrite puts text on the system clipboard. Overridden in tests.
+var clipboardWrite = func(text string) error {
+	if clipboard.Unsupported {
+		return errors.New("no clipboard available")
 	}
+	return clipboard.WriteAll(text)
 }
 
-// waitForNextLogLine waits for the next line from an existing channel
-func waitForNextLogLine(logChan <-chan k8s.LogLine) tea.Cmd {
-	if logChan == nil {
-		return nil
-	}
+// copyToClipboard copies text in the background; what names it in the
+// status message. Without a clipboard (e.g. over SSH) only the status fails.
+func copyToClipboard(text, what string) tea.Cmd {
 	return func() tea.Msg {
-		line, ok := <-logChan
-		if !ok {
-			return logStreamEndedMsg{}
-		}
-		if line.Error != nil {
-			return logStreamErrorMsg{err: line.Error}
-		}
-		return logLineMsg{line: line}
+		return clipboardCopiedMsg{what: what, err: clipboardWrite(text)}
 	}
 }
 
-// stopLogStream stops the current log stream
-func (m *Model) stopLogStream() {
-	if m.logCancel != nil {
-		m.logCancel()
-		m.logCancel = nil
+// copyKubectlCommand copies the kubectl command doing what the view shows,
+// for operations the app doesn't support, and shows it in the status bar
+func (m Model) copyKubectlCommand() tea.Cmd {
+	command, ok := m.kubectlCommandForView()
+	if !ok {
+		return nil
 	}
-	m.logChan = nil
-	m.logStreamActive = false
-	m.logView.SetState(ui.LogViewStateEnded)
+	return copyToClipboard(command, command)
 }
 
-// Update implements tea.Model
-func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch msg := msg.(type) {
-	case tea.WindowSizeMsg:
-		m.width = msg.Width
-		m.height = msg.Height
-		m.help.Width = msg.Width
-		m.logView.SetSize(msg.Width, msg.Height-4) // Reserve space for header/footer
-		m.execView.SetSize(msg.Width, msg.Height-4)
-		m.filesView.SetSize(msg.Width, msg.Height-4)
-		m.ready = true
-		return m, nil
+// kubectlCommandForView builds the kubectl command for the current view:
+// a shell in the selected pod, the streamed logs, the browsed directory or
+// file, or the selected deployment
+func (m Model) kubectlCommandForView() (string, bool) {
+	if m.k8sClient == nil {
+		return "", false
+	}
+	kubeContext := m.k8sClient.CurrentContext()
 
-	case k8sClientReadyMsg:
-		m.loadingK8s = false
-		if msg.err != nil {
-			m.k8sErr = msg.err
-			return m, nil
-		}
-		m.k8sClient = msg.client
-		m.loadingPods = true
-		// Load pods and contexts after client is ready
-		return m, tea.Batch(m.loadPods, m.loadContexts)
+	switch m.view {
+	case model.ViewLogs:
+		return kubectlCommand(kubeContext, m.logNamespace, m.kubectlLogsArgs()...), true
 
-	case podsLoadedMsg:
-		m.loadingPods = false
-		if msg.err != nil {
-			m.k8sErr = msg.err
-			return m, nil
+	case model.ViewDeployments:
+		d, ok := m.selectedDeployment()
+		if !ok {
+			return "", false
 		}
-		m.pods = msg.pods
-		m.k8sErr = nil
-		return m, nil
+		return kubectlCommand(kubeContext, d.Namespace, "describe", "deployment", d.Name), true
 
-	case namespacesLoadedMsg:
-		m.loadingNamespaces = false
-		if msg.err != nil {
-			m.k8sErr = msg.err
-			return m, nil
+	case model.ViewFiles:
+		pod, ok := m.selectedPod()
+		if !ok {
+			return "", false
 		}
-		m.namespaces = msg.namespaces
-		// Find and select current namespace
-		for i, ns := range m.namespaces {
-			if ns.IsCurrent {
-				m.selectedNamespaceIndex = i
-				break
-			}
+		container := m.filesView.Container()
+		if m.filesView.IsViewingFile() {
+			name := m.filesView.ViewingFile()
+			source := pod.Name + ":" + k8s.JoinPath(m.filesView.CurrentPath(), name)
+			return kubectlCommand(kubeContext, pod.Namespace, "cp", source, "./"+name, "-c", container), true
 		}
-		return m, nil
+		return kubectlCommand(kubeContext, pod.Namespace, "exec", pod.Name, "-c", container, "--",
+			"ls", "-la", m.filesView.CurrentPath()), true
 
-	case contextsLoadedMsg:
-		if msg.err != nil {
-			m.k8sErr = msg.err
-			return m, nil
-		}
-		m.contexts = msg.contexts
-		// Find and select current context
-		for i, ctx := range m.contexts {
-			if ctx.IsCurrent {
-				m.selectedContextIndex = i
-				break
-			}
+	case model.ViewPodList:
+		pod, ok := m.selectedPod()
+		if !ok {
+			return "", false
 		}
-		return m, nil
+		return kubectlCommand(kubeContext, pod.Namespace, "exec", "-it", pod.Name, "-c", pod.PrimaryContainer(),
+			"--", "sh"), true
+	}
+	return "", false
+}
 
-	case logStreamChanMsg:
-		// Store the channel and start reading
-		m.logChan = msg.logChan
-		m.logView.SetState(ui.LogViewStateStreaming)
-		return m, waitForNextLogLine(m.logChan)
+// kubectlLogsArgs returns the kubectl logs arguments matching the stream
+// in the log view
+func (m Model) kubectlLogsArgs() []string {
+	args := []string{"logs"}
+	switch {
+	case m.logSelector != "":
+		args = append(args, "-l", m.logSelector, "--all-containers", "--prefix")
+	case m.logAllContainers:
+		args = append(args, m.logPod, "--all-containers", "--prefix")
+	default:
+		args = append(args, m.logPod, "-c", m.selectedContainer)
+	}
+	if m.logView.IsPrevious() {
+		args = append(args, "--previous")
+	} else {
+		args = append(args, "-f")
+	}
+	if m.logTailLines > 0 {
+		args = append(args, "--tail", strconv.FormatInt(m.logTailLines, 10))
+	}
+	return args
+}
 
-	case logStreamStartedMsg:
-		m.selectedContainer = msg.container
-		m.logView.SetState(ui.LogViewStateStreaming)
-		return m, nil
+// kubectlCommand builds a kubectl command line for a context and namespace,
+// ready to paste into a shell. The context is left out in-cluster, where
+// kubectl uses the service account too.
+func kubectlCommand(kubeContext, namespace string, args ...string) string {
+	parts := []string{"kubectl"}
+	if kubeContext != "" && kubeContext != k8s.InClusterContext {
+		parts = append(parts, "--context", kubeContext)
+	}
+	parts = append(parts, "-n", namespace)
+	parts = append(parts, args...)
+	for i, part := range parts {
+		parts[i] = shellQuote(part)
+	}
+	return strings.Join(parts, " ")
+}
 
-	case logLineMsg:
-		if msg.line.Error != nil {
-			m.logView.SetError(msg.line.Error.Error())
-			m.logStreamActive = false
-			return m, nil
-		}
-		m.logView.AddLine(msg.line.Content)
-		// Continue reading if stream is active
-		if m.logStreamActive && m.view == model.ViewLogs && m.logChan != nil {
-			return m, waitForNextLogLine(m.logChan)
-		}
-		return m, nil
+// shellQuote single-quotes s for POSIX shells unless every character is
+// safe unquoted
+func shellQuote(s string) string {
+	safe := func(r rune) bool {
+		return r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("-_./:=@%+,", r))
+	}
+	if s != "" && !strings.ContainsFunc(s, func(r rune) bool { return !safe(r) }) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
 
-	case logStreamErrorMsg:
-		m.logView.SetError(msg.err.Error())
-		m.logStreamActive = false
-		return m, nil
+// maxEditBytes caps the size of files opened in the editor
+const maxEditBytes = 1024 * 1024
 
-	case logStreamEndedMsg:
-		m.logView.SetState(ui.LogViewStateEnded)
-		m.logStreamActive = false
-		return m, nil
+// editorCommand builds the command that edits path, honouring $VISUAL
+// and $EDITOR with vi as the fallback. Overridden in tests.
+var editorCommand = func(path string) *exec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	args := strings.Fields(editor)
+	if len(args) == 0 {
+		args = []string{"vi"}
+	}
+	return exec.Command(args[0], append(args[1:], path)...)
+}
 
-	case execResultMsg:
-		m.execRunning = false
-		if msg.result.Error != nil {
-			m.execView.SetError(msg.result.Error.Error())
-			m.execView.AddOutput(msg.result.Stderr, true)
-		} else {
-			m.execView.SetState(ui.ExecViewStateComplete)
-			if msg.result.Stdout != "" {
-				m.execView.AddOutput(msg.result.Stdout, false)
-			}
-			if msg.result.Stderr != "" {
-				m.execView.AddOutput(msg.result.Stderr, true)
-			}
+// pagerCommand builds the command that pages path: $PAGER, else less,
+// else the user's editor. Overridden in tests.
+var pagerCommand = func(path string) (*exec.Cmd, error) {
+	if args := strings.Fields(os.Getenv("PAGER")); len(args) > 0 {
+		if _, err := exec.LookPath(args[0]); err == nil {
+			return exec.Command(args[0], append(args[1:], path)...), nil
 		}
-		m.execView.Focus()
-		return m, nil
+	}
+	if less, err := exec.LookPath("less"); err == nil {
+		return exec.Command(less, path), nil
+	}
+	if os.Getenv("VISUAL") != "" || os.Getenv("EDITOR") != "" {
+		return editorCommand(path), nil
+	}
+	return nil, errors.New("no pager found; set $PAGER or $EDITOR, or install less")
+}
 
-	case dirLoadedMsg:
-		if msg.err != nil {
-			m.filesView.SetError(msg.err.Error())
-			return m, nil
-		}
-		m.filesView.SetCurrentPath(msg.path)
-		m.filesView.SetEntries(msg.entries)
-		return m, nil
+// setStatus shows a transient notification for statusMessageTTL
+func (m *Model) setStatus(text string) tea.Cmd {
+	return m.setStatusFor(text, statusMessageTTL)
+}
 
-	case fileContentMsg:
-		if msg.err != nil {
-			m.filesView.SetError(msg.err.Error())
-			return m, nil
+// setStatusFor shows a transient notification and schedules its removal
+// after ttl. A newer notification replaces it and outlives its timer.
+func (m *Model) setStatusFor(text string, ttl time.Duration) tea.Cmd {
+	m.statusID++
+	m.statusMessage = text
+	m.statusAlert = false
+	id := m.statusID
+	return tea.Tick(ttl, func(time.Time) tea.Msg {
+		return clearStatusMsg{id: id}
+	})
+}
+
+// setAlert shows a highlighted notification about pods that got worse
+func (m *Model) setAlert(text string) tea.Cmd {
+	cmd := m.setStatusFor("Alert: "+text, alertMessageTTL)
+	m.statusAlert = true
+	return cmd
+}
+
+// startPodWatch replaces any running pod watch with one for the current namespace
+func (m *Model) startPodWatch() tea.Cmd {
+	m.stopPodWatch()
+	if m.k8sClient == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.podWatchCancel = cancel
+	id := m.podWatchID
+	client := m.k8sClient
+	namespace := client.CurrentNamespace()
+	allNamespaces := m.allNamespaces
+	selector := m.labelSelector
+
+	return func() tea.Msg {
+		if allNamespaces {
+			events, err := client.WatchAllPods(ctx, selector)
+			return podWatchStartedMsg{id: id, events: events, err: err}
 		}
-		m.filesView.SetFileContent(msg.filename, msg.content)
-		return m, nil
+		events, err := client.WatchPods(ctx, namespace, selector)
+		return podWatchStartedMsg{id: id, events: events, err: err}
+	}
+}
 
-	case tea.KeyMsg:
-		return m.handleKeyPress(msg)
+// startPing checks the connection of a new client right away, superseding
+// the checks of the previous one
+func (m *Model) startPing() tea.Cmd {
+	m.pingID++
+	m.pingChecked = false
+	m.pingFailures = 0
+	return m.ping()
+}
+
+// ping checks that the API server answers
+func (m Model) ping() tea.Cmd {
+	client := m.k8sClient
+	if client == nil {
+		return nil
 	}
+	id := m.pingID
 
-	return m, nil
+	return func() tea.Msg {
+		ctx, cancel := client.RequestContext()
+		defer cancel()
+
+		latency, err := client.Ping(ctx)
+		return pingResultMsg{id: id, latency: latency, err: err}
+	}
 }
 
-// handleKeyPress processes keyboard input
-func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	// Global keybindings that work in any view
+// connectionIndicator renders the result of the last connection check: a
+// green dot with the latency, a red dot while checks fail, and a
+// prominent warning once several failed in a row
+func (m Model) connectionIndicator() string {
 	switch {
-	case key.Matches(msg, m.keys.Quit):
-		return m, tea.Quit
+	case !m.pingChecked:
+		return ""
+	case m.pingFailures >= disconnectedAfter:
+		return m.styles.Error.Render("● DISCONNECTED")
+	case m.pingFailures > 0:
+		return m.styles.StatusFailed.Render("● no response")
+	default:
+		return m.styles.StatusRunning.Render(fmt.Sprintf("● %dms", m.pingLatency.Milliseconds()))
+	}
+}
 
-	case key.Matches(msg, m.keys.Help):
-		m.showHelp = !m.showHelp
-		if m.showHelp {
-			m.prevView = m.view
-			m.view = model.ViewHelp
-		} else {
-			m.view = m.prevView
-		}
-		return m, nil
+// quit stops every stream and running command so their goroutines exit,
+// then quits the program
+func (m *Model) quit() tea.Cmd {
+	m.stopPodWatch()
+	m.stopLogStream()
+	m.stopExec()
+	m.stopFileBrowser()
+	return tea.Quit
+}
 
-	case key.Matches(msg, m.keys.Back):
-		return m.handleBack()
+// stopPodWatch cancels the running pod watch and invalidates its messages
+func (m *Model) stopPodWatch() {
+	if m.podWatchCancel != nil {
+		m.podWatchCancel()
+		m.podWatchCancel = nil
 	}
+	m.podWatchID++
+}
 
-	// View-specific keybindings
-	switch m.view {
-	case model.ViewPodList:
-		return m.handlePodListKeys(msg)
-	case model.ViewLogs:
-		return m.handleLogViewKeys(msg)
-	case model.ViewExec:
-		return m.handleExecViewKeys(msg)
-	case model.ViewFiles:
-		return m.handleFilesViewKeys(msg)
-	case model.ViewNamespaceSelector:
-		return m.handleNamespaceSelectorKeys(msg)
-	case model.ViewContextSelector:
-		return m.handleContextSelectorKeys(msg)
-	case model.ViewHelp:
-		// Any key except ? closes help
-		m.showHelp = false
-		m.view = m.prevView
-		return m, nil
+// waitForNextPodEvent waits for the next event from a pod watch
+func waitForNextPodEvent(id int, events <-chan k8s.PodEvent) tea.Cmd {
+	return func() tea.Msg {
+		event, ok := <-events
+		if !ok {
+			return podWatchEndedMsg{id: id}
+		}
+		if event.Error != nil {
+			return podWatchEndedMsg{id: id, err: event.Error}
+		}
+		return podEventMsg{id: id, events: events, event: event}
 	}
+}
 
-	return m, nil
+// applyPodEvent updates the pod list in place from a watch event
+func (m *Model) applyPodEvent(event k8s.PodEvent) {
+	m.keepPodSelection(func() {
+		idx := slices.IndexFunc(m.pods, func(p k8s.PodInfo) bool {
+			return p.Name == event.Pod.Name && p.Namespace == event.Pod.Namespace
+		})
+
+		switch {
+		case event.Type == k8s.PodEventDeleted:
+			if idx >= 0 {
+				m.pods = slices.Delete(m.pods, idx, idx+1)
+			}
+		case idx >= 0:
+			m.pods[idx] = event.Pod
+		default:
+			// Keep the list sorted like ListPods
+			pos, _ := slices.BinarySearchFunc(m.pods, event.Pod, k8s.ComparePods)
+			m.pods = slices.Insert(m.pods, pos, event.Pod)
+		}
+	})
 }
 
-// handleBack handles the escape/back key
-func (m Model) handleBack() (tea.Model, tea.Cmd) {
-	if m.view.IsOverlay() {
-		m.view = m.prevView
-		m.showHelp = false
-		return m, nil
+// keepPodSelection runs update, which changes the pod list, and keeps the
+// cursor on the pod it was on. If that pod is gone the cursor stays at the
+// same position, on a neighbour.
+func (m *Model) keepPodSelection(update func()) {
+	selected, ok := m.selectedPod()
+	update()
+	if ok {
+		key := podKey(selected)
+		if idx := slices.IndexFunc(m.visiblePods(), func(p k8s.PodInfo) bool {
+			return podKey(p) == key
+		}); idx >= 0 {
+			m.selectedPodIndex = idx
+		}
 	}
+	m.clampPodSelection()
+}
 
-	// From log view, stop streaming and go back
-	if m.view == model.ViewLogs {
-		m.stopLogStream()
-		m.view = model.ViewPodList
-		return m, nil
-	}
+// podKey identifies a pod across namespaces
+func podKey(pod k8s.PodInfo) string {
+	return pod.Namespace + "/" + pod.Name
+}
 
-	// From exec view, stop any running command and go back
-	if m.view == model.ViewExec {
-		m.stopExec()
-		m.view = model.ViewPodList
-		return m, nil
+// trackPods records pods as seen and marks those with a container that
+// restarted since they were last seen. A recreated container starts its
+// count over and is not a restart. With alertOnFailure configured, pods that got worse ring
+// the bell. With all set, pods is the full list and pods that are gone are
+// forgotten. It returns a command that clears the marks.
+func (m *Model) trackPods(pods []k8s.PodInfo, all bool) tea.Cmd {
+	flashed := false
+	var alerts []string
+	seen := make(map[string]bool, len(pods))
+	for _, pod := range pods {
+		key := podKey(pod)
+		seen[key] = true
+		if prev, ok := m.seenPods[key]; ok {
+			if podRestarted(prev, pod) {
+				m.restartedAt[key] = time.Now()
+				flashed = true
+			}
+			if regression := podRegression(prev, pod); regression != "" && m.config.AlertOnFailure {
+				alerts = append(alerts, pod.Name+" "+regression)
+			}
+		}
+		m.seenPods[key] = pod
 	}
 
-	// From files view, handle differently based on state
-	if m.view == model.ViewFiles {
-		// If viewing a file, go back to directory listing
-		if m.filesView.IsViewingFile() {
-			m.filesView.ExitFileView()
-			return m, nil
+	if all {
+		for key := range m.seenPods {
+			if !seen[key] {
+				m.forgetPod(key)
+			}
 		}
-		// Otherwise go back to pod list
-		m.stopFileBrowser()
-		m.view = model.ViewPodList
-		return m, nil
 	}
 
-	// From main views, go back to pod list
-	if m.view != model.ViewPodList {
-		m.view = model.ViewPodList
-		return m, nil
+	var cmds []tea.Cmd
+	if flashed {
+		cmds = append(cmds, tea.Tick(restartFlashTTL, func(time.Time) tea.Msg {
+			return restartFlashMsg{}
+		}))
 	}
+	if len(alerts) > 0 {
+		cmds = append(cmds, m.setAlert(strings.Join(alerts, ", ")), m.ringBell())
+	}
+	return tea.Batch(cmds...)
+}
 
-	return m, nil
+// forgetPod drops what is known about a pod's restarts and state
+func (m *Model) forgetPod(key string) {
+	delete(m.seenPods, key)
+	delete(m.restartedAt, key)
 }
 
-// handlePodListKeys handles keys specific to the pod list view
-func (m Model) handlePodListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+// podRegression describes how a pod got worse since it was last seen:
+// it failed, started crash looping or restarted. It is empty otherwise.
+func podRegression(prev, pod k8s.PodInfo) string {
 	switch {
-	case key.Matches(msg, m.keys.Up):
+	case pod.Status == k8s.PodStatusFailed && prev.Status != k8s.PodStatusFailed:
+		return "failed"
+	case crashLoopingPod(pod) && !crashLoopingPod(prev):
+		return "is in CrashLoopBackOff"
+	case podRestarted(prev, pod):
+		return "restarted"
+	}
+	return ""
+}
+
+// podRestarted reports whether a container of pod restarted since prev.
+// Containers are paired by name and compared with k8s.CompareContainer, so
+// one container being recreated doesn't hide another's restart.
+func podRestarted(prev, pod k8s.PodInfo) bool {
+	for _, c := range pod.Containers {
+		i := slices.IndexFunc(prev.Containers, func(p k8s.ContainerStatus) bool { return p.Name == c.Name })
+		if i < 0 {
+			continue
+		}
+		if change, _ := k8s.CompareContainer(prev.Containers[i], c); change == k8s.ContainerRestarted {
+			return true
+		}
+	}
+	return false
+}
+
+// crashLoopingPod reports whether any container of the pod is in
+// CrashLoopBackOff
+func crashLoopingPod(pod k8s.PodInfo) bool {
+	return slices.ContainsFunc(pod.Containers, k8s.ContainerStatus.IsCrashLooping)
+}
+
+// ringBell rings the terminal bell
+func (m Model) ringBell() tea.Cmd {
+	out := m.bellOut
+	if out == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		fmt.Fprint(out, "\a")
+		return nil
+	}
+}
+
+// restartsCell renders a pod's restart count, marked if it just went up
+func (m Model) restartsCell(pod k8s.PodInfo) string {
+	restarts := strconv.Itoa(int(pod.Restarts))
+	if _, ok := m.restartedAt[podKey(pod)]; !ok {
+		return restarts
+	}
+	if m.styles.Verbose {
+		return restarts + " new"
+	}
+	return restarts + "↑"
+}
+
+// logStreamChanMsg carries the log channel after stream creation
+type logStreamChanMsg struct {
+	id      int
+	logChan <-chan k8s.LogLine
+}
+
+// initLogStream prepares and starts log streaming for the selected pod
+func (m *Model) initLogStream() tea.Cmd {
+	if m.k8sClient == nil {
+		return func() tea.Msg {
+			return logStreamErrorMsg{id: m.logStreamID, err: fmt.Errorf("k8s client not initialized")}
+		}
+	}
+
+	if m.logSelector != "" {
+		return m.restartWorkloadLogStream()
+	}
+
+	pod, ok := m.selectedPod()
+	if !ok {
+		return func() tea.Msg {
+			return logStreamErrorMsg{id: m.logStreamID, err: fmt.Errorf("no pod selected")}
+		}
+	}
+
+	// Stop any existing stream
+	m.stopLogStream()
+	m.logView.CloseSplit()
+
+	// Determine container to use
+	container := m.selectedContainer
+	if container == "" {
+		container = pod.PrimaryContainer()
+	}
+
+	// Set up log view
+	m.logView.SetBackground(false)
+	m.logView.Clear()
+	if m.logAllContainers {
+		m.logView.SetPodInfo(pod.Namespace, pod.Name, allContainersLabel)
+	} else {
+		m.logView.SetPodInfo(pod.Namespace, pod.Name, container)
+	}
+	m.logView.SetCombined(m.logAllContainers)
+	m.logView.SetPrevious(!m.logAllContainers && crashLooping(pod, container))
+	m.logView.SetTailLines(m.logTailLines)
+	m.logView.SetState(ui.LogViewStateStreaming)
+	m.selectedContainer = container
+	m.logNamespace = pod.Namespace
+	m.logPod = pod.Name
+	m.logLastLineAt = time.Time{}
+
+	// Pick up where the same logs were left, streaming only what arrived
+	// since instead of the tail again
+	if entry, ok := m.takeLogView(m.logCacheKey()); ok && !m.logView.IsPrevious() {
+		m.logView.Restore(entry.snapshot)
+		m.logLastLineAt = entry.lastLineAt
+		since := entry.lastLineAt
+		return m.openLogStream(&since)
+	}
+
+	return m.openLogStream(nil)
+}
+
+// logCacheKey identifies the streamed logs of one container, or of every
+// container of the pod, in the current context
+func (m Model) logCacheKey() string {
+	kubeContext := ""
+	if m.k8sClient != nil {
+		kubeContext = m.k8sClient.CurrentContext()
+	}
+	container := m.selectedContainer
+	if m.logAllContainers {
+		container = allContainersLabel
+	}
+	return strings.Join([]string{kubeContext, m.logNamespace, m.logPod, container}, "/")
+}
+
+// saveLogView remembers the log view being left so reopening the same logs
+// restores it, dropping the oldest saved view beyond maxLogCacheEntries.
+// Workload logs and a previous instance's aren't kept: their pods change.
+// Nor are logs without a server timestamp to resume from.
+func (m *Model) saveLogView() {
+	if m.logSelector != "" || m.logView.IsPrevious() || m.logView.LineCount() == 0 || m.logLastLineAt.IsZero() {
+		return
+	}
+	key := m.logCacheKey()
+	m.takeLogView(key)
+	m.logCache[key] = logCacheEntry{snapshot: m.logView.Snapshot(), lastLineAt: m.logLastLineAt}
+	m.logCacheOrder = append(m.logCacheOrder, key)
+	if len(m.logCacheOrder) > maxLogCacheEntries {
+		delete(m.logCache, m.logCacheOrder[0])
+		m.logCacheOrder = m.logCacheOrder[1:]
+	}
+}
+
+// takeLogView removes and returns the log view saved under key
+func (m *Model) takeLogView(key string) (logCacheEntry, bool) {
+	entry, ok := m.logCache[key]
+	if ok {
+		delete(m.logCache, key)
+		m.logCacheOrder = slices.DeleteFunc(m.logCacheOrder, func(k string) bool { return k == key })
+	}
+	return entry, ok
+}
+
+// initDeploymentLogStream starts tailing the logs of every pod of a
+// deployment, following pods as they are replaced
+func (m *Model) initDeploymentLogStream(d k8s.DeploymentInfo) tea.Cmd {
+	m.logSelector = d.Selector
+	m.logNamespace = d.Namespace
+	m.logPod = d.Name
+	m.logView.SetPodInfo(d.Namespace, "deployment/"+d.Name, allPodsLabel)
+	return m.restartWorkloadLogStream()
+}
+
+// restartWorkloadLogStream (re)opens the merged stream of the workload in
+// the log view, e.g. after the tail length changed
+func (m *Model) restartWorkloadLogStream() tea.Cmd {
+	m.stopLogStream()
+	m.logView.CloseSplit()
+
+	m.logView.SetBackground(false)
+	m.logView.Clear()
+	m.logView.SetCombined(true)
+	m.logView.SetPrevious(false)
+	m.logView.SetTailLines(m.logTailLines)
+	m.logView.SetState(ui.LogViewStateStreaming)
+	m.logLastLineAt = time.Time{}
+
+	return m.openLogStream(nil)
+}
+
+// crashLooping reports whether a container of the pod is in
+// CrashLoopBackOff, so its current instance likely has no logs yet and the
+// previous instance's logs tell why it died
+func crashLooping(pod k8s.PodInfo, container string) bool {
+	status, ok := pod.Container(container)
+	return ok && status.IsCrashLooping()
+}
+
+// openLogStream opens the stream for the pod and container in the log view.
+// With since set it resumes after the line stamped then instead of
+// tailing, so a reconnect doesn't repeat the lines already shown.
+func (m *Model) openLogStream(since *time.Time) tea.Cmd {
+	m.logResumeAfter = time.Time{}
+	if since != nil {
+		m.logResumeAfter = *since
+	}
+
+	// Create context for this stream
+	ctx, cancel := context.WithCancel(context.Background())
+	m.logCancel = cancel
+	m.logStreamActive = true
+
+	// Capture values for closure
+	namespace := m.logNamespace
+	podName := m.logPod
+	container := m.selectedContainer
+	client := m.k8sClient
+	allContainers := m.logAllContainers
+	selector := m.logSelector
+	tailLines := m.logTailLines
+	previous := m.logView.IsPrevious()
+	id := m.logStreamID
+
+	return func() tea.Msg {
+		// The previous instance has exited, so there is nothing to follow
+		opts := k8s.LogOptions{
+			Namespace:  namespace,
+			Pod:        podName,
+			Container:  container,
+			Follow:     !previous,
+			TailLines:  tailLines,
+			Timestamps: true,
+			SinceTime:  since,
+			Previous:   previous,
+		}
+		if since != nil {
+			opts.TailLines = 0
+		}
+
+		var logChan <-chan k8s.LogLine
+		var err error
+		if selector != "" {
+			sel, parseErr := labels.Parse(selector)
+			if parseErr != nil {
+				return logStreamErrorMsg{id: id, err: fmt.Errorf("invalid selector %q: %w", selector, parseErr)}
+			}
+			logChan, err = client.StreamLogsMultiPod(ctx, namespace, sel, opts)
+		} else if allContainers {
+			logChan, err = client.StreamLogsAllContainers(ctx, namespace, podName, opts)
+		} else {
+			logChan, err = client.StreamLogs(ctx, opts)
+		}
+		if err != nil {
+			return logStreamErrorMsg{id: id, err: err}
+		}
+
+		// Return the channel so we can store it
+		return logStreamChanMsg{id: id, logChan: logChan}
+	}
+}
+
+// resumeLogView brings a log view that went to the background back to the
+// front, rendering the lines buffered meanwhile. Reading stopped when it
+// went to the background, so it starts again.
+func (m *Model) resumeLogView() tea.Cmd {
+	if !m.logView.IsBackground() {
+		return nil
+	}
+	m.logView.SetBackground(false)
+	if !m.logStreamActive {
+		return nil
+	}
+	return waitForNextLogLine(m.logStreamID, m.logChan)
+}
+
+// waitForNextLogLine waits for the next line from an existing channel
+func waitForNextLogLine(id int, logChan <-chan k8s.LogLine) tea.Cmd {
+	if logChan == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		line, ok := <-logChan
+		if !ok {
+			return logStreamEndedMsg{id: id}
+		}
+		if line.Error != nil {
+			return logStreamErrorMsg{id: id, err: line.Error}
+		}
+		return logLineMsg{id: id, line: line}
+	}
+}
+
+// stopLogStream stops the current log stream
+func (m *Model) stopLogStream() {
+	if m.logCancel != nil {
+		m.logCancel()
+		m.logCancel = nil
+	}
+	m.logChan = nil
+	m.logStreamActive = false
+	m.logStreamID++
+	m.logReconnects = 0
+	m.logView.SetState(ui.LogViewStateEnded)
+}
+
+// scheduleLogReconnect reopens a followed stream that the API server closed
+// (idle timeout, apiserver restart) after a backoff. A stream stopped by
+// the user never gets here: stopLogStream bumps the id so its end is
+// dropped. It returns nil once follow is off or the attempts are used up,
+// and for the previous instance of a container, which has exited.
+func (m *Model) scheduleLogReconnect() tea.Cmd {
+	if !m.logView.IsFollow() || m.logView.IsPrevious() || m.logReconnects >= maxLogReconnects {
+		return nil
+	}
+	if m.logCancel != nil {
+		m.logCancel()
+		m.logCancel = nil
+	}
+	m.logChan = nil
+	m.logStreamID++
+	m.logReconnects++
+
+	id := m.logStreamID
+	delay := retryDelay(m.logReconnects)
+	return tea.Batch(
+		m.setStatusFor(fmt.Sprintf("Log stream ended; reconnecting (attempt %d)...", m.logReconnects), delay+statusMessageTTL),
+		tea.Tick(delay, func(time.Time) tea.Msg {
+			return logReconnectMsg{id: id}
+		}),
+	)
+}
+
+// Update implements tea.Model
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.help.Width = msg.Width
+		m.logView.SetSize(msg.Width, msg.Height-4) // Reserve space for header/footer
+		m.execView.SetSize(msg.Width, msg.Height-4)
+		m.filesView.SetSize(msg.Width, msg.Height-4)
+		m.yamlView.SetSize(msg.Width, msg.Height-4)
+		m.envView.SetSize(msg.Width, msg.Height-4)
+		m.eventsView.SetSize(msg.Width, msg.Height-4)
+		m.dataView.SetSize(msg.Width, msg.Height-4)
+		m.prompt.SetWidth(msg.Width)
+		m.search.SetSize(msg.Width, msg.Height-4)
+		m.ready = true
+		return m, nil
+
+	case k8sClientReadyMsg:
+		m.loadingK8s = false
+		if errors.Is(msg.err, k8s.ErrNoCurrentContext) {
+			// Nothing to load pods from until a context is chosen
+			m.k8sErr = msg.err
+			m.prevView = model.ViewPodList
+			m.view = model.ViewContextSelector
+			return m, m.loadContexts
+		}
+		if msg.err != nil {
+			m.k8sErr = msg.err
+			return m, nil
+		}
+		m.k8sClient = msg.client
+		m.loadingPods = true
+		// Load pods and contexts after client is ready
+		return m, tea.Batch(m.loadPods, m.loadContexts, m.startPing())
+
+	case podsLoadedMsg:
+		if msg.id != m.podsID {
+			return m, nil // Listed before a namespace or context switch
+		}
+		if msg.allNamespaces != m.allNamespaces {
+			return m, nil // Listed before the mode was toggled
+		}
+		m.loadingPods = false
+		if msg.allNamespaces && k8s.IsForbidden(msg.err) {
+			// RBAC may only grant access to single namespaces
+			m.allNamespaces = false
+			m.loadingPods = true
+			return m, tea.Batch(m.loadPods, m.setStatus(fmt.Sprintf(
+				"Not allowed to list pods in all namespaces; showing namespace %s", m.k8sClient.CurrentNamespace())))
+		}
+		if msg.err != nil {
+			m.k8sErr = msg.err
+			if !k8s.IsRetryable(msg.err) {
+				m.podsRetry = 0
+				return m, nil
+			}
+			m.podsRetry++
+			attempt := m.podsRetry
+			return m, tea.Tick(retryDelay(attempt), func(time.Time) tea.Msg {
+				return retryPodsMsg{attempt: attempt}
+			})
+		}
+		m.podsRetry = 0
+		m.keepPodSelection(func() { m.pods = msg.pods })
+		m.lastRefresh = time.Now()
+		m.k8sErr = nil
+		flashCmd := m.trackPods(msg.pods, true)
+		if m.stickyWorkload != "" {
+			m.selectPodByWorkload(m.stickyWorkload)
+			m.stickyWorkload = ""
+		}
+		m.clampPodSelection()
+		// Keep the list current with a watch instead of polling
+		return m, tea.Batch(m.startPodWatch(), flashCmd)
+
+	case podWatchStartedMsg:
+		if msg.id != m.podWatchID {
+			return m, nil
+		}
+		if msg.err != nil {
+			return m, m.setStatus(fmt.Sprintf("Pod watch unavailable: %v", msg.err))
+		}
+		return m, waitForNextPodEvent(msg.id, msg.events)
+
+	case podEventMsg:
+		if msg.id != m.podWatchID {
+			return m, nil
+		}
+		m.applyPodEvent(msg.event)
+		var flashCmd tea.Cmd
+		if msg.event.Type == k8s.PodEventDeleted {
+			m.forgetPod(podKey(msg.event.Pod))
+		} else {
+			flashCmd = m.trackPods([]k8s.PodInfo{msg.event.Pod}, false)
+		}
+		return m, tea.Batch(waitForNextPodEvent(msg.id, msg.events), flashCmd)
+
+	case podWatchEndedMsg:
+		if msg.id != m.podWatchID {
+			return m, nil
+		}
+		// Watches end routinely (server timeouts); relist to catch up on missed changes
+		resync := tea.Tick(podWatchResyncDelay, func(time.Time) tea.Msg {
+			return podWatchResyncMsg{id: msg.id}
+		})
+		if msg.err != nil {
+			return m, tea.Batch(resync, m.setStatus(msg.err.Error()))
+		}
+		return m, resync
+
+	case podWatchResyncMsg:
+		if msg.id != m.podWatchID {
+			return m, nil
+		}
+		return m, m.loadPods
+
+	case deploymentsLoadedMsg:
+		m.loadingDeployments = false
+		m.deploymentsErr = msg.err
+		if msg.err != nil {
+			return m, nil
+		}
+		m.deployments = msg.deployments
+		if n := len(m.deployments); m.selectedDeploymentIndex >= n {
+			m.selectedDeploymentIndex = max(n-1, 0)
+		}
+		return m, nil
+
+	case topMetricsLoadedMsg:
+		if msg.id != m.topID {
+			return m, nil
+		}
+		m.loadingTop = false
+		m.topErr = msg.err
+		if msg.err == nil {
+			m.topMetrics = msg.metrics
+			if n := len(m.topMetrics); m.selectedTopIndex >= n {
+				m.selectedTopIndex = max(n-1, 0)
+			}
+		}
+		// Keep polling even when metrics are unavailable, since
+		// metrics-server may just be starting
+		id := m.topID
+		return m, tea.Tick(topRefreshInterval, func(time.Time) tea.Msg {
+			return topTickMsg{id: id}
+		})
+
+	case topTickMsg:
+		if msg.id != m.topID || m.view != model.ViewTop {
+			return m, nil
+		}
+		return m, m.loadTopMetrics
+
+	case servicesLoadedMsg:
+		m.loadingServices = false
+		m.servicesErr = msg.err
+		if msg.err != nil {
+			return m, nil
+		}
+		m.services = msg.services
+		if n := len(m.services); m.selectedServiceIndex >= n {
+			m.selectedServiceIndex = max(n-1, 0)
+		}
+		return m, nil
+
+	case workloadRestartedMsg:
+		if msg.err != nil {
+			return m, m.setStatus(msg.err.Error())
+		}
+		id := m.podWatchID
+		return m, tea.Batch(m.setStatus(msg.status), tea.Tick(restartRefreshDelay, func(time.Time) tea.Msg {
+			return podWatchResyncMsg{id: id}
+		}))
+
+	case deploymentActionMsg:
+		if msg.err != nil {
+			return m, m.setStatus(msg.err.Error())
+		}
+		// Reload so the list reflects the new replica counts
+		return m, tea.Batch(m.setStatus(msg.status), m.loadDeployments)
+
+	case contextSwitchRequestedMsg:
+		return m, m.switchContext(msg.name, msg.persist)
+
+	case contextSwitchedMsg:
+		if msg.id != m.contextSwitchID {
+			return m, nil
+		}
+		m.switchingContext = ""
+		if msg.err != nil {
+			// The old client is still intact, so keep using it
+			return m, m.setStatus(fmt.Sprintf("Failed to switch context: %v", msg.err))
+		}
+		return m, m.useContextClient(msg.client, msg.persist)
+
+	case contextPersistedMsg:
+		if msg.err != nil {
+			return m, m.setStatus(fmt.Sprintf("Failed to save default context: %v", msg.err))
+		}
+		return m, m.setStatus(fmt.Sprintf("Saved %s as the kubeconfig's current context", msg.name))
+
+	case clipboardCopiedMsg:
+		if msg.err != nil {
+			return m, m.setStatus(fmt.Sprintf("Could not copy %s: %v", msg.what, msg.err))
+		}
+		return m, m.setStatus(fmt.Sprintf("Copied %s", msg.what))
+
+	case bundleSavedMsg:
+		if msg.err != nil {
+			return m, m.setStatus(fmt.Sprintf("Bundle failed: %v", msg.err))
+		}
+		return m, m.setStatus(fmt.Sprintf("Bundle saved to %s", msg.path))
+
+	case configReloadedMsg:
+		if msg.err != nil {
+			m.loadingPods = false
+			m.k8sErr = msg.err
+			return m, m.setStatus("Reload failed")
+		}
+		m.k8sClient = msg.client
+		m.k8sErr = nil
+		m.stopPodWatch()
+		m.loadingPods = true
+		m.podsID++
+		m.loadingNamespaces = true
+		m.namespacesID++
+		statusCmd := m.setStatus("Reloaded")
+		return m, tea.Batch(m.loadPods, m.loadNamespaces, m.loadContexts, m.startPing(), statusCmd)
+
+	case pingTickMsg:
+		if msg.id != m.pingID {
+			return m, nil
+		}
+		return m, m.ping()
+
+	case pingResultMsg:
+		if msg.id != m.pingID {
+			return m, nil
+		}
+		m.pingChecked = true
+		m.pingLatency = msg.latency
+		if msg.err != nil {
+			m.pingFailures++
+		} else {
+			m.pingFailures = 0
+		}
+		id := msg.id
+		return m, tea.Tick(pingInterval, func(time.Time) tea.Msg {
+			return pingTickMsg{id: id}
+		})
+
+	case clearStatusMsg:
+		if msg.id == m.statusID {
+			m.statusMessage = ""
+		}
+		return m, nil
+
+	case restartFlashMsg:
+		for key, at := range m.restartedAt {
+			if time.Since(at) >= restartFlashTTL {
+				delete(m.restartedAt, key)
+			}
+		}
+		return m, nil
+
+	case gotoTimeoutMsg:
+		if msg.id == m.gotoID {
+			m.stopGoto()
+		}
+		return m, nil
+
+	case retryPodsMsg:
+		if msg.attempt != m.podsRetry {
+			return m, nil
+		}
+		return m, m.loadPods
+
+	case namespacesLoadedMsg:
+		if msg.id != m.namespacesID {
+			return m, nil // Listed before the cluster changed
+		}
+		m.loadingNamespaces = false
+		m.namespacesErr = msg.err
+		if msg.err != nil {
+			if !k8s.IsRetryable(msg.err) {
+				m.namespacesRetry = 0
+				return m, nil
+			}
+			m.namespacesRetry++
+			attempt := m.namespacesRetry
+			return m, tea.Tick(retryDelay(attempt), func(time.Time) tea.Msg {
+				return retryNamespacesMsg{attempt: attempt}
+			})
+		}
+		m.namespacesRetry = 0
+		m.namespaces = msg.namespaces
+		// Find and select current namespace
+		for i, ns := range m.namespaces {
+			if ns.IsCurrent {
+				m.selectedNamespaceIndex = i
+				break
+			}
+		}
+		m.search.SetCandidates(m.searchCandidates())
+		if m.view == model.ViewNamespaceSelector {
+			return m, m.loadNamespaceSummaries()
+		}
+		return m, nil
+
+	case namespaceSummaryMsg:
+		if msg.id != m.namespaceSummaryID || msg.index >= len(m.namespaces) {
+			return m, nil
+		}
+		// A namespace that can't be summarized just shows no counts
+		if msg.err == nil {
+			m.namespaces[msg.index].SetSummary(msg.summary)
+		}
+		// Stop once the selector closes; reopening it starts over
+		if m.view != model.ViewNamespaceSelector {
+			return m, nil
+		}
+		return m, m.loadNamespaceSummary(msg.index + namespaceSummaryWorkers)
+
+	case retryNamespacesMsg:
+		if msg.attempt != m.namespacesRetry {
+			return m, nil
+		}
+		return m, m.loadNamespaces
+
+	case contextsLoadedMsg:
+		if msg.err != nil {
+			m.k8sErr = msg.err
+			return m, nil
+		}
+		m.contexts = msg.contexts
+		// Find and select current context
+		for i, ctx := range m.contexts {
+			if ctx.IsCurrent {
+				m.selectedContextIndex = i
+				break
+			}
+		}
+		m.search.SetCandidates(m.searchCandidates())
+		return m, nil
+
+	case logStreamChanMsg:
+		if msg.id != m.logStreamID {
+			return m, nil
+		}
+		// Store the channel and start reading, or wait for the logs to be
+		// in front again if a reconnect finished behind another view
+		m.logChan = msg.logChan
+		m.logView.SetState(ui.LogViewStateStreaming)
+		if m.view != model.ViewLogs {
+			m.logView.SetBackground(true)
+			return m, nil
+		}
+		return m, waitForNextLogLine(m.logStreamID, m.logChan)
+
+	case logStreamStartedMsg:
+		m.selectedContainer = msg.container
+		m.logView.SetState(ui.LogViewStateStreaming)
+		return m, nil
+
+	case logLineMsg:
+		if msg.id != m.logStreamID {
+			return m, nil
+		}
+		if msg.line.Error != nil {
+			m.logView.SetError(msg.line.Error.Error())
+			m.logStreamActive = false
+			return m, nil
+		}
+		// A resumed stream starts at the second of the last line shown, so
+		// lines up to it are repeats
+		if at := msg.line.Timestamp; at.IsZero() || at.After(m.logResumeAfter) {
+			// Lines arriving while another view is active are buffered silently
+			m.logView.SetBackground(m.view != model.ViewLogs)
+			m.logView.AddLine(msg.line.Content)
+			if !at.IsZero() {
+				m.logLastLineAt = at
+			}
+			m.logReconnects = 0
+		}
+		// Continue reading if stream is active
+		if m.logStreamActive && m.view == model.ViewLogs && m.logChan != nil {
+			return m, waitForNextLogLine(m.logStreamID, m.logChan)
+		}
+		return m, nil
+
+	case logStreamErrorMsg:
+		if msg.id != m.logStreamID {
+			return m, nil
+		}
+		// A failed reconnect keeps trying while the error is transient
+		if m.logReconnects > 0 && k8s.IsRetryable(msg.err) {
+			if cmd := m.scheduleLogReconnect(); cmd != nil {
+				return m, cmd
+			}
+		}
+		m.logView.SetError(msg.err.Error())
+		m.logStreamActive = false
+		return m, nil
+
+	case logStreamEndedMsg:
+		if msg.id != m.logStreamID {
+			return m, nil
+		}
+		if cmd := m.scheduleLogReconnect(); cmd != nil {
+			return m, cmd
+		}
+		m.logView.SetState(ui.LogViewStateEnded)
+		m.logStreamActive = false
+		return m, nil
+
+	case logReconnectMsg:
+		if msg.id != m.logStreamID || m.k8sClient == nil {
+			return m, nil
+		}
+		// Without a line to resume after, the tail repeats nothing
+		if m.logLastLineAt.IsZero() {
+			return m, m.openLogStream(nil)
+		}
+		since := m.logLastLineAt
+		return m, m.openLogStream(&since)
+
+	case splitLogsLoadedMsg:
+		// Dropped once the split was closed or other logs were opened
+		if !m.logView.IsSplit() || msg.key != m.logCacheKey() {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.logView.SetSplitError(msg.err.Error())
+			return m, nil
+		}
+		m.logView.SetSplitLines(msg.lines)
+		return m, nil
+
+	case execStreamMsg:
+		if msg.id != m.execID {
+			return m, nil
+		}
+		m.execChunks = msg.chunks
+		return m, waitForExecChunk(m.execID, m.execChunks)
+
+	case execOutputMsg:
+		if msg.id != m.execID {
+			return m, nil
+		}
+		m.execView.AddOutput(strings.TrimSuffix(msg.chunk.Data, "\n"), msg.chunk.Stderr)
+		return m, waitForExecChunk(m.execID, m.execChunks)
+
+	case execResultMsg:
+		if msg.id != m.execID {
+			return m, nil
+		}
+		// Release the finished command's context, and its stdin with it
+		if m.execCancel != nil {
+			m.execCancel()
+			m.execCancel = nil
+		}
+		m.execChunks = nil
+		m.execRunning = false
+		if msg.result.Error != nil {
+			m.execView.SetError(msg.result.Error.Error())
+			m.execView.AddOutput(msg.result.Stderr, true)
+		} else {
+			m.execView.SetExitCode(msg.result.ExitCode)
+			if msg.result.Stdout != "" {
+				m.execView.AddOutput(msg.result.Stdout, false)
+			}
+			if msg.result.Stderr != "" {
+				m.execView.AddOutput(msg.result.Stderr, true)
+			}
+		}
+		if k8s.IsExecNotFound(msg.result.Error) {
+			m.execView.AddOutput("Command not found. No shell? Press Ctrl+T to start a debug container.", false)
+		}
+		m.execView.Focus()
+		return m, nil
+
+	case debugContainerMsg:
+		if msg.id != m.execID {
+			return m, nil
+		}
+		m.execRunning = false
+		if msg.err != nil {
+			m.execView.SetError(msg.err.Error())
+		} else {
+			pod, ok := m.selectedPod()
+			if ok {
+				m.execView.SetPodInfo(pod.Namespace, pod.Name, msg.container)
+			}
+			m.execView.SetState(ui.ExecViewStateIdle)
+			m.execView.AddOutput(fmt.Sprintf("Debug container %s is running; commands now run in it", msg.container), false)
+		}
+		m.execView.Focus()
+		return m, nil
+
+	case gotoPathCheckedMsg:
+		if msg.err != nil {
+			return m, m.setStatus(fmt.Sprintf("Cannot go to %s: %v", msg.path, msg.err))
+		}
+		m.filesView.JumpTo(msg.path)
+		m.filesView.SetState(ui.FileBrowserStateLoading)
+		return m, m.loadDirectory(msg.path)
+
+	case pathCompletionMsg:
+		// Drop completions for input the user has since changed
+		if m.view != model.ViewPrompt || m.promptAction != promptGotoPath || m.prompt.Value() != msg.input {
+			return m, nil
+		}
+		if msg.err != nil {
+			return m, m.setStatus(fmt.Sprintf("Cannot complete %s: %v", msg.input, msg.err))
+		}
+		value, candidates := completePath(msg.dir, msg.prefix, msg.entries)
+		if value == "" {
+			return m, m.setStatus(fmt.Sprintf("No matches for %s", msg.input))
+		}
+		m.prompt.SetValue(value)
+		if len(candidates) > 1 {
+			return m, m.setStatus(strings.Join(candidates, "  "))
+		}
+		return m, nil
+
+	case dirLoadedMsg:
+		if msg.err != nil {
+			m.filesView.SetError(msg.err.Error())
+			return m, nil
+		}
+		m.filesView.SetCurrentPath(msg.path)
+		m.filesView.SetEntries(msg.entries)
+		return m, nil
+
+	case fileEditLoadedMsg:
+		switch {
+		case msg.err != nil:
+			return m, m.setStatus(fmt.Sprintf("Could not open %s: %v", msg.filename, msg.err))
+		case len(msg.content) > maxEditBytes:
+			return m, m.setStatus(fmt.Sprintf("%s is too large to edit (over %s)", msg.filename, k8s.FormatSize(maxEditBytes)))
+		case k8s.IsBinary([]byte(msg.content)):
+			return m, m.setStatus(fmt.Sprintf("%s is a binary file and can't be edited", msg.filename))
+		}
+		return m, openEditor(msg.path, msg.filename, msg.content)
+
+	case fileEditedMsg:
+		return m, m.saveEditedFile(msg)
+
+	case pagerClosedMsg:
+		_ = os.Remove(msg.tmpPath)
+		if m.pagerPaused && m.logView.IsPaused() {
+			m.logView.TogglePause()
+		}
+		m.pagerPaused = false
+		if msg.err != nil {
+			return m, m.setStatus(fmt.Sprintf("Pager failed: %v", msg.err))
+		}
+		return m, nil
+
+	case bookmarksSavedMsg:
+		if msg.err != nil {
+			return m, m.setStatus(fmt.Sprintf("Could not save bookmarks: %v", msg.err))
+		}
+		return m, nil
+
+	case fileWrittenMsg:
+		if msg.err != nil {
+			return m, m.setStatus(fmt.Sprintf("Could not save %s: %v", msg.filename, msg.err))
+		}
+		// Writing the file doesn't signal the process, so anything that
+		// only reads its config at startup keeps the old contents
+		status := m.setStatus(fmt.Sprintf("Saved %s; the app won't see the change until it reloads its config", msg.filename))
+		if m.view == model.ViewFiles && m.filesView.ViewingFile() == msg.filename {
+			return m, tea.Batch(status, m.loadFileContent(msg.path, msg.filename))
+		}
+		return m, status
+
+	case fileContentMsg:
+		if msg.err != nil && msg.offset > 0 {
+			m.filesView.FailMoreContent()
+			return m, m.setStatus(fmt.Sprintf("Could not read more of %s: %v", msg.filename, msg.err))
+		}
+		if msg.err != nil {
+			m.filesView.SetError(msg.err.Error())
+			return m, nil
+		}
+		m.filesView.SetFileChunk(msg.filename, msg.chunk)
+		return m, nil
+
+	case podYAMLMsg:
+		if msg.err != nil {
+			m.yamlView.SetError(msg.err.Error())
+			return m, nil
+		}
+		m.yamlView.SetContent(msg.content)
+		return m, nil
+
+	case envLoadedMsg:
+		if msg.target != m.envTarget || msg.requested != m.envView.Declared() {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.envView.SetError(msg.err.Error())
+			return m, nil
+		}
+		note := ""
+		if msg.declared != msg.requested {
+			note = "No env command in the container; showing the declared environment"
+		}
+		m.envView.SetVars(msg.vars, msg.declared, note)
+		return m, nil
+
+	case eventsLoadedMsg:
+		if msg.all != m.eventsAll {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.eventsView.SetError(msg.err.Error())
+			return m, nil
+		}
+		m.eventsView.SetContent(ui.FormatEvents(msg.events, msg.all, m.styles))
+		// Oldest first, so start at the most recent events
+		m.eventsView.GotoBottom()
+		return m, nil
+
+	case dataItemsLoadedMsg:
+		if msg.kind != m.dataView.Kind() {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.dataView.SetError(msg.err.Error())
+			return m, nil
+		}
+		m.dataView.SetItems(msg.items)
+		return m, nil
+
+	case tea.KeyMsg:
+		newModel, cmd := m.handleKeyPress(msg)
+		// Closing help or an overlay brings the logs back to the front
+		if next, ok := newModel.(Model); ok && next.view == model.ViewLogs {
+			resume := next.resumeLogView()
+			return next, tea.Batch(cmd, resume)
+		}
+		return newModel, cmd
+	}
+
+	return m, nil
+}
+
+// handleKeyPress processes keyboard input
+func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Text prompts capture every key, including ones bound to global actions
+	switch m.view {
+	case model.ViewPrompt:
+		return m.handlePromptKeys(msg)
+	case model.ViewConfirm:
+		return m.handleConfirmKeys(msg)
+	case model.ViewSearch:
+		return m.handleSearchKeys(msg)
+	}
+
+	// Ctrl+C interrupts a running exec command instead of quitting
+	if m.view == model.ViewExec && m.execRunning && msg.Type == tea.KeyCtrlC {
+		m.interruptExec()
+		return m, nil
+	}
+
+	// Printable keys belong to a focused input, so only non-printable
+	// bindings such as ctrl+c quit from there
+	typing := m.inputFocused() && (msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace)
+
+	// Global keybindings that work in any view
+	switch {
+	case !typing && key.Matches(msg, m.keys.Quit):
+		return m, m.quit()
+
+	case !typing && key.Matches(msg, m.keys.Help):
+		m.showHelp = !m.showHelp
+		if m.showHelp {
+			m.helpReturnView = m.view
+			m.view = model.ViewHelp
+		} else {
+			m.view = m.helpReturnView
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Back):
+		return m.handleBack()
+	}
+
+	// View-specific keybindings
+	switch m.view {
+	case model.ViewPodList:
+		return m.handlePodListKeys(msg)
+	case model.ViewDeployments:
+		return m.handleDeploymentsKeys(msg)
+	case model.ViewTop:
+		return m.handleTopKeys(msg)
+	case model.ViewLogs:
+		return m.handleLogViewKeys(msg)
+	case model.ViewExec:
+		return m.handleExecViewKeys(msg)
+	case model.ViewFiles:
+		return m.handleFilesViewKeys(msg)
+	case model.ViewNamespaceSelector:
+		return m.handleNamespaceSelectorKeys(msg)
+	case model.ViewContextSelector:
+		return m.handleContextSelectorKeys(msg)
+	case model.ViewServices:
+		return m.handleServicesKeys(msg)
+	case model.ViewContainerSelector:
+		return m.handleContainerSelectorKeys(msg)
+	case model.ViewYAML:
+		var cmd tea.Cmd
+		m.yamlView, cmd = m.yamlView.Update(msg)
+		return m, cmd
+	case model.ViewEvents:
+		return m.handleEventsKeys(msg)
+	case model.ViewEnv:
+		return m.handleEnvKeys(msg)
+	case model.ViewConfigMaps, model.ViewSecrets:
+		return m.handleDataBrowserKeys(msg)
+	case model.ViewAbout:
+		// Read-only; any key closes it
+		m.view = m.prevView
+		return m, nil
+	case model.ViewHelp:
+		// Any key except ? closes help
+		m.showHelp = false
+		m.view = m.helpReturnView
+		return m, nil
+	}
+
+	return m, nil
+}
+
+// inputFocused reports whether keystrokes go to a text input
+func (m Model) inputFocused() bool {
+	switch m.view {
+	case model.ViewPrompt, model.ViewSearch, model.ViewConfirm:
+		return true
+	case model.ViewExec:
+		return m.execView.IsFocused() && !m.execView.ShowingPresets()
+	case model.ViewFiles:
+		return m.filesView.IsFiltering()
+	case model.ViewPodList:
+		return m.gotoActive
+	}
+	return false
+}
+
+// handleBack handles the escape/back key
+func (m Model) handleBack() (tea.Model, tea.Cmd) {
+	// From the data browser, leave a value or key list before closing
+	if (m.view == model.ViewConfigMaps || m.view == model.ViewSecrets) && m.dataView.Back() {
+		return m, nil
+	}
+
+	// From the pod list, leave jump-to-pod mode first
+	if m.view == model.ViewPodList && m.gotoActive {
+		m.stopGoto()
+		return m, nil
+	}
+
+	// From the pod list, clear a label selector first
+	if m.view == model.ViewPodList && m.labelSelector != "" {
+		m.labelSelector = ""
+		return m, m.reloadPods()
+	}
+
+	// From the pod list, clear a service filter and go back to the services
+	if m.view == model.ViewPodList && m.serviceFilter.Name != "" {
+		m.serviceFilter = k8s.ServiceInfo{}
+		m.clampPodSelection()
+		m.view = model.ViewServices
+		return m, nil
+	}
+
+	if m.view == model.ViewHelp {
+		m.view = m.helpReturnView
+		m.showHelp = false
+		return m, nil
+	}
+
+	if m.view.IsOverlay() {
+		m.view = m.prevView
+		m.showHelp = false
+		return m, nil
+	}
+
+	// From log view, clear an active search first
+	if m.view == model.ViewLogs && m.logView.SearchTerm() != "" {
+		m.logView.ClearSearch()
+		return m, nil
+	}
+
+	// From log view, close the split pane first
+	if m.view == model.ViewLogs && m.logView.IsSplit() {
+		m.logView.CloseSplit()
+		return m, nil
+	}
+
+	// From log view, stop streaming and go back to where the logs were opened
+	if m.view == model.ViewLogs {
+		m.saveLogView()
+		m.stopLogStream()
+		m.view = model.ViewPodList
+		if m.logSelector != "" {
+			m.logSelector = ""
+			m.view = model.ViewDeployments
+		}
+		return m, nil
+	}
+
+	// From exec view, close the presets menu first
+	if m.view == model.ViewExec && m.execView.ShowingPresets() {
+		m.execView.ClosePresets()
+		return m, nil
+	}
+
+	// From exec view, stop any running command and go back
+	if m.view == model.ViewExec {
+		m.stopExec()
+		m.view = model.ViewPodList
+		return m, nil
+	}
+
+	// From files view, handle differently based on state
+	if m.view == model.ViewFiles {
+		// Close the bookmark picker first
+		if m.filesView.ShowingBookmarks() {
+			m.filesView.CloseBookmarks()
+			return m, nil
+		}
+		// If viewing a file, go back to directory listing
+		if m.filesView.IsViewingFile() {
+			m.filesView.ExitFileView()
+			return m, nil
+		}
+		// Clear a filter without leaving the directory
+		if m.filesView.IsFiltering() || m.filesView.Filter() != "" {
+			m.filesView.ClearFilter()
+			return m, nil
+		}
+		// Otherwise go back to pod list
+		m.stopFileBrowser()
+		m.view = model.ViewPodList
+		return m, nil
+	}
+
+	// From main views, go back to pod list
+	if m.view != model.ViewPodList {
+		m.view = model.ViewPodList
+		return m, nil
+	}
+
+	return m, nil
+}
+
+// handlePodListKeys handles keys specific to the pod list view
+func (m Model) handlePodListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.gotoActive {
+		return m.handleGotoKeys(msg)
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.JumpTo):
+		m.gotoActive = true
+		m.gotoBuffer = ""
+		return m, m.restartGotoTimeout()
+
+	case key.Matches(msg, m.keys.Up):
 		if m.selectedPodIndex > 0 {
 			m.selectedPodIndex--
+			m.podExpanded = false
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Down):
+		if m.selectedPodIndex < len(m.visiblePods())-1 {
+			m.selectedPodIndex++
+			m.podExpanded = false
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Enter):
+		if _, ok := m.selectedPod(); ok {
+			m.podExpanded = !m.podExpanded
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Logs):
+		if _, ok := m.selectedPod(); ok {
+			m.view = model.ViewLogs
+			m.selectedContainer = "" // Reset to use first container
+			m.logSelector = ""
+			cmd := m.initLogStream()
+			return m, cmd
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Exec):
+		return m.chooseContainer(model.ViewExec)
+
+	case key.Matches(msg, m.keys.Files):
+		return m.chooseContainer(model.ViewFiles)
+
+	case key.Matches(msg, m.keys.YAML):
+		if pod, ok := m.selectedPod(); ok {
+			m.prevView = m.view
+			m.view = model.ViewYAML
+			m.yamlView.SetTitle(fmt.Sprintf("YAML: %s/%s", pod.Namespace, pod.Name))
+			m.yamlView.SetLoading()
+			return m, m.loadPodYAML(pod.Namespace, pod.Name)
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Env):
+		return m.chooseContainer(model.ViewEnv)
+
+	case key.Matches(msg, m.keys.Find):
+		m.prevView = m.view
+		m.view = model.ViewSearch
+		m.search.Open()
+		// Namespaces and contexts may not have been listed yet
+		if m.k8sClient == nil {
+			return m, nil
+		}
+		return m, tea.Batch(m.loadNamespaces, m.loadContexts)
+
+	case key.Matches(msg, m.keys.Events):
+		m.prevView = m.view
+		m.view = model.ViewEvents
+		// Without a selected pod there is only the namespace to show
+		pod, ok := m.selectedPod()
+		m.eventsPod = pod
+		m.eventsAll = !ok
+		return m, m.showEvents()
+
+	case key.Matches(msg, m.keys.AllNamespaces):
+		m.allNamespaces = !m.allNamespaces
+		m.serviceFilter = k8s.ServiceInfo{} // Services belong to one namespace
+		return m, m.reloadPods()
+
+	case key.Matches(msg, m.keys.LabelSelector):
+		m.openPrompt(promptLabelSelector, "Label selector (e.g. app=web, empty to clear):", m.labelSelector)
+		return m, nil
+
+	case key.Matches(msg, m.keys.Services):
+		m.prevView = m.view
+		m.view = model.ViewServices
+		m.loadingServices = true
+		return m, m.loadServices
+
+	case key.Matches(msg, m.keys.ConfigMaps):
+		return m, m.openDataBrowser(model.ViewConfigMaps)
+
+	case key.Matches(msg, m.keys.Secrets):
+		return m, m.openDataBrowser(model.ViewSecrets)
+
+	case key.Matches(msg, m.keys.Bundle):
+		if pod, ok := m.selectedPod(); ok {
+			m.bundlePod = pod
+			m.openPrompt(promptBundlePath, "Save troubleshooting bundle to:", pod.Name+"-bundle.txt")
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Copy):
+		if pod, ok := m.selectedPod(); ok {
+			return m, copyToClipboard(pod.Namespace+"/"+pod.Name, pod.Name)
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.CopyKubectl):
+		return m, m.copyKubectlCommand()
+
+	case key.Matches(msg, m.keys.Namespace):
+		m.prevView = m.view
+		m.view = model.ViewNamespaceSelector
+		m.loadingNamespaces = true
+		return m, m.loadNamespaces
+
+	case key.Matches(msg, m.keys.Context):
+		m.prevView = m.view
+		m.view = model.ViewContextSelector
+		return m, m.loadContexts
+
+	case key.Matches(msg, m.keys.Restart):
+		pod, ok := m.selectedPod()
+		if !ok {
+			return m, nil
+		}
+		if !pod.Owner.Restartable() {
+			return m, m.setStatus(fmt.Sprintf("%s is not managed by a Deployment, StatefulSet or DaemonSet", pod.Name))
+		}
+		m.askConfirm(fmt.Sprintf("Restart %s in %s?", pod.Owner, pod.Namespace), m.restartWorkload(pod), nil)
+		return m, nil
+
+	case key.Matches(msg, m.keys.About):
+		m.prevView = m.view
+		m.view = model.ViewAbout
+		return m, nil
+
+	case key.Matches(msg, m.keys.Refresh):
+		m.loadingPods = true
+		return m, m.loadPods
+
+	case key.Matches(msg, m.keys.Reload):
+		// Without a client there is no kubeconfig to re-read; retry from scratch
+		if m.k8sClient == nil {
+			m.loadingK8s = true
+			m.k8sErr = nil
+			return m, m.initK8sClient
+		}
+		m.loadingPods = true
+		return m, m.reloadConfig
+
+	case key.Matches(msg, m.keys.HideCompleted):
+		m.hideCompleted = !m.hideCompleted
+		m.clampPodSelection()
+		return m, nil
+
+	case key.Matches(msg, m.keys.OwnerColumn):
+		m.showOwner = !m.showOwner
+		return m, nil
+
+	case key.Matches(msg, m.keys.ImageColumn):
+		m.showImage = !m.showImage
+		return m, nil
+
+	case key.Matches(msg, m.keys.NodeColumns):
+		m.showNodeIP = !m.showNodeIP
+		return m, nil
+
+	case key.Matches(msg, m.keys.NodeFilter):
+		selected, ok := m.selectedPod()
+		m.nodeFilter = nextNodeFilter(m.podNodes(), m.nodeFilter)
+		m.clampPodSelection()
+		if ok {
+			m.selectPodByName(selected.Name)
+		}
+		if m.nodeFilter == "" {
+			return m, m.setStatus("Showing pods on all nodes")
+		}
+		return m, m.setStatus(fmt.Sprintf("Showing pods on node %s", m.nodeFilter))
+
+	case key.Matches(msg, m.keys.GroupByOwner):
+		// Regrouping reorders the list; keep the cursor on the same pod
+		selected, ok := m.selectedPod()
+		m.groupByOwner = !m.groupByOwner
+		if ok {
+			m.selectPodByName(selected.Name)
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Deployments):
+		m.view = model.ViewDeployments
+		m.loadingDeployments = true
+		return m, m.loadDeployments
+
+	case key.Matches(msg, m.keys.Top):
+		return m, m.openTop()
+	}
+
+	return m, nil
+}
+
+// chooseContainer opens target, the exec view, the file browser or the env
+// view, on the selected pod. A pod with several containers first asks which one, with
+// the cursor on the container last chosen for it.
+func (m Model) chooseContainer(target model.ViewState) (tea.Model, tea.Cmd) {
+	pod, ok := m.selectedPod()
+	if !ok {
+		return m, nil
+	}
+	if len(pod.Containers) < 2 {
+		return m.openContainerView(target, pod, pod.PrimaryContainer())
+	}
+
+	current, ok := m.containerChoices[podKey(pod)]
+	if !ok {
+		current = pod.PrimaryContainer()
+	}
+	m.selectedContainerIndex = max(slices.IndexFunc(pod.Containers, func(c k8s.ContainerStatus) bool {
+		return c.Name == current
+	}), 0)
+	m.containerTarget = target
+	m.prevView = m.view
+	m.view = model.ViewContainerSelector
+	return m, nil
+}
+
+// openContainerView opens the exec view, the file browser or the env view
+// on container
+func (m Model) openContainerView(target model.ViewState, pod k8s.PodInfo, container string) (tea.Model, tea.Cmd) {
+	if target == model.ViewEnv {
+		// The env view is an overlay; from the container selector, it goes
+		// back to where the selector was opened
+		if m.view != model.ViewContainerSelector {
+			m.prevView = m.view
+		}
+		m.view = target
+		m.envTarget = containerRef{namespace: pod.Namespace, pod: pod.Name, container: container}
+		m.envView.Open(m.envTarget.String(), false)
+		return m, m.loadEnv(false)
+	}
+
+	m.view = target
+	if target == model.ViewExec {
+		m.execView.SetPodInfo(pod.Namespace, pod.Name, container)
+		m.execView.SetState(ui.ExecViewStateIdle)
+		m.execView.Focus()
+		return m, nil
+	}
+
+	m.filesView.Clear()
+	m.filesView.SetPodInfo(pod.Namespace, pod.Name, container)
+	m.filesView.SetState(ui.FileBrowserStateLoading)
+	return m, m.loadDirectory("/")
+}
+
+// handleContainerSelectorKeys handles keys for the container selector
+func (m Model) handleContainerSelectorKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	pod, ok := m.selectedPod()
+	if !ok {
+		m.view = m.prevView
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		if m.selectedContainerIndex > 0 {
+			m.selectedContainerIndex--
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Down):
+		if m.selectedContainerIndex < len(pod.Containers)-1 {
+			m.selectedContainerIndex++
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Enter):
+		if m.selectedContainerIndex >= len(pod.Containers) {
+			return m, nil
+		}
+		container := pod.Containers[m.selectedContainerIndex].Name
+		if m.containerChoices == nil {
+			m.containerChoices = make(map[string]string)
+		}
+		m.containerChoices[podKey(pod)] = container
+		return m.openContainerView(m.containerTarget, pod, container)
+	}
+
+	return m, nil
+}
+
+// handleServicesKeys handles keys for the service list overlay
+func (m Model) handleServicesKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		if m.selectedServiceIndex > 0 {
+			m.selectedServiceIndex--
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Down):
+		if m.selectedServiceIndex < len(m.services)-1 {
+			m.selectedServiceIndex++
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Enter):
+		if m.selectedServiceIndex < len(m.services) {
+			return m, m.showServicePods(m.services[m.selectedServiceIndex])
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Services):
+		m.view = m.prevView
+		return m, nil
+
+	case key.Matches(msg, m.keys.Refresh):
+		m.loadingServices = true
+		return m, m.loadServices
+	}
+
+	return m, nil
+}
+
+// showServicePods lists only the pods backing svc, matched by its selector
+func (m *Model) showServicePods(svc k8s.ServiceInfo) tea.Cmd {
+	m.serviceFilter = svc
+	m.view = model.ViewPodList
+	m.selectedPodIndex = 0
+	if len(svc.Selector) == 0 {
+		return m.setStatus(fmt.Sprintf("Service %s has no selector; its endpoints are managed by hand", svc.Name))
+	}
+	return m.setStatus(fmt.Sprintf("Showing pods backing service %s", svc.Name))
+}
+
+// handleDeploymentsKeys handles keys specific to the deployments view
+func (m Model) handleDeploymentsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		if m.selectedDeploymentIndex > 0 {
+			m.selectedDeploymentIndex--
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Down):
+		if m.selectedDeploymentIndex < len(m.deployments)-1 {
+			m.selectedDeploymentIndex++
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Deployments):
+		m.view = model.ViewPodList
+		return m, nil
+
+	case key.Matches(msg, m.keys.Scale):
+		if d, ok := m.selectedDeployment(); ok {
+			m.scaleTarget = d
+			m.openPrompt(promptScaleReplicas, fmt.Sprintf("Scale %s to replicas:", d.Name),
+				strconv.Itoa(int(d.Replicas)))
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Restart):
+		if d, ok := m.selectedDeployment(); ok {
+			m.askConfirm(fmt.Sprintf("Restart Deployment/%s in %s?", d.Name, d.Namespace), m.restartDeployment(d), nil)
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.CopyKubectl):
+		return m, m.copyKubectlCommand()
+
+	case key.Matches(msg, m.keys.Logs):
+		d, ok := m.selectedDeployment()
+		if !ok {
+			return m, nil
+		}
+		if d.Selector == "" {
+			return m, m.setStatus(fmt.Sprintf("%s has no pod selector", d.Name))
+		}
+		m.view = model.ViewLogs
+		return m, m.initDeploymentLogStream(d)
+
+	case key.Matches(msg, m.keys.Refresh):
+		m.loadingDeployments = true
+		return m, m.loadDeployments
+
+	case key.Matches(msg, m.keys.Namespace):
+		m.prevView = m.view
+		m.view = model.ViewNamespaceSelector
+		m.loadingNamespaces = true
+		return m, m.loadNamespaces
+
+	case key.Matches(msg, m.keys.Context):
+		m.prevView = m.view
+		m.view = model.ViewContextSelector
+		return m, m.loadContexts
+	}
+
+	return m, nil
+}
+
+// handleTopKeys handles keys specific to the top view
+func (m Model) handleTopKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		if m.selectedTopIndex > 0 {
+			m.selectedTopIndex--
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Down):
+		if m.selectedTopIndex < len(m.topMetrics)-1 {
+			m.selectedTopIndex++
+		}
+		return m, nil
+
+	case msg.String() == "s":
+		m.topSortMemory = !m.topSortMemory
+		m.selectedTopIndex = 0
+		return m, nil
+
+	case key.Matches(msg, m.keys.Top):
+		m.view = model.ViewPodList
+		return m, nil
+
+	case key.Matches(msg, m.keys.Refresh):
+		return m, m.openTop()
+
+	case key.Matches(msg, m.keys.Namespace):
+		m.prevView = m.view
+		m.view = model.ViewNamespaceSelector
+		m.loadingNamespaces = true
+		return m, m.loadNamespaces
+	}
+
+	return m, nil
+}
+
+// scaleDeployment sets the replica count of a deployment
+func (m Model) scaleDeployment(d k8s.DeploymentInfo, replicas int32) tea.Cmd {
+	client := m.k8sClient
+	if client == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		ctx, cancel := client.RequestContext()
+		defer cancel()
+
+		if err := client.ScaleDeployment(ctx, d.Namespace, d.Name, replicas); err != nil {
+			return deploymentActionMsg{err: err}
+		}
+		return deploymentActionMsg{status: fmt.Sprintf("Scaled %s to %d replicas", d.Name, replicas)}
+	}
+}
+
+// restartDeployment triggers a rolling restart of a deployment
+func (m Model) restartDeployment(d k8s.DeploymentInfo) tea.Cmd {
+	client := m.k8sClient
+	if client == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		ctx, cancel := client.RequestContext()
+		defer cancel()
+
+		if err := client.RestartDeployment(ctx, d.Namespace, d.Name); err != nil {
+			return deploymentActionMsg{err: err}
+		}
+		return deploymentActionMsg{status: fmt.Sprintf("Restarting %s", d.Name)}
+	}
+}
+
+// restartWorkload triggers a rolling restart of the workload owning pod
+func (m Model) restartWorkload(pod k8s.PodInfo) tea.Cmd {
+	client := m.k8sClient
+	if client == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		ctx, cancel := client.RequestContext()
+		defer cancel()
+
+		if err := client.RestartWorkload(ctx, pod.Namespace, pod.Owner.Kind, pod.Owner.Name); err != nil {
+			return workloadRestartedMsg{err: err}
+		}
+		return workloadRestartedMsg{status: fmt.Sprintf("Restarting %s", pod.Owner)}
+	}
+}
+
+// handleGotoKeys handles keys in jump-to-pod mode. Typed characters extend
+// the name prefix; any other key ends the mode and acts as usual.
+func (m Model) handleGotoKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyRunes, tea.KeySpace:
+		m.gotoBuffer += string(msg.Runes)
+	case tea.KeyBackspace:
+		runes := []rune(m.gotoBuffer)
+		if len(runes) == 0 {
+			m.stopGoto()
+			return m, nil
+		}
+		m.gotoBuffer = string(runes[:len(runes)-1])
+	case tea.KeyEnter:
+		m.stopGoto()
+		return m, nil
+	default:
+		m.stopGoto()
+		return m.handlePodListKeys(msg)
+	}
+
+	if index := m.gotoMatch(); index >= 0 {
+		m.selectedPodIndex = index
+	}
+	return m, m.restartGotoTimeout()
+}
+
+// gotoMatch returns the index of the first visible pod whose name starts
+// with the goto buffer, or -1
+func (m Model) gotoMatch() int {
+	if m.gotoBuffer == "" {
+		return -1
+	}
+	prefix := strings.ToLower(m.gotoBuffer)
+	for i, pod := range m.visiblePods() {
+		if strings.HasPrefix(strings.ToLower(pod.Name), prefix) {
+			return i
+		}
+	}
+	return -1
+}
+
+// restartGotoTimeout schedules the end of jump-to-pod mode, superseding
+// the previous timeout
+func (m *Model) restartGotoTimeout() tea.Cmd {
+	m.gotoID++
+	id := m.gotoID
+	return tea.Tick(gotoTimeout, func(time.Time) tea.Msg {
+		return gotoTimeoutMsg{id: id}
+	})
+}
+
+// stopGoto leaves jump-to-pod mode, keeping the selection
+func (m *Model) stopGoto() {
+	m.gotoActive = false
+	m.gotoBuffer = ""
+	m.gotoID++
+}
+
+// handleEventsKeys handles keys while the events overlay is open
+func (m Model) handleEventsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.Matches(msg, m.keys.AllEvents) && m.eventsPod.Name != "" {
+		m.eventsAll = !m.eventsAll
+		return m, m.showEvents()
+	}
+
+	var cmd tea.Cmd
+	m.eventsView, cmd = m.eventsView.Update(msg)
+	return m, cmd
+}
+
+// handleEnvKeys handles keys in the environment overlay
+func (m Model) handleEnvKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.String() == "d":
+		declared := !m.envView.Declared()
+		m.envView.SetDeclared(declared)
+		return m, m.loadEnv(declared)
+	case key.Matches(msg, m.keys.Reveal):
+		m.envView.ToggleReveal()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.envView, cmd = m.envView.Update(msg)
+	return m, cmd
+}
+
+// loadEnv loads the environment of the container in envTarget, as its
+// processes see it or as declared. Containers without env fall back to
+// the declared environment.
+func (m Model) loadEnv(declared bool) tea.Cmd {
+	target := m.envTarget
+	if m.k8sClient == nil {
+		return func() tea.Msg {
+			return envLoadedMsg{target: target, requested: declared, err: fmt.Errorf("k8s client not initialized")}
+		}
+	}
+
+	client := m.k8sClient
+
+	return func() tea.Msg {
+		msg := envLoadedMsg{target: target, requested: declared, declared: declared}
+		if !declared {
+			ctx, cancel := client.ExecContext()
+			defer cancel()
+
+			msg.vars, msg.err = client.GetRuntimeEnv(ctx, target.namespace, target.pod, target.container)
+			if !errors.Is(msg.err, k8s.ErrNoEnvCommand) {
+				return msg
+			}
+			msg.declared = true
+		}
+
+		ctx, cancel := client.RequestContext()
+		defer cancel()
+
+		msg.vars, msg.err = client.GetContainerEnv(ctx, target.namespace, target.pod, target.container)
+		return msg
+	}
+}
+
+// showEvents titles the events overlay for the current mode and loads it
+func (m *Model) showEvents() tea.Cmd {
+	if m.eventsAll {
+		namespace := ""
+		if m.k8sClient != nil {
+			namespace = m.k8sClient.CurrentNamespace()
+		}
+		title := fmt.Sprintf("Events: namespace %s", namespace)
+		if m.eventsPod.Name != "" {
+			title += fmt.Sprintf(" | a: events of %s", m.eventsPod.Name)
+		}
+		m.eventsView.SetTitle(title)
+	} else {
+		m.eventsView.SetTitle(fmt.Sprintf("Events: %s/%s | a: all events in namespace",
+			m.eventsPod.Namespace, m.eventsPod.Name))
+	}
+	m.eventsView.SetLoading()
+	return m.loadEvents(m.eventsAll, m.eventsPod)
+}
+
+// loadEvents fetches the events of a pod, or of the whole namespace
+func (m Model) loadEvents(all bool, pod k8s.PodInfo) tea.Cmd {
+	if m.k8sClient == nil {
+		return func() tea.Msg {
+			return eventsLoadedMsg{all: all, err: fmt.Errorf("k8s client not initialized")}
+		}
+	}
+
+	client := m.k8sClient
+
+	return func() tea.Msg {
+		ctx, cancel := client.RequestContext()
+		defer cancel()
+
+		if all {
+			events, err := client.ListEvents(ctx, "", "")
+			return eventsLoadedMsg{all: true, events: events, err: err}
+		}
+		events, err := client.ListEvents(ctx, pod.Namespace, pod.Name)
+		return eventsLoadedMsg{events: events, err: err}
+	}
+}
+
+// handleDataBrowserKeys handles keys while the ConfigMap or Secret browser
+// is open
+func (m Model) handleDataBrowserKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Enter):
+		m.dataView.Enter()
+		return m, nil
+	case key.Matches(msg, m.keys.Reveal):
+		m.dataView.ToggleReveal()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.dataView, cmd = m.dataView.Update(msg)
+	return m, cmd
+}
+
+// openDataBrowser shows the ConfigMaps or Secrets of the current namespace
+func (m *Model) openDataBrowser(view model.ViewState) tea.Cmd {
+	m.prevView = m.view
+	m.view = view
+	secret := view == model.ViewSecrets
+	m.dataView.Open(view.String(), secret)
+	return m.loadDataItems(view.String(), secret)
+}
+
+// loadDataItems lists the ConfigMaps or Secrets of the current namespace
+func (m Model) loadDataItems(kind string, secret bool) tea.Cmd {
+	if m.k8sClient == nil {
+		return func() tea.Msg {
+			return dataItemsLoadedMsg{kind: kind, err: fmt.Errorf("k8s client not initialized")}
+		}
+	}
+
+	client := m.k8sClient
+
+	return func() tea.Msg {
+		ctx, cancel := client.RequestContext()
+		defer cancel()
+
+		if secret {
+			secrets, err := client.ListSecrets(ctx, "")
+			return dataItemsLoadedMsg{kind: kind, items: secretItems(secrets), err: err}
+		}
+		configMaps, err := client.ListConfigMaps(ctx, "")
+		return dataItemsLoadedMsg{kind: kind, items: configMapItems(configMaps), err: err}
+	}
+}
+
+// configMapItems converts ConfigMaps for the data browser
+func configMapItems(configMaps []k8s.ConfigMapInfo) []ui.DataItem {
+	items := make([]ui.DataItem, 0, len(configMaps))
+	for _, cm := range configMaps {
+		items = append(items, ui.DataItem{Name: cm.Name, Entries: dataEntries(cm.Keys(), cm.Data)})
+	}
+	return items
+}
+
+// secretItems converts Secrets for the data browser, showing their type
+func secretItems(secrets []k8s.SecretInfo) []ui.DataItem {
+	items := make([]ui.DataItem, 0, len(secrets))
+	for _, secret := range secrets {
+		items = append(items, ui.DataItem{
+			Name:    secret.Name,
+			Detail:  secret.Type,
+			Entries: dataEntries(secret.Keys(), secret.Data),
+		})
+	}
+	return items
+}
+
+// dataEntries lists data values in key order
+func dataEntries(keys []string, data map[string][]byte) []ui.DataEntry {
+	entries := make([]ui.DataEntry, 0, len(keys))
+	for _, k := range keys {
+		entries = append(entries, ui.DataEntry{Key: k, Value: data[k]})
+	}
+	return entries
+}
+
+// openPrompt shows the text prompt overlay for an action
+func (m *Model) openPrompt(action promptAction, title, value string) {
+	m.prevView = m.view
+	m.view = model.ViewPrompt
+	m.promptAction = action
+	m.prompt.Open(title, value)
+}
+
+// closePrompt hides the text prompt overlay
+func (m *Model) closePrompt() {
+	m.prompt.Close()
+	m.promptAction = promptNone
+	m.view = m.prevView
+}
+
+// askConfirm shows the confirmation overlay. onYes or onNo runs with the
+// answer, after which the overlay returns to the current view.
+func (m *Model) askConfirm(question string, onYes, onNo tea.Cmd) {
+	m.prevView = m.view
+	m.view = model.ViewConfirm
+	m.confirmation.Open(question, onYes, onNo)
+}
+
+// handleConfirmKeys handles keys while the confirmation overlay is open
+func (m Model) handleConfirmKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.Type == tea.KeyCtrlC {
+		return m, m.quit()
+	}
+
+	var cmd tea.Cmd
+	m.confirmation, cmd = m.confirmation.Update(msg)
+	if !m.confirmation.IsOpen() {
+		m.view = m.prevView
+	}
+	return m, cmd
+}
+
+// requestContextSwitch returns a command asking for a switch to name
+func requestContextSwitch(name string, persist bool) tea.Cmd {
+	return func() tea.Msg {
+		return contextSwitchRequestedMsg{name: name, persist: persist}
+	}
+}
+
+// handlePromptKeys handles keys while the text prompt is open
+func (m Model) handlePromptKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, m.quit()
+
+	case tea.KeyEsc:
+		// Typing searched the logs already; cancelling puts back the search
+		// the prompt started from
+		if m.promptAction == promptLogSearch {
+			m.logView.Search(m.logSearchBefore)
+		}
+		m.closePrompt()
+		return m, nil
+
+	case tea.KeyEnter:
+		value := m.prompt.Value()
+		if value == "" && m.promptAction != promptLabelSelector && m.promptAction != promptLogSearch {
+			return m, nil
+		}
+		action := m.promptAction
+		m.closePrompt()
+
+		switch action {
+		case promptBundlePath:
+			return m, m.saveBundle(m.bundlePod, value)
+		case promptLogSearch:
+			m.logView.Search(value)
+		case promptDebugImage:
+			return m.startDebugContainer(value)
+		case promptScaleReplicas:
+			replicas, err := strconv.ParseInt(value, 10, 32)
+			if err != nil || replicas < 0 {
+				return m, m.setStatus(fmt.Sprintf("Invalid replica count %q", value))
+			}
+			return m, m.scaleDeployment(m.scaleTarget, int32(replicas))
+		case promptLogTail:
+			return m.setLogTail(value)
+		case promptLabelSelector:
+			return m.setLabelSelector(value)
+		case promptGotoPath:
+			return m.gotoPath(value)
+		}
+		return m, nil
+
+	case tea.KeyTab:
+		if m.promptAction == promptGotoPath {
+			return m, m.loadPathCompletion(m.prompt.Value())
+		}
+	}
+
+	var cmd tea.Cmd
+	m.prompt, cmd = m.prompt.Update(msg)
+	// Log search is incremental: matches follow the term as it is typed
+	if value := m.prompt.Value(); m.promptAction == promptLogSearch && value != m.logView.SearchTerm() {
+		m.logView.Search(value)
+	}
+	return m, cmd
+}
+
+// setLogTail validates a tail length and restreams the logs with it.
+// Lengths above config.MaxLogTailLines are capped.
+func (m Model) setLogTail(value string) (tea.Model, tea.Cmd) {
+	lines, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil || lines <= 0 {
+		return m, m.setStatus(fmt.Sprintf("Invalid tail length %q", value))
+	}
+
+	var status tea.Cmd
+	if lines > config.MaxLogTailLines {
+		lines = config.MaxLogTailLines
+		status = m.setStatus(fmt.Sprintf("Tail length capped at %d lines", lines))
+	}
+	m.logTailLines = lines
+	return m, tea.Batch(status, m.initLogStream())
+}
+
+// setLabelSelector validates a label selector and relists the pods with
+// it. An empty selector lists every pod again.
+func (m Model) setLabelSelector(value string) (tea.Model, tea.Cmd) {
+	if _, err := labels.Parse(value); err != nil {
+		return m, m.setStatus(fmt.Sprintf("Invalid label selector %q: %v", value, err))
+	}
+	if value == m.labelSelector {
+		return m, nil
+	}
+	m.labelSelector = value
+	return m, m.reloadPods()
+}
+
+// reloadPods drops the listed pods and lists them again, restarting the watch
+func (m *Model) reloadPods() tea.Cmd {
+	m.stopPodWatch()
+	m.clearPods()
+	m.loadingPods = true
+	m.podsID++
+	return m.loadPods
+}
+
+// saveBundle collects a troubleshooting bundle for a pod and writes it to path
+func (m Model) saveBundle(pod k8s.PodInfo, path string) tea.Cmd {
+	client := m.k8sClient
+	if client == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		path, err := expandHome(path)
+		if err != nil {
+			return bundleSavedMsg{err: err}
+		}
+
+		ctx, cancel := client.RequestContext()
+		defer cancel()
+
+		parts, err := client.CollectBundle(ctx, pod.Namespace, pod.Name, k8s.DefaultBundleLogLines)
+		if err != nil {
+			return bundleSavedMsg{err: err}
+		}
+
+		if err := os.WriteFile(path, []byte(k8s.BuildBundle(parts)), 0o600); err != nil {
+			return bundleSavedMsg{err: fmt.Errorf("failed to write bundle: %w", err)}
+		}
+		return bundleSavedMsg{path: path}
+	}
+}
+
+// handleNamespaceSelectorKeys handles keys for namespace selection
+func (m Model) handleNamespaceSelectorKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		if m.selectedNamespaceIndex > 0 {
+			m.selectedNamespaceIndex--
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Down):
+		if m.selectedNamespaceIndex < len(m.namespaces)-1 {
+			m.selectedNamespaceIndex++
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Enter):
+		if m.selectedNamespaceIndex < len(m.namespaces) {
+			return m, m.switchNamespace(m.namespaces[m.selectedNamespaceIndex].Name)
+		}
+		return m, nil
+	}
+
+	return m, nil
+}
+
+// switchNamespace makes name the current namespace, returns from the
+// overlay and reloads what the view shows
+func (m *Model) switchNamespace(name string) tea.Cmd {
+	if pod, ok := m.selectedPod(); ok && m.config.StickySelection {
+		m.stickyWorkload = workloadName(pod)
+	}
+	m.k8sClient.SetNamespace(name)
+	m.stopPodWatch()
+	m.clearPods()
+	m.serviceFilter = k8s.ServiceInfo{} // Services belong to the old namespace
+	m.allNamespaces = false
+	m.view = m.prevView
+	m.loadingPods = true
+	m.podsID++
+	if m.view == model.ViewDeployments {
+		m.loadingDeployments = true
+		return tea.Batch(m.loadPods, m.loadDeployments)
+	}
+	if m.view == model.ViewTop {
+		m.topMetrics = nil
+		return tea.Batch(m.loadPods, m.openTop())
+	}
+	return m.loadPods
+}
+
+// handleContextSelectorKeys handles keys for context selection
+func (m Model) handleContextSelectorKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		if m.selectedContextIndex > 0 {
+			m.selectedContextIndex--
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Down):
+		if m.selectedContextIndex < len(m.contexts)-1 {
+			m.selectedContextIndex++
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Enter):
+		if m.k8sClient != nil && m.k8sClient.InCluster() {
+			m.view = m.prevView
+			return m, nil
+		}
+		if m.selectedContextIndex < len(m.contexts) {
+			// The kubeconfig can't be written in read-only mode
+			if m.opts.ReadOnly {
+				return m, m.switchContext(m.contexts[m.selectedContextIndex].Name, false)
+			}
+			// Ask before writing the choice back to the kubeconfig. The
+			// question returns to the view the selector was opened from;
+			// declining still switches, cancelling doesn't.
+			name := m.contexts[m.selectedContextIndex].Name
+			m.view = m.prevView
+			m.askConfirm(fmt.Sprintf("Make %s the default context in the kubeconfig?", name),
+				requestContextSwitch(name, true), requestContextSwitch(name, false))
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Reload):
+		// Pick up contexts added or changed by other tools since startup.
+		// Without a client the contexts are read straight from the file.
+		if m.k8sClient == nil {
+			return m, m.loadContexts
+		}
+		m.loadingPods = true
+		return m, m.reloadConfig
+	}
+
+	return m, nil
+}
+
+// persistContext writes the client's current context to the kubeconfig so
+// other tools follow the switch
+func (m Model) persistContext() tea.Cmd {
+	client := m.k8sClient
+	if client == nil {
+		return nil
+	}
+
+	name := client.CurrentContext()
+	return func() tea.Msg {
+		return contextPersistedMsg{name: name, err: client.PersistCurrentContext()}
+	}
+}
+
+// switchContext returns from the overlay and builds a client for name in
+// the background, since that can be slow for an unreachable cluster. The
+// current client stays in use until the new one is ready; persist also
+// makes name the kubeconfig's current context once switched.
+func (m *Model) switchContext(name string, persist bool) tea.Cmd {
+	m.view = m.prevView
+	client := m.k8sClient
+	opts := m.clientOptions(k8s.WithContext(name))
+
+	m.contextSwitchID++
+	m.switchingContext = name
+	id := m.contextSwitchID
+	return func() tea.Msg {
+		var next *k8s.Client
+		var err error
+		if client != nil {
+			next, err = client.ForContext(name)
+		} else {
+			// No client yet, as when the kubeconfig sets no current context
+			next, err = k8s.NewClient(opts...)
+		}
+		return contextSwitchedMsg{id: id, name: name, client: next, persist: persist, err: err}
+	}
+}
+
+// clearPods forgets the listed pods when the namespace or cluster changes,
+// so they aren't shown as the new scope's while it loads
+func (m *Model) clearPods() {
+	m.pods = nil
+	m.lastRefresh = time.Time{}
+}
+
+// useContextClient replaces the client after a context switch and reloads
+// what the view shows
+func (m *Model) useContextClient(client *k8s.Client, persist bool) tea.Cmd {
+	m.k8sClient = client
+	m.k8sErr = nil
+	m.stopPodWatch()
+	m.clearPods()
+	m.loadingPods = true
+	m.podsID++
+	m.nodeFilter = "" // Nodes belong to the old cluster
+	m.serviceFilter = k8s.ServiceInfo{}
+
+	// So do the namespaces; the reload marks the new context's default
+	// namespace as current, and lists and summaries still loading from
+	// the old cluster are dropped
+	m.namespaces = nil
+	m.namespacesErr = nil
+	m.selectedNamespaceIndex = 0
+	m.namespacesID++
+	m.namespaceSummaryID++
+	m.search.SetCandidates(m.searchCandidates())
+
+	cmds := []tea.Cmd{m.loadPods, m.loadNamespaces, m.loadContexts, m.startPing()}
+	if m.view == model.ViewDeployments {
+		m.loadingDeployments = true
+		cmds = append(cmds, m.loadDeployments)
+	}
+	if m.view == model.ViewTop {
+		m.topMetrics = nil
+		cmds = append(cmds, m.openTop())
+	}
+	if persist {
+		cmds = append(cmds, m.persistContext())
+	}
+	return tea.Batch(cmds...)
+}
+
+// handleSearchKeys handles keys while the cross-view search is open
+func (m Model) handleSearchKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, m.quit()
+
+	case tea.KeyEsc:
+		m.search.Close()
+		m.view = m.prevView
+		return m, nil
+
+	case tea.KeyEnter:
+		result, ok := m.search.Selected()
+		if !ok {
+			return m, nil
+		}
+		m.search.Close()
+		return m.openSearchResult(result)
+	}
+
+	var cmd tea.Cmd
+	m.search, cmd = m.search.Update(msg)
+	m.search.SetCandidates(m.searchCandidates())
+	return m, cmd
+}
+
+// openSearchResult navigates to a search result: selects the pod, or
+// switches to the namespace or context
+func (m Model) openSearchResult(result ui.SearchResult) (tea.Model, tea.Cmd) {
+	switch result.Kind {
+	case ui.SearchPod:
+		m.view = model.ViewPodList
+		// Show the pod even if it is filtered out
+		if m.hideCompleted && !slices.ContainsFunc(m.visiblePods(), func(p k8s.PodInfo) bool {
+			return p.Name == result.Name
+		}) {
+			m.hideCompleted = false
+		}
+		m.selectPodByName(result.Name)
+		return m, nil
+
+	case ui.SearchNamespace:
+		if m.k8sClient == nil {
+			return m, nil
+		}
+		return m, m.switchNamespace(result.Name)
+
+	case ui.SearchContext:
+		if m.k8sClient == nil {
+			return m, nil
+		}
+		if m.k8sClient.InCluster() {
+			m.view = m.prevView
+			return m, m.setStatus("Context switching is not available with in-cluster configuration")
+		}
+		return m, m.switchContext(result.Name, false)
+	}
+
+	return m, nil
+}
+
+// searchCandidates lists everything the cross-view search can find
+func (m Model) searchCandidates() []ui.SearchResult {
+	candidates := make([]ui.SearchResult, 0, len(m.pods)+len(m.namespaces)+len(m.contexts))
+	for _, ns := range m.namespaces {
+		detail := ""
+		if ns.IsCurrent {
+			detail = "current"
+		}
+		candidates = append(candidates, ui.SearchResult{Kind: ui.SearchNamespace, Name: ns.Name, Detail: detail})
+	}
+	for _, ctx := range m.contexts {
+		detail := "cluster " + ctx.Cluster
+		if ctx.IsCurrent {
+			detail = "current, " + detail
+		}
+		candidates = append(candidates, ui.SearchResult{Kind: ui.SearchContext, Name: ctx.Name, Detail: detail})
+	}
+	for _, pod := range m.pods {
+		candidates = append(candidates, ui.SearchResult{Kind: ui.SearchPod, Name: pod.Name, Detail: pod.DisplayStatus()})
+	}
+	return candidates
+}
+
+// handleLogViewKeys handles keys specific to the log view
+func (m Model) handleLogViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Down):
+		m.logView.ScrollDown(1)
+		return m, nil
+
+	case key.Matches(msg, m.keys.Up):
+		m.logView.ScrollUp(1)
+		return m, nil
+
+	case key.Matches(msg, m.keys.GotoTop):
+		m.logView.GotoTop()
+		return m, nil
+
+	case key.Matches(msg, m.keys.GotoEnd):
+		m.logView.GotoBottom()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Follow):
+		m.logView.ToggleFollow()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Pause):
+		m.logView.TogglePause()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Tail):
+		m.openPrompt(promptLogTail, "Tail lines:", strconv.FormatInt(m.logTailLines, 10))
+		return m, nil
+
+	case key.Matches(msg, m.keys.PageDown):
+		m.logView.PageDown()
+		return m, nil
+
+	case key.Matches(msg, m.keys.PageUp):
+		m.logView.PageUp()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Search):
+		m.logSearchBefore = m.logView.SearchTerm()
+		m.openPrompt(promptLogSearch, "Search logs:", m.logSearchBefore)
+		return m, nil
+
+	case key.Matches(msg, m.keys.NextMatch):
+		m.logView.NextMatch()
+		return m, nil
+
+	case key.Matches(msg, m.keys.PrevMatch):
+		m.logView.PrevMatch()
+		return m, nil
+
+	case key.Matches(msg, m.keys.AllContainers):
+		if m.logSelector != "" {
+			return m, m.setStatus("Workload logs always include every container")
+		}
+		m.logAllContainers = !m.logAllContainers
+		return m, m.initLogStream()
+
+	case key.Matches(msg, m.keys.Wrap):
+		m.logView.ToggleWrap()
+		return m, nil
+
+	case key.Matches(msg, m.keys.PrettyJSON):
+		m.logView.TogglePrettyJSON()
+		return m, nil
+
+	case key.Matches(msg, m.keys.LevelColors):
+		m.logView.ToggleLevelColors()
+		return m, nil
+
+	case key.Matches(msg, m.keys.ScrollLeft):
+		m.logView.ScrollLeft()
+		return m, nil
+
+	case key.Matches(msg, m.keys.ScrollRight):
+		m.logView.ScrollRight()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Copy):
+		lines := m.logView.VisibleLines()
+		if len(lines) == 0 {
+			return m, nil
+		}
+		return m, copyToClipboard(strings.Join(lines, "\n"), fmt.Sprintf("%d log lines", len(lines)))
+
+	case key.Matches(msg, m.keys.CopyKubectl):
+		return m, m.copyKubectlCommand()
+
+	case key.Matches(msg, m.keys.Pager):
+		return m, m.openLogPager()
+
+	case key.Matches(msg, m.keys.SplitPrevious):
+		return m, m.toggleLogSplit()
+
+	case key.Matches(msg, m.keys.SplitNarrower):
+		m.logView.NarrowSplit()
+		return m, nil
+
+	case key.Matches(msg, m.keys.SplitWider):
+		m.logView.WidenSplit()
+		return m, nil
+	}
+
+	// Pass to log view for viewport handling
+	var cmd tea.Cmd
+	m.logView, cmd = m.logView.Update(msg)
+	return m, cmd
+}
+
+// toggleLogSplit opens or closes a pane with the logs of the container's
+// previous instance next to the live ones, e.g. to compare a crash with
+// the restart that followed
+func (m *Model) toggleLogSplit() tea.Cmd {
+	if m.logView.IsSplit() {
+		m.logView.CloseSplit()
+		return nil
+	}
+	if m.logSelector != "" || m.logAllContainers {
+		return m.setStatus("The split shows one container's previous instance; open a single container's logs")
+	}
+	if m.logView.IsPrevious() {
+		return m.setStatus("Already showing the previous instance")
+	}
+	if m.k8sClient == nil {
+		return nil
+	}
+
+	m.logView.OpenSplit()
+	client := m.k8sClient
+	key := m.logCacheKey()
+	opts := k8s.LogOptions{
+		Namespace: m.logNamespace,
+		Pod:       m.logPod,
+		Container: m.selectedContainer,
+		TailLines: m.logTailLines,
+		Previous:  true,
+	}
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), client.Timeout())
+		defer cancel()
+		logChan, err := client.StreamLogs(ctx, opts)
+		if err != nil {
+			return splitLogsLoadedMsg{key: key, err: err}
+		}
+		var lines []string
+		for line := range logChan {
+			if line.Error != nil {
+				return splitLogsLoadedMsg{key: key, err: line.Error}
+			}
+			lines = append(lines, line.Content)
+		}
+		return splitLogsLoadedMsg{key: key, lines: lines}
+	}
+}
+
+// openLogPager writes the log buffer to a temp file and suspends the UI
+// while the pager runs on it. The view is paused meanwhile; the stream
+// keeps its place and catches up once the pager exits.
+func (m *Model) openLogPager() tea.Cmd {
+	lines := m.logView.Lines()
+	if len(lines) == 0 {
+		return m.setStatus("No log lines to open")
+	}
+
+	tmp, err := os.CreateTemp("", "k8s-tui-*-"+m.logPod+".log")
+	if err != nil {
+		return m.setStatus(fmt.Sprintf("Could not open the pager: %v", err))
+	}
+	_, err = tmp.WriteString(strings.Join(lines, "\n") + "\n")
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	var cmd *exec.Cmd
+	if err == nil {
+		cmd, err = pagerCommand(tmp.Name())
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return m.setStatus(fmt.Sprintf("Could not open the pager: %v", err))
+	}
+
+	if !m.logView.IsPaused() {
+		m.logView.TogglePause()
+		m.pagerPaused = true
+	}
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return pagerClosedMsg{tmpPath: tmp.Name(), err: err}
+	})
+}
+
+// handleExecViewKeys handles keys specific to the exec view
+func (m Model) handleExecViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Earlier output can be copied while a command runs
+	if msg.Type == tea.KeyCtrlY {
+		output := m.execView.Output()
+		if len(output) == 0 {
+			return m, m.setStatus("No exec output to copy")
+		}
+		return m, copyToClipboard(strings.Join(output, "\n"), fmt.Sprintf("%d exec output lines", len(output)))
+	}
+
+	// Don't process keys while command is running (except for cancel)
+	if m.execRunning {
+		return m, nil
+	}
+
+	if msg.Type == tea.KeyCtrlL {
+		m.execView.Clear()
+		return m, m.setStatus("Cleared exec output")
+	}
+
+	if msg.Type == tea.KeyCtrlP {
+		m.execView.TogglePresets()
+		return m, nil
+	}
+
+	if msg.Type == tea.KeyCtrlT {
+		m.openPrompt(promptDebugImage, "Debug container image:", k8s.DefaultDebugImage)
+		return m, nil
+	}
+
+	if m.execView.ShowingPresets() {
+		return m.handleExecPresetKeys(msg)
+	}
+
+	switch msg.Type {
+	case tea.KeyEnter:
+		// Execute the command
+		cmd := m.execView.GetCommand()
+		if cmd != "" {
+			return m.runExecCommand(cmd)
+		}
+		return m, nil
+	}
+
+	// Pass to exec view for input handling
+	var viewCmd tea.Cmd
+	m.execView, viewCmd = m.execView.Update(msg)
+	return m, viewCmd
+}
+
+// handleExecPresetKeys handles keys while the exec presets menu is open
+func (m Model) handleExecPresetKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Enter runs the highlighted preset
+	if msg.Type == tea.KeyEnter {
+		if preset, ok := m.execView.SelectedPreset(); ok {
+			m.execView.ClosePresets()
+			return m.runExecCommand(preset.Command)
+		}
+		return m, nil
+	}
+
+	// Digits run the preset with that number
+	if msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] >= '1' && msg.Runes[0] <= '9' {
+		if preset, ok := m.execView.PresetByNumber(int(msg.Runes[0] - '0')); ok {
+			m.execView.ClosePresets()
+			return m.runExecCommand(preset.Command)
+		}
+		return m, nil
+	}
+
+	// Pass to exec view for menu navigation
+	var viewCmd tea.Cmd
+	m.execView, viewCmd = m.execView.Update(msg)
+	return m, viewCmd
+}
+
+// runExecCommand starts executing a command in the pod
+func (m Model) runExecCommand(command string) (tea.Model, tea.Cmd) {
+	if m.k8sClient == nil {
+		m.execView.SetError("k8s client not initialized")
+		return m, nil
+	}
+
+	pod, ok := m.selectedPod()
+	if !ok {
+		m.execView.SetError("no pod selected")
+		return m, nil
+	}
+	container := m.execView.Container()
+
+	// Parse command, with an optional "< file" to pipe a local file as stdin
+	line, stdinPath := k8s.SplitStdinRedirect(command)
+	args := k8s.ParseCommand(line)
+	if len(args) == 0 {
+		return m, nil
+	}
+
+	// Open the stdin file up front so a bad path leaves the command editable
+	var stdin *os.File
+	if stdinPath != "" {
+		f, err := openStdinFile(stdinPath)
+		if err != nil {
+			m.execView.SetError(err.Error())
+			return m, nil
+		}
+		stdin = f
+	}
+
+	// Add to history and show marker
+	m.execView.AddToHistory(command)
+	m.execView.AddCommandMarker(command)
+	m.execView.ClearInput()
+	m.execView.SetState(ui.ExecViewStateRunning)
+	m.execRunning = true
+
+	// Create context for this exec
+	ctx, cancel := m.k8sClient.ExecContext()
+	m.execCancel = cancel
+	m.execID++
+	id := m.execID
+
+	// Capture values for closure
+	client := m.k8sClient
+	opts := k8s.ExecOptions{
+		Namespace: pod.Namespace,
+		Pod:       pod.Name,
+		Container: container,
+		Command:   args,
+	}
+
+	cmd := func() tea.Msg {
+		if stdin != nil {
+			// The command reads stdin until it ends or is interrupted
+			context.AfterFunc(ctx, func() { _ = stdin.Close() })
+			opts.Stdin = stdin
+		}
+		chunks, err := client.ExecStream(ctx, opts)
+		if err != nil {
+			return execResultMsg{id: id, result: k8s.ExecResult{Error: err}}
+		}
+		return execStreamMsg{id: id, chunks: chunks}
+	}
+
+	return m, cmd
+}
+
+// waitForExecChunk waits for the next output of a running command. The
+// final chunk becomes an execResultMsg.
+func waitForExecChunk(id int, chunks <-chan k8s.ExecChunk) tea.Cmd {
+	if chunks == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		chunk, ok := <-chunks
+		if !ok || chunk.Done {
+			return execResultMsg{id: id, result: k8s.ExecResult{ExitCode: chunk.ExitCode, Error: chunk.Error}}
+		}
+		return execOutputMsg{id: id, chunk: chunk}
+	}
+}
+
+// startDebugContainer adds an ephemeral debug container to the selected
+// pod and waits for it to run, so images without a shell can be inspected
+func (m Model) startDebugContainer(image string) (tea.Model, tea.Cmd) {
+	if m.k8sClient == nil {
+		m.execView.SetError("k8s client not initialized")
+		return m, nil
+	}
+
+	pod, ok := m.selectedPod()
+	if !ok {
+		m.execView.SetError("no pod selected")
+		return m, nil
+	}
+
+	m.execView.AddOutput(fmt.Sprintf("Starting debug container with image %s...", image), false)
+	m.execView.SetState(ui.ExecViewStateRunning)
+	m.execRunning = true
+
+	// Pulling the image counts against the exec timeout; leaving the view
+	// cancels
+	ctx, cancel := m.k8sClient.ExecContext()
+	m.execCancel = cancel
+	m.execID++
+	id := m.execID
+
+	client := m.k8sClient
+	return m, func() tea.Msg {
+		container, err := client.CreateEphemeralDebugContainer(ctx, pod.Namespace, pod.Name, image)
+		if err != nil {
+			return debugContainerMsg{id: id, err: err}
+		}
+		if err := client.WaitForEphemeralContainer(ctx, pod.Namespace, pod.Name, container); err != nil {
+			return debugContainerMsg{id: id, err: fmt.Errorf("debug container %s did not start: %w", container, err)}
+		}
+		return debugContainerMsg{id: id, container: container}
+	}
+}
+
+// stopExec cancels the current exec operation
+func (m *Model) stopExec() {
+	if m.execCancel != nil {
+		m.execCancel()
+		m.execCancel = nil
+	}
+	m.execChunks = nil
+	m.execRunning = false
+	m.execID++
+}
+
+// interruptExec cancels the running command and returns the exec view to
+// its prompt. Cancelling the context ends the command's stream, so it
+// still resolves; its remaining output is dropped as stale.
+func (m *Model) interruptExec() {
+	m.stopExec()
+	m.execView.AddOutput("[interrupted]", true)
+	m.execView.SetState(ui.ExecViewStateIdle)
+	m.execView.Focus()
+}
+
+// loadPodYAML fetches the manifest of a pod for the YAML view
+func (m Model) loadPodYAML(namespace, name string) tea.Cmd {
+	if m.k8sClient == nil {
+		return func() tea.Msg {
+			return podYAMLMsg{err: fmt.Errorf("k8s client not initialized")}
+		}
+	}
+
+	client := m.k8sClient
+
+	return func() tea.Msg {
+		ctx, cancel := client.RequestContext()
+		defer cancel()
+
+		content, err := client.GetPodYAML(ctx, namespace, name)
+		return podYAMLMsg{content: content, err: err}
+	}
+}
+
+// loadDirectory loads directory contents for the file browser
+func (m Model) loadDirectory(path string) tea.Cmd {
+	opts, err := m.fileOptions(path)
+	if err != nil {
+		return func() tea.Msg {
+			return dirLoadedMsg{err: err}
 		}
-		return m, nil
+	}
+	client := m.k8sClient
 
-	case key.Matches(msg, m.keys.Down):
-		if m.selectedPodIndex < len(m.pods)-1 {
-			m.selectedPodIndex++
+	return func() tea.Msg {
+		ctx, cancel := client.ExecContext()
+		defer cancel()
+
+		entries, err := client.ListDir(ctx, opts)
+		return dirLoadedMsg{entries: entries, path: path, err: err}
+	}
+}
+
+// gotoPath validates a path typed into the go-to-path prompt and checks
+// that it is a directory before the browser jumps there
+func (m Model) gotoPath(value string) (tea.Model, tea.Cmd) {
+	if !strings.HasPrefix(value, "/") {
+		return m, m.setStatus(fmt.Sprintf("Path must be absolute: %q", value))
+	}
+	target := path.Clean(value)
+
+	opts, err := m.fileOptions(target)
+	if err != nil {
+		return m, m.setStatus(fmt.Sprintf("Cannot go to %s: %v", target, err))
+	}
+	client := m.k8sClient
+
+	return m, func() tea.Msg {
+		ctx, cancel := client.ExecContext()
+		defer cancel()
+
+		info, err := client.StatFile(ctx, opts)
+		if err == nil && !info.IsDir && !info.IsSymlink {
+			err = fmt.Errorf("not a directory")
 		}
-		return m, nil
+		return gotoPathCheckedMsg{path: target, err: err}
+	}
+}
 
-	case key.Matches(msg, m.keys.Logs):
-		if len(m.pods) > 0 {
-			m.view = model.ViewLogs
-			m.selectedContainer = "" // Reset to use first container
-			cmd := m.initLogStream()
-			return m, cmd
+// loadPathCompletion lists the directory a partial path points into so
+// the prompt can complete its last component
+func (m Model) loadPathCompletion(input string) tea.Cmd {
+	if !strings.HasPrefix(input, "/") {
+		return nil
+	}
+	dir, prefix := splitPartialPath(input)
+
+	opts, err := m.fileOptions(dir)
+	if err != nil {
+		return func() tea.Msg {
+			return pathCompletionMsg{input: input, err: err}
 		}
-		return m, nil
+	}
+	client := m.k8sClient
 
-	case key.Matches(msg, m.keys.Exec):
-		if len(m.pods) > 0 {
-			m.view = model.ViewExec
-			pod := m.pods[m.selectedPodIndex]
-			container := ""
-			if len(pod.Containers) > 0 {
-				container = pod.Containers[0].Name
-			}
-			m.execView.SetPodInfo(pod.Namespace, pod.Name, container)
-			m.execView.SetState(ui.ExecViewStateIdle)
-			m.execView.Focus()
+	return func() tea.Msg {
+		ctx, cancel := client.ExecContext()
+		defer cancel()
+
+		entries, err := client.ListDir(ctx, opts)
+		return pathCompletionMsg{input: input, dir: dir, prefix: prefix, entries: entries, err: err}
+	}
+}
+
+// splitPartialPath splits prompt input into the directory to list and the
+// name prefix to complete. Input ending in "/" completes inside it.
+func splitPartialPath(input string) (dir, prefix string) {
+	if strings.HasSuffix(input, "/") {
+		return input, ""
+	}
+	return k8s.ParentPath(input), input[strings.LastIndex(input, "/")+1:]
+}
+
+// completePath completes prefix against the entries of dir. It returns the
+// new prompt value, extended as far as all matches agree, and the matching
+// names; value is empty when nothing matches. A single directory match
+// gets a trailing "/" so the next Tab descends into it.
+func completePath(dir, prefix string, entries []k8s.FileInfo) (value string, candidates []string) {
+	var match k8s.FileInfo
+	for _, entry := range entries {
+		if entry.Name == "." || entry.Name == ".." || !strings.HasPrefix(entry.Name, prefix) {
+			continue
 		}
-		return m, nil
+		match = entry
+		candidates = append(candidates, entry.Name)
+	}
+	if len(candidates) == 0 {
+		return "", nil
+	}
+	if len(candidates) == 1 {
+		value = k8s.JoinPath(dir, match.Name)
+		if match.IsDir {
+			value += "/"
+		}
+		return value, candidates
+	}
 
-	case key.Matches(msg, m.keys.Files):
-		if len(m.pods) > 0 {
-			m.view = model.ViewFiles
-			pod := m.pods[m.selectedPodIndex]
-			container := ""
-			if len(pod.Containers) > 0 {
-				container = pod.Containers[0].Name
+	common := candidates[0]
+	for _, name := range candidates[1:] {
+		for !strings.HasPrefix(name, common) {
+			common = common[:len(common)-1]
+		}
+	}
+	slices.Sort(candidates)
+	return k8s.JoinPath(dir, common), candidates
+}
+
+// loadFileContent loads the first chunk of a file for preview
+func (m Model) loadFileContent(path, filename string) tea.Cmd {
+	return m.loadFileChunk(path, filename, 0)
+}
+
+// loadFileChunk reads the preview chunk of a file starting at offset.
+// Without a shell to page with, the first chunk is read on its own and
+// the rest of the file can't be loaded.
+func (m Model) loadFileChunk(path, filename string, offset int64) tea.Cmd {
+	opts, err := m.fileOptions(path)
+	if err != nil {
+		return func() tea.Msg {
+			return fileContentMsg{offset: offset, filename: filename, err: err}
+		}
+	}
+	client := m.k8sClient
+
+	return func() tea.Msg {
+		ctx, cancel := client.ExecContext()
+		defer cancel()
+
+		chunk, err := client.ReadFileChunk(ctx, opts, offset, ui.MaxFilePreviewBytes())
+		if errors.Is(err, k8s.ErrNoShell) && offset == 0 {
+			var content string
+			content, err = client.ReadFile(ctx, opts, ui.MaxFilePreviewBytes())
+			chunk = k8s.FileChunk{Content: content, Size: int64(len(content))}
+		}
+		return fileContentMsg{chunk: chunk, offset: offset, filename: filename, err: err}
+	}
+}
+
+// fileOptions targets path in the first container of the selected pod
+func (m Model) fileOptions(path string) (k8s.FileOptions, error) {
+	if m.k8sClient == nil {
+		return k8s.FileOptions{}, fmt.Errorf("k8s client not initialized")
+	}
+
+	pod, ok := m.selectedPod()
+	if !ok {
+		return k8s.FileOptions{}, fmt.Errorf("no pod selected")
+	}
+	container := m.filesView.Container()
+	if container == "" {
+		container = pod.PrimaryContainer()
+	}
+
+	return k8s.FileOptions{
+		Namespace: pod.Namespace,
+		Pod:       pod.Name,
+		Container: container,
+		Path:      path,
+	}, nil
+}
+
+// loadFileForEdit reads the whole file, one byte past the edit limit so
+// oversized files are refused rather than truncated on save
+func (m Model) loadFileForEdit(path, filename string) tea.Cmd {
+	opts, err := m.fileOptions(path)
+	if err != nil {
+		return func() tea.Msg {
+			return fileEditLoadedMsg{path: path, filename: filename, err: err}
+		}
+	}
+	client := m.k8sClient
+
+	return func() tea.Msg {
+		ctx, cancel := client.ExecContext()
+		defer cancel()
+
+		content, err := client.ReadFile(ctx, opts, maxEditBytes+1)
+		return fileEditLoadedMsg{path: path, filename: filename, content: content, err: err}
+	}
+}
+
+// openEditor writes content to a temp file and suspends the UI while the
+// user's editor runs on it
+func openEditor(path, filename, content string) tea.Cmd {
+	tmp, err := os.CreateTemp("", "k8s-tui-*-"+filename)
+	if err != nil {
+		return func() tea.Msg {
+			return fileEditedMsg{path: path, filename: filename, err: err}
+		}
+	}
+	_, err = tmp.WriteString(content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return func() tea.Msg {
+			return fileEditedMsg{path: path, filename: filename, err: err}
+		}
+	}
+
+	return tea.ExecProcess(editorCommand(tmp.Name()), func(err error) tea.Msg {
+		return fileEditedMsg{path: path, filename: filename, tmpPath: tmp.Name(), original: content, err: err}
+	})
+}
+
+// saveEditedFile reads the editor's result and asks to write it back to the
+// container when it changed
+func (m *Model) saveEditedFile(msg fileEditedMsg) tea.Cmd {
+	if msg.tmpPath != "" {
+		defer func() { _ = os.Remove(msg.tmpPath) }()
+	}
+	if msg.err != nil {
+		return m.setStatus(fmt.Sprintf("Editor failed: %v", msg.err))
+	}
+
+	edited, err := os.ReadFile(msg.tmpPath)
+	if err != nil {
+		return m.setStatus(fmt.Sprintf("Could not read the edited file: %v", err))
+	}
+	if string(edited) == msg.original {
+		return m.setStatus(fmt.Sprintf("No changes to %s", msg.filename))
+	}
+
+	opts, err := m.fileOptions(msg.path)
+	if err != nil {
+		return m.setStatus(fmt.Sprintf("Could not save %s: %v", msg.filename, err))
+	}
+	client := m.k8sClient
+
+	write := func() tea.Msg {
+		ctx, cancel := client.ExecContext()
+		defer cancel()
+
+		err := client.WriteFile(ctx, opts, edited)
+		return fileWrittenMsg{path: msg.path, filename: msg.filename, err: err}
+	}
+	m.askConfirm(fmt.Sprintf("Write changes to %s in the container?", msg.path), write, nil)
+	return nil
+}
+
+// handleFilesViewKeys handles keys specific to the files view
+func (m Model) handleFilesViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.filesView.ShowingBookmarks() {
+		return m.handleBookmarkKeys(msg)
+	}
+
+	if !m.filesView.IsViewingFile() && !m.filesView.IsFiltering() {
+		switch msg.String() {
+		case "b":
+			path := m.filesView.CurrentPath()
+			status := fmt.Sprintf("Removed bookmark %s", path)
+			if m.filesView.ToggleBookmark() {
+				status = fmt.Sprintf("Bookmarked %s", path)
+			}
+			return m, tea.Batch(m.setStatus(status), m.saveBookmarks())
+		case ":":
+			m.openPrompt(promptGotoPath, "Go to path (tab completes):", k8s.JoinPath(m.filesView.CurrentPath(), ""))
+			return m, nil
+		case "B":
+			if !m.filesView.OpenBookmarks() {
+				return m, m.setStatus("No bookmarks yet; press 'b' to bookmark this directory")
 			}
-			m.filesView.Clear()
-			m.filesView.SetPodInfo(pod.Namespace, pod.Name, container)
+			return m, nil
+		}
+	}
+
+	// Handle backspace for parent directory navigation (when not viewing a file)
+	if msg.Type == tea.KeyBackspace && !m.filesView.IsViewingFile() && !m.filesView.IsFiltering() {
+		// If viewing a file, backspace is handled by handleBack
+		parent := m.filesView.NavigateToParent()
+		if parent != "" {
 			m.filesView.SetState(ui.FileBrowserStateLoading)
-			return m, m.loadDirectory("/")
+			return m, m.loadDirectory(parent)
 		}
 		return m, nil
+	}
 
-	case key.Matches(msg, m.keys.Namespace):
-		m.prevView = m.view
-		m.view = model.ViewNamespaceSelector
-		m.loadingNamespaces = true
-		return m, m.loadNamespaces
+	if !m.filesView.IsFiltering() && key.Matches(msg, m.keys.CopyKubectl) {
+		return m, m.copyKubectlCommand()
+	}
 
-	case key.Matches(msg, m.keys.Context):
-		m.prevView = m.view
-		m.view = model.ViewContextSelector
-		return m, m.loadContexts
+	if m.filesView.IsViewingFile() && key.Matches(msg, m.keys.Edit) {
+		filename := m.filesView.ViewingFile()
+		path := k8s.JoinPath(m.filesView.CurrentPath(), filename)
+		return m, m.loadFileForEdit(path, filename)
+	}
 
-	case key.Matches(msg, m.keys.Refresh):
-		m.loadingPods = true
-		return m, m.loadPods
+	// Handle Enter for navigation/file viewing
+	if msg.Type == tea.KeyEnter && !m.filesView.IsViewingFile() {
+		m.filesView.AcceptFilter()
+		path, isFile := m.filesView.NavigateToEntry()
+		if path == "" {
+			return m, nil
+		}
+
+		if isFile {
+			// Load file content
+			entry := m.filesView.SelectedEntry()
+			m.filesView.SetState(ui.FileBrowserStateLoading)
+			return m, m.loadFileContent(path, entry.Name)
+		}
+		// Load directory
+		m.filesView.SetState(ui.FileBrowserStateLoading)
+		return m, m.loadDirectory(path)
 	}
 
-	return m, nil
+	// Pass to files view for navigation handling
+	var viewCmd tea.Cmd
+	m.filesView, viewCmd = m.filesView.Update(msg)
+
+	// Scrolling to the end of a large file's loaded part reads on
+	if offset, ok := m.filesView.MoreContentRequest(); ok {
+		filename := m.filesView.ViewingFile()
+		path := k8s.JoinPath(m.filesView.CurrentPath(), filename)
+		return m, tea.Batch(viewCmd, m.loadFileChunk(path, filename, offset))
+	}
+	return m, viewCmd
 }
 
-// handleNamespaceSelectorKeys handles keys for namespace selection
-func (m Model) handleNamespaceSelectorKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+// handleBookmarkKeys handles keys while the bookmark picker is open
+func (m Model) handleBookmarkKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch {
-	case key.Matches(msg, m.keys.Up):
-		if m.selectedNamespaceIndex > 0 {
-			m.selectedNamespaceIndex--
+	case msg.Type == tea.KeyEnter:
+		path, ok := m.filesView.SelectedBookmark()
+		if !ok {
+			return m, nil
 		}
-		return m, nil
+		m.filesView.CloseBookmarks()
+		m.filesView.JumpTo(path)
+		m.filesView.SetState(ui.FileBrowserStateLoading)
+		return m, m.loadDirectory(path)
 
-	case key.Matches(msg, m.keys.Down):
-		if m.selectedNamespaceIndex < len(m.namespaces)-1 {
-			m.selectedNamespaceIndex++
+	case msg.String() == "d":
+		path, ok := m.filesView.RemoveSelectedBookmark()
+		if !ok {
+			return m, nil
 		}
-		return m, nil
+		return m, tea.Batch(m.setStatus(fmt.Sprintf("Removed bookmark %s", path)), m.saveBookmarks())
+	}
+
+	// Pass to files view for picker navigation
+	var viewCmd tea.Cmd
+	m.filesView, viewCmd = m.filesView.Update(msg)
+	return m, viewCmd
+}
+
+// saveBookmarks writes the file browser bookmarks to disk
+func (m Model) saveBookmarks() tea.Cmd {
+	path := m.bookmarksPath
+	if path == "" {
+		return nil
+	}
+	bookmarks := m.filesView.Bookmarks()
+
+	return func() tea.Msg {
+		return bookmarksSavedMsg{err: config.SaveBookmarks(path, bookmarks)}
+	}
+}
+
+// stopFileBrowser cleans up file browser state
+func (m *Model) stopFileBrowser() {
+	if m.filesCancel != nil {
+		m.filesCancel()
+		m.filesCancel = nil
+	}
+	m.filesView.Clear()
+}
+
+// View implements tea.Model
+func (m Model) View() string {
+	if !m.ready {
+		return "Initializing..."
+	}
+
+	// Build the main content based on current view
+	var content string
+	switch m.view {
+	case model.ViewPodList:
+		content = m.viewPodList()
+	case model.ViewLogs:
+		content = m.viewLogs()
+	case model.ViewExec:
+		content = m.viewExec()
+	case model.ViewFiles:
+		content = m.viewFiles()
+	case model.ViewDeployments:
+		content = m.viewDeployments()
+	case model.ViewTop:
+		content = m.viewTop()
+	case model.ViewNamespaceSelector:
+		content = m.viewNamespaceSelector()
+	case model.ViewContextSelector:
+		content = m.viewContextSelector()
+	case model.ViewServices:
+		content = m.viewServices()
+	case model.ViewContainerSelector:
+		content = m.viewContainerSelector()
+	case model.ViewYAML:
+		content = m.yamlView.View()
+	case model.ViewEvents:
+		content = m.eventsView.View()
+	case model.ViewEnv:
+		content = m.envView.View()
+	case model.ViewConfigMaps, model.ViewSecrets:
+		content = m.dataView.View()
+	case model.ViewPrompt:
+		content = m.prompt.View()
+		if m.promptAction == promptLogSearch {
+			// The logs stay in sight so matches show while typing
+			content = m.viewLogs() + "\n" + m.logSearchStatus()
+		}
+	case model.ViewConfirm:
+		content = m.confirmation.View()
+	case model.ViewSearch:
+		content = m.search.View()
+	case model.ViewAbout:
+		content = m.viewAbout()
+	case model.ViewHelp:
+		content = m.viewHelp()
+	default:
+		content = "Unknown view"
+	}
+
+	if m.view == model.ViewPodList && m.gotoActive {
+		content += "\n" + m.styles.StatusBar.Render(m.gotoStatus())
+	}
+
+	// Add status and help bars at bottom
+	helpView := m.help.View(m.keys)
+
+	return content + "\n" + m.statusBar() + "\n\n" + helpView
+}
+
+// k8sErrorMessage describes a failed attempt to do action, e.g. "list
+// pods in namespace default". RBAC and credential failures say what to
+// check instead of showing the raw API error.
+func k8sErrorMessage(err error, action string) string {
+	switch k8s.ClassifyError(err) {
+	case k8s.ErrorForbidden:
+		return fmt.Sprintf("Forbidden: cannot %s (check RBAC)", action)
+	case k8s.ErrorUnauthorized:
+		return "Unauthorized: token may be expired (log in again, then press 'R' to reload the kubeconfig)"
+	}
+	return fmt.Sprintf("Error: %v", err)
+}
+
+// namespaceScope names the namespaces a list covers, for messages
+func (m Model) namespaceScope(all bool) string {
+	if all {
+		return "all namespaces"
+	}
+	if m.k8sClient == nil {
+		return "the current namespace"
+	}
+	return "namespace " + m.k8sClient.CurrentNamespace()
+}
+
+// statusBar renders transient notifications on one line above the help
+// bar. The line is kept even when empty and never wraps, so views keep
+// their height as notifications come and go.
+func (m Model) statusBar() string {
+	var parts []string
+	if m.switchingContext != "" {
+		parts = append(parts, fmt.Sprintf("Switching context to %s...", m.switchingContext))
+	}
+	if m.statusMessage != "" {
+		parts = append(parts, m.statusMessage)
+	}
 
-	case key.Matches(msg, m.keys.Enter):
-		if m.selectedNamespaceIndex < len(m.namespaces) {
-			ns := m.namespaces[m.selectedNamespaceIndex]
-			m.k8sClient.SetNamespace(ns.Name)
-			m.view = m.prevView
-			m.loadingPods = true
-			return m, m.loadPods
-		}
-		return m, nil
+	// Multi-line errors are flattened so they stay on the line
+	text := strings.ReplaceAll(strings.Join(parts, " | "), "\n", " ")
+	if m.width > 0 {
+		text = ui.Truncate(text, m.width)
+	}
+	if m.statusAlert && m.statusMessage != "" {
+		return m.styles.Error.Render(text)
 	}
+	return m.styles.StatusBar.Render(text)
+}
 
-	return m, nil
+// gotoStatus describes the jump-to-pod buffer for the footer
+func (m Model) gotoStatus() string {
+	status := "Go to: " + m.gotoBuffer
+	if m.gotoBuffer != "" && m.gotoMatch() < 0 {
+		status += " (no match)"
+	}
+	return status
 }
 
-// handleContextSelectorKeys handles keys for context selection
-func (m Model) handleContextSelectorKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch {
-	case key.Matches(msg, m.keys.Up):
-		if m.selectedContextIndex > 0 {
-			m.selectedContextIndex--
-		}
-		return m, nil
+// logSearchStatus renders the log search prompt as one line below the logs
+func (m Model) logSearchStatus() string {
+	status := m.prompt.InlineView()
+	if m.prompt.Value() != "" && m.logView.MatchCount() == 0 {
+		status += " (no match)"
+	}
+	return status
+}
 
-	case key.Matches(msg, m.keys.Down):
-		if m.selectedContextIndex < len(m.contexts)-1 {
-			m.selectedContextIndex++
+// viewPodList renders the pod list view
+func (m Model) viewPodList() string {
+	var b strings.Builder
+
+	// Header
+	header := "K8s Pod Manager"
+	if m.k8sClient != nil {
+		namespace := m.k8sClient.CurrentNamespace()
+		if m.allNamespaces {
+			namespace = "all"
 		}
-		return m, nil
+		header += fmt.Sprintf(" | Context: %s | Namespace: %s",
+			m.k8sClient.CurrentContext(), namespace)
+	}
+	if m.opts.ReadOnly {
+		header += " | Read-only"
+	}
+	if m.labelSelector != "" {
+		header += " | Labels: " + m.labelSelector
+	}
+	if m.nodeFilter != "" {
+		header += " | Node: " + m.nodeFilter
+	}
+	if m.serviceFilter.Name != "" {
+		header += " | Service: " + m.serviceFilter.Name
+	}
+	if hidden := m.hiddenPodCount(); hidden > 0 {
+		header += fmt.Sprintf(" | %d completed hidden", hidden)
+	}
+	header += m.refreshIndicator()
+	b.WriteString(m.styles.Header.Render(header))
+	if indicator := m.connectionIndicator(); indicator != "" {
+		b.WriteString(" " + indicator)
+	}
+	b.WriteString("\n")
+	// The status counts take the blank line under the header
+	if len(m.pods) > 0 && m.k8sErr == nil {
+		b.WriteString(m.podStatusSummary())
+	}
+	b.WriteString("\n")
 
-	case key.Matches(msg, m.keys.Enter):
-		if m.selectedContextIndex < len(m.contexts) {
-			ctx := m.contexts[m.selectedContextIndex]
-			if err := m.k8sClient.SwitchContext(ctx.Name); err != nil {
-				m.k8sErr = err
-				return m, nil
-			}
-			m.view = m.prevView
-			m.loadingPods = true
-			return m, tea.Batch(m.loadPods, m.loadContexts)
+	// Error state
+	if m.k8sErr != nil {
+		b.WriteString(m.styles.Error.Render(k8sErrorMessage(m.k8sErr, "list pods in "+m.namespaceScope(m.allNamespaces))))
+		b.WriteString("\n\n")
+		if m.podsRetry > 0 {
+			b.WriteString(fmt.Sprintf("Reconnecting (attempt %d)...\n\n", m.podsRetry))
 		}
-		return m, nil
+		b.WriteString("Press 'r' to retry, 'c' to change context, 'n' to change namespace")
+		return b.String()
 	}
 
-	return m, nil
-}
+	// Loading state
+	if m.loadingK8s {
+		b.WriteString("Connecting to Kubernetes cluster...")
+		return b.String()
+	}
 
-// handleLogViewKeys handles keys specific to the log view
-func (m Model) handleLogViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "j", "down":
-		m.logView.ScrollDown(1)
-		return m, nil
+	// A refresh keeps the current list on screen
+	if m.loadingPods && len(m.pods) == 0 {
+		b.WriteString("Loading pods...")
+		return b.String()
+	}
 
-	case "k", "up":
-		m.logView.ScrollUp(1)
-		return m, nil
+	// Empty state
+	pods := m.visiblePods()
+	if len(m.pods) == 0 && m.allNamespaces {
+		b.WriteString("No pods found in any namespace.\n\n")
+		b.WriteString("Press 'A' to show one namespace, 'c' to switch context")
+		return b.String()
+	}
 
-	case "g":
-		m.logView.GotoTop()
-		return m, nil
+	if len(m.pods) == 0 && m.labelSelector != "" {
+		b.WriteString(fmt.Sprintf("No pods match label selector %s.\n\n", m.labelSelector))
+		b.WriteString("Press 'esc' to clear the selector, 'F' to change it")
+		return b.String()
+	}
 
-	case "G":
-		m.logView.GotoBottom()
-		return m, nil
+	if len(m.pods) == 0 {
+		b.WriteString("No pods found in this namespace.\n\n")
+		b.WriteString("Press 'n' to switch namespace, 'c' to switch context")
+		return b.String()
+	}
 
-	case "f", "F":
-		m.logView.ToggleFollow()
-		return m, nil
+	if len(pods) == 0 && m.serviceFilter.Name != "" && m.hiddenPodCount() == 0 {
+		b.WriteString(fmt.Sprintf("No pods in this namespace back service %s (selector: %s).\n\n",
+			m.serviceFilter.Name, m.serviceFilter.SelectorString()))
+		b.WriteString("Press 'esc' to go back to the services")
+		return b.String()
+	}
 
-	case "pgdown", " ":
-		m.logView.PageDown()
-		return m, nil
+	if len(pods) == 0 && m.nodeFilter != "" && m.hiddenPodCount() == 0 {
+		b.WriteString(fmt.Sprintf("No pods in this namespace run on node %s.\n\n", m.nodeFilter))
+		b.WriteString("Press 'N' to change the node filter")
+		return b.String()
+	}
 
-	case "pgup":
-		m.logView.PageUp()
-		return m, nil
+	if len(pods) == 0 {
+		b.WriteString("All pods in this namespace are completed.\n\n")
+		b.WriteString("Press 'h' to show completed pods")
+		return b.String()
 	}
 
-	// Pass to log view for viewport handling
-	var cmd tea.Cmd
-	m.logView, cmd = m.logView.Update(msg)
-	return m, cmd
-}
+	// Pod list header
+	layout := newPodListLayout(m.width, m.allNamespaces, m.showOwner, m.showImage, m.showNodeIP)
+	b.WriteString(m.styles.Header.Render(layout.row("  ", "NAMESPACE", "NAME", fit("STATUS", podStatusWidth),
+		"READY", "RESTARTS", "AGE", "OWNER", "IMAGE", "NODE", "IP")))
+	b.WriteString("\n")
+	b.WriteString(strings.Repeat("-", layout.width()) + "\n")
 
-// handleExecViewKeys handles keys specific to the exec view
-func (m Model) handleExecViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	// Don't process keys while command is running (except for cancel)
-	if m.execRunning {
-		return m, nil
+	var groupSizes map[k8s.OwnerRef]int
+	if m.groupByOwner {
+		groupSizes = make(map[k8s.OwnerRef]int)
+		for i := range pods {
+			groupSizes[pods[i].Owner]++
+		}
 	}
 
-	switch msg.Type {
-	case tea.KeyEnter:
-		// Execute the command
-		cmd := m.execView.GetCommand()
-		if cmd != "" {
-			return m.runExecCommand(cmd)
+	// Pod list. rowPods holds the pod index of each row; selStart and
+	// selEnd bound the selected pod's rows, including its group header
+	// and expanded containers.
+	var rows []string
+	var rowPods []int
+	selStart, selEnd := 0, 0
+	for i := range pods {
+		pod := &pods[i]
+		if i == m.selectedPodIndex {
+			selStart = len(rows)
 		}
-		return m, nil
-	}
 
-	// Pass to exec view for input handling
-	var viewCmd tea.Cmd
-	m.execView, viewCmd = m.execView.Update(msg)
-	return m, viewCmd
-}
+		if m.groupByOwner && (i == 0 || pods[i-1].Owner != pod.Owner) {
+			rows = append(rows, m.styles.Header.Render(fmt.Sprintf("%s (%d)",
+				ownerGroupLabel(pod.Owner), groupSizes[pod.Owner])))
+			rowPods = append(rowPods, i)
+		}
 
-// runExecCommand starts executing a command in the pod
-func (m Model) runExecCommand(command string) (tea.Model, tea.Cmd) {
-	if m.k8sClient == nil {
-		m.execView.SetError("k8s client not initialized")
-		return m, nil
-	}
+		prefix := "  "
+		if i == m.selectedPodIndex {
+			prefix = m.styles.Selected.Render("> ")
+		}
 
-	if m.selectedPodIndex >= len(m.pods) {
-		m.execView.SetError("no pod selected")
-		return m, nil
+		// Pad before styling so escape codes don't break column alignment
+		status := m.styles.PodStatus(pod.Status, pod.StatusMessage).
+			Render(fit(pod.DisplayStatus(), podStatusWidth))
+
+		rows = append(rows, layout.row(prefix, pod.Namespace, pod.Name, status, pod.Ready,
+			m.restartsCell(*pod), k8s.FormatAge(pod.Age), pod.Owner.String(), imageCell(pod), pod.Node, pod.IP))
+		rowPods = append(rowPods, i)
+
+		if m.podExpanded && i == m.selectedPodIndex {
+			for _, row := range containerRows(pod) {
+				if m.width > 0 {
+					row = ui.Truncate(row, m.width)
+				}
+				rows = append(rows, row)
+				rowPods = append(rowPods, i)
+			}
+		}
+		if i == m.selectedPodIndex {
+			selEnd = len(rows) - 1
+		}
 	}
 
-	pod := m.pods[m.selectedPodIndex]
-	container := ""
-	if len(pod.Containers) > 0 {
-		container = pod.Containers[0].Name
+	start, end := podListWindow(len(rows), selStart, selEnd, m.podListHeight())
+	for _, row := range rows[start:end] {
+		b.WriteString(row)
+		b.WriteString("\n")
 	}
 
-	// Parse command
-	args := k8s.ParseCommand(command)
-	if len(args) == 0 {
-		return m, nil
+	// The scroll indicator takes the blank line under the list
+	if start > 0 || end < len(rows) {
+		b.WriteString(fmt.Sprintf("Showing pods %d-%d of %d", rowPods[start]+1, rowPods[end-1]+1, len(pods)))
+	}
+	b.WriteString("\n")
+	if pod, ok := m.selectedPod(); ok {
+		b.WriteString(podDetails(&pod))
+		b.WriteString("\n")
+	}
+	if m.opts.ReadOnly {
+		b.WriteString("Press 'l' for logs, 'f' for files, 'y' for yaml, 'r' to refresh")
+	} else {
+		b.WriteString("Press 'l' for logs, 'e' for exec, 'f' for files, 'y' for yaml, 'r' to refresh")
 	}
 
-	// Add to history and show marker
-	m.execView.AddToHistory(command)
-	m.execView.AddCommandMarker(command)
-	m.execView.ClearInput()
-	m.execView.SetState(ui.ExecViewStateRunning)
-	m.execRunning = true
+	return b.String()
+}
 
-	// Create context for this exec
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	m.execCancel = cancel
+// podListChrome counts the pod list lines around the rows: header and
+// status counts, column header and separator, scroll indicator, details and
+// hints, then the status bar, blank line and help bar under every view
+const podListChrome = 10
 
-	// Capture values for closure
-	client := m.k8sClient
-	opts := k8s.ExecOptions{
-		Namespace: pod.Namespace,
-		Pod:       pod.Name,
-		Container: container,
-		Command:   args,
+// podListHeight returns how many rows of the pod list fit on screen, or 0
+// before the terminal size is known
+func (m Model) podListHeight() int {
+	if m.height <= 0 {
+		return 0
 	}
-
-	cmd := func() tea.Msg {
-		result := client.Exec(ctx, opts)
-		return execResultMsg{result: result}
+	height := m.height - podListChrome
+	if m.gotoActive {
+		height--
 	}
-
-	return m, cmd
+	return max(height, 1)
 }
 
-// stopExec cancels the current exec operation
-func (m *Model) stopExec() {
-	if m.execCancel != nil {
-		m.execCancel()
-		m.execCancel = nil
+// podListWindow returns the range of rows to render so the selected rows
+// from selStart to selEnd stay in view, keeping the selection's first row
+// when the selection is taller than the window. A height of 0 shows all.
+func podListWindow(total, selStart, selEnd, height int) (start, end int) {
+	if height <= 0 || total <= height {
+		return 0, total
 	}
-	m.execRunning = false
+	if selEnd >= height {
+		start = selEnd - height + 1
+	}
+	start = min(start, selStart)
+	return start, min(start+height, total)
 }
 
-// loadDirectory loads directory contents for the file browser
-func (m Model) loadDirectory(path string) tea.Cmd {
-	if m.k8sClient == nil {
-		return func() tea.Msg {
-			return dirLoadedMsg{err: fmt.Errorf("k8s client not initialized")}
-		}
+// refreshIndicator tells how fresh the pod list is for the header: a
+// marker while a refresh is loading, else the time since the last list
+func (m Model) refreshIndicator() string {
+	switch {
+	case m.loadingPods && len(m.pods) > 0:
+		return " | refreshing..."
+	case m.lastRefresh.IsZero() || m.loadingPods:
+		return ""
+	default:
+		return fmt.Sprintf(" | updated %s ago", k8s.FormatAge(time.Since(m.lastRefresh)))
 	}
+}
 
-	if m.selectedPodIndex >= len(m.pods) {
-		return func() tea.Msg {
-			return dirLoadedMsg{err: fmt.Errorf("no pod selected")}
-		}
+// summaryStatuses lists the pod statuses counted in the pod list header
+var summaryStatuses = []k8s.PodStatus{
+	k8s.PodStatusRunning,
+	k8s.PodStatusPending,
+	k8s.PodStatusFailed,
+	k8s.PodStatusTerminating,
+}
+
+// podStatusCounts tallies pods by status
+func podStatusCounts(pods []k8s.PodInfo) map[k8s.PodStatus]int {
+	counts := make(map[k8s.PodStatus]int)
+	for i := range pods {
+		counts[pods[i].Status]++
 	}
+	return counts
+}
 
-	pod := m.pods[m.selectedPodIndex]
-	container := ""
-	if len(pod.Containers) > 0 {
-		container = pod.Containers[0].Name
+// podStatusSummary renders the status counts of all listed pods, such as
+// "Running: 40  Pending: 2  Failed: 1  Terminating: 0"
+func (m Model) podStatusSummary() string {
+	counts := podStatusCounts(m.pods)
+	parts := make([]string, len(summaryStatuses))
+	for i, status := range summaryStatuses {
+		parts[i] = m.styles.PodStatus(status, "").Render(fmt.Sprintf("%s: %d", status, counts[status]))
 	}
+	return strings.Join(parts, "  ")
+}
 
-	client := m.k8sClient
-	namespace := pod.Namespace
-	podName := pod.Name
+// viewDeployments renders the deployment list view
+func (m Model) viewDeployments() string {
+	var b strings.Builder
 
-	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
+	header := "K8s Pod Manager > Deployments"
+	if m.k8sClient != nil {
+		header += fmt.Sprintf(" | Context: %s | Namespace: %s",
+			m.k8sClient.CurrentContext(),
+			m.k8sClient.CurrentNamespace())
+	}
+	if m.opts.ReadOnly {
+		header += " | Read-only"
+	}
+	b.WriteString(m.styles.Header.Render(header))
+	b.WriteString("\n\n")
 
-		opts := k8s.FileOptions{
-			Namespace: namespace,
-			Pod:       podName,
-			Container: container,
-			Path:      path,
-		}
+	if m.deploymentsErr != nil {
+		b.WriteString(m.styles.Error.Render(k8sErrorMessage(m.deploymentsErr, "list deployments in "+m.namespaceScope(false))))
+		b.WriteString("\n\nPress 'r' to retry, 'd' for pods")
+		return b.String()
+	}
 
-		entries, err := client.ListDir(ctx, opts)
-		return dirLoadedMsg{entries: entries, path: path, err: err}
+	if m.loadingDeployments {
+		b.WriteString("Loading deployments...")
+		return b.String()
 	}
-}
 
-// loadFileContent loads file contents for preview
-func (m Model) loadFileContent(path, filename string) tea.Cmd {
-	if m.k8sClient == nil {
-		return func() tea.Msg {
-			return fileContentMsg{err: fmt.Errorf("k8s client not initialized")}
-		}
+	if len(m.deployments) == 0 {
+		b.WriteString("No deployments found in this namespace.\n\n")
+		b.WriteString("Press 'n' to switch namespace, 'd' for pods")
+		return b.String()
 	}
 
-	if m.selectedPodIndex >= len(m.pods) {
-		return func() tea.Msg {
-			return fileContentMsg{err: fmt.Errorf("no pod selected")}
+	b.WriteString(m.styles.Header.Render(fmt.Sprintf("%-40s %-8s %-12s %-10s %-15s",
+		"NAME", "READY", "UP-TO-DATE", "AVAILABLE", "AGE")))
+	b.WriteString("\n")
+	b.WriteString(strings.Repeat("-", 89) + "\n")
+
+	for i, d := range m.deployments {
+		prefix := "  "
+		if i == m.selectedDeploymentIndex {
+			prefix = m.styles.Selected.Render("> ")
 		}
+		b.WriteString(fmt.Sprintf("%s%s %-8s %-12d %-10d %-15s\n",
+			prefix,
+			fit(d.Name, 38),
+			d.Ready(),
+			d.UpdatedReplicas,
+			d.AvailableReplicas,
+			k8s.FormatAge(d.Age)))
 	}
 
-	pod := m.pods[m.selectedPodIndex]
-	container := ""
-	if len(pod.Containers) > 0 {
-		container = pod.Containers[0].Name
+	if m.opts.ReadOnly {
+		b.WriteString("\nPress 'd' for pods, 'r' to refresh")
+	} else {
+		b.WriteString("\nPress 's' to scale, 'x' to restart, 'd' for pods, 'r' to refresh")
 	}
 
-	client := m.k8sClient
-	namespace := pod.Namespace
-	podName := pod.Name
+	return b.String()
+}
 
-	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
+// viewTop renders the pods of the current namespace by resource usage
+func (m Model) viewTop() string {
+	var b strings.Builder
 
-		opts := k8s.FileOptions{
-			Namespace: namespace,
-			Pod:       podName,
-			Container: container,
-			Path:      path,
-		}
+	sortedBy := "CPU"
+	if m.topSortMemory {
+		sortedBy = "memory"
+	}
+	header := "K8s Pod Manager > Top"
+	if m.k8sClient != nil {
+		header += fmt.Sprintf(" | Context: %s | Namespace: %s",
+			m.k8sClient.CurrentContext(),
+			m.k8sClient.CurrentNamespace())
+	}
+	header += " | Sorted by " + sortedBy
+	b.WriteString(m.styles.Header.Render(header))
+	b.WriteString("\n\n")
 
-		content, err := client.ReadFile(ctx, opts, ui.MaxFilePreviewBytes())
-		return fileContentMsg{content: content, filename: filename, err: err}
+	if errors.Is(m.topErr, k8s.ErrMetricsUnavailable) {
+		b.WriteString("Resource usage is not available: this cluster doesn't serve the metrics API.\n")
+		b.WriteString("Install metrics-server to see the CPU and memory pods use; requests and\n")
+		b.WriteString("limits are shown in the pod list details.\n\n")
+		b.WriteString("Press 'r' to retry, 'esc' for pods")
+		return b.String()
 	}
-}
 
-// handleFilesViewKeys handles keys specific to the files view
-func (m Model) handleFilesViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	// Handle backspace for parent directory navigation (when not viewing a file)
-	if msg.Type == tea.KeyBackspace && !m.filesView.IsViewingFile() {
-		// If viewing a file, backspace is handled by handleBack
-		parent := m.filesView.NavigateToParent()
-		if parent != "" {
-			m.filesView.SetState(ui.FileBrowserStateLoading)
-			return m, m.loadDirectory(parent)
-		}
-		return m, nil
+	if m.topErr != nil {
+		b.WriteString(m.styles.Error.Render(k8sErrorMessage(m.topErr, "get pod metrics in "+m.namespaceScope(false))))
+		b.WriteString("\n\nPress 'r' to retry, 'esc' for pods")
+		return b.String()
 	}
 
-	// Handle Enter for navigation/file viewing
-	if msg.Type == tea.KeyEnter && !m.filesView.IsViewingFile() {
-		path, isFile := m.filesView.NavigateToEntry()
-		if path == "" {
-			return m, nil
-		}
+	if m.loadingTop && len(m.topMetrics) == 0 {
+		b.WriteString("Loading resource usage...")
+		return b.String()
+	}
 
-		if isFile {
-			// Load file content
-			entry := m.filesView.SelectedEntry()
-			m.filesView.SetState(ui.FileBrowserStateLoading)
-			return m, m.loadFileContent(path, entry.Name)
+	rows := m.topRows()
+	if len(rows) == 0 {
+		b.WriteString("No pod metrics in this namespace yet.\n\n")
+		b.WriteString("Press 'r' to refresh, 'esc' for pods")
+		return b.String()
+	}
+
+	layout := newTopLayout(m.width)
+	b.WriteString(m.styles.Header.Render(layout.row("  ", "NAME", "CPU", "%REQ", "%LIM", "MEMORY", "%REQ", "%LIM")))
+	b.WriteString("\n")
+	b.WriteString(strings.Repeat("-", layout.width()) + "\n")
+
+	lines := make([]string, 0, len(rows))
+	for i, row := range rows {
+		prefix := "  "
+		if i == m.selectedTopIndex {
+			prefix = m.styles.Selected.Render("> ")
 		}
-		// Load directory
-		m.filesView.SetState(ui.FileBrowserStateLoading)
-		return m, m.loadDirectory(path)
+		pod := row.pod
+		lines = append(lines, layout.row(prefix, row.metrics.Name,
+			formatCPU(row.metrics.CPU), usagePercent(row.metrics.CPU, pod.CPURequest), usagePercent(row.metrics.CPU, pod.CPULimit),
+			formatMemory(row.metrics.Memory), usagePercent(row.metrics.Memory, pod.MemRequest), usagePercent(row.metrics.Memory, pod.MemLimit)))
 	}
 
-	// Pass to files view for navigation handling
-	var viewCmd tea.Cmd
-	m.filesView, viewCmd = m.filesView.Update(msg)
-	return m, viewCmd
-}
+	start, end := podListWindow(len(lines), m.selectedTopIndex, m.selectedTopIndex, m.podListHeight())
+	for _, line := range lines[start:end] {
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
 
-// stopFileBrowser cleans up file browser state
-func (m *Model) stopFileBrowser() {
-	if m.filesCancel != nil {
-		m.filesCancel()
-		m.filesCancel = nil
+	otherSort := "memory"
+	if m.topSortMemory {
+		otherSort = "CPU"
 	}
-	m.filesView.Clear()
+	b.WriteString("\nPress 's' to sort by " + otherSort + ", 'r' to refresh, 'esc' for pods")
+	return b.String()
 }
 
-// View implements tea.Model
-func (m Model) View() string {
-	if !m.ready {
-		return "Initializing..."
+// topRow is a pod's usage next to the pod, for its requests and limits.
+// The pod is zero when metrics list a pod the pod list doesn't have yet.
+type topRow struct {
+	metrics k8s.PodMetrics
+	pod     k8s.PodInfo
+}
+
+// topRows joins pod usage with the listed pods, hungriest first
+func (m Model) topRows() []topRow {
+	pods := make(map[string]k8s.PodInfo, len(m.pods))
+	for _, pod := range m.pods {
+		pods[podKey(pod)] = pod
 	}
 
-	// Build the main content based on current view
-	var content string
-	switch m.view {
-	case model.ViewPodList:
-		content = m.viewPodList()
-	case model.ViewLogs:
-		content = m.viewLogs()
-	case model.ViewExec:
-		content = m.viewExec()
-	case model.ViewFiles:
-		content = m.viewFiles()
-	case model.ViewNamespaceSelector:
-		content = m.viewNamespaceSelector()
-	case model.ViewContextSelector:
-		content = m.viewContextSelector()
-	case model.ViewHelp:
-		content = m.viewHelp()
-	default:
-		content = "Unknown view"
+	rows := make([]topRow, 0, len(m.topMetrics))
+	for _, metrics := range m.topMetrics {
+		rows = append(rows, topRow{metrics: metrics,
+			pod: pods[podKey(k8s.PodInfo{Namespace: metrics.Namespace, Name: metrics.Name})]})
 	}
+	sortTopRows(rows, m.topSortMemory)
+	return rows
+}
 
-	// Add help bar at bottom
-	helpView := m.help.View(m.keys)
+// sortTopRows orders rows by CPU or memory usage, highest first, then by name
+func sortTopRows(rows []topRow, byMemory bool) {
+	slices.SortStableFunc(rows, func(a, b topRow) int {
+		usageA, usageB := a.metrics.CPU, b.metrics.CPU
+		if byMemory {
+			usageA, usageB = a.metrics.Memory, b.metrics.Memory
+		}
+		if c := usageB.Cmp(usageA); c != 0 {
+			return c
+		}
+		return strings.Compare(a.metrics.Name, b.metrics.Name)
+	})
+}
 
-	return content + "\n\n" + helpView
+// formatCPU formats CPU usage in millicores like kubectl top, e.g. "250m"
+func formatCPU(q resource.Quantity) string {
+	return fmt.Sprintf("%dm", q.MilliValue())
 }
 
-// viewPodList renders the pod list view
-func (m Model) viewPodList() string {
+// formatMemory formats memory usage in mebibytes like kubectl top, e.g. "128Mi"
+func formatMemory(q resource.Quantity) string {
+	return fmt.Sprintf("%dMi", q.Value()/(1024*1024))
+}
+
+// usagePercent formats usage as a percentage of a request or limit, or "-"
+// when the pod sets none
+func usagePercent(usage, of resource.Quantity) string {
+	if of.IsZero() {
+		return "-"
+	}
+	return fmt.Sprintf("%d%%", usage.MilliValue()*100/of.MilliValue())
+}
+
+// viewServices renders the service list overlay
+func (m Model) viewServices() string {
 	var b strings.Builder
 
-	// Header
-	b.WriteString("K8s Pod Manager")
+	header := "K8s Pod Manager > Services"
 	if m.k8sClient != nil {
-		b.WriteString(fmt.Sprintf(" | Context: %s | Namespace: %s",
+		header += fmt.Sprintf(" | Context: %s | Namespace: %s",
 			m.k8sClient.CurrentContext(),
-			m.k8sClient.CurrentNamespace()))
+			m.k8sClient.CurrentNamespace())
 	}
+	b.WriteString(m.styles.Header.Render(header))
 	b.WriteString("\n\n")
 
-	// Error state
-	if m.k8sErr != nil {
-		b.WriteString(fmt.Sprintf("Error: %v\n\n", m.k8sErr))
-		b.WriteString("Press 'r' to retry, 'c' to change context, 'n' to change namespace")
-		return b.String()
-	}
-
-	// Loading state
-	if m.loadingK8s {
-		b.WriteString("Connecting to Kubernetes cluster...")
+	if m.servicesErr != nil {
+		b.WriteString(m.styles.Error.Render(k8sErrorMessage(m.servicesErr, "list services in "+m.namespaceScope(false))))
+		b.WriteString("\n\nPress 'r' to retry, 'esc' to go back")
 		return b.String()
 	}
 
-	if m.loadingPods {
-		b.WriteString("Loading pods...")
+	if m.loadingServices {
+		b.WriteString("Loading services...")
 		return b.String()
 	}
 
-	// Empty state
-	if len(m.pods) == 0 {
-		b.WriteString("No pods found in this namespace.\n\n")
-		b.WriteString("Press 'n' to switch namespace, 'c' to switch context")
+	if len(m.services) == 0 {
+		b.WriteString("No services found in this namespace.\n\n")
+		b.WriteString("Press 'esc' to go back")
 		return b.String()
 	}
 
-	// Pod list header
-	b.WriteString(fmt.Sprintf("%-40s %-12s %-8s %-10s %-15s\n",
-		"NAME", "STATUS", "READY", "RESTARTS", "AGE"))
-	b.WriteString(strings.Repeat("-", 85) + "\n")
+	b.WriteString(m.styles.Header.Render(fmt.Sprintf("%-30s %-13s %-16s %-24s %-24s %s",
+		"NAME", "TYPE", "CLUSTER-IP", "PORTS", "SELECTOR", "AGE")))
+	b.WriteString("\n")
+	b.WriteString(strings.Repeat("-", 118) + "\n")
 
-	// Pod list
-	for i := range m.pods {
-		pod := &m.pods[i]
+	for i, svc := range m.services {
 		prefix := "  "
-		if i == m.selectedPodIndex {
-			prefix = "> "
+		if i == m.selectedServiceIndex {
+			prefix = m.styles.Selected.Render("> ")
 		}
-
-		age := formatAge(pod.Age)
-		b.WriteString(fmt.Sprintf("%s%-38s %-12s %-8s %-10d %-15s\n",
+		b.WriteString(fmt.Sprintf("%s%s %s %-16s %s %s %s\n",
 			prefix,
-			truncate(pod.Name, 38),
-			pod.Status,
-			pod.Ready,
-			pod.Restarts,
-			age))
+			fit(svc.Name, 28),
+			m.styles.ServiceType(svc.Type).Render(ui.PadRight(svc.Type, 13)),
+			svc.ClusterIP,
+			fit(svc.PortList(), 24),
+			fit(svc.SelectorString(), 24),
+			k8s.FormatAge(svc.Age)))
 	}
 
-	b.WriteString("\n")
-	b.WriteString("Press 'l' for logs, 'e' for exec, 'f' for files, 'r' to refresh")
+	b.WriteString("\nPress 'enter' to list the pods backing a service, 'r' to refresh, 'esc' to go back")
 
 	return b.String()
 }
 
 func (m Model) viewLogs() string {
-	if m.selectedPodIndex >= len(m.pods) {
+	if _, ok := m.selectedPod(); !ok {
 		return "K8s Pod Manager > Logs\n\n[No pod selected]\n\nPress 'esc' to go back"
 	}
 
@@ -1015,13 +5169,13 @@ func (m Model) viewLogs() string {
 
 	// Help text
 	b.WriteString("\n")
-	b.WriteString("j/k: scroll | g/G: top/bottom | f: toggle follow | esc: back")
+	b.WriteString(m.keys.HelpForView(model.ViewLogs).Footer())
 
 	return b.String()
 }
 
 func (m Model) viewExec() string {
-	if m.selectedPodIndex >= len(m.pods) {
+	if _, ok := m.selectedPod(); !ok {
 		return "K8s Pod Manager > Exec\n\n[No pod selected]\n\nPress 'esc' to go back"
 	}
 
@@ -1039,13 +5193,13 @@ func (m Model) viewExec() string {
 
 	// Help text
 	b.WriteString("\n")
-	b.WriteString("Enter: run command | Up/Down: history | Tab: switch focus | esc: back")
+	b.WriteString(m.keys.HelpForView(model.ViewExec).Footer())
 
 	return b.String()
 }
 
 func (m Model) viewFiles() string {
-	if m.selectedPodIndex >= len(m.pods) {
+	if _, ok := m.selectedPod(); !ok {
 		return "K8s Pod Manager > Files\n\n[No pod selected]\n\nPress 'esc' to go back"
 	}
 
@@ -1074,6 +5228,18 @@ func (m Model) viewNamespaceSelector() string {
 		return b.String()
 	}
 
+	if m.namespacesRetry > 0 {
+		b.WriteString(fmt.Sprintf("Error: %v\n\nReconnecting (attempt %d)...\n", m.namespacesErr, m.namespacesRetry))
+		b.WriteString("\nPress 'esc' to cancel")
+		return b.String()
+	}
+
+	if len(m.namespaces) == 0 && m.namespacesErr != nil {
+		b.WriteString(m.styles.Error.Render(k8sErrorMessage(m.namespacesErr, "list namespaces")) + "\n")
+		b.WriteString("\nPress 'esc' to cancel")
+		return b.String()
+	}
+
 	if len(m.namespaces) == 0 {
 		b.WriteString("No namespaces found.\n")
 		b.WriteString("\nPress 'esc' to cancel")
@@ -1089,7 +5255,62 @@ func (m Model) viewNamespaceSelector() string {
 		if ns.IsCurrent {
 			current = " (current)"
 		}
-		b.WriteString(fmt.Sprintf("%s%s%s\n", prefix, ns.Name, current))
+		b.WriteString(fmt.Sprintf("%s%s %s%s\n", prefix, fit(ns.Name+current, namespaceNameWidth),
+			k8s.FormatAge(ns.Age), namespaceSummary(ns)))
+	}
+
+	b.WriteString("\nPress 'enter' to select, 'esc' to cancel")
+
+	return b.String()
+}
+
+// namespaceSummary describes a namespace's pods and quota usage, e.g.
+// " (42 pods; pods 42/50, requests.cpu 3/4)", or nothing until loaded
+func namespaceSummary(ns k8s.NamespaceInfo) string {
+	if !ns.Summarized {
+		return ""
+	}
+
+	pods := fmt.Sprintf("%d pods", ns.PodCount)
+	if ns.PodCount == 1 {
+		pods = "1 pod"
+	}
+	if len(ns.Quotas) == 0 {
+		return fmt.Sprintf(" (%s)", pods)
+	}
+
+	usage := make([]string, 0, len(ns.Quotas))
+	for _, q := range ns.Quotas {
+		usage = append(usage, fmt.Sprintf("%s %s/%s", q.Resource, q.Used, q.Hard))
+	}
+	return fmt.Sprintf(" (%s; %s)", pods, strings.Join(usage, ", "))
+}
+
+// viewContainerSelector lists the selected pod's containers to open exec
+// or the file browser in
+func (m Model) viewContainerSelector() string {
+	var b strings.Builder
+
+	pod, _ := m.selectedPod()
+	action := "exec"
+	switch m.containerTarget {
+	case model.ViewFiles:
+		action = "files"
+	case model.ViewEnv:
+		action = "env"
+	}
+	b.WriteString(fmt.Sprintf("Select container for %s in %s/%s\n\n", action, pod.Namespace, pod.Name))
+
+	for i, c := range pod.Containers {
+		prefix := "  "
+		if i == m.selectedContainerIndex {
+			prefix = "> "
+		}
+		state := c.State
+		if c.StateReason != "" {
+			state += ": " + c.StateReason
+		}
+		b.WriteString(fmt.Sprintf("%s%s (%s)\n", prefix, c.Name, state))
 	}
 
 	b.WriteString("\nPress 'enter' to select, 'esc' to cancel")
@@ -1102,9 +5323,19 @@ func (m Model) viewContextSelector() string {
 
 	b.WriteString("Select Context\n\n")
 
+	if errors.Is(m.k8sErr, k8s.ErrNoCurrentContext) {
+		b.WriteString("No current context set — please select one\n\n")
+	}
+
+	if m.k8sClient != nil && m.k8sClient.InCluster() {
+		b.WriteString("Running with in-cluster configuration; context switching is not available.\n")
+		b.WriteString("\nPress 'esc' to go back")
+		return b.String()
+	}
+
 	if len(m.contexts) == 0 {
 		b.WriteString("No contexts found.\n")
-		b.WriteString("\nPress 'esc' to cancel")
+		b.WriteString("\nPress 'R' to reload the kubeconfig, 'esc' to cancel")
 		return b.String()
 	}
 
@@ -1121,13 +5352,57 @@ func (m Model) viewContextSelector() string {
 		b.WriteString(fmt.Sprintf("    Cluster: %s, Namespace: %s\n", ctx.Cluster, ctx.Namespace))
 	}
 
-	b.WriteString("\nPress 'enter' to select, 'esc' to cancel")
+	b.WriteString("\nPress 'enter' to select, 'R' to reload the kubeconfig, 'esc' to cancel")
+
+	return b.String()
+}
+
+// viewAbout shows which kubeconfig, context and API server the client is
+// connected to, to rule out acting on the wrong cluster
+func (m Model) viewAbout() string {
+	var b strings.Builder
+	b.WriteString(m.styles.Header.Render("Connection Info") + "\n\n")
+
+	client := m.k8sClient
+	if client == nil {
+		b.WriteString("Not connected to a cluster.\n")
+		b.WriteString("\nPress any key to close")
+		return b.String()
+	}
+
+	row := func(label, value string) {
+		b.WriteString(fmt.Sprintf("  %-12s %s\n", label+":", value))
+	}
+
+	row("Context", client.CurrentContext())
+	if client.InCluster() {
+		row("Kubeconfig", "none (in-cluster service account)")
+	} else {
+		if info, err := client.GetContextInfo(client.CurrentContext()); err == nil {
+			cluster := info.Cluster
+			if info.Server != "" {
+				cluster += " (" + info.Server + ")"
+			}
+			row("Cluster", cluster)
+		}
+		row("Kubeconfig", strings.Join(filepath.SplitList(client.KubeconfigPath()), ", "))
+	}
+	row("API server", client.ServerURL())
+	row("Namespace", client.CurrentNamespace())
+	if client.ReadOnly() {
+		row("Mode", "read-only")
+	}
 
+	b.WriteString("\nPress any key to close")
 	return b.String()
 }
 
+// viewHelp lists every key of the view help was opened from
 func (m Model) viewHelp() string {
-	return "Help\n\n" + m.help.View(m.keys) + "\n\nPress any key to close"
+	keys := m.keys.HelpForView(m.helpReturnView)
+	full := m.help
+	full.ShowAll = true
+	return "Help: " + keys.Title + "\n\n" + full.View(keys) + "\n\nPress any key to close"
 }
 
 // CurrentView returns the current view state (used for testing).
@@ -1160,7 +5435,17 @@ func (m Model) Pods() []k8s.PodInfo {
 	return m.pods
 }
 
-// SelectedPodIndex returns the index of the currently selected pod.
+// VisiblePods returns the pods shown in the list after filtering.
+func (m Model) VisiblePods() []k8s.PodInfo {
+	return m.visiblePods()
+}
+
+// HideCompleted reports whether completed pods are hidden from the list.
+func (m Model) HideCompleted() bool {
+	return m.hideCompleted
+}
+
+// SelectedPodIndex returns the index of the currently selected pod in the visible list.
 func (m Model) SelectedPodIndex() int {
 	return m.selectedPodIndex
 }
@@ -1172,22 +5457,443 @@ func (m Model) K8sError() error {
 
 // Helper functions
 
-func formatAge(d time.Duration) string {
-	if d < time.Minute {
-		return fmt.Sprintf("%ds", int(d.Seconds()))
+// expandHome replaces a leading ~ in a path with the user's home directory
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
 	}
-	if d < time.Hour {
-		return fmt.Sprintf("%dm", int(d.Minutes()))
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
+
+// openStdinFile opens a local file to pipe to an exec'd command
+func openStdinFile(path string) (*os.File, error) {
+	path, err := expandHome(path)
+	if err != nil {
+		return nil, err
 	}
-	if d < 24*time.Hour {
-		return fmt.Sprintf("%dh", int(d.Hours()))
+	f, err := os.Open(path) //nolint:gosec // The user chose the file to send
+	if err != nil {
+		return nil, fmt.Errorf("failed to open stdin file: %w", err)
 	}
-	return fmt.Sprintf("%dd", int(d.Hours()/24))
+	return f, nil
 }
 
-func truncate(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
+// isCompletedPod reports whether a pod has terminated (Job pods that
+// succeeded, evicted or failed pods)
+func isCompletedPod(status k8s.PodStatus) bool {
+	return status == k8s.PodStatusSucceeded || status == k8s.PodStatusFailed
+}
+
+// visiblePods returns the pods shown in the list after applying filters
+// and grouping
+func (m Model) visiblePods() []k8s.PodInfo {
+	if !m.hideCompleted && !m.groupByOwner && m.nodeFilter == "" && m.serviceFilter.Name == "" {
+		return m.pods
+	}
+
+	result := make([]k8s.PodInfo, 0, len(m.pods))
+	for _, pod := range m.pods {
+		if !m.matchesPodFilters(pod) {
+			continue
+		}
+		if !m.hideCompleted || !isCompletedPod(pod.Status) {
+			result = append(result, pod)
+		}
+	}
+
+	if m.groupByOwner {
+		// Stable, so pods stay sorted by name within each group
+		slices.SortStableFunc(result, func(a, b k8s.PodInfo) int {
+			return compareOwners(a.Owner, b.Owner)
+		})
+	}
+	return result
+}
+
+// compareOwners orders owner groups by kind and name, standalone pods last
+func compareOwners(a, b k8s.OwnerRef) int {
+	switch {
+	case a == b:
+		return 0
+	case a.Name == "":
+		return 1
+	case b.Name == "":
+		return -1
+	}
+	return strings.Compare(a.String(), b.String())
+}
+
+// ownerGroupLabel names an owner group in the grouped pod list
+func ownerGroupLabel(owner k8s.OwnerRef) string {
+	if owner.Name == "" {
+		return "Standalone pods"
+	}
+	return owner.String()
+}
+
+// workloadName identifies the workload a pod belongs to across namespaces:
+// its owner's name, or the pod name for standalone pods
+func workloadName(pod k8s.PodInfo) string {
+	if pod.Owner.Name != "" {
+		return pod.Owner.Name
+	}
+	return pod.Name
+}
+
+// selectPodByWorkload moves the cursor to the first visible pod of the
+// named workload, matching on owner or, when owners are unknown, on the
+// pod name prefix since generated suffixes differ between namespaces
+func (m *Model) selectPodByWorkload(workload string) {
+	idx := slices.IndexFunc(m.visiblePods(), func(p k8s.PodInfo) bool {
+		if p.Owner.Name != "" {
+			return p.Owner.Name == workload
+		}
+		return p.Name == workload || strings.HasPrefix(p.Name, workload+"-")
+	})
+	if idx >= 0 {
+		m.selectedPodIndex = idx
+	}
+}
+
+// selectPodByName moves the cursor to the named pod if it is visible
+func (m *Model) selectPodByName(name string) {
+	idx := slices.IndexFunc(m.visiblePods(), func(p k8s.PodInfo) bool {
+		return p.Name == name
+	})
+	if idx >= 0 {
+		m.selectedPodIndex = idx
+	}
+}
+
+// hiddenPodCount returns the number of completed pods hidden from the
+// list, among those on the filtered node
+func (m Model) hiddenPodCount() int {
+	if !m.hideCompleted {
+		return 0
+	}
+	count := 0
+	for _, pod := range m.pods {
+		if m.matchesPodFilters(pod) && isCompletedPod(pod.Status) {
+			count++
+		}
+	}
+	return count
+}
+
+// matchesPodFilters reports whether a pod passes the node and service
+// filters; hiding completed pods is handled separately
+func (m Model) matchesPodFilters(pod k8s.PodInfo) bool {
+	if m.nodeFilter != "" && pod.Node != m.nodeFilter {
+		return false
+	}
+	if m.serviceFilter.Name != "" &&
+		(pod.Namespace != m.serviceFilter.Namespace || !m.serviceFilter.Selects(pod.Labels)) {
+		return false
+	}
+	return true
+}
+
+// podNodes returns the distinct nodes the loaded pods are scheduled on
+func (m Model) podNodes() []string {
+	var nodes []string
+	for _, pod := range m.pods {
+		if pod.Node != "" && !slices.Contains(nodes, pod.Node) {
+			nodes = append(nodes, pod.Node)
+		}
+	}
+	slices.Sort(nodes)
+	return nodes
+}
+
+// nextNodeFilter cycles from all nodes through each node and back
+func nextNodeFilter(nodes []string, current string) string {
+	if current == "" {
+		if len(nodes) == 0 {
+			return ""
+		}
+		return nodes[0]
+	}
+	idx := slices.Index(nodes, current)
+	if idx < 0 || idx == len(nodes)-1 {
+		return ""
+	}
+	return nodes[idx+1]
+}
+
+// selectedPod returns the pod under the cursor in the visible list
+func (m Model) selectedPod() (k8s.PodInfo, bool) {
+	pods := m.visiblePods()
+	if m.selectedPodIndex < 0 || m.selectedPodIndex >= len(pods) {
+		return k8s.PodInfo{}, false
+	}
+	return pods[m.selectedPodIndex], true
+}
+
+// selectedDeployment returns the deployment under the cursor
+func (m Model) selectedDeployment() (k8s.DeploymentInfo, bool) {
+	if m.selectedDeploymentIndex < 0 || m.selectedDeploymentIndex >= len(m.deployments) {
+		return k8s.DeploymentInfo{}, false
+	}
+	return m.deployments[m.selectedDeploymentIndex], true
+}
+
+// clampPodSelection keeps the selection within the visible list
+func (m *Model) clampPodSelection() {
+	if n := len(m.visiblePods()); m.selectedPodIndex >= n {
+		m.selectedPodIndex = max(n-1, 0)
+	}
+}
+
+// podDetails renders a one-line summary of the selected pod's resources
+func podDetails(pod *k8s.PodInfo) string {
+	details := fmt.Sprintf("CPU req/lim: %s/%s | Mem req/lim: %s/%s",
+		k8s.FormatQuantity(pod.CPURequest),
+		k8s.FormatQuantity(pod.CPULimit),
+		k8s.FormatQuantity(pod.MemRequest),
+		k8s.FormatQuantity(pod.MemLimit))
+	if pod.QoSClass != "" {
+		details += " | QoS: " + pod.QoSClass
+	}
+	return details
+}
+
+// imageCell shows the pod's first image, counting the other containers
+// so a sidecar's image isn't mistaken for missing
+func imageCell(pod *k8s.PodInfo) string {
+	if others := len(pod.Containers) - 1; others > 0 {
+		return fmt.Sprintf("%s +%d", pod.Image, others)
 	}
-	return s[:maxLen-3] + "..."
+	return pod.Image
+}
+
+// containerRows describes each container of an expanded pod on an
+// indented row of its own, init containers first
+func containerRows(pod *k8s.PodInfo) []string {
+	rows := make([]string, 0, len(pod.InitContainers)+len(pod.Containers))
+	add := func(c k8s.ContainerStatus, init bool) {
+		name := c.Name
+		if init {
+			name += " (init)"
+		}
+		ready := "not ready"
+		if c.Ready {
+			ready = "ready"
+		}
+		state := c.State
+		if c.StateReason != "" {
+			state += ": " + c.StateReason
+		}
+
+		row := fmt.Sprintf("    └ %s %s %s restarts %-4d image %s",
+			fit(name, containerNameWidth), fit(ready, 9), fit(state, podStatusWidth+12),
+			c.RestartCount, c.Image)
+		if digest := c.Digest(); digest != "" {
+			row += " (" + digest + ")"
+		} else if c.State == "Waiting" {
+			row += " (not pulled)"
+		}
+		if last := c.LastTermination(); last != "" {
+			row += " | last " + last
+		}
+		rows = append(rows, row)
+	}
+
+	for _, c := range pod.InitContainers {
+		add(c, true)
+	}
+	for _, c := range pod.Containers {
+		add(c, false)
+	}
+	return rows
+}
+
+// fit truncates or pads s to exactly width terminal cells
+func fit(s string, width int) string {
+	return ui.PadRight(ui.Truncate(s, width), width)
+}
+
+// Pod list column widths; NAME takes the space the others leave
+const (
+	podNamespaceWidth = 20
+	podStatusWidth    = 16 // Fits CrashLoopBackOff and ImagePullBackOff
+	podReadyWidth     = 7
+	podRestartsWidth  = 8
+	podAgeWidth       = 7
+	podOwnerWidth     = 30
+	podImageWidth     = 40
+	podNodeWidth      = 24
+	podIPWidth        = 15 // Fits any IPv4 address
+	minPodNameWidth   = 20
+	maxPodNameWidth   = 63 // Longest valid pod name
+
+	containerNameWidth = 24 // Container name column of an expanded pod
+	namespaceNameWidth = 40 // Name column of the namespace selector
+)
+
+// podListLayout holds the pod list columns that fit the terminal
+type podListLayout struct {
+	namespace                   bool
+	name                        int
+	ready, restarts, age, owner bool
+	image, node, ip             bool
+}
+
+// newPodListLayout sizes the NAME column to the terminal width. While it
+// would be narrower than minPodNameWidth, columns are dropped: IP, node and
+// image first, then age, restarts, ready, and the owner column. The NAMESPACE
+// column, shown when listing all namespaces, is kept.
+func newPodListLayout(width int, showNamespace, showOwner, showImage, showNodeIP bool) podListLayout {
+	l := podListLayout{namespace: showNamespace, ready: true, restarts: true, age: true, owner: showOwner,
+		image: showImage, node: showNodeIP, ip: showNodeIP}
+	for width-l.fixedWidth() < minPodNameWidth {
+		switch {
+		case l.ip:
+			l.ip = false
+		case l.node:
+			l.node = false
+		case l.image:
+			l.image = false
+		case l.age:
+			l.age = false
+		case l.restarts:
+			l.restarts = false
+		case l.ready:
+			l.ready = false
+		case l.owner:
+			l.owner = false
+		default:
+			l.name = minPodNameWidth
+			return l
+		}
+	}
+	l.name = min(width-l.fixedWidth(), maxPodNameWidth)
+	return l
+}
+
+// fixedWidth is the width of the selection prefix and every column but NAME
+func (l podListLayout) fixedWidth() int {
+	width := 2 + 1 + podStatusWidth
+	if l.namespace {
+		width += podNamespaceWidth + 1
+	}
+	if l.ready {
+		width += 1 + podReadyWidth
+	}
+	if l.restarts {
+		width += 1 + podRestartsWidth
+	}
+	if l.age {
+		width += 1 + podAgeWidth
+	}
+	if l.owner {
+		width += 1 + podOwnerWidth
+	}
+	if l.image {
+		width += 1 + podImageWidth
+	}
+	if l.node {
+		width += 1 + podNodeWidth
+	}
+	if l.ip {
+		width += 1 + podIPWidth
+	}
+	return width
+}
+
+// width is the rendered width of a row
+func (l podListLayout) width() int {
+	return l.fixedWidth() + l.name
+}
+
+// row lays out one line of the pod list. status is passed already padded
+// so that it can be styled.
+func (l podListLayout) row(prefix, namespace, name, status, ready, restarts, age, owner, image, node, ip string) string {
+	var b strings.Builder
+	b.WriteString(prefix)
+	if l.namespace {
+		b.WriteString(fit(namespace, podNamespaceWidth) + " ")
+	}
+	b.WriteString(fit(name, l.name))
+	b.WriteString(" " + status)
+	if l.ready {
+		b.WriteString(" " + fit(ready, podReadyWidth))
+	}
+	if l.restarts {
+		b.WriteString(" " + fit(restarts, podRestartsWidth))
+	}
+	if l.age {
+		b.WriteString(" " + fit(age, podAgeWidth))
+	}
+	if l.owner {
+		b.WriteString(" " + fit(owner, podOwnerWidth))
+	}
+	if l.image {
+		b.WriteString(" " + fit(image, podImageWidth))
+	}
+	if l.node {
+		b.WriteString(" " + fit(node, podNodeWidth))
+	}
+	if l.ip {
+		b.WriteString(" " + fit(ip, podIPWidth))
+	}
+	return b.String()
+}
+
+// Top view column widths; NAME takes the space the others leave
+const (
+	topUsageWidth   = 8
+	topPercentWidth = 5
+)
+
+// topLayout holds the top view columns that fit the terminal
+type topLayout struct {
+	name     int
+	percents bool
+}
+
+// newTopLayout sizes the NAME column to the terminal width, dropping the
+// percentage columns while it would be narrower than minPodNameWidth
+func newTopLayout(width int) topLayout {
+	l := topLayout{percents: true}
+	if width-l.fixedWidth() < minPodNameWidth {
+		l.percents = false
+	}
+	l.name = min(max(width-l.fixedWidth(), minPodNameWidth), maxPodNameWidth)
+	return l
+}
+
+// fixedWidth is the width of the selection prefix and every column but NAME
+func (l topLayout) fixedWidth() int {
+	width := 2 + 2*(1+topUsageWidth)
+	if l.percents {
+		width += 4 * (1 + topPercentWidth)
+	}
+	return width
+}
+
+// width is the rendered width of a row
+func (l topLayout) width() int {
+	return l.fixedWidth() + l.name
+}
+
+// row lays out one line of the top view
+func (l topLayout) row(prefix, name, cpu, cpuRequest, cpuLimit, memory, memRequest, memLimit string) string {
+	var b strings.Builder
+	b.WriteString(prefix)
+	b.WriteString(fit(name, l.name))
+	b.WriteString(" " + fit(cpu, topUsageWidth))
+	if l.percents {
+		b.WriteString(" " + fit(cpuRequest, topPercentWidth))
+		b.WriteString(" " + fit(cpuLimit, topPercentWidth))
+	}
+	b.WriteString(" " + fit(memory, topUsageWidth))
+	if l.percents {
+		b.WriteString(" " + fit(memRequest, topPercentWidth))
+		b.WriteString(" " + fit(memLimit, topPercentWidth))
+	}
+	return b.String()
 }
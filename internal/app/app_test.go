@@ -1,12 +1,35 @@
 package app
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
 	"testing"
+	"time"
+	"unicode/utf8"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+	"github.com/muesli/termenv"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
+	"github.com/maxime/k8s-tui/internal/config"
 	"github.com/maxime/k8s-tui/internal/k8s"
 	"github.com/maxime/k8s-tui/internal/model"
+	"github.com/maxime/k8s-tui/internal/ui"
 )
 
 func TestNew(t *testing.T) {
@@ -84,6 +107,41 @@ func TestUpdate_QuitCtrlC(t *testing.T) {
 	}
 }
 
+func TestUpdate_QuitStopsStreams(t *testing.T) {
+	m := makeReadyWithPods(New())
+
+	var contexts []context.Context
+	track := func() context.CancelFunc {
+		ctx, cancel := context.WithCancel(context.Background())
+		contexts = append(contexts, ctx)
+		return cancel
+	}
+	m.logCancel = track()
+	m.logStreamActive = true
+	m.execCancel = track()
+	m.execRunning = true
+	m.podWatchCancel = track()
+	m.filesCancel = track()
+	logID := m.logStreamID
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+	m = newModel.(Model)
+	if cmd == nil {
+		t.Fatal("expected a quit command")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("expected tea.Quit")
+	}
+	for i, ctx := range contexts {
+		if ctx.Err() == nil {
+			t.Errorf("context %d should be cancelled on quit", i)
+		}
+	}
+	if m.logStreamActive || m.execRunning || m.logStreamID == logID {
+		t.Error("quitting should stop the log stream and exec")
+	}
+}
+
 func TestUpdate_HelpToggle(t *testing.T) {
 	m := New()
 	// First, make the model ready
@@ -343,6 +401,117 @@ func TestUpdate_ExecViewNavigation(t *testing.T) {
 	}
 }
 
+func TestUpdate_DefaultContainerAnnotation(t *testing.T) {
+	m := makeReady(New())
+	m.k8sClient = &k8s.Client{}
+	m.pods = []k8s.PodInfo{{
+		Name:             "annotated",
+		Namespace:        "default",
+		Status:           k8s.PodStatusRunning,
+		Containers:       []k8s.ContainerStatus{{Name: "istio-proxy"}, {Name: "app"}},
+		DefaultContainer: "app",
+	}}
+
+	opts, err := m.fileOptions("/")
+	if err != nil || opts.Container != "app" {
+		t.Errorf("files should use the annotated container, got %q (%v)", opts.Container, err)
+	}
+
+	// The container selector starts on the annotated container
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	m = newModel.(Model)
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+	if m.execView.Container() != "app" {
+		t.Errorf("exec should use the annotated container, got %q", m.execView.Container())
+	}
+}
+
+func TestUpdate_ContainerSelector(t *testing.T) {
+	m := makeReady(New())
+	m.k8sClient = &k8s.Client{}
+	m.pods = []k8s.PodInfo{{
+		Name:       "web",
+		Namespace:  "default",
+		Status:     k8s.PodStatusRunning,
+		Containers: []k8s.ContainerStatus{{Name: "app", State: "Running"}, {Name: "sidecar", State: "Running"}},
+	}}
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewContainerSelector {
+		t.Fatalf("a pod with several containers should ask for one, got %v", m.CurrentView())
+	}
+	view := m.View()
+	if !containsString(view, "Select container for files in default/web") || !containsString(view, "> app (Running)") {
+		t.Errorf("expected the containers listed, got:\n%s", view)
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m = newModel.(Model)
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewFiles || cmd == nil {
+		t.Fatalf("enter should open the file browser, got %v", m.CurrentView())
+	}
+	if opts, _ := m.fileOptions("/"); opts.Container != "sidecar" {
+		t.Errorf("files should use the chosen container, got %q", opts.Container)
+	}
+
+	// Coming back to the pod starts on the last choice, for exec too
+	m.view = model.ViewPodList
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	m = newModel.(Model)
+	if m.selectedContainerIndex != 1 {
+		t.Errorf("expected sidecar preselected, got index %d", m.selectedContainerIndex)
+	}
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewExec || m.execView.Container() != "sidecar" {
+		t.Errorf("expected exec in sidecar, got %v in %q", m.CurrentView(), m.execView.Container())
+	}
+
+	// Esc cancels back to the pod list
+	m.view = model.ViewPodList
+	m = typeKeys(m, "e")
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewPodList {
+		t.Errorf("esc should cancel the selector, got %v", m.CurrentView())
+	}
+}
+
+func TestUpdate_EnvAsksForContainer(t *testing.T) {
+	m := makeReady(New())
+	m.pods = []k8s.PodInfo{{
+		Name:       "web",
+		Namespace:  "default",
+		Status:     k8s.PodStatusRunning,
+		Containers: []k8s.ContainerStatus{{Name: "app", State: "Running"}, {Name: "sidecar", State: "Running"}},
+	}}
+
+	m = typeKeys(m, "E")
+	if m.CurrentView() != model.ViewContainerSelector || !containsString(m.View(), "Select container for env in default/web") {
+		t.Fatalf("a pod with several containers should ask for one, got:\n%s", m.View())
+	}
+
+	m = typeKeys(m, "j")
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewEnv || cmd == nil {
+		t.Fatalf("enter should open the env view, got %v", m.CurrentView())
+	}
+	if m.envTarget.container != "sidecar" {
+		t.Errorf("env should use the chosen container, got %q", m.envTarget.container)
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewPodList {
+		t.Errorf("esc should go back to the pod list, got %v", m.CurrentView())
+	}
+}
+
 func TestUpdate_ExecViewRequiresPods(t *testing.T) {
 	m := New()
 	m = makeReady(m)
@@ -382,6 +551,45 @@ func TestUpdate_ExecViewBackNavigation(t *testing.T) {
 	}
 }
 
+func TestUpdate_QuitKeyTypesInFocusedInput(t *testing.T) {
+	m := New()
+	m = makeReadyWithPods(m)
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	m = newModel.(Model)
+
+	for _, r := range "q?" {
+		newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = newModel.(Model)
+	}
+	if m.CurrentView() != model.ViewExec {
+		t.Fatalf("q and ? should not leave the exec view, got %v", m.CurrentView())
+	}
+	if got := m.execView.GetCommand(); got != "q?" {
+		t.Errorf("q and ? should be typed into the input, got %q", got)
+	}
+
+	// Ctrl+C still quits from a focused input
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	if cmd == nil {
+		t.Fatal("ctrl+c should quit from a focused input")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("ctrl+c should return a quit command")
+	}
+
+	// With the input unfocused (tab to the output), q quits again
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = newModel.(Model)
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+	if cmd == nil {
+		t.Fatal("q should quit when no input is focused")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("q should return a quit command when no input is focused")
+	}
+}
+
 func TestUpdate_ExecViewInitialization(t *testing.T) {
 	m := New()
 	m = makeReadyWithPods(m)
@@ -500,3 +708,4711 @@ func TestUpdate_FilesViewInitialization(t *testing.T) {
 		t.Error("View should contain 'Files'")
 	}
 }
+
+func TestUpdate_FilesFilterCapturesKeys(t *testing.T) {
+	m := New()
+	m = makeReadyWithPods(m)
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	m = newModel.(Model)
+	m.filesView.SetCurrentPath("/var/log")
+	m.filesView.SetEntries([]k8s.FileInfo{{Name: "queue.log"}, {Name: "app.log"}})
+
+	for _, key := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune{'/'}},
+		{Type: tea.KeyRunes, Runes: []rune{'q'}},
+		{Type: tea.KeyRunes, Runes: []rune{'x'}},
+		{Type: tea.KeyBackspace},
+	} {
+		newModel, _ = m.Update(key)
+		m = newModel.(Model)
+	}
+
+	// q was typed rather than quitting, and backspace edited the filter
+	// instead of going to the parent directory
+	if m.filesView.Filter() != "q" || m.filesView.CurrentPath() != "/var/log" {
+		t.Fatalf("expected filter %q in /var/log, got %q in %s", "q", m.filesView.Filter(), m.filesView.CurrentPath())
+	}
+	if entries := m.filesView.VisibleEntries(); len(entries) != 1 || entries[0].Name != "queue.log" {
+		t.Errorf("expected only queue.log, got %+v", entries)
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewFiles || m.filesView.Filter() != "" {
+		t.Errorf("esc should clear the filter and stay in the directory, got view %v filter %q",
+			m.CurrentView(), m.filesView.Filter())
+	}
+}
+
+func TestEditorCommand(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "code -w")
+	if got := editorCommand("/tmp/f").Args; !slices.Equal(got, []string{"code", "-w", "/tmp/f"}) {
+		t.Errorf("editorCommand() args = %q, want EDITOR split into words", got)
+	}
+
+	t.Setenv("VISUAL", "nano")
+	if got := editorCommand("/tmp/f").Args; !slices.Equal(got, []string{"nano", "/tmp/f"}) {
+		t.Errorf("editorCommand() args = %q, want VISUAL to win", got)
+	}
+
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "")
+	if got := editorCommand("/tmp/f").Args; !slices.Equal(got, []string{"vi", "/tmp/f"}) {
+		t.Errorf("editorCommand() args = %q, want vi as the fallback", got)
+	}
+}
+
+func TestPagerCommand(t *testing.T) {
+	t.Setenv("PAGER", "cat -n")
+	cmd, err := pagerCommand("/tmp/f")
+	if err != nil || !slices.Equal(cmd.Args, []string{"cat", "-n", "/tmp/f"}) {
+		t.Errorf("pagerCommand() = %v, %v; want PAGER split into words", cmd, err)
+	}
+
+	// Without less on PATH, the editor is used
+	t.Setenv("PATH", t.TempDir())
+	t.Setenv("PAGER", "")
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "nano")
+	cmd, err = pagerCommand("/tmp/f")
+	if err != nil || !slices.Equal(cmd.Args, []string{"nano", "/tmp/f"}) {
+		t.Errorf("pagerCommand() = %v, %v; want the editor as the fallback", cmd, err)
+	}
+
+	t.Setenv("EDITOR", "")
+	if _, err := pagerCommand("/tmp/f"); err == nil {
+		t.Error("expected an error without a pager or editor")
+	}
+}
+
+// viewingFile opens the file browser on a previewed file
+func viewingFile(t *testing.T) Model {
+	t.Helper()
+	m := makeReadyWithPods(New())
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	m = newModel.(Model)
+	m.filesView.SetCurrentPath("/etc/app")
+	m.filesView.SetEntries([]k8s.FileInfo{{Name: "app.conf", Size: 12}})
+	m.filesView.SetFileContent("app.conf", "level: info\n")
+	return m
+}
+
+func TestUpdate_FilePaging(t *testing.T) {
+	m := viewingFile(t)
+	content := strings.Repeat("level: info\n", 100)
+	newModel, _ := m.Update(fileContentMsg{chunk: k8s.FileChunk{Content: content, Size: 1 << 20}, filename: "app.conf"})
+	m = newModel.(Model)
+	if !containsString(m.View(), fmt.Sprintf("bytes 1–%d of %d", len(content), 1<<20)) {
+		t.Fatalf("expected the loaded range shown, got:\n%s", m.View())
+	}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'G'}})
+	m = newModel.(Model)
+	if cmd == nil {
+		t.Fatal("reaching the bottom should read the next chunk")
+	}
+
+	// No client in tests, so the read fails and scrolling may retry
+	msg := cmd()
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		for _, c := range batch {
+			if c != nil {
+				if loaded, ok := c().(fileContentMsg); ok {
+					msg = loaded
+				}
+			}
+		}
+	}
+	loaded, ok := msg.(fileContentMsg)
+	if !ok || loaded.offset != int64(len(content)) || loaded.err == nil {
+		t.Fatalf("expected a failed read at %d, got %+v", len(content), msg)
+	}
+	newModel, _ = m.Update(loaded)
+	m = newModel.(Model)
+	if !m.filesView.IsViewingFile() || !containsString(m.statusMessage, "Could not read more of app.conf") {
+		t.Errorf("expected the file kept with a status, got %q", m.statusMessage)
+	}
+}
+
+func TestUpdate_FileBookmarks(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := makeReadyWithPods(New())
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	m = newModel.(Model)
+	m.filesView.SetCurrentPath("/var/log")
+	m.filesView.SetEntries([]k8s.FileInfo{{Name: "app.log"}})
+
+	// Nothing to pick yet
+	m = typeKeys(m, "B")
+	if m.filesView.ShowingBookmarks() || !containsString(m.statusMessage, "No bookmarks yet") {
+		t.Errorf("expected a hint instead of an empty picker, got %q", m.statusMessage)
+	}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+	m = newModel.(Model)
+	if cmd == nil || !slices.Equal(m.filesView.Bookmarks(), []string{"/var/log"}) {
+		t.Fatalf("expected /var/log bookmarked and saved, got %v", m.filesView.Bookmarks())
+	}
+	if !containsString(m.View(), "Path: /var/log [bookmarked]") {
+		t.Errorf("expected the path marked as bookmarked, got:\n%s", m.View())
+	}
+
+	// The bookmarks are saved for the next session
+	newModel, _ = m.Update(m.saveBookmarks()())
+	m = newModel.(Model)
+	if reloaded := New(); !slices.Equal(reloaded.filesView.Bookmarks(), []string{"/var/log"}) {
+		t.Errorf("expected the bookmark loaded on startup, got %v", reloaded.filesView.Bookmarks())
+	}
+
+	// Jump to a bookmark from elsewhere; backspace history keeps the old directory
+	m.filesView.JumpTo("/etc/app")
+	m = typeKeys(m, "b")
+	m.filesView.JumpTo("/")
+	m = typeKeys(m, "B")
+	if !m.filesView.ShowingBookmarks() || !containsString(m.View(), "/etc/app") {
+		t.Fatalf("expected the bookmark picker, got:\n%s", m.View())
+	}
+	m = typeKeys(m, "j")
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+	if cmd == nil || m.filesView.ShowingBookmarks() || m.filesView.CurrentPath() != "/etc/app" {
+		t.Errorf("expected to jump to /etc/app, got %s", m.filesView.CurrentPath())
+	}
+	if m.filesView.State() != ui.FileBrowserStateLoading {
+		t.Error("jumping should list the bookmarked directory")
+	}
+
+	// Remove a bookmark from the picker; esc closes it
+	m.filesView.SetEntries(nil)
+	m = typeKeys(m, "B")
+	m = typeKeys(m, "d")
+	if !slices.Equal(m.filesView.Bookmarks(), []string{"/etc/app"}) {
+		t.Errorf("expected /var/log removed, got %v", m.filesView.Bookmarks())
+	}
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(Model)
+	if m.filesView.ShowingBookmarks() || m.CurrentView() != model.ViewFiles {
+		t.Error("esc should close the picker and stay in the file browser")
+	}
+
+	// A failed save is reported
+	newModel, _ = m.Update(bookmarksSavedMsg{err: errors.New("read-only file system")})
+	m = newModel.(Model)
+	if !containsString(m.statusMessage, "Could not save bookmarks") {
+		t.Errorf("expected the save error reported, got %q", m.statusMessage)
+	}
+}
+
+func TestSplitPartialPath(t *testing.T) {
+	tests := []struct {
+		input, dir, prefix string
+	}{
+		{"/", "/", ""},
+		{"/va", "/", "va"},
+		{"/var/", "/var/", ""},
+		{"/var/lo", "/var", "lo"},
+	}
+	for _, tt := range tests {
+		dir, prefix := splitPartialPath(tt.input)
+		if dir != tt.dir || prefix != tt.prefix {
+			t.Errorf("splitPartialPath(%q) = %q, %q; want %q, %q", tt.input, dir, prefix, tt.dir, tt.prefix)
+		}
+	}
+}
+
+func TestCompletePath(t *testing.T) {
+	entries := []k8s.FileInfo{
+		{Name: ".."},
+		{Name: "log", IsDir: true},
+		{Name: "lib", IsDir: true},
+		{Name: "local", IsDir: true},
+		{Name: "run"},
+	}
+
+	value, candidates := completePath("/var", "r", entries)
+	if value != "/var/run" || !slices.Equal(candidates, []string{"run"}) {
+		t.Errorf("expected a single file completed without a slash, got %q %v", value, candidates)
+	}
+
+	value, _ = completePath("/var", "lo", entries)
+	if value != "/var/lo" {
+		t.Errorf("expected the common prefix of log and local, got %q", value)
+	}
+
+	value, candidates = completePath("/var/", "l", entries)
+	if value != "/var/l" || !slices.Equal(candidates, []string{"lib", "local", "log"}) {
+		t.Errorf("expected sorted candidates, got %q %v", value, candidates)
+	}
+
+	value, _ = completePath("/var", "log", entries)
+	if value != "/var/log/" {
+		t.Errorf("expected a directory completed with a slash, got %q", value)
+	}
+
+	if value, _ = completePath("/var", "x", entries); value != "" {
+		t.Errorf("expected no completion, got %q", value)
+	}
+}
+
+func TestUpdate_FileGotoPath(t *testing.T) {
+	m := makeReadyWithPods(New())
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	m = newModel.(Model)
+	m.filesView.SetCurrentPath("/var")
+	m.filesView.SetEntries([]k8s.FileInfo{{Name: "log", IsDir: true}})
+
+	m = typeKeys(m, ":")
+	if m.view != model.ViewPrompt || m.prompt.Value() != "/var/" {
+		t.Fatalf("expected the prompt prefilled with the current directory, got %q", m.prompt.Value())
+	}
+
+	// Completions for input that has since changed are dropped
+	newModel, _ = m.Update(pathCompletionMsg{input: "/v", dir: "/", prefix: "v", entries: []k8s.FileInfo{{Name: "var", IsDir: true}}})
+	m = newModel.(Model)
+	if m.prompt.Value() != "/var/" {
+		t.Errorf("expected a stale completion ignored, got %q", m.prompt.Value())
+	}
+
+	m = typeKeys(m, "l")
+	newModel, _ = m.Update(pathCompletionMsg{input: "/var/l", dir: "/var/", prefix: "l", entries: []k8s.FileInfo{{Name: "log", IsDir: true}}})
+	m = newModel.(Model)
+	if m.prompt.Value() != "/var/log/" {
+		t.Errorf("expected /var/log/ completed, got %q", m.prompt.Value())
+	}
+
+	// Relative paths are rejected without leaving the browser
+	m.prompt.SetValue("etc")
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+	if m.view != model.ViewFiles || !containsString(m.statusMessage, "must be absolute") {
+		t.Errorf("expected a relative path rejected, got view %v status %q", m.view, m.statusMessage)
+	}
+
+	newModel, _ = m.Update(gotoPathCheckedMsg{path: "/etc/passwd", err: errors.New("not a directory")})
+	m = newModel.(Model)
+	if m.filesView.CurrentPath() != "/var" || !containsString(m.statusMessage, "Cannot go to /etc/passwd: not a directory") {
+		t.Errorf("expected the bad path reported, got %q", m.statusMessage)
+	}
+
+	newModel, cmd := m.Update(gotoPathCheckedMsg{path: "/var/log"})
+	m = newModel.(Model)
+	if cmd == nil || m.filesView.CurrentPath() != "/var/log" {
+		t.Errorf("expected a jump to /var/log, got %q", m.filesView.CurrentPath())
+	}
+}
+
+func TestUpdate_FileEditKey(t *testing.T) {
+	m := viewingFile(t)
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	if cmd == nil {
+		t.Fatal("e should load the file for editing")
+	}
+	msg, ok := cmd().(fileEditLoadedMsg)
+	if !ok {
+		t.Fatalf("expected fileEditLoadedMsg, got %T", cmd())
+	}
+	if msg.path != "/etc/app/app.conf" || msg.filename != "app.conf" {
+		t.Errorf("expected /etc/app/app.conf, got %q (%q)", msg.path, msg.filename)
+	}
+	// No client in tests, so the read fails and is reported
+	if msg.err == nil {
+		t.Error("expected an error without a k8s client")
+	}
+
+	newModel, _ := m.Update(msg)
+	m = newModel.(Model)
+	if !strings.Contains(m.statusMessage, "Could not open app.conf") {
+		t.Errorf("expected the failure in the status, got %q", m.statusMessage)
+	}
+}
+
+func TestUpdate_FileEditRefusesUnsafeContent(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"binary", "\x7fELF\x02\x01\x01\x00\x00", "binary file"},
+		{"too large", strings.Repeat("a", maxEditBytes+1), "too large to edit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := viewingFile(t)
+			newModel, _ := m.Update(fileEditLoadedMsg{path: "/etc/app/app.conf", filename: "app.conf", content: tt.content})
+			m = newModel.(Model)
+			if !strings.Contains(m.statusMessage, tt.want) {
+				t.Errorf("expected status to mention %q, got %q", tt.want, m.statusMessage)
+			}
+		})
+	}
+}
+
+func TestUpdate_FileEdited(t *testing.T) {
+	writeTemp := func(t *testing.T, content string) string {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "app.conf")
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	t.Run("unchanged", func(t *testing.T) {
+		m := viewingFile(t)
+		tmp := writeTemp(t, "level: info\n")
+
+		newModel, _ := m.Update(fileEditedMsg{path: "/etc/app/app.conf", filename: "app.conf", tmpPath: tmp, original: "level: info\n"})
+		m = newModel.(Model)
+		if !strings.Contains(m.statusMessage, "No changes to app.conf") {
+			t.Errorf("expected no-change status, got %q", m.statusMessage)
+		}
+		if _, err := os.Stat(tmp); !os.IsNotExist(err) {
+			t.Error("the temp file should be removed")
+		}
+	})
+
+	t.Run("changed", func(t *testing.T) {
+		m := viewingFile(t)
+		tmp := writeTemp(t, "level: debug\n")
+
+		// No client in tests, so the write back is refused up front
+		newModel, _ := m.Update(fileEditedMsg{path: "/etc/app/app.conf", filename: "app.conf", tmpPath: tmp, original: "level: info\n"})
+		m = newModel.(Model)
+		if !strings.Contains(m.statusMessage, "Could not save app.conf") {
+			t.Errorf("expected a save attempt, got %q", m.statusMessage)
+		}
+	})
+
+	t.Run("asks before writing", func(t *testing.T) {
+		m := viewingFile(t)
+		m.k8sClient = &k8s.Client{}
+		tmp := writeTemp(t, "level: debug\n")
+
+		newModel, cmd := m.Update(fileEditedMsg{path: "/etc/app/app.conf", filename: "app.conf", tmpPath: tmp, original: "level: info\n"})
+		m = newModel.(Model)
+		if cmd != nil || m.CurrentView() != model.ViewConfirm || !containsString(m.View(), "Write changes to /etc/app/app.conf") {
+			t.Fatalf("expected a confirmation before writing, got view %v", m.CurrentView())
+		}
+
+		newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		m = newModel.(Model)
+		if cmd != nil || m.CurrentView() != model.ViewFiles {
+			t.Errorf("cancelling should return to the file without writing, got view %v", m.CurrentView())
+		}
+	})
+
+	t.Run("editor failed", func(t *testing.T) {
+		m := viewingFile(t)
+		newModel, _ := m.Update(fileEditedMsg{path: "/etc/app/app.conf", filename: "app.conf", err: errors.New("exit status 1")})
+		m = newModel.(Model)
+		if !strings.Contains(m.statusMessage, "Editor failed") {
+			t.Errorf("expected editor failure in the status, got %q", m.statusMessage)
+		}
+	})
+}
+
+func TestUpdate_FileWritten(t *testing.T) {
+	m := viewingFile(t)
+
+	newModel, _ := m.Update(fileWrittenMsg{path: "/etc/app/app.conf", filename: "app.conf", err: errors.New("read-only file system: /etc/app/app.conf")})
+	m = newModel.(Model)
+	if !strings.Contains(m.statusMessage, "Could not save app.conf: read-only file system") {
+		t.Errorf("expected the write error in the status, got %q", m.statusMessage)
+	}
+
+	newModel, _ = m.Update(fileWrittenMsg{path: "/etc/app/app.conf", filename: "app.conf"})
+	m = newModel.(Model)
+	if !strings.Contains(m.statusMessage, "reloads its config") {
+		t.Errorf("expected a reload warning in the status, got %q", m.statusMessage)
+	}
+}
+
+func TestUpdate_ExecPresetDispatch(t *testing.T) {
+	m := New()
+	m = makeReadyWithPods(m)
+	m.k8sClient = &k8s.Client{}
+	m.execView.SetPresets([]config.ExecPreset{
+		{Name: "DNS", Command: "cat /etc/resolv.conf"},
+		{Name: "Env", Command: "env"},
+	})
+
+	// Enter exec view and open the presets menu
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	m = newModel.(Model)
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+	m = newModel.(Model)
+
+	if !m.execView.ShowingPresets() {
+		t.Fatal("Ctrl+P should open the presets menu")
+	}
+
+	// Select preset 2 by number
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'2'}})
+	m = newModel.(Model)
+
+	if cmd == nil {
+		t.Fatal("selecting a preset should return an exec command")
+	}
+	if !m.execRunning {
+		t.Error("selecting a preset should start the exec")
+	}
+	if m.execView.State() != ui.ExecViewStateRunning {
+		t.Errorf("exec view state = %v, want Running", m.execView.State())
+	}
+	if m.execView.ShowingPresets() {
+		t.Error("presets menu should close after running a preset")
+	}
+}
+
+func TestUpdate_ExecPresetsEscClosesMenu(t *testing.T) {
+	m := New()
+	m = makeReadyWithPods(m)
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	m = newModel.(Model)
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+	m = newModel.(Model)
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(Model)
+
+	if m.execView.ShowingPresets() {
+		t.Error("esc should close the presets menu")
+	}
+	if m.CurrentView() != model.ViewExec {
+		t.Errorf("esc should stay in Exec view when closing presets, got %v", m.CurrentView())
+	}
+}
+
+func TestUpdate_BackgroundLogLineDoesNotAlterForeground(t *testing.T) {
+	m := New()
+	m = makeReadyWithPods(m)
+
+	before := m.View()
+
+	newModel, cmd := m.Update(logLineMsg{line: k8s.LogLine{Content: "late line"}})
+	m = newModel.(Model)
+
+	if cmd != nil {
+		t.Error("background log line should not schedule further reads")
+	}
+	if m.View() != before {
+		t.Error("background log line should not change the foreground view")
+	}
+	if !m.logView.IsBackground() {
+		t.Error("log view should be buffering in the background")
+	}
+	if m.logView.LineCount() != 1 {
+		t.Errorf("background line should still be buffered, got %d lines", m.logView.LineCount())
+	}
+}
+
+func TestUpdate_YAMLView(t *testing.T) {
+	m := New()
+	m = makeReadyWithPods(m)
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = newModel.(Model)
+
+	if m.CurrentView() != model.ViewYAML {
+		t.Fatalf("Should be in YAML view, got %v", m.CurrentView())
+	}
+	if cmd == nil {
+		t.Fatal("Opening YAML view should return a load command")
+	}
+
+	newModel, _ = m.Update(podYAMLMsg{content: "apiVersion: v1\nkind: Pod\n"})
+	m = newModel.(Model)
+
+	view := m.View()
+	if !containsString(view, "YAML: default/test-pod") {
+		t.Error("View should contain the YAML header")
+	}
+	if !containsString(view, "kind: Pod") {
+		t.Error("View should contain the manifest")
+	}
+
+	// Esc closes the overlay
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(Model)
+
+	if m.CurrentView() != model.ViewPodList {
+		t.Errorf("Should be back in PodList view, got %v", m.CurrentView())
+	}
+}
+
+func TestUpdate_YAMLViewRequiresPods(t *testing.T) {
+	m := New()
+	m = makeReady(m)
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = newModel.(Model)
+
+	if m.CurrentView() != model.ViewPodList {
+		t.Errorf("Should stay in PodList view when no pods, got %v", m.CurrentView())
+	}
+}
+
+func TestUpdate_EnvView(t *testing.T) {
+	m := makeReadyWithPods(New())
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'E'}})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewEnv || cmd == nil {
+		t.Fatalf("expected the environment to load, got view %v", m.CurrentView())
+	}
+	if loaded, ok := cmd().(envLoadedMsg); !ok || loaded.err == nil {
+		t.Errorf("expected an error without a client, got %+v", loaded)
+	}
+
+	target := containerRef{namespace: "default", pod: "test-pod", container: "main"}
+	vars := []k8s.EnvVar{
+		{Name: "API_TOKEN", Value: "s3cret"},
+		{Name: "DB_URL", Source: "secret db/url", Secret: true},
+	}
+
+	// Results for another container are dropped
+	newModel, _ = m.Update(envLoadedMsg{target: containerRef{namespace: "default", pod: "other", container: "main"}, vars: vars})
+	m = newModel.(Model)
+	if !containsString(m.View(), "Loading...") {
+		t.Fatalf("expected a stale result dropped, got:\n%s", m.View())
+	}
+
+	// A container without env falls back to the declared environment
+	newModel, _ = m.Update(envLoadedMsg{target: target, declared: true, vars: vars})
+	m = newModel.(Model)
+	view := m.View()
+	for _, want := range []string{"Env: default/test-pod/main (declared)", "No env command", "API_TOKEN=********", "DB_URL <- secret db/url"} {
+		if !containsString(view, want) {
+			t.Errorf("expected %q in:\n%s", want, view)
+		}
+	}
+
+	m = typeKeys(m, "v")
+	if !containsString(m.View(), "API_TOKEN=s3cret") {
+		t.Errorf("expected the token revealed, got:\n%s", m.View())
+	}
+
+	// Switching back to the runtime environment drops declared results
+	// still in flight
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	m = newModel.(Model)
+	if cmd == nil || m.envView.Declared() {
+		t.Fatal("d should load the runtime environment")
+	}
+	newModel, _ = m.Update(envLoadedMsg{target: target, requested: true, declared: true, vars: vars})
+	m = newModel.(Model)
+	if !containsString(m.View(), "Loading...") {
+		t.Errorf("expected the declared result dropped, got:\n%s", m.View())
+	}
+
+	newModel, _ = m.Update(envLoadedMsg{target: target, err: errors.New("pods \"test-pod\" is forbidden")})
+	m = newModel.(Model)
+	if !containsString(m.View(), "is forbidden") {
+		t.Errorf("expected the error shown, got:\n%s", m.View())
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewPodList {
+		t.Errorf("esc should close the environment, got %v", m.CurrentView())
+	}
+}
+
+func TestUpdate_RefreshKeepsSelectedPod(t *testing.T) {
+	pod := func(name string) k8s.PodInfo {
+		return k8s.PodInfo{Name: name, Namespace: "default", Status: k8s.PodStatusRunning}
+	}
+	m := makeReady(New())
+	m.loadingK8s = false
+	m.k8sClient = &k8s.Client{}
+	m.pods = []k8s.PodInfo{pod("web-c"), pod("web-d")}
+	m.selectedPodIndex = 1
+
+	// Pods created before the selected one shift it down
+	newModel, _ := m.Update(podsLoadedMsg{pods: []k8s.PodInfo{pod("web-a"), pod("web-b"), pod("web-c"), pod("web-d")}})
+	m = newModel.(Model)
+	if p, _ := m.selectedPod(); p.Name != "web-d" || m.selectedPodIndex != 3 {
+		t.Errorf("expected web-d to stay selected, got %q at %d", p.Name, m.selectedPodIndex)
+	}
+
+	// So do pods added by the watch
+	newModel, _ = m.Update(podEventMsg{id: m.podWatchID, event: k8s.PodEvent{Type: k8s.PodEventAdded, Pod: pod("api-0")}})
+	m = newModel.(Model)
+	if p, _ := m.selectedPod(); p.Name != "web-d" {
+		t.Errorf("expected web-d to stay selected after a watch event, got %q", p.Name)
+	}
+
+	// A selected pod that is gone leaves the cursor on a neighbour
+	newModel, _ = m.Update(podsLoadedMsg{pods: []k8s.PodInfo{pod("api-0"), pod("web-a"), pod("web-b"), pod("web-c")}})
+	m = newModel.(Model)
+	if p, _ := m.selectedPod(); p.Name != "web-c" {
+		t.Errorf("expected the nearest pod selected, got %q", p.Name)
+	}
+}
+
+func TestPodStatusCounts(t *testing.T) {
+	pods := []k8s.PodInfo{
+		{Name: "a", Status: k8s.PodStatusRunning},
+		{Name: "b", Status: k8s.PodStatusRunning},
+		{Name: "c", Status: k8s.PodStatusPending},
+		{Name: "d", Status: k8s.PodStatusFailed},
+		{Name: "e", Status: k8s.PodStatusSucceeded},
+		{Name: "f", Status: k8s.PodStatusRunning, StatusMessage: "CrashLoopBackOff"},
+	}
+
+	counts := podStatusCounts(pods)
+	want := map[k8s.PodStatus]int{
+		k8s.PodStatusRunning:   3,
+		k8s.PodStatusPending:   1,
+		k8s.PodStatusFailed:    1,
+		k8s.PodStatusSucceeded: 1,
+	}
+	for status, n := range want {
+		if counts[status] != n {
+			t.Errorf("%s: expected %d, got %d", status, n, counts[status])
+		}
+	}
+	if counts[k8s.PodStatusTerminating] != 0 {
+		t.Errorf("expected no terminating pods, got %d", counts[k8s.PodStatusTerminating])
+	}
+	if len(podStatusCounts(nil)) != 0 {
+		t.Error("expected no counts for no pods")
+	}
+}
+
+func TestView_PodListStatusCounts(t *testing.T) {
+	m := makeReadyWithPods(New())
+	m.loadingK8s = false
+	m.pods = append(m.pods, k8s.PodInfo{Name: "pending-pod", Namespace: "default", Status: k8s.PodStatusPending})
+
+	view := m.View()
+	if !containsString(view, "Running: 1  Pending: 1  Failed: 0  Terminating: 0") {
+		t.Errorf("expected the status counts under the header, got:\n%s", view)
+	}
+
+	// Counts follow each refresh
+	newModel, _ := m.Update(podsLoadedMsg{pods: m.pods[:1]})
+	m = newModel.(Model)
+	if view := m.View(); !containsString(view, "Running: 1  Pending: 0") {
+		t.Errorf("expected the counts updated after a refresh, got:\n%s", view)
+	}
+}
+
+func TestView_PodListRefreshIndicator(t *testing.T) {
+	m := makeReadyWithPods(New())
+	m.loadingK8s = false
+	m.k8sClient = &k8s.Client{}
+
+	newModel, _ := m.Update(podsLoadedMsg{pods: m.pods})
+	m = newModel.(Model)
+	if m.lastRefresh.IsZero() {
+		t.Fatal("listing pods should record the refresh time")
+	}
+	m.lastRefresh = time.Now().Add(-3 * time.Second)
+	if view := m.View(); !containsString(view, "updated 3s ago") {
+		t.Errorf("expected the time since the refresh in the header, got:\n%s", view)
+	}
+
+	// Refreshing keeps the list visible
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	m = newModel.(Model)
+	view := m.View()
+	if !containsString(view, "refreshing...") || !containsString(view, "test-pod") || containsString(view, "Loading pods") {
+		t.Errorf("expected the list to stay during a refresh, got:\n%s", view)
+	}
+
+	// Pods of another namespace are not shown while it loads
+	m.switchNamespace("kube-system")
+	view = m.View()
+	if containsString(view, "test-pod") || !containsString(view, "Loading pods") || containsString(view, "updated") {
+		t.Errorf("expected the old namespace's pods cleared, got:\n%s", view)
+	}
+}
+
+func TestView_PodListShowsResources(t *testing.T) {
+	m := New()
+	m = makeReadyWithPods(m)
+	m.loadingK8s = false
+	m.pods[0].CPURequest = resource.MustParse("250m")
+	m.pods[0].MemLimit = resource.MustParse("256Mi")
+	m.pods[0].QoSClass = "Burstable"
+
+	view := m.View()
+
+	if !containsString(view, "CPU req/lim: 250m/-") {
+		t.Errorf("View should show CPU request and missing limit, got:\n%s", view)
+	}
+	if !containsString(view, "Mem req/lim: -/256Mi") {
+		t.Errorf("View should show memory limit, got:\n%s", view)
+	}
+	if !containsString(view, "QoS: Burstable") {
+		t.Errorf("View should show the QoS class, got:\n%s", view)
+	}
+}
+
+func TestView_PodListShowsInitProgress(t *testing.T) {
+	m := New()
+	m = makeReadyWithPods(m)
+	m.loadingK8s = false
+	m.pods[0].Status = k8s.PodStatusPending
+	m.pods[0].StatusMessage = "Init:1/2"
+	m.pods[0].Ready = "0/1"
+
+	lines := podListLines(t, m)
+	if !containsString(lines[2], "Init:1/2") || !containsString(lines[2], "0/1") {
+		t.Errorf("expected init progress in the status column, got %q", lines[2])
+	}
+}
+
+func TestView_PodListShowsStuckContainer(t *testing.T) {
+	m := New()
+	m = makeReadyWithPods(m)
+	m.loadingK8s = false
+	m.pods[0].StatusMessage = "CrashLoopBackOff"
+	m.pods[0].Ready = "1/2"
+
+	lines := podListLines(t, m)
+	if !containsString(lines[2], "CrashLoopBackOff ") {
+		t.Errorf("expected the crash loop in full in the status column, got %q", lines[2])
+	}
+}
+
+func TestView_NoColorRendersPlainText(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	m := New()
+	m = makeReadyWithPods(m)
+	m.loadingK8s = false
+
+	view := m.View()
+
+	if containsString(view, "\x1b[") {
+		t.Errorf("View should not contain ANSI escapes with NO_COLOR set, got %q", view)
+	}
+	if !containsString(view, "Running") {
+		t.Error("View should still show the pod status")
+	}
+}
+
+func makeReadyWithCompletedPods(m Model) Model {
+	m = makeReadyWithPods(m)
+	m.loadingK8s = false
+	m.pods = append(m.pods,
+		k8s.PodInfo{Name: "job-done", Namespace: "default", Status: k8s.PodStatusSucceeded},
+		k8s.PodInfo{Name: "evicted", Namespace: "default", Status: k8s.PodStatusFailed},
+	)
+	return m
+}
+
+func TestUpdate_ToggleHideCompleted(t *testing.T) {
+	m := New()
+	m.hideCompleted = false
+	m = makeReadyWithCompletedPods(m)
+
+	if len(m.VisiblePods()) != 3 {
+		t.Fatalf("expected all 3 pods visible, got %d", len(m.VisiblePods()))
+	}
+
+	// Select the last pod, then hide completed pods
+	m.selectedPodIndex = 2
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'h'}})
+	m = newModel.(Model)
+
+	if !m.HideCompleted() {
+		t.Fatal("h should enable hiding completed pods")
+	}
+	visible := m.VisiblePods()
+	if len(visible) != 1 || visible[0].Name != "test-pod" {
+		t.Fatalf("expected only the running pod visible, got %+v", visible)
+	}
+	if m.SelectedPodIndex() != 0 {
+		t.Errorf("selection should be clamped to the visible list, got %d", m.SelectedPodIndex())
+	}
+
+	// Actions apply to the visible pod
+	if pod, ok := m.selectedPod(); !ok || pod.Name != "test-pod" {
+		t.Errorf("expected test-pod selected, got %+v", pod)
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'h'}})
+	m = newModel.(Model)
+
+	if m.HideCompleted() || len(m.VisiblePods()) != 3 {
+		t.Error("second h should show completed pods again")
+	}
+}
+
+func TestView_HiddenCompletedCount(t *testing.T) {
+	m := New()
+	m.hideCompleted = true
+	m = makeReadyWithCompletedPods(m)
+
+	view := m.View()
+	if !containsString(view, "2 completed hidden") {
+		t.Errorf("header should show hidden pod count, got:\n%s", view)
+	}
+	if containsString(view, "job-done") || containsString(view, "evicted") {
+		t.Error("completed pods should not be listed")
+	}
+
+	m.hideCompleted = false
+	view = m.View()
+	if containsString(view, "completed hidden") {
+		t.Error("hidden count should not be shown when nothing is hidden")
+	}
+	if !containsString(view, "job-done") {
+		t.Error("completed pods should be listed when shown")
+	}
+}
+
+func makeReadyWithOwnedPods(m Model) Model {
+	m = makeReady(m)
+	m.loadingK8s = false
+	m.pods = []k8s.PodInfo{
+		{Name: "api-1", Status: k8s.PodStatusRunning, Owner: k8s.OwnerRef{Kind: "Deployment", Name: "api"}},
+		{Name: "debug", Status: k8s.PodStatusRunning},
+		{Name: "web-1", Status: k8s.PodStatusRunning, Owner: k8s.OwnerRef{Kind: "Deployment", Name: "web"}},
+		{Name: "web-2", Status: k8s.PodStatusRunning, Owner: k8s.OwnerRef{Kind: "Deployment", Name: "web"}},
+		{Name: "zk-0", Status: k8s.PodStatusRunning, Owner: k8s.OwnerRef{Kind: "StatefulSet", Name: "zk"}},
+	}
+	return m
+}
+
+// typeKeys sends each rune as a key press
+func typeKeys(m Model, text string) Model {
+	for _, r := range text {
+		newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = newModel.(Model)
+	}
+	return m
+}
+
+func TestUpdate_JumpToPod(t *testing.T) {
+	m := makeReadyWithOwnedPods(New())
+
+	m = typeKeys(m, "/")
+	if !m.gotoActive {
+		t.Fatal("/ should start jump-to-pod mode")
+	}
+
+	m = typeKeys(m, "w")
+	if pod, _ := m.selectedPod(); pod.Name != "web-1" {
+		t.Errorf("typing w should select web-1, got %q", pod.Name)
+	}
+	m = typeKeys(m, "eb-2")
+	if pod, _ := m.selectedPod(); pod.Name != "web-2" {
+		t.Errorf("typing web-2 should select web-2, got %q", pod.Name)
+	}
+	if len(m.visiblePods()) != 5 {
+		t.Error("jumping should not hide other pods")
+	}
+	if !containsString(m.View(), "Go to: web-2") {
+		t.Errorf("footer should show the goto buffer, got:\n%s", m.View())
+	}
+
+	// Keys bound to actions are typed, not run
+	m = typeKeys(m, "q")
+	if !m.gotoActive || !containsString(m.View(), "Go to: web-2q (no match)") {
+		t.Errorf("q should be typed into the buffer, got:\n%s", m.View())
+	}
+	if pod, _ := m.selectedPod(); pod.Name != "web-2" {
+		t.Error("a prefix without matches should keep the selection")
+	}
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	m = newModel.(Model)
+	if m.gotoBuffer != "web-2" {
+		t.Errorf("backspace should delete the last character, got %q", m.gotoBuffer)
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(Model)
+	if m.gotoActive || m.CurrentView() != model.ViewPodList {
+		t.Error("esc should leave jump-to-pod mode and stay in the pod list")
+	}
+	if pod, _ := m.selectedPod(); pod.Name != "web-2" {
+		t.Error("leaving jump-to-pod mode should keep the selection")
+	}
+}
+
+func TestUpdate_JumpToPodTimeout(t *testing.T) {
+	m := makeReadyWithOwnedPods(New())
+	m = typeKeys(m, "/z")
+	id := m.gotoID
+
+	// A timeout from an earlier keystroke is ignored
+	newModel, _ := m.Update(gotoTimeoutMsg{id: id - 1})
+	m = newModel.(Model)
+	if !m.gotoActive {
+		t.Fatal("a stale timeout should not end jump-to-pod mode")
+	}
+
+	newModel, _ = m.Update(gotoTimeoutMsg{id: id})
+	m = newModel.(Model)
+	if m.gotoActive || containsString(m.View(), "Go to:") {
+		t.Error("the timeout should end jump-to-pod mode")
+	}
+	if pod, _ := m.selectedPod(); pod.Name != "zk-0" {
+		t.Errorf("the selection should stay on zk-0, got %q", pod.Name)
+	}
+}
+
+func TestUpdate_JumpToPodOtherKeyEndsMode(t *testing.T) {
+	m := makeReadyWithOwnedPods(New())
+	m = typeKeys(m, "/web")
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = newModel.(Model)
+	if m.gotoActive {
+		t.Error("a non-text key should end jump-to-pod mode")
+	}
+	if pod, _ := m.selectedPod(); pod.Name != "web-2" {
+		t.Errorf("the key should then act as usual, got %q", pod.Name)
+	}
+}
+
+func TestUpdate_ToggleOwnerColumn(t *testing.T) {
+	m := makeReadyWithOwnedPods(New())
+
+	if containsString(m.View(), "OWNER") {
+		t.Error("owner column should be hidden by default")
+	}
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	m = newModel.(Model)
+
+	view := m.View()
+	if !containsString(view, "OWNER") || !containsString(view, "StatefulSet/zk") {
+		t.Errorf("o should show the owner column, got:\n%s", view)
+	}
+}
+
+func TestUpdate_ToggleImageColumn(t *testing.T) {
+	m := makeReadyWithPods(New())
+	m.loadingK8s = false
+	m.width = 160
+	m.pods[0].Image = "registry.example.com/web:latest"
+	m.pods[0].Containers = append(m.pods[0].Containers, k8s.ContainerStatus{Name: "proxy"})
+
+	if containsString(m.View(), "IMAGE") {
+		t.Error("image column should be hidden by default")
+	}
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'I'}})
+	m = newModel.(Model)
+
+	view := m.View()
+	if !containsString(view, "IMAGE") || !containsString(view, "registry.example.com/web:latest +1") {
+		t.Errorf("I should show the image column, got:\n%s", view)
+	}
+}
+
+func TestUpdate_GroupByOwner(t *testing.T) {
+	m := makeReadyWithOwnedPods(New())
+	m.selectedPodIndex = 1 // debug
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'O'}})
+	m = newModel.(Model)
+
+	names := make([]string, 0, len(m.VisiblePods()))
+	for _, pod := range m.VisiblePods() {
+		names = append(names, pod.Name)
+	}
+	expected := []string{"api-1", "web-1", "web-2", "zk-0", "debug"}
+	if fmt.Sprint(names) != fmt.Sprint(expected) {
+		t.Errorf("expected pods grouped by owner %v, got %v", expected, names)
+	}
+	if pod, ok := m.selectedPod(); !ok || pod.Name != "debug" {
+		t.Errorf("selection should follow the pod when regrouping, got %+v", pod)
+	}
+	if m.pods[1].Name != "debug" {
+		t.Error("grouping must not reorder the underlying pod list")
+	}
+
+	view := m.View()
+	for _, header := range []string{"Deployment/web (2)", "StatefulSet/zk (1)", "Standalone pods (1)"} {
+		if !containsString(view, header) {
+			t.Errorf("grouped view should contain %q, got:\n%s", header, view)
+		}
+	}
+}
+
+func makeReadyWithScheduledPods(m Model) Model {
+	m = makeReady(m)
+	m.loadingK8s = false
+	m.pods = []k8s.PodInfo{
+		{Name: "api-1", Status: k8s.PodStatusRunning, Node: "node-b", IP: "10.0.1.4"},
+		{Name: "api-2", Status: k8s.PodStatusRunning, Node: "node-a", IP: "10.0.0.7"},
+		{Name: "pending", Status: k8s.PodStatusPending},
+		{Name: "web-1", Status: k8s.PodStatusRunning, Node: "node-a", IP: "10.0.0.9"},
+	}
+	return m
+}
+
+func TestUpdate_ToggleNodeColumns(t *testing.T) {
+	m := makeReadyWithScheduledPods(New())
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'W'}})
+	m = newModel.(Model)
+
+	// 80 columns leave no room next to NAME, so the optional columns go first
+	lines := podListLines(t, m)
+	if strings.Contains(lines[0], "NODE") || !strings.Contains(lines[0], "AGE") {
+		t.Errorf("node and IP should be dropped before age on 80 columns, got %q", lines[0])
+	}
+
+	newModel, _ = m.Update(tea.WindowSizeMsg{Width: 160, Height: 24})
+	m = newModel.(Model)
+	lines = podListLines(t, m)
+	if !strings.Contains(lines[0], "NODE") || !strings.Contains(lines[0], "IP") {
+		t.Errorf("wide terminals should show node and IP, got %q", lines[0])
+	}
+	if !strings.Contains(lines[2], "node-b") || !strings.Contains(lines[2], "10.0.1.4") {
+		t.Errorf("expected api-1's node and IP, got %q", lines[2])
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'W'}})
+	m = newModel.(Model)
+	if lines = podListLines(t, m); strings.Contains(lines[0], "NODE") {
+		t.Errorf("W should hide the columns again, got %q", lines[0])
+	}
+}
+
+func TestUpdate_NodeFilter(t *testing.T) {
+	m := makeReadyWithScheduledPods(New())
+	m.selectedPodIndex = 3 // web-1
+
+	press := func() {
+		t.Helper()
+		newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'N'}})
+		m = newModel.(Model)
+	}
+	names := func() string {
+		var result []string
+		for _, pod := range m.VisiblePods() {
+			result = append(result, pod.Name)
+		}
+		return strings.Join(result, ",")
+	}
+
+	press()
+	if m.nodeFilter != "node-a" || names() != "api-2,web-1" {
+		t.Fatalf("expected node-a's pods, got %q on %q", names(), m.nodeFilter)
+	}
+	if pod, ok := m.selectedPod(); !ok || pod.Name != "web-1" {
+		t.Errorf("selection should stay on web-1, got %+v", pod)
+	}
+	if !containsString(m.View(), "Node: node-a") || !strings.Contains(m.statusMessage, "node-a") {
+		t.Errorf("header and status should name the node, got status %q", m.statusMessage)
+	}
+
+	press()
+	if m.nodeFilter != "node-b" || names() != "api-1" {
+		t.Errorf("expected node-b's pods, got %q on %q", names(), m.nodeFilter)
+	}
+
+	press()
+	if m.nodeFilter != "" || len(m.VisiblePods()) != 4 {
+		t.Errorf("expected all pods again, got %q on %q", names(), m.nodeFilter)
+	}
+}
+
+func TestView_NodeFilterWithoutPods(t *testing.T) {
+	m := makeReadyWithScheduledPods(New())
+	m.nodeFilter = "node-gone"
+
+	if view := m.View(); !containsString(view, "No pods in this namespace run on node node-gone") {
+		t.Errorf("expected a hint about the node filter, got:\n%s", view)
+	}
+}
+
+func TestNextNodeFilter(t *testing.T) {
+	nodes := []string{"node-a", "node-b"}
+	tests := []struct {
+		nodes   []string
+		current string
+		want    string
+	}{
+		{nodes, "", "node-a"},
+		{nodes, "node-a", "node-b"},
+		{nodes, "node-b", ""},
+		{nodes, "node-gone", ""},
+		{nil, "", ""},
+	}
+	for _, tt := range tests {
+		if got := nextNodeFilter(tt.nodes, tt.current); got != tt.want {
+			t.Errorf("nextNodeFilter(%v, %q) = %q, want %q", tt.nodes, tt.current, got, tt.want)
+		}
+	}
+}
+
+func TestIsCompletedPod(t *testing.T) {
+	tests := []struct {
+		status k8s.PodStatus
+		want   bool
+	}{
+		{k8s.PodStatusRunning, false},
+		{k8s.PodStatusPending, false},
+		{k8s.PodStatusSucceeded, true},
+		{k8s.PodStatusFailed, true},
+		{k8s.PodStatusUnknown, false},
+		{k8s.PodStatusTerminating, false},
+	}
+
+	for _, tt := range tests {
+		if got := isCompletedPod(tt.status); got != tt.want {
+			t.Errorf("isCompletedPod(%q) = %v, want %v", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestVisiblePods_HideCompletedWithNodeFilter(t *testing.T) {
+	m := New()
+	m.hideCompleted = true
+	m = makeReadyWithPods(m)
+	m.pods = []k8s.PodInfo{
+		{Name: "api", Status: k8s.PodStatusRunning, Node: "node-a"},
+		{Name: "job-a", Status: k8s.PodStatusSucceeded, Node: "node-a"},
+		{Name: "job-b", Status: k8s.PodStatusSucceeded, Node: "node-b"},
+		{Name: "web", Status: k8s.PodStatusPending, Node: "node-b"},
+	}
+	m.nodeFilter = "node-a"
+
+	visible := m.visiblePods()
+	if len(visible) != 1 || visible[0].Name != "api" {
+		t.Errorf("expected only api visible, got %+v", visible)
+	}
+	// Completed pods on other nodes are filtered, not hidden
+	if got := m.hiddenPodCount(); got != 1 {
+		t.Errorf("expected 1 hidden pod, got %d", got)
+	}
+	if counts := podStatusCounts(m.pods); counts[k8s.PodStatusSucceeded] != 2 {
+		t.Errorf("status counts should include hidden pods, got %v", counts)
+	}
+}
+
+func TestView_AllPodsCompletedAndHidden(t *testing.T) {
+	m := New()
+	m.hideCompleted = true
+	m = makeReadyWithCompletedPods(m)
+	m.pods = m.pods[1:]
+
+	view := m.View()
+	if !containsString(view, "All pods in this namespace are completed") {
+		t.Errorf("expected hint about hidden completed pods, got:\n%s", view)
+	}
+}
+
+func TestUpdate_ReloadKeyDispatchesReload(t *testing.T) {
+	m := New()
+	m = makeReadyWithPods(m)
+	m.loadingK8s = false
+	m.k8sClient = &k8s.Client{}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'R'}})
+	m = newModel.(Model)
+
+	if cmd == nil {
+		t.Fatal("R should return a reload command")
+	}
+	if !m.loadingPods {
+		t.Error("R should show the loading state")
+	}
+}
+
+func TestUpdate_ConfigReloadedReloadsEverything(t *testing.T) {
+	m := New()
+	m = makeReadyWithPods(m)
+	m.k8sErr = errors.New("connection refused")
+
+	client := &k8s.Client{}
+	newModel, cmd := m.Update(configReloadedMsg{client: client})
+	m = newModel.(Model)
+
+	if m.k8sClient != client {
+		t.Error("reloaded client should replace the old one")
+	}
+	if m.K8sError() != nil {
+		t.Error("successful reload should clear the error")
+	}
+	if !m.loadingPods || !m.loadingNamespaces {
+		t.Error("reload should mark pods and namespaces as loading")
+	}
+
+	if cmd == nil {
+		t.Fatal("expected reload commands")
+	}
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok {
+		t.Fatalf("expected a batch of commands, got %T", cmd())
+	}
+	// Pods, namespaces, contexts, the connection check and the status timeout
+	if len(batch) != 5 {
+		t.Errorf("expected 5 reload commands, got %d", len(batch))
+	}
+
+	if !containsString(m.View(), "Reloaded") {
+		t.Error("view should show the reloaded notification")
+	}
+}
+
+func TestUpdate_ConnectionIndicator(t *testing.T) {
+	m := makeReadyWithPods(New())
+	m.loadingK8s = false
+	m.k8sClient = &k8s.Client{}
+
+	if cmd := m.startPing(); cmd == nil {
+		t.Fatal("expected a connection check for the client")
+	}
+	if containsString(m.View(), "●") {
+		t.Error("no indicator should show before the first check")
+	}
+
+	newModel, cmd := m.Update(pingResultMsg{id: m.pingID, latency: 42 * time.Millisecond})
+	m = newModel.(Model)
+	if cmd == nil {
+		t.Error("a check should schedule the next one")
+	}
+	if view := m.View(); !containsString(view, "● 42ms") {
+		t.Errorf("expected the latency in the header, got:\n%s", view)
+	}
+
+	failed := pingResultMsg{id: m.pingID, err: errors.New("connection refused")}
+	newModel, _ = m.Update(failed)
+	m = newModel.(Model)
+	if view := m.View(); !containsString(view, "● no response") || containsString(view, "DISCONNECTED") {
+		t.Errorf("expected a failed check reported, got:\n%s", view)
+	}
+	for range disconnectedAfter - 1 {
+		newModel, _ = m.Update(failed)
+		m = newModel.(Model)
+	}
+	if view := m.View(); !containsString(view, "DISCONNECTED") {
+		t.Errorf("expected repeated failures reported as disconnected, got:\n%s", view)
+	}
+
+	// Checks of a replaced client are dropped
+	oldID := m.pingID
+	m.startPing()
+	newModel, cmd = m.Update(pingResultMsg{id: oldID, latency: time.Millisecond})
+	m = newModel.(Model)
+	if cmd != nil || m.pingChecked {
+		t.Error("a stale check should be ignored")
+	}
+	if _, cmd = m.Update(pingTickMsg{id: oldID}); cmd != nil {
+		t.Error("a stale tick should not check again")
+	}
+	if _, cmd = m.Update(pingTickMsg{id: m.pingID}); cmd == nil {
+		t.Error("a tick should check the connection")
+	}
+}
+
+func TestUpdate_ClearStatusMsg(t *testing.T) {
+	m := New()
+	m = makeReady(m)
+
+	m.setStatus("first")
+	m.setStatus("second")
+
+	// A timeout for an older message must not clear the newer one
+	newModel, _ := m.Update(clearStatusMsg{id: m.statusID - 1})
+	m = newModel.(Model)
+	if m.statusMessage != "second" {
+		t.Errorf("stale clear should be ignored, got %q", m.statusMessage)
+	}
+
+	newModel, _ = m.Update(clearStatusMsg{id: m.statusID})
+	m = newModel.(Model)
+	if m.statusMessage != "" {
+		t.Errorf("status should be cleared, got %q", m.statusMessage)
+	}
+}
+
+func TestApplyPodEvent(t *testing.T) {
+	m := New()
+	m = makeReadyWithPods(m)
+
+	// Added pods are inserted in name order
+	m.applyPodEvent(k8s.PodEvent{Type: k8s.PodEventAdded, Pod: k8s.PodInfo{Name: "api-pod", Namespace: "default"}})
+	m.applyPodEvent(k8s.PodEvent{Type: k8s.PodEventAdded, Pod: k8s.PodInfo{Name: "zeta-pod", Namespace: "default"}})
+	names := make([]string, 0, len(m.pods))
+	for _, p := range m.pods {
+		names = append(names, p.Name)
+	}
+	if len(names) != 3 || names[0] != "api-pod" || names[1] != "test-pod" || names[2] != "zeta-pod" {
+		t.Fatalf("expected sorted pods, got %v", names)
+	}
+
+	// Modified pods are replaced in place
+	m.applyPodEvent(k8s.PodEvent{Type: k8s.PodEventModified, Pod: k8s.PodInfo{
+		Name: "test-pod", Namespace: "default", Status: k8s.PodStatusTerminating,
+	}})
+	if len(m.pods) != 3 || m.pods[1].Status != k8s.PodStatusTerminating {
+		t.Errorf("expected test-pod to be updated in place, got %+v", m.pods)
+	}
+
+	// Deleted pods are removed and the selection stays in range
+	m.selectedPodIndex = 2
+	m.applyPodEvent(k8s.PodEvent{Type: k8s.PodEventDeleted, Pod: k8s.PodInfo{Name: "zeta-pod", Namespace: "default"}})
+	if len(m.pods) != 2 {
+		t.Fatalf("expected zeta-pod to be removed, got %d pods", len(m.pods))
+	}
+	if m.selectedPodIndex != 1 {
+		t.Errorf("selection should be clamped after delete, got %d", m.selectedPodIndex)
+	}
+}
+
+func TestUpdate_PodsLoadedStartsWatch(t *testing.T) {
+	m := New()
+	m = makeReady(m)
+	m.k8sClient = &k8s.Client{}
+	watchID := m.podWatchID
+
+	newModel, cmd := m.Update(podsLoadedMsg{pods: []k8s.PodInfo{{Name: "test-pod"}}})
+	m = newModel.(Model)
+
+	if cmd == nil {
+		t.Fatal("loading pods should start a pod watch")
+	}
+	if m.podWatchCancel == nil || m.podWatchID == watchID {
+		t.Error("a new watch should replace the previous one")
+	}
+}
+
+func TestUpdate_RestartMarker(t *testing.T) {
+	m := makeReadyWithPods(New())
+	m.loadingK8s = false
+	m.k8sClient = &k8s.Client{}
+	pod := m.pods[0]
+
+	restarts := func(pod k8s.PodInfo, containers ...k8s.ContainerStatus) k8s.PodInfo {
+		pod.Containers = containers
+		pod.Restarts = 0
+		for _, c := range containers {
+			pod.Restarts += c.RestartCount
+		}
+		return pod
+	}
+
+	// The first load only records the counts
+	pod = restarts(pod, k8s.ContainerStatus{Name: "main", ContainerID: "containerd://a", RestartCount: 2})
+	newModel, _ := m.Update(podsLoadedMsg{pods: []k8s.PodInfo{pod}})
+	m = newModel.(Model)
+	if len(m.restartedAt) != 0 {
+		t.Fatal("the first load should not mark restarts")
+	}
+
+	// A watch event with a higher count marks the row
+	pod = restarts(pod, k8s.ContainerStatus{Name: "main", ContainerID: "containerd://b", RestartCount: 3})
+	newModel, cmd := m.Update(podEventMsg{id: m.podWatchID, events: make(chan k8s.PodEvent),
+		event: k8s.PodEvent{Type: k8s.PodEventModified, Pod: pod}})
+	m = newModel.(Model)
+	if cmd == nil || !containsString(podListLines(t, m)[2], "3↑") {
+		t.Errorf("a restarted pod should be marked, got %q", podListLines(t, m)[2])
+	}
+
+	// The mark clears once it expired
+	m.restartedAt[podKey(pod)] = time.Now().Add(-restartFlashTTL)
+	newModel, _ = m.Update(restartFlashMsg{})
+	m = newModel.(Model)
+	if containsString(podListLines(t, m)[2], "↑") {
+		t.Error("the restart mark should expire")
+	}
+
+	// A recreated pod starts over and is not marked
+	pod = restarts(pod,
+		k8s.ContainerStatus{Name: "main", ContainerID: "containerd://c"},
+		k8s.ContainerStatus{Name: "sidecar", ContainerID: "containerd://d"})
+	newModel, _ = m.Update(podsLoadedMsg{pods: []k8s.PodInfo{pod}})
+	m = newModel.(Model)
+	if len(m.restartedAt) != 0 || m.seenPods[podKey(pod)].Restarts != 0 {
+		t.Error("a lower count should be recorded without marking")
+	}
+
+	// A container restarting is marked even when another one was recreated
+	// and the pod's total stays the same
+	pod = restarts(pod,
+		k8s.ContainerStatus{Name: "main", ContainerID: "containerd://e", RestartCount: 1},
+		k8s.ContainerStatus{Name: "sidecar", ContainerID: "containerd://f", RestartCount: 1})
+	m.seenPods[podKey(pod)] = pod
+	pod = restarts(pod,
+		k8s.ContainerStatus{Name: "main", ContainerID: "containerd://g"},
+		k8s.ContainerStatus{Name: "sidecar", ContainerID: "containerd://h", RestartCount: 2})
+	newModel, _ = m.Update(podsLoadedMsg{pods: []k8s.PodInfo{pod}})
+	m = newModel.(Model)
+	if _, ok := m.restartedAt[podKey(pod)]; !ok {
+		t.Error("a container restart should be marked despite another container starting over")
+	}
+}
+
+func TestPodRegression(t *testing.T) {
+	crashLooping := []k8s.ContainerStatus{{Name: "main", State: "Waiting", StateReason: "CrashLoopBackOff"}}
+	main := func(restarts int32) []k8s.ContainerStatus {
+		return []k8s.ContainerStatus{{Name: "main", State: "Running", RestartCount: restarts}}
+	}
+	running := k8s.PodInfo{Status: k8s.PodStatusRunning, Restarts: 1, Containers: main(1)}
+
+	tests := []struct {
+		name string
+		prev k8s.PodInfo
+		pod  k8s.PodInfo
+		want string
+	}{
+		{"unchanged", running, running, ""},
+		{"failed", running, k8s.PodInfo{Status: k8s.PodStatusFailed, Restarts: 1, Containers: main(1)}, "failed"},
+		{"still failed", k8s.PodInfo{Status: k8s.PodStatusFailed}, k8s.PodInfo{Status: k8s.PodStatusFailed}, ""},
+		{"crash looping", running, k8s.PodInfo{Status: k8s.PodStatusRunning, Restarts: 2, Containers: crashLooping}, "is in CrashLoopBackOff"},
+		{"still crash looping", k8s.PodInfo{Status: k8s.PodStatusRunning, Containers: crashLooping},
+			k8s.PodInfo{Status: k8s.PodStatusRunning, Containers: crashLooping}, ""},
+		{"restarted", running, k8s.PodInfo{Status: k8s.PodStatusRunning, Restarts: 2, Containers: main(2)}, "restarted"},
+		{"recreated", running, k8s.PodInfo{Status: k8s.PodStatusRunning, Containers: main(0)}, ""},
+		{"succeeded", running, k8s.PodInfo{Status: k8s.PodStatusSucceeded, Restarts: 1, Containers: main(1)}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := podRegression(tt.prev, tt.pod); got != tt.want {
+				t.Errorf("podRegression() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdate_AlertOnFailure(t *testing.T) {
+	m := makeReadyWithPods(New())
+	m.k8sClient = &k8s.Client{}
+	pod := m.pods[0]
+
+	// Off by default
+	newModel, _ := m.Update(podsLoadedMsg{pods: []k8s.PodInfo{pod}})
+	m = newModel.(Model)
+	failed := pod
+	failed.Status = k8s.PodStatusFailed
+	newModel, _ = m.Update(podsLoadedMsg{pods: []k8s.PodInfo{failed}})
+	m = newModel.(Model)
+	if m.statusAlert {
+		t.Fatal("alerts should be opt-in")
+	}
+
+	m.config.AlertOnFailure = true
+	newModel, cmd := m.Update(podEventMsg{id: m.podWatchID, events: make(chan k8s.PodEvent),
+		event: k8s.PodEvent{Type: k8s.PodEventModified, Pod: pod}})
+	m = newModel.(Model)
+	if m.statusAlert {
+		t.Fatal("recovering should not alert")
+	}
+
+	newModel, cmd = m.Update(podEventMsg{id: m.podWatchID, events: make(chan k8s.PodEvent),
+		event: k8s.PodEvent{Type: k8s.PodEventModified, Pod: failed}})
+	m = newModel.(Model)
+	if !m.statusAlert || !containsString(m.statusMessage, "test-pod failed") || cmd == nil {
+		t.Fatalf("expected an alert about test-pod, got %q", m.statusMessage)
+	}
+
+	// A later notification is not highlighted
+	m.setStatus("Copied")
+	if m.statusAlert {
+		t.Error("a notification should replace the alert")
+	}
+}
+
+func TestRingBell(t *testing.T) {
+	var out bytes.Buffer
+	m := New()
+	m.bellOut = &out
+
+	m.ringBell()()
+	if out.String() != "\a" {
+		t.Errorf("expected BEL, got %q", out.String())
+	}
+}
+
+func TestUpdate_RestartTrackingForgetsGonePods(t *testing.T) {
+	m := makeReadyWithPods(New())
+	m.k8sClient = &k8s.Client{}
+	m.seenPods["default/gone"] = k8s.PodInfo{Restarts: 4}
+	m.restartedAt["default/gone"] = time.Now()
+	m.seenPods["default/deleted"] = k8s.PodInfo{Restarts: 1}
+
+	newModel, _ := m.Update(podsLoadedMsg{pods: []k8s.PodInfo{m.pods[0]}})
+	m = newModel.(Model)
+	if _, ok := m.seenPods["default/gone"]; ok {
+		t.Error("pods missing from a load should be forgotten")
+	}
+	if _, ok := m.restartedAt["default/gone"]; ok {
+		t.Error("marks of pods missing from a load should be dropped")
+	}
+
+	m.seenPods["default/deleted"] = k8s.PodInfo{Restarts: 1}
+	newModel, _ = m.Update(podEventMsg{id: m.podWatchID, events: make(chan k8s.PodEvent),
+		event: k8s.PodEvent{Type: k8s.PodEventDeleted, Pod: k8s.PodInfo{Name: "deleted", Namespace: "default"}}})
+	m = newModel.(Model)
+	if _, ok := m.seenPods["default/deleted"]; ok {
+		t.Error("deleted pods should be forgotten")
+	}
+}
+
+func TestUpdate_PodEventFromStaleWatchIgnored(t *testing.T) {
+	m := New()
+	m = makeReadyWithPods(m)
+	m.stopPodWatch()
+
+	events := make(chan k8s.PodEvent)
+	stale := podEventMsg{
+		id:     m.podWatchID - 1,
+		events: events,
+		event:  k8s.PodEvent{Type: k8s.PodEventAdded, Pod: k8s.PodInfo{Name: "old-ns-pod"}},
+	}
+	newModel, cmd := m.Update(stale)
+	m = newModel.(Model)
+
+	if len(m.pods) != 1 {
+		t.Errorf("events from a replaced watch should be ignored, got %d pods", len(m.pods))
+	}
+	if cmd != nil {
+		t.Error("a replaced watch should not be read further")
+	}
+
+	current := stale
+	current.id = m.podWatchID
+	newModel, cmd = m.Update(current)
+	m = newModel.(Model)
+
+	if len(m.pods) != 2 {
+		t.Errorf("events from the current watch should apply, got %d pods", len(m.pods))
+	}
+	if cmd == nil {
+		t.Error("expected to keep reading the current watch")
+	}
+}
+
+func TestUpdate_NamespaceSwitchStopsWatch(t *testing.T) {
+	m := New()
+	m = makeReadyWithPods(m)
+	m.k8sClient = &k8s.Client{}
+	m.namespaces = []k8s.NamespaceInfo{{Name: "other"}}
+	m.view = model.ViewNamespaceSelector
+
+	cancelled := false
+	m.podWatchCancel = func() { cancelled = true }
+	watchID := m.podWatchID
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+
+	if !cancelled {
+		t.Error("switching namespace should cancel the pod watch")
+	}
+	if m.podWatchID == watchID {
+		t.Error("switching namespace should invalidate pending watch messages")
+	}
+}
+
+func TestUpdate_PodsListedBeforeNamespaceSwitchDropped(t *testing.T) {
+	m := makeReadyWithPods(New())
+	m.k8sClient = &k8s.Client{}
+	m.namespaces = []k8s.NamespaceInfo{{Name: "other"}}
+	m.view = model.ViewNamespaceSelector
+	oldID := m.podsID
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+
+	newModel, cmd := m.Update(podsLoadedMsg{id: oldID, pods: []k8s.PodInfo{{Name: "old-pod", Namespace: "default"}}})
+	m = newModel.(Model)
+	if cmd != nil || len(m.pods) != 0 || !m.loadingPods {
+		t.Fatalf("a list from the old namespace should be dropped, got %+v", m.pods)
+	}
+
+	newModel, _ = m.Update(podsLoadedMsg{id: m.podsID, pods: []k8s.PodInfo{{Name: "new-pod", Namespace: "other"}}})
+	m = newModel.(Model)
+	if m.loadingPods || len(m.pods) != 1 || m.pods[0].Name != "new-pod" {
+		t.Errorf("expected the new namespace's pods, got %+v", m.pods)
+	}
+}
+
+func TestView_PlainModeHasNoANSI(t *testing.T) {
+	// Force color output so the test would catch styled rendering
+	prev := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	t.Cleanup(func() { lipgloss.SetColorProfile(prev) })
+
+	m := NewWithOptions(Options{Plain: true})
+	m = makeReadyWithPods(m)
+	m.loadingK8s = false
+	m.setStatus("Reloaded")
+
+	view := m.View()
+	if containsString(view, "\x1b[") {
+		t.Errorf("plain mode should not emit ANSI escapes, got %q", view)
+	}
+	if containsString(view, "•") || containsString(view, "↑") {
+		t.Errorf("plain mode help should use ASCII, got %q", view)
+	}
+
+	// Verbose status phrasing in the log view
+	m.view = model.ViewLogs
+	m.logView.SetState(ui.LogViewStateStreaming)
+	m.logView.AddLine("hello")
+	view = m.View()
+	if !containsString(view, "Streaming logs, 1 line, following") {
+		t.Errorf("plain mode should use verbose log status, got:\n%s", view)
+	}
+	if containsString(view, "\x1b[") {
+		t.Errorf("plain log view should not emit ANSI escapes, got %q", view)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	want := []time.Duration{
+		time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second,
+		16 * time.Second, 30 * time.Second, 30 * time.Second,
+	}
+	for i, w := range want {
+		if got := retryDelay(i + 1); got != w {
+			t.Errorf("retryDelay(%d) = %v, want %v", i+1, got, w)
+		}
+	}
+}
+
+func TestUpdate_PodsLoadRetriesTransientErrors(t *testing.T) {
+	m := New()
+	m = makeReady(m)
+	m.loadingK8s = false
+
+	transient := fmt.Errorf("failed to list pods: %w", io.ErrUnexpectedEOF)
+	newModel, cmd := m.Update(podsLoadedMsg{err: transient})
+	m = newModel.(Model)
+
+	if cmd == nil {
+		t.Fatal("transient error should schedule a retry")
+	}
+	if m.podsRetry != 1 {
+		t.Errorf("expected attempt 1, got %d", m.podsRetry)
+	}
+	if !containsString(m.View(), "Reconnecting (attempt 1)...") {
+		t.Errorf("view should show reconnecting, got:\n%s", m.View())
+	}
+
+	newModel, _ = m.Update(podsLoadedMsg{err: transient})
+	m = newModel.(Model)
+	if m.podsRetry != 2 {
+		t.Errorf("expected attempt 2, got %d", m.podsRetry)
+	}
+
+	// A retry scheduled for an older attempt is dropped
+	_, cmd = m.Update(retryPodsMsg{attempt: 1})
+	if cmd != nil {
+		t.Error("stale retry should be ignored")
+	}
+	_, cmd = m.Update(retryPodsMsg{attempt: 2})
+	if cmd == nil {
+		t.Error("current retry should reload pods")
+	}
+
+	// Success resets the backoff
+	newModel, _ = m.Update(podsLoadedMsg{pods: []k8s.PodInfo{{Name: "test-pod"}}})
+	m = newModel.(Model)
+	if m.podsRetry != 0 || m.K8sError() != nil {
+		t.Errorf("successful load should reset retries, got attempt %d err %v", m.podsRetry, m.K8sError())
+	}
+}
+
+func TestUpdate_PodsLoadFatalErrorNotRetried(t *testing.T) {
+	m := New()
+	m = makeReady(m)
+	m.loadingK8s = false
+
+	unauthorized := apierrors.NewUnauthorized("token expired")
+	newModel, cmd := m.Update(podsLoadedMsg{err: unauthorized})
+	m = newModel.(Model)
+
+	if cmd != nil {
+		t.Error("fatal error should not be retried")
+	}
+	if m.podsRetry != 0 {
+		t.Errorf("expected no retry attempts, got %d", m.podsRetry)
+	}
+	if containsString(m.View(), "Reconnecting") {
+		t.Error("view should not show reconnecting for fatal errors")
+	}
+	if !containsString(m.View(), "Unauthorized: token may be expired") {
+		t.Errorf("expected advice for an expired token, got:\n%s", m.View())
+	}
+}
+
+func TestUpdate_ForbiddenErrorsExplained(t *testing.T) {
+	m := New()
+	m = makeReady(m)
+	m.loadingK8s = false
+	m.k8sClient = &k8s.Client{}
+	m.k8sClient.SetNamespace("default")
+
+	forbidden := fmt.Errorf("failed to list pods: %w",
+		apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", errors.New("denied")))
+	newModel, cmd := m.Update(podsLoadedMsg{err: forbidden})
+	m = newModel.(Model)
+	if cmd != nil {
+		t.Error("RBAC denials should not be retried")
+	}
+	if !containsString(m.View(), "Forbidden: cannot list pods in namespace default (check RBAC)") {
+		t.Errorf("expected an RBAC hint, got:\n%s", m.View())
+	}
+
+	m.view = model.ViewNamespaceSelector
+	newModel, cmd = m.Update(namespacesLoadedMsg{err: apierrors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, "", errors.New("denied"))})
+	m = newModel.(Model)
+	if cmd != nil || !containsString(m.View(), "Forbidden: cannot list namespaces (check RBAC)") {
+		t.Errorf("expected an RBAC hint in the namespace selector, got:\n%s", m.View())
+	}
+}
+
+func TestView_NamespaceAndPodErrorsKeptApart(t *testing.T) {
+	m := makeReadyWithPods(New())
+
+	// Listing pods works while listing namespaces is denied
+	newModel, _ := m.Update(namespacesLoadedMsg{err: apierrors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, "", errors.New("denied"))})
+	m = newModel.(Model)
+	if containsString(m.View(), "Forbidden") {
+		t.Errorf("the pod list should not show the namespace error, got:\n%s", m.View())
+	}
+
+	// And the other way round
+	refused := fmt.Errorf("failed to list pods: %w", apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", errors.New("denied")))
+	newModel, _ = m.Update(podsLoadedMsg{err: refused})
+	m = newModel.(Model)
+	newModel, _ = m.Update(namespacesLoadedMsg{namespaces: nil})
+	m = newModel.(Model)
+	m.view = model.ViewNamespaceSelector
+	if view := m.View(); containsString(view, "cannot list namespaces") || !containsString(view, "No namespaces found") {
+		t.Errorf("the selector should not show the pod error, got:\n%s", view)
+	}
+}
+
+func TestUpdate_NamespacesLoadRetriesTransientErrors(t *testing.T) {
+	m := New()
+	m = makeReady(m)
+	m.view = model.ViewNamespaceSelector
+
+	refused := fmt.Errorf("failed to list namespaces: %w", syscall.ECONNREFUSED)
+	newModel, cmd := m.Update(namespacesLoadedMsg{err: refused})
+	m = newModel.(Model)
+
+	if cmd == nil || m.namespacesRetry != 1 {
+		t.Fatalf("expected a scheduled retry, got attempt %d", m.namespacesRetry)
+	}
+	if !containsString(m.View(), "Reconnecting (attempt 1)...") {
+		t.Errorf("namespace selector should show reconnecting, got:\n%s", m.View())
+	}
+}
+
+func TestUpdate_NamespaceSummaries(t *testing.T) {
+	m := New()
+	m = makeReady(m)
+	m.view = model.ViewNamespaceSelector
+
+	newModel, _ := m.Update(namespacesLoadedMsg{namespaces: []k8s.NamespaceInfo{
+		{Name: "default", IsCurrent: true},
+		{Name: "production", Age: 800 * 24 * time.Hour},
+	}})
+	m = newModel.(Model)
+
+	// The selector shows namespaces and their age before any counts arrive
+	if !containsString(m.View(), "production") || !containsString(m.View(), " 2y70d\n") {
+		t.Errorf("expected production without a count, got:\n%s", m.View())
+	}
+
+	newModel, _ = m.Update(namespaceSummaryMsg{id: m.namespaceSummaryID, index: 1, summary: k8s.NamespaceSummary{PodCount: 42}})
+	m = newModel.(Model)
+	if !containsString(m.View(), " 2y70d (42 pods)") {
+		t.Errorf("expected the pod count, got:\n%s", m.View())
+	}
+
+	// Summaries from an earlier listing are dropped
+	newModel, _ = m.Update(namespaceSummaryMsg{id: m.namespaceSummaryID - 1, index: 0, summary: k8s.NamespaceSummary{PodCount: 7}})
+	m = newModel.(Model)
+	if m.namespaces[0].Summarized {
+		t.Error("a stale summary should be ignored")
+	}
+
+	// A failed summary leaves the namespace without counts
+	newModel, _ = m.Update(namespaceSummaryMsg{id: m.namespaceSummaryID, index: 0, err: errors.New("forbidden")})
+	m = newModel.(Model)
+	if m.namespaces[0].Summarized {
+		t.Error("a failed summary should not be recorded")
+	}
+}
+
+func TestNamespaceSummary(t *testing.T) {
+	tests := []struct {
+		ns   k8s.NamespaceInfo
+		want string
+	}{
+		{k8s.NamespaceInfo{Name: "default"}, ""},
+		{k8s.NamespaceInfo{Name: "default", Summarized: true}, " (0 pods)"},
+		{k8s.NamespaceInfo{Name: "default", Summarized: true, PodCount: 1}, " (1 pod)"},
+		{
+			k8s.NamespaceInfo{Name: "production", Summarized: true, PodCount: 42, Quotas: []k8s.QuotaUsage{
+				{Quota: "compute", Resource: "pods", Used: "42", Hard: "50"},
+				{Quota: "compute", Resource: "requests.cpu", Used: "3", Hard: "4"},
+			}},
+			" (42 pods; pods 42/50, requests.cpu 3/4)",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := namespaceSummary(tt.ns); got != tt.want {
+			t.Errorf("namespaceSummary(%+v) = %q, want %q", tt.ns, got, tt.want)
+		}
+	}
+}
+
+func TestUpdate_BundlePrompt(t *testing.T) {
+	m := New()
+	m = makeReadyWithPods(m)
+	m.k8sClient = &k8s.Client{}
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+	m = newModel.(Model)
+
+	if m.CurrentView() != model.ViewPrompt {
+		t.Fatalf("b should open the prompt, got %v", m.CurrentView())
+	}
+	if m.prompt.Value() != "test-pod-bundle.txt" {
+		t.Errorf("expected default bundle path, got %q", m.prompt.Value())
+	}
+
+	// Keys bound to global actions are typed into the prompt
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewPrompt {
+		t.Error("q should be typed into the prompt, not close it")
+	}
+	if m.prompt.Value() != "test-pod-bundle.txtq" {
+		t.Errorf("expected q appended, got %q", m.prompt.Value())
+	}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewPodList {
+		t.Errorf("enter should close the prompt, got %v", m.CurrentView())
+	}
+	if cmd == nil {
+		t.Error("enter should start saving the bundle")
+	}
+	if m.bundlePod.Name != "test-pod" {
+		t.Errorf("bundle should target the selected pod, got %q", m.bundlePod.Name)
+	}
+}
+
+func TestUpdate_BundlePromptCancel(t *testing.T) {
+	m := New()
+	m = makeReadyWithPods(m)
+	m.k8sClient = &k8s.Client{}
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+	m = newModel.(Model)
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(Model)
+
+	if m.CurrentView() != model.ViewPodList {
+		t.Errorf("esc should close the prompt, got %v", m.CurrentView())
+	}
+	if cmd != nil {
+		t.Error("cancelling should not save a bundle")
+	}
+}
+
+func TestUpdate_BundleSaved(t *testing.T) {
+	m := New()
+	m = makeReadyWithPods(m)
+	m.loadingK8s = false
+
+	newModel, _ := m.Update(bundleSavedMsg{path: "/tmp/test-pod-bundle.txt"})
+	m = newModel.(Model)
+	if !containsString(m.View(), "Bundle saved to /tmp/test-pod-bundle.txt") {
+		t.Errorf("expected saved notification, got:\n%s", m.View())
+	}
+
+	newModel, _ = m.Update(bundleSavedMsg{err: errors.New("permission denied")})
+	m = newModel.(Model)
+	if !containsString(m.View(), "Bundle failed: permission denied") {
+		t.Errorf("expected failure notification, got:\n%s", m.View())
+	}
+}
+
+func TestExpandHome(t *testing.T) {
+	t.Setenv("HOME", "/home/tester")
+
+	tests := map[string]string{
+		"~/bundle.txt":    "/home/tester/bundle.txt",
+		"/tmp/bundle.txt": "/tmp/bundle.txt",
+		"bundle.txt":      "bundle.txt",
+		"~other/file":     "~other/file",
+	}
+	for in, want := range tests {
+		got, err := expandHome(in)
+		if err != nil {
+			t.Fatalf("expandHome(%q) error: %v", in, err)
+		}
+		if got != want {
+			t.Errorf("expandHome(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestUpdate_LogViewRestored(t *testing.T) {
+	m := makeReadyWithPods(New())
+	m.k8sClient = &k8s.Client{}
+
+	m = typeKeys(m, "l")
+	for i := 0; i < 50; i++ {
+		m.logView.AddLine(fmt.Sprintf("line %d", i))
+	}
+	m.logView.ScrollUp(10)
+	lastLine := time.Now().Add(-time.Minute)
+	m.logLastLineAt = lastLine
+	offset := m.logView.Snapshot().YOffset
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewPodList || len(m.logCache) != 1 {
+		t.Fatalf("expected the log view saved on leaving, got %d entries", len(m.logCache))
+	}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	m = newModel.(Model)
+	if cmd == nil {
+		t.Fatal("expected the stream to resume")
+	}
+	if m.logView.LineCount() != 50 || m.logView.IsFollow() {
+		t.Fatalf("expected 50 lines restored without follow, got %d follow=%v", m.logView.LineCount(), m.logView.IsFollow())
+	}
+	if got := m.logView.Snapshot().YOffset; got != offset {
+		t.Errorf("expected offset %d restored, got %d", offset, got)
+	}
+	if !m.logLastLineAt.Equal(lastLine) || !m.logResumeAfter.Equal(lastLine) {
+		t.Errorf("expected the stream to resume after the last line, got %v", m.logLastLineAt)
+	}
+	if len(m.logCache) != 0 || len(m.logCacheOrder) != 0 {
+		t.Error("a restored view should leave the cache")
+	}
+
+	// A view saved for another container doesn't restore into this one
+	m.view = model.ViewPodList
+	m.selectedContainer = "sidecar"
+	m.saveLogView()
+	m = typeKeys(m, "l")
+	if m.logView.LineCount() != 0 || len(m.logCache) != 1 {
+		t.Errorf("expected main's logs to start afresh, got %d lines", m.logView.LineCount())
+	}
+}
+
+func TestModel_SaveLogViewBounded(t *testing.T) {
+	m := makeReadyWithPods(New())
+	m.logView.AddLine("line")
+	m.logLastLineAt = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	m.selectedContainer = "main"
+	m.logNamespace = "default"
+
+	for i := 0; i <= maxLogCacheEntries; i++ {
+		m.logPod = fmt.Sprintf("pod-%d", i)
+		m.saveLogView()
+	}
+	if len(m.logCache) != maxLogCacheEntries || len(m.logCacheOrder) != maxLogCacheEntries {
+		t.Fatalf("expected %d saved views, got %d", maxLogCacheEntries, len(m.logCache))
+	}
+	if _, ok := m.logCache["/default/pod-0/main"]; ok {
+		t.Error("the oldest view should be dropped")
+	}
+
+	// Saving the same logs again replaces their entry
+	m.saveLogView()
+	if len(m.logCacheOrder) != maxLogCacheEntries {
+		t.Errorf("expected the entry replaced, got %d", len(m.logCacheOrder))
+	}
+
+	// Workload logs aren't kept
+	m.logPod = "web"
+	m.logSelector = "app=web"
+	m.saveLogView()
+	if _, ok := m.logCache["/default/web/main"]; ok {
+		t.Error("workload logs shouldn't be saved")
+	}
+}
+
+func TestUpdate_LogWrapToggle(t *testing.T) {
+	m := New()
+	m = makeReadyWithPods(m)
+	m.view = model.ViewLogs
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}})
+	m = newModel.(Model)
+	if !m.logView.IsWrap() {
+		t.Fatal("w should turn wrapping on")
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}})
+	m = newModel.(Model)
+	if m.logView.IsWrap() {
+		t.Error("w should turn wrapping back off")
+	}
+}
+
+func TestUpdate_LogHorizontalScrollKeys(t *testing.T) {
+	m := New()
+	m = makeReadyWithPods(m)
+	m.view = model.ViewLogs
+	m.logView.AddLine(strings.Repeat("x", 200))
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	m = newModel.(Model)
+	if m.logView.HOffset() == 0 {
+		t.Fatal("l should scroll right")
+	}
+	if m.view != model.ViewLogs {
+		t.Errorf("l should not leave the log view, got %v", m.view)
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'h'}})
+	m = newModel.(Model)
+	if m.logView.HOffset() != 0 {
+		t.Errorf("h should scroll back left, got %d", m.logView.HOffset())
+	}
+}
+
+func TestUpdate_LogPrettyJSONToggle(t *testing.T) {
+	m := New()
+	m = makeReadyWithPods(m)
+	m.view = model.ViewLogs
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'J'}})
+	m = newModel.(Model)
+	if !m.logView.IsPrettyJSON() {
+		t.Fatal("J should turn pretty-printing on")
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'J'}})
+	m = newModel.(Model)
+	if m.logView.IsPrettyJSON() {
+		t.Error("J should turn pretty-printing back off")
+	}
+}
+
+func TestUpdate_LogLevelColorsToggle(t *testing.T) {
+	m := New()
+	m = makeReadyWithPods(m)
+	m.view = model.ViewLogs
+
+	m = typeKeys(m, "L")
+	if !m.logView.IsLevelColors() {
+		t.Fatal("L should turn level colors on")
+	}
+
+	m = typeKeys(m, "L")
+	if m.logView.IsLevelColors() {
+		t.Error("L should turn level colors back off")
+	}
+}
+
+func TestUpdate_LogPager(t *testing.T) {
+	var paged string
+	prev := pagerCommand
+	pagerCommand = func(path string) (*exec.Cmd, error) {
+		data, err := os.ReadFile(path)
+		paged = string(data)
+		return exec.Command("true", path), err
+	}
+	t.Cleanup(func() { pagerCommand = prev })
+
+	m := makeReadyWithPods(New())
+	m.view = model.ViewLogs
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	m = newModel.(Model)
+	if !containsString(m.statusMessage, "No log lines") {
+		t.Errorf("expected a status for an empty buffer, got %q", m.statusMessage)
+	}
+
+	m.logView.AddLines([]string{"first", "second"})
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	m = newModel.(Model)
+	if cmd == nil || paged != "first\nsecond\n" {
+		t.Fatalf("expected the buffer handed to the pager, got %q", paged)
+	}
+	if !m.logView.IsPaused() {
+		t.Error("the view should be paused while the pager is open")
+	}
+
+	tmp := filepath.Join(t.TempDir(), "pod.log")
+	if err := os.WriteFile(tmp, []byte("x"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	newModel, _ = m.Update(pagerClosedMsg{tmpPath: tmp})
+	m = newModel.(Model)
+	if m.logView.IsPaused() {
+		t.Error("the view should resume once the pager exits")
+	}
+	if _, err := os.Stat(tmp); !os.IsNotExist(err) {
+		t.Error("the temp file should be removed")
+	}
+
+	// A view the user paused stays paused
+	m.logView.TogglePause()
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	m = newModel.(Model)
+	newModel, _ = m.Update(pagerClosedMsg{tmpPath: tmp, err: errors.New("exit status 2")})
+	m = newModel.(Model)
+	if !m.logView.IsPaused() || !containsString(m.statusMessage, "Pager failed") {
+		t.Errorf("expected the view still paused and the failure shown, got %q", m.statusMessage)
+	}
+}
+
+func TestUpdate_LogSplit(t *testing.T) {
+	m := makeReadyWithPods(New())
+	m.k8sClient = &k8s.Client{}
+	m.view = model.ViewLogs
+	m.logNamespace, m.logPod, m.selectedContainer = "default", "web", "app"
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'P'}})
+	m = newModel.(Model)
+	if !m.logView.IsSplit() || cmd == nil {
+		t.Fatal("P should open the split and load the previous logs")
+	}
+
+	// Logs loaded next to other logs are dropped
+	newModel, _ = m.Update(splitLogsLoadedMsg{key: "other", lines: []string{"stale"}})
+	m = newModel.(Model)
+	newModel, _ = m.Update(splitLogsLoadedMsg{key: m.logCacheKey(), lines: []string{"panic: boom"}})
+	m = newModel.(Model)
+	view := m.View()
+	if !containsString(view, "panic: boom") || containsString(view, "stale") {
+		t.Errorf("expected the previous logs in the split, got:\n%s", view)
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'>'}})
+	m = newModel.(Model)
+	if m.logView.SplitRatio() <= 0.5 {
+		t.Errorf("> should widen the left pane, got ratio %v", m.logView.SplitRatio())
+	}
+
+	// Esc closes the split before leaving the logs
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(Model)
+	if m.logView.IsSplit() || m.view != model.ViewLogs {
+		t.Error("esc should close the split and stay in the log view")
+	}
+
+	m.logAllContainers = true
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'P'}})
+	m = newModel.(Model)
+	if m.logView.IsSplit() || cmd == nil || !containsString(m.statusMessage, "one container") {
+		t.Errorf("expected the split refused for combined logs, got %q", m.statusMessage)
+	}
+}
+
+func TestUpdate_LogPauseKeepsReading(t *testing.T) {
+	m := New()
+	m = makeReadyWithPods(m)
+	m.view = model.ViewLogs
+	m.logStreamActive = true
+	m.logChan = make(chan k8s.LogLine)
+	m.logView.SetState(ui.LogViewStateStreaming)
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	m = newModel.(Model)
+	if !m.logView.IsPaused() {
+		t.Fatal("p should pause the log view")
+	}
+
+	newModel, cmd := m.Update(logLineMsg{id: m.logStreamID, line: k8s.LogLine{Content: "buffered"}})
+	m = newModel.(Model)
+	if cmd == nil {
+		t.Error("the stream should keep being read while paused")
+	}
+	if m.logView.LineCount() != 1 || !containsString(m.View(), "[+1 new]") {
+		t.Errorf("lines should buffer while paused, got:\n%s", m.View())
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	m = newModel.(Model)
+	if m.logView.IsPaused() || m.logView.State() != ui.LogViewStateStreaming {
+		t.Error("p should resume streaming")
+	}
+}
+
+func TestUpdate_LogLineBehindHelpResumesOnReturn(t *testing.T) {
+	m := makeReadyWithPods(New())
+	m.view = model.ViewLogs
+	m.logStreamActive = true
+	logChan := make(chan k8s.LogLine, 1)
+	m.logChan = logChan
+	m.logView.SetState(ui.LogViewStateStreaming)
+
+	m = typeKeys(m, "?")
+	newModel, cmd := m.Update(logLineMsg{id: m.logStreamID, line: k8s.LogLine{Content: "while covered"}})
+	m = newModel.(Model)
+	if cmd != nil || !m.logView.IsBackground() {
+		t.Fatal("a line behind help should be buffered without reading further")
+	}
+
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'?'}})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewLogs || m.logView.IsBackground() {
+		t.Fatalf("closing help should bring the logs to the front, got view %v", m.CurrentView())
+	}
+	if !containsString(m.View(), "while covered") {
+		t.Errorf("expected the buffered line rendered, got:\n%s", m.View())
+	}
+	if cmd == nil {
+		t.Fatal("closing help should resume reading the stream")
+	}
+	logChan <- k8s.LogLine{Content: "next"}
+	if line, ok := cmd().(logLineMsg); !ok || line.line.Content != "next" {
+		t.Errorf("expected the next line read, got %+v", line)
+	}
+
+	// The same goes for overlays closed with esc
+	m = typeKeys(m, "t")
+	newModel, _ = m.Update(logLineMsg{id: m.logStreamID, line: k8s.LogLine{Content: "behind the prompt"}})
+	m = newModel.(Model)
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewLogs || m.logView.IsBackground() || cmd == nil {
+		t.Errorf("esc should bring the logs back and resume reading, got view %v", m.CurrentView())
+	}
+}
+
+// setLogTailViaPrompt opens the tail prompt, replaces its value and submits it
+func setLogTailViaPrompt(t *testing.T, m Model, value string) (Model, tea.Cmd) {
+	t.Helper()
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewPrompt {
+		t.Fatalf("t should open the tail prompt, got %v", m.CurrentView())
+	}
+	for range m.prompt.Value() {
+		newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+		m = newModel.(Model)
+	}
+	m = typeKeys(m, value)
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	return newModel.(Model), cmd
+}
+
+func TestUpdate_LogTailPrompt(t *testing.T) {
+	m := New()
+	m = makeReadyWithPods(m)
+	m.k8sClient = &k8s.Client{}
+	m.view = model.ViewLogs
+	if m.logTailLines != config.DefaultLogTailLines {
+		t.Fatalf("expected default tail of %d, got %d", config.DefaultLogTailLines, m.logTailLines)
+	}
+
+	oldID := m.logStreamID
+	m, cmd := setLogTailViaPrompt(t, m, "10")
+	if m.CurrentView() != model.ViewLogs {
+		t.Errorf("submitting should return to the log view, got %v", m.CurrentView())
+	}
+	if m.logTailLines != 10 {
+		t.Errorf("expected tail of 10, got %d", m.logTailLines)
+	}
+	if cmd == nil || m.logStreamID == oldID {
+		t.Error("changing the tail should restart the stream")
+	}
+	if !containsString(m.View(), "(tail 10)") {
+		t.Errorf("header should show the tail length, got:\n%s", m.View())
+	}
+}
+
+func TestUpdate_LogTailValidation(t *testing.T) {
+	m := New()
+	m = makeReadyWithPods(m)
+	m.view = model.ViewLogs
+
+	for _, value := range []string{"0", "-5", "lots"} {
+		m, _ = setLogTailViaPrompt(t, m, value)
+		if !containsString(m.statusMessage, "Invalid tail length") {
+			t.Errorf("%q should be rejected, got status %q", value, m.statusMessage)
+		}
+		if m.logTailLines != config.DefaultLogTailLines {
+			t.Errorf("%q should keep the previous tail, got %d", value, m.logTailLines)
+		}
+	}
+
+	m, _ = setLogTailViaPrompt(t, m, "999999999")
+	if m.logTailLines != config.MaxLogTailLines {
+		t.Errorf("expected tail capped at %d, got %d", config.MaxLogTailLines, m.logTailLines)
+	}
+	if !containsString(m.statusMessage, "capped") {
+		t.Errorf("capping should be reported, got %q", m.statusMessage)
+	}
+}
+
+// setLabelSelectorViaPrompt opens the label selector prompt from the pod
+// list, replaces its value and submits it
+func setLabelSelectorViaPrompt(t *testing.T, m Model, value string) (Model, tea.Cmd) {
+	t.Helper()
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'F'}})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewPrompt || m.promptAction != promptLabelSelector {
+		t.Fatalf("expected the label selector prompt, got %v", m.CurrentView())
+	}
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+	m = typeKeys(newModel.(Model), value)
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	return newModel.(Model), cmd
+}
+
+func TestUpdate_LabelSelector(t *testing.T) {
+	m := makeReadyWithPods(New())
+	m.loadingK8s = false
+
+	m, _ = setLabelSelectorViaPrompt(t, m, "app in (web")
+	if m.labelSelector != "" || !containsString(m.statusMessage, "Invalid label selector") {
+		t.Errorf("expected an invalid selector rejected, got %q (status %q)", m.labelSelector, m.statusMessage)
+	}
+
+	m, cmd := setLabelSelectorViaPrompt(t, m, "app=web")
+	if m.CurrentView() != model.ViewPodList || m.labelSelector != "app=web" {
+		t.Fatalf("expected selector app=web on the pod list, got %q in %v", m.labelSelector, m.CurrentView())
+	}
+	if cmd == nil || !m.loadingPods || len(m.pods) != 0 {
+		t.Error("a new selector should relist the pods")
+	}
+	if view := m.View(); !containsString(view, "Labels: app=web") {
+		t.Errorf("expected the selector in the header, got:\n%s", view)
+	}
+
+	m.loadingPods = false
+	if view := m.View(); !containsString(view, "No pods match label selector app=web") {
+		t.Errorf("expected the empty state to name the selector, got:\n%s", view)
+	}
+
+	// The prompt starts from the current selector
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'F'}})
+	if prompt := newModel.(Model).prompt; prompt.Value() != "app=web" {
+		t.Errorf("expected the prompt prefilled with the selector, got %q", prompt.Value())
+	}
+
+	// Esc clears it
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(Model)
+	if m.labelSelector != "" || cmd == nil || m.CurrentView() != model.ViewPodList {
+		t.Errorf("expected esc to clear the selector and relist, got %q", m.labelSelector)
+	}
+
+	// So does submitting an empty selector
+	m.labelSelector = "app=web"
+	m, cmd = setLabelSelectorViaPrompt(t, m, "")
+	if m.labelSelector != "" || cmd == nil {
+		t.Errorf("expected an empty selector to clear it, got %q", m.labelSelector)
+	}
+}
+
+func TestUpdate_LogSearch(t *testing.T) {
+	m := New()
+	m = makeReadyWithPods(m)
+	m.view = model.ViewLogs
+	for _, line := range []string{"start", "error: one", "ok", "error: two"} {
+		m.logView.AddLine(line)
+	}
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewPrompt {
+		t.Fatalf("/ should open the search prompt, got %v", m.CurrentView())
+	}
+
+	// Matches follow the term while it is typed, with the logs in sight
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("error: t")})
+	m = newModel.(Model)
+	if m.logView.SearchTerm() != "error: t" || m.logView.MatchCount() != 1 {
+		t.Errorf("typing should search as it goes, got %d matches for %q", m.logView.MatchCount(), m.logView.SearchTerm())
+	}
+	if view := m.View(); !containsString(view, "error: two") || !containsString(view, "Search logs:") {
+		t.Errorf("expected the logs and the prompt while typing, got:\n%s", view)
+	}
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	m = newModel.(Model)
+	if !containsString(m.View(), "(no match)") {
+		t.Errorf("expected a hint without matches, got:\n%s", m.View())
+	}
+	for range "t x" {
+		newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+		m = newModel.(Model)
+	}
+	if m.logView.SearchTerm() != "error:" || m.logView.MatchCount() != 2 {
+		t.Errorf("deleting should widen the search, got %d matches for %q", m.logView.MatchCount(), m.logView.SearchTerm())
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+
+	if m.CurrentView() != model.ViewLogs {
+		t.Fatalf("enter should return to the log view, got %v", m.CurrentView())
+	}
+	if m.logView.MatchCount() != 2 || m.logView.IsFollow() {
+		t.Errorf("expected 2 matches with follow off, got %d (follow %v)",
+			m.logView.MatchCount(), m.logView.IsFollow())
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	m = newModel.(Model)
+	if m.logView.CurrentMatchLine() != 3 {
+		t.Errorf("n should move to the next match, got line %d", m.logView.CurrentMatchLine())
+	}
+	if !containsString(m.View(), "match 2 of 2") {
+		t.Error("status bar should show the match position")
+	}
+
+	// Cancelling the prompt puts back the search it started from
+	m = typeKeys(m, "/")
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+	m = newModel.(Model)
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("ok")})
+	m = newModel.(Model)
+	if m.logView.MatchCount() != 1 {
+		t.Errorf("expected the typed term searched, got %d matches", m.logView.MatchCount())
+	}
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewLogs || m.logView.SearchTerm() != "error:" || m.logView.MatchCount() != 2 {
+		t.Fatalf("esc should restore the previous search, got view %v term %q", m.CurrentView(), m.logView.SearchTerm())
+	}
+
+	// Esc clears the search before leaving the log view
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewLogs || m.logView.SearchTerm() != "" {
+		t.Errorf("esc should clear the search and stay in logs, got view %v term %q",
+			m.CurrentView(), m.logView.SearchTerm())
+	}
+}
+
+func TestUpdate_ExecDebugContainer(t *testing.T) {
+	m := New()
+	m = makeReadyWithPods(m)
+	m.k8sClient = &k8s.Client{}
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	m = newModel.(Model)
+
+	// A missing shell suggests starting a debug container
+	newModel, _ = m.Update(execResultMsg{id: m.execID, result: k8s.ExecResult{
+		Error: errors.New(`exec: "sh": executable file not found in $PATH`),
+	}})
+	m = newModel.(Model)
+	if !containsString(m.View(), "Press Ctrl+T to start a debug container") {
+		t.Errorf("expected debug container hint, got:\n%s", m.View())
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlT})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewPrompt {
+		t.Fatalf("Ctrl+T should prompt for the debug image, got %v", m.CurrentView())
+	}
+	if m.prompt.Value() != k8s.DefaultDebugImage {
+		t.Errorf("expected default debug image, got %q", m.prompt.Value())
+	}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewExec || cmd == nil || !m.execRunning {
+		t.Fatalf("enter should start the debug container from the exec view, got view %v", m.CurrentView())
+	}
+
+	newModel, _ = m.Update(debugContainerMsg{id: m.execID, container: "debugger-abcde"})
+	m = newModel.(Model)
+	if m.execRunning {
+		t.Error("exec should be idle once the debug container runs")
+	}
+	if m.execView.Container() != "debugger-abcde" {
+		t.Errorf("commands should target the debug container, got %q", m.execView.Container())
+	}
+}
+
+func TestUpdate_ToggleAllContainerLogs(t *testing.T) {
+	m := New()
+	m = makeReadyWithPods(m)
+	m.k8sClient = &k8s.Client{}
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	m = newModel.(Model)
+	oldStream := m.logStreamID
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	m = newModel.(Model)
+
+	if !m.logAllContainers || cmd == nil {
+		t.Fatal("a should restart the stream with all containers")
+	}
+	if !containsString(m.View(), "Logs: default/test-pod/(all containers)") {
+		t.Errorf("header should show the combined mode, got:\n%s", m.View())
+	}
+
+	// The replaced stream ending must not end the new one
+	newModel, _ = m.Update(logStreamEndedMsg{id: oldStream})
+	m = newModel.(Model)
+	if !m.logStreamActive || m.logView.State() != ui.LogViewStateStreaming {
+		t.Error("messages from the replaced stream should be ignored")
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	m = newModel.(Model)
+	if m.logAllContainers || !containsString(m.View(), "Logs: default/test-pod/main") {
+		t.Error("second a should return to the single container stream")
+	}
+}
+
+func TestUpdate_StickySelectionAcrossNamespaces(t *testing.T) {
+	m := New()
+	m.config.StickySelection = true
+	m = makeReady(m)
+	m.k8sClient = &k8s.Client{}
+	web := k8s.OwnerRef{Kind: "Deployment", Name: "web"}
+	m.pods = []k8s.PodInfo{
+		{Name: "api-5d8f-abcde", Owner: k8s.OwnerRef{Kind: "Deployment", Name: "api"}},
+		{Name: "web-7c9b-fghij", Owner: web},
+	}
+	m.selectedPodIndex = 1
+	m.namespaces = []k8s.NamespaceInfo{{Name: "staging"}}
+	m.view = model.ViewNamespaceSelector
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+
+	newModel, _ = m.Update(podsLoadedMsg{id: m.podsID, pods: []k8s.PodInfo{
+		{Name: "api-11aa-zzzzz", Owner: k8s.OwnerRef{Kind: "Deployment", Name: "api"}},
+		{Name: "cache-0", Owner: k8s.OwnerRef{Kind: "StatefulSet", Name: "cache"}},
+		{Name: "web-22bb-yyyyy", Owner: web},
+	}})
+	m = newModel.(Model)
+
+	if pod, ok := m.selectedPod(); !ok || pod.Name != "web-22bb-yyyyy" {
+		t.Errorf("selection should stay on the web workload, got %+v", pod)
+	}
+
+	// Later reloads in the same namespace don't move the cursor
+	m.selectedPodIndex = 0
+	newModel, _ = m.Update(podsLoadedMsg{pods: m.pods})
+	m = newModel.(Model)
+	if m.SelectedPodIndex() != 0 {
+		t.Errorf("sticky selection should only apply to the namespace switch, got %d", m.SelectedPodIndex())
+	}
+}
+
+func TestSelectPodByWorkload_NamePrefix(t *testing.T) {
+	m := New()
+	m.pods = []k8s.PodInfo{{Name: "webhook-1"}, {Name: "web-abc12"}}
+
+	m.selectPodByWorkload("web")
+	if m.selectedPodIndex != 1 {
+		t.Errorf("expected the web- prefixed pod, got index %d", m.selectedPodIndex)
+	}
+}
+
+func TestUpdate_StickySelectionIsOptIn(t *testing.T) {
+	m := New()
+	m.config.StickySelection = false
+	m = makeReady(m)
+	m.k8sClient = &k8s.Client{}
+	m.pods = []k8s.PodInfo{{Name: "api-1"}, {Name: "web-1"}}
+	m.selectedPodIndex = 1
+	m.namespaces = []k8s.NamespaceInfo{{Name: "staging"}}
+	m.view = model.ViewNamespaceSelector
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+	newModel, _ = m.Update(podsLoadedMsg{pods: []k8s.PodInfo{{Name: "web-2"}, {Name: "api-2"}}})
+	m = newModel.(Model)
+
+	if m.SelectedPodIndex() != 1 {
+		t.Errorf("without sticky selection the index should be kept, got %d", m.SelectedPodIndex())
+	}
+}
+
+func makeReadyWithDeployments(m Model) Model {
+	m = makeReadyWithPods(m)
+	m.loadingK8s = false
+	m.k8sClient = &k8s.Client{}
+	m.view = model.ViewDeployments
+	m.deployments = []k8s.DeploymentInfo{
+		{Name: "api", Namespace: "default", Replicas: 1, ReadyReplicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+		{Name: "web", Namespace: "default", Replicas: 3, ReadyReplicas: 2, UpdatedReplicas: 3, AvailableReplicas: 2},
+	}
+	return m
+}
+
+func TestUpdate_ToggleDeploymentsView(t *testing.T) {
+	m := New()
+	m = makeReadyWithPods(m)
+	m.k8sClient = &k8s.Client{}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewDeployments {
+		t.Fatalf("d should open the deployments view, got %v", m.CurrentView())
+	}
+	if cmd == nil || !m.loadingDeployments {
+		t.Error("opening the deployments view should load deployments")
+	}
+
+	newModel, _ = m.Update(deploymentsLoadedMsg{deployments: []k8s.DeploymentInfo{
+		{Name: "web", Namespace: "default", Replicas: 3, ReadyReplicas: 2, UpdatedReplicas: 3, AvailableReplicas: 2},
+	}})
+	m = newModel.(Model)
+	view := m.View()
+	if !containsString(view, "web") || !containsString(view, "2/3") || !containsString(view, "UP-TO-DATE") {
+		t.Errorf("deployments view should list web as 2/3 ready, got:\n%s", view)
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewPodList {
+		t.Errorf("d should toggle back to pods, got %v", m.CurrentView())
+	}
+}
+
+func TestUpdate_ScaleDeploymentPrompt(t *testing.T) {
+	m := New()
+	m = makeReadyWithDeployments(m)
+
+	// Select web, then open the scale prompt
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m = newModel.(Model)
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	m = newModel.(Model)
+
+	if m.CurrentView() != model.ViewPrompt {
+		t.Fatalf("s should open the prompt, got %v", m.CurrentView())
+	}
+	if m.prompt.Value() != "3" {
+		t.Errorf("prompt should default to the current replica count, got %q", m.prompt.Value())
+	}
+	if m.scaleTarget.Name != "web" {
+		t.Errorf("scale should target the selected deployment, got %q", m.scaleTarget.Name)
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	m = newModel.(Model)
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'5'}})
+	m = newModel.(Model)
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+
+	if m.CurrentView() != model.ViewDeployments {
+		t.Errorf("enter should return to the deployments view, got %v", m.CurrentView())
+	}
+	if cmd == nil {
+		t.Error("enter should start scaling the deployment")
+	}
+}
+
+func TestUpdate_ReadOnlyHidesMutations(t *testing.T) {
+	m := makeReadyWithDeployments(NewWithOptions(Options{ReadOnly: true}))
+
+	for _, r := range []rune{'s', 'x'} {
+		newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = newModel.(Model)
+		if m.CurrentView() != model.ViewDeployments || cmd != nil {
+			t.Errorf("%c should do nothing in read-only mode, got view %v", r, m.CurrentView())
+		}
+	}
+	view := m.View()
+	if !containsString(view, "Read-only") || containsString(view, "to scale") {
+		t.Errorf("expected the read-only header and no scale hint, got:\n%s", view)
+	}
+
+	m.view = model.ViewPodList
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	m = newModel.(Model)
+	if m.CurrentView() == model.ViewExec {
+		t.Fatal("exec should be disabled in read-only mode")
+	}
+	if containsString(m.View(), "'e' for exec") {
+		t.Errorf("expected no exec hint in read-only mode, got:\n%s", m.View())
+	}
+
+	// Contexts switch without offering to write the kubeconfig
+	m.view = model.ViewContextSelector
+	m.contexts = []k8s.ContextInfo{{Name: "dev", IsCurrent: true}, {Name: "prod"}}
+	m.selectedContextIndex = 1
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+	if m.CurrentView() == model.ViewConfirm || cmd == nil || m.switchingContext != "prod" {
+		t.Errorf("expected a direct switch to prod, got view %v switching %q", m.CurrentView(), m.switchingContext)
+	}
+}
+
+func TestUpdate_ScaleDeploymentInvalidCount(t *testing.T) {
+	m := New()
+	m = makeReadyWithDeployments(m)
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	m = newModel.(Model)
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	m = newModel.(Model)
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+
+	if !containsString(m.statusMessage, "Invalid replica count") {
+		t.Errorf("expected invalid count status, got %q", m.statusMessage)
+	}
+}
+
+func TestUpdate_RestartDeployment(t *testing.T) {
+	m := New()
+	m = makeReadyWithDeployments(m)
+
+	m = typeKeys(m, "x")
+	if m.CurrentView() != model.ViewConfirm || !containsString(m.View(), "Restart Deployment/api in default?") {
+		t.Fatalf("x should ask before restarting, got:\n%s", m.View())
+	}
+
+	// Declining cancels
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	m = newModel.(Model)
+	if cmd != nil || m.CurrentView() != model.ViewDeployments {
+		t.Fatalf("expected the restart cancelled, got view %v", m.CurrentView())
+	}
+
+	m = typeKeys(m, "x")
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = newModel.(Model)
+	if cmd == nil || m.CurrentView() != model.ViewDeployments {
+		t.Fatal("confirming should restart the selected deployment")
+	}
+
+	newModel, cmd = m.Update(deploymentActionMsg{status: "Restarting api"})
+	m = newModel.(Model)
+	if m.statusMessage != "Restarting api" {
+		t.Errorf("expected restart status, got %q", m.statusMessage)
+	}
+	if cmd == nil {
+		t.Error("a finished action should reload deployments")
+	}
+
+	newModel, _ = m.Update(deploymentActionMsg{err: errors.New("failed to restart deployment \"api\": forbidden")})
+	m = newModel.(Model)
+	if !containsString(m.statusMessage, "forbidden") {
+		t.Errorf("expected the error in the status, got %q", m.statusMessage)
+	}
+}
+
+func TestUpdate_DeploymentLogs(t *testing.T) {
+	m := New()
+	m = makeReadyWithDeployments(m)
+
+	// api has no selector, so there are no pods to tail
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewDeployments || !containsString(m.statusMessage, "no pod selector") {
+		t.Fatalf("expected a status about the missing selector, got view %v status %q", m.CurrentView(), m.statusMessage)
+	}
+
+	m.deployments[1].Selector = "app=web"
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m = newModel.(Model)
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewLogs {
+		t.Fatalf("l should open the log view, got %v", m.CurrentView())
+	}
+	if cmd == nil || !m.logStreamActive || m.logSelector != "app=web" {
+		t.Fatalf("expected a stream for app=web, active=%v selector %q", m.logStreamActive, m.logSelector)
+	}
+	if view := m.View(); !containsString(view, "deployment/web") || !containsString(view, allPodsLabel) {
+		t.Errorf("expected the deployment in the header, got:\n%s", view)
+	}
+
+	// Every container is already merged
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	m = newModel.(Model)
+	if m.logAllContainers || !containsString(m.statusMessage, "every container") {
+		t.Errorf("a should not toggle containers for a workload, got status %q", m.statusMessage)
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewDeployments || m.logSelector != "" || m.logStreamActive {
+		t.Errorf("esc should stop the stream and return to deployments, got view %v selector %q", m.CurrentView(), m.logSelector)
+	}
+}
+
+func TestUpdate_TopView(t *testing.T) {
+	m := makeReadyWithPods(New())
+	m.k8sClient = &k8s.Client{}
+	m.pods[0].CPURequest = resource.MustParse("500m")
+	m.pods[0].MemLimit = resource.MustParse("256Mi")
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'T'}})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewTop || !m.loadingTop || cmd == nil {
+		t.Fatalf("T should open the top view and load metrics, got view %v", m.CurrentView())
+	}
+
+	newModel, cmd = m.Update(topMetricsLoadedMsg{id: m.topID, metrics: []k8s.PodMetrics{
+		{Name: "test-pod", Namespace: "default", CPU: resource.MustParse("250m"), Memory: resource.MustParse("64Mi")},
+		{Name: "hungry", Namespace: "default", CPU: resource.MustParse("100m"), Memory: resource.MustParse("1Gi")},
+	}})
+	m = newModel.(Model)
+	if cmd == nil {
+		t.Error("loaded metrics should schedule a refresh")
+	}
+	view := m.View()
+	lines := strings.Split(view, "\n")
+	if !containsString(view, "Sorted by CPU") {
+		t.Errorf("expected the sort order in the header, got:\n%s", view)
+	}
+	first := slices.IndexFunc(lines, func(l string) bool { return strings.Contains(l, "test-pod") })
+	second := slices.IndexFunc(lines, func(l string) bool { return strings.Contains(l, "hungry") })
+	if first < 0 || second < first {
+		t.Fatalf("test-pod uses more CPU and should come first, got:\n%s", view)
+	}
+	if row := lines[first]; !containsString(row, "250m") || !containsString(row, "50%") || !containsString(row, "25%") {
+		t.Errorf("expected usage against requests and limits, got %q", row)
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	m = newModel.(Model)
+	if rows := m.topRows(); !m.topSortMemory || rows[0].metrics.Name != "hungry" {
+		t.Errorf("s should sort by memory, got %+v", rows)
+	}
+
+	// Refreshes stop once the view is left
+	newModel, cmd = m.Update(topTickMsg{id: m.topID})
+	m = newModel.(Model)
+	if cmd == nil {
+		t.Error("a tick should refresh the open top view")
+	}
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewPodList {
+		t.Fatalf("esc should go back to pods, got %v", m.CurrentView())
+	}
+	if _, cmd = m.Update(topTickMsg{id: m.topID}); cmd != nil {
+		t.Error("a tick should not refresh a closed top view")
+	}
+}
+
+func TestUpdate_TopMetricsFromEarlierVisitDropped(t *testing.T) {
+	m := makeReadyWithPods(New())
+	m.k8sClient = &k8s.Client{}
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'T'}})
+	m = newModel.(Model)
+	stale := m.topID
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(Model)
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'T'}})
+	m = newModel.(Model)
+
+	newModel, cmd := m.Update(topMetricsLoadedMsg{id: stale, metrics: []k8s.PodMetrics{
+		{Name: "test-pod", Namespace: "default", CPU: resource.MustParse("250m")},
+	}})
+	m = newModel.(Model)
+	if cmd != nil {
+		t.Error("metrics from an earlier visit should not schedule another refresh")
+	}
+	if !m.loadingTop || len(m.topMetrics) != 0 {
+		t.Errorf("metrics from an earlier visit should be dropped, got loading %v metrics %v", m.loadingTop, m.topMetrics)
+	}
+}
+
+func TestView_TopMetricsUnavailable(t *testing.T) {
+	m := makeReadyWithPods(New())
+	m.k8sClient = &k8s.Client{}
+	m.view = model.ViewTop
+	m.topErr = fmt.Errorf("wrapped: %w", k8s.ErrMetricsUnavailable)
+
+	if view := m.View(); !containsString(view, "Install metrics-server") {
+		t.Errorf("expected an explanation about metrics-server, got:\n%s", view)
+	}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	m = newModel.(Model)
+	if cmd == nil || !m.loadingTop {
+		t.Error("r should retry loading metrics")
+	}
+}
+
+func TestUsagePercent(t *testing.T) {
+	tests := []struct {
+		usage, of string
+		want      string
+	}{
+		{"250m", "500m", "50%"},
+		{"750m", "500m", "150%"},
+		{"64Mi", "256Mi", "25%"},
+		{"100m", "0", "-"},
+	}
+
+	for _, tt := range tests {
+		if got := usagePercent(resource.MustParse(tt.usage), resource.MustParse(tt.of)); got != tt.want {
+			t.Errorf("usagePercent(%s, %s) = %q, want %q", tt.usage, tt.of, got, tt.want)
+		}
+	}
+
+	if got := formatCPU(resource.MustParse("1.5")); got != "1500m" {
+		t.Errorf("formatCPU = %q, want 1500m", got)
+	}
+	if got := formatMemory(resource.MustParse("1Gi")); got != "1024Mi" {
+		t.Errorf("formatMemory = %q, want 1024Mi", got)
+	}
+}
+
+func TestNewTopLayout(t *testing.T) {
+	wide := newTopLayout(100)
+	if !wide.percents || wide.width() != 100 {
+		t.Errorf("expected percentages and a row filling 100 columns, got %+v width %d", wide, wide.width())
+	}
+	if widest := newTopLayout(300); widest.name != maxPodNameWidth {
+		t.Errorf("the name column should stop growing at %d, got %d", maxPodNameWidth, widest.name)
+	}
+
+	narrow := newTopLayout(50)
+	if narrow.percents || narrow.name < minPodNameWidth {
+		t.Errorf("expected percentages dropped to keep the name readable, got %+v", narrow)
+	}
+}
+
+func TestUpdate_EventsOverlay(t *testing.T) {
+	m := New()
+	m = makeReadyWithPods(m)
+	m.k8sClient = &k8s.Client{}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewEvents {
+		t.Fatalf("v should open the events overlay, got %v", m.CurrentView())
+	}
+	if cmd == nil || m.eventsAll || m.eventsPod.Name != "test-pod" {
+		t.Fatalf("events should load for the selected pod, all=%v pod=%q", m.eventsAll, m.eventsPod.Name)
+	}
+	if !containsString(m.eventsView.Title(), "default/test-pod") {
+		t.Errorf("unexpected title %q", m.eventsView.Title())
+	}
+
+	newModel, _ = m.Update(eventsLoadedMsg{events: []k8s.EventInfo{
+		{Type: "Warning", Reason: "FailedScheduling", Message: "0/3 nodes are available", Count: 2},
+	}})
+	m = newModel.(Model)
+	if !containsString(m.View(), "FailedScheduling") {
+		t.Errorf("events overlay should show the event, got:\n%s", m.View())
+	}
+
+	// a switches to every event in the namespace; late pod results are dropped
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	m = newModel.(Model)
+	if !m.eventsAll || cmd == nil {
+		t.Fatal("a should load namespace events")
+	}
+	newModel, _ = m.Update(eventsLoadedMsg{events: []k8s.EventInfo{{Reason: "Stale"}}})
+	m = newModel.(Model)
+	if containsString(m.eventsView.Content(), "Stale") {
+		t.Error("results for the pod mode should be dropped after switching")
+	}
+	newModel, _ = m.Update(eventsLoadedMsg{all: true, events: []k8s.EventInfo{
+		{Type: "Normal", Reason: "ScalingReplicaSet", Object: "Deployment/web", Count: 1},
+	}})
+	m = newModel.(Model)
+	if !containsString(m.eventsView.Content(), "Deployment/web") {
+		t.Errorf("namespace events should show the object column, got:\n%s", m.eventsView.Content())
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewPodList {
+		t.Errorf("esc should close the overlay, got %v", m.CurrentView())
+	}
+}
+
+func TestUpdate_EventsWithoutPodShowNamespace(t *testing.T) {
+	m := New()
+	m = makeReady(m)
+	m.k8sClient = &k8s.Client{}
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewEvents || !m.eventsAll {
+		t.Fatalf("without pods v should show namespace events, view=%v all=%v", m.CurrentView(), m.eventsAll)
+	}
+
+	// There is no pod to switch back to
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	m = newModel.(Model)
+	if !m.eventsAll {
+		t.Error("a should not switch to pod events without a pod")
+	}
+}
+
+func TestUpdate_SecretBrowser(t *testing.T) {
+	m := New()
+	m = makeReadyWithPods(m)
+	m.k8sClient = &k8s.Client{}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'S'}})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewSecrets || cmd == nil {
+		t.Fatalf("S should open and load the secret browser, got %v", m.CurrentView())
+	}
+
+	// Results for the configmap browser are dropped
+	newModel, _ = m.Update(dataItemsLoadedMsg{kind: "ConfigMaps", items: []ui.DataItem{{Name: "stale"}}})
+	m = newModel.(Model)
+	if containsString(m.View(), "stale") {
+		t.Error("configmaps should not show in the secret browser")
+	}
+
+	newModel, _ = m.Update(dataItemsLoadedMsg{kind: "Secrets", items: secretItems([]k8s.SecretInfo{{
+		Name: "db-creds", Type: "Opaque", Data: map[string][]byte{"password": []byte("hunter2")},
+	}})})
+	m = newModel.(Model)
+	if !containsString(m.View(), "db-creds") {
+		t.Fatalf("secret browser should list the secret, got:\n%s", m.View())
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+	if containsString(m.View(), "hunter2") {
+		t.Error("secret values should be masked until revealed")
+	}
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	m = newModel.(Model)
+	if !containsString(m.View(), "hunter2") {
+		t.Errorf("v should reveal secret values, got:\n%s", m.View())
+	}
+
+	// esc walks back up before closing the overlay
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewSecrets {
+		t.Fatalf("esc should return to the secret list first, got %v", m.CurrentView())
+	}
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewPodList {
+		t.Errorf("esc should close the secret browser, got %v", m.CurrentView())
+	}
+}
+
+// makeReadyWithServices sets up pods labelled for two services
+func makeReadyWithServices(m Model) Model {
+	m = makeReadyWithOwnedPods(m)
+	for i := range m.pods {
+		if m.pods[i].Owner.Name != "" {
+			m.pods[i].Labels = map[string]string{"app": m.pods[i].Owner.Name}
+		}
+	}
+	return m
+}
+
+func testServices() []k8s.ServiceInfo {
+	return []k8s.ServiceInfo{
+		{Name: "external-db", Type: k8s.ServiceTypeClusterIP},
+		{
+			Name: "web", Type: k8s.ServiceTypeNodePort, ClusterIP: "10.0.0.10",
+			Ports:    []k8s.ServicePort{{Port: 80, NodePort: 30080, Protocol: "TCP"}},
+			Selector: map[string]string{"app": "web"},
+		},
+	}
+}
+
+func TestUpdate_ServicesShowBackingPods(t *testing.T) {
+	m := makeReadyWithServices(New())
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewServices || cmd == nil {
+		t.Fatalf("s should open the services and load them, got %v", m.CurrentView())
+	}
+
+	newModel, _ = m.Update(servicesLoadedMsg{services: testServices()})
+	m = newModel.(Model)
+	view := m.View()
+	for _, want := range []string{"web", "NodePort", "80:30080/TCP", "app=web", "<none>"} {
+		if !containsString(view, want) {
+			t.Errorf("services view should contain %q, got:\n%s", want, view)
+		}
+	}
+
+	m = typeKeys(m, "j")
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewPodList {
+		t.Fatalf("enter should show the backing pods in the pod list, got %v", m.CurrentView())
+	}
+
+	pods := m.visiblePods()
+	if len(pods) != 2 || pods[0].Name != "web-1" || pods[1].Name != "web-2" {
+		t.Errorf("expected only the web pods, got %v", pods)
+	}
+	if !containsString(m.View(), "Service: web") {
+		t.Error("header should name the service filter")
+	}
+
+	// Esc clears the filter and goes back to the services
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewServices || m.serviceFilter.Name != "" {
+		t.Errorf("esc should clear the filter and return to the services, got %v", m.CurrentView())
+	}
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewPodList || len(m.visiblePods()) != len(m.pods) {
+		t.Error("esc from the services should return to the full pod list")
+	}
+}
+
+func TestUpdate_ServiceWithoutSelector(t *testing.T) {
+	m := makeReadyWithServices(New())
+	m = typeKeys(m, "s")
+	newModel, _ := m.Update(servicesLoadedMsg{services: testServices()})
+	m = newModel.(Model)
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+	if !containsString(m.statusMessage, "has no selector") {
+		t.Errorf("expected a no-selector notice, got %q", m.statusMessage)
+	}
+	if !containsString(m.View(), "No pods in this namespace back service external-db") {
+		t.Errorf("expected an empty state, got:\n%s", m.View())
+	}
+}
+
+func TestUpdate_ServicesLoadError(t *testing.T) {
+	m := makeReadyWithServices(New())
+	m = typeKeys(m, "s")
+
+	newModel, _ := m.Update(servicesLoadedMsg{err: fmt.Errorf("forbidden")})
+	m = newModel.(Model)
+	if !containsString(m.View(), "Error: forbidden") {
+		t.Errorf("expected the load error, got:\n%s", m.View())
+	}
+}
+
+func TestUpdate_ConfigMapBrowser(t *testing.T) {
+	m := New()
+	m = makeReady(m)
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'C'}})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewConfigMaps || cmd == nil {
+		t.Fatalf("C should open and load the configmap browser, got %v", m.CurrentView())
+	}
+
+	// Without a client the load reports an error
+	newModel, _ = m.Update(cmd())
+	m = newModel.(Model)
+	if !containsString(m.View(), "k8s client not initialized") {
+		t.Errorf("expected the load error, got:\n%s", m.View())
+	}
+
+	newModel, _ = m.Update(dataItemsLoadedMsg{kind: "ConfigMaps", items: configMapItems([]k8s.ConfigMapInfo{{
+		Name: "app-config", Data: map[string][]byte{"mode": []byte("production"), "level": []byte("debug")},
+	}})})
+	m = newModel.(Model)
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+	view := m.View()
+	if !containsString(view, "production") || !containsString(view, "debug") {
+		t.Errorf("configmap values should show inline, got:\n%s", view)
+	}
+	if strings.Index(view, "level") > strings.Index(view, "mode") {
+		t.Error("keys should be listed in order")
+	}
+}
+
+// podListLines returns the rendered pod list lines from the column header on
+func podListLines(t *testing.T, m Model) []string {
+	t.Helper()
+	lines := strings.Split(m.viewPodList(), "\n")
+	for i, line := range lines {
+		if strings.Contains(line, "NAME") {
+			return lines[i:]
+		}
+	}
+	t.Fatalf("no pod list header in:\n%s", m.viewPodList())
+	return nil
+}
+
+func TestView_PodListNarrowTerminal(t *testing.T) {
+	m := New()
+	m = makeReadyWithPods(m)
+	m.loadingK8s = false
+	newModel, _ := m.Update(tea.WindowSizeMsg{Width: 40, Height: 24})
+	m = newModel.(Model)
+
+	lines := podListLines(t, m)
+	if strings.Contains(lines[0], "AGE") || strings.Contains(lines[0], "RESTARTS") {
+		t.Errorf("narrow terminals should drop the age and restarts columns, got %q", lines[0])
+	}
+	if len(lines[1]) > 40 {
+		t.Errorf("separator should fit in 40 columns, got %d", len(lines[1]))
+	}
+	for _, line := range lines[:3] {
+		if w := runewidth.StringWidth(line); w > 40 {
+			t.Errorf("line %q is %d cells wide, want at most 40", line, w)
+		}
+	}
+}
+
+func TestPodListWindow(t *testing.T) {
+	tests := []struct {
+		name                       string
+		total, selStart, selEnd    int
+		height, wantStart, wantEnd int
+	}{
+		{"fits", 5, 4, 4, 10, 0, 5},
+		{"unknown height", 50, 40, 40, 0, 0, 50},
+		{"selection in first window", 50, 3, 3, 10, 0, 10},
+		{"selection at bottom", 50, 49, 49, 10, 40, 50},
+		{"selection in the middle", 50, 25, 25, 10, 16, 26},
+		{"expanded selection", 50, 20, 23, 10, 14, 24},
+		{"selection taller than window", 50, 20, 35, 10, 20, 30},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := podListWindow(tt.total, tt.selStart, tt.selEnd, tt.height)
+			if start != tt.wantStart || end != tt.wantEnd {
+				t.Errorf("podListWindow() = %d, %d; want %d, %d", start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestView_PodListScrollsToSelection(t *testing.T) {
+	m := New()
+	m = makeReady(m) // 24 rows leave 14 for pods
+	m.k8sClient = &k8s.Client{}
+	m.loadingK8s = false
+	for i := 0; i < 30; i++ {
+		m.pods = append(m.pods, k8s.PodInfo{Name: fmt.Sprintf("pod-%02d", i), Status: k8s.PodStatusRunning})
+	}
+
+	view := m.View()
+	if !containsString(view, "pod-13") || containsString(view, "pod-14") {
+		t.Errorf("expected only the first 14 pods, got:\n%s", view)
+	}
+	if !containsString(view, "Showing pods 1-14 of 30") {
+		t.Errorf("expected a scroll indicator, got:\n%s", view)
+	}
+
+	m.selectedPodIndex = 29
+	view = m.View()
+	if !containsString(view, "> ") || !containsString(view, "pod-29") || containsString(view, "pod-15") {
+		t.Errorf("the selection should scroll into view, got:\n%s", view)
+	}
+	if !containsString(view, "Showing pods 17-30 of 30") {
+		t.Errorf("expected the indicator to follow, got:\n%s", view)
+	}
+	if lines := strings.Count(view, "\n") + 1; lines > 24 {
+		t.Errorf("the view should fit the terminal, got %d lines", lines)
+	}
+}
+
+func TestView_PodListWideTerminal(t *testing.T) {
+	m := New()
+	m = makeReadyWithPods(m)
+	m.loadingK8s = false
+	m.pods[0].Name = strings.Repeat("a", 60)
+	newModel, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 24})
+	m = newModel.(Model)
+
+	lines := podListLines(t, m)
+	if !strings.Contains(lines[0], "AGE") || !strings.Contains(lines[0], "RESTARTS") {
+		t.Errorf("wide terminals should show every column, got %q", lines[0])
+	}
+	if !strings.Contains(lines[2], strings.Repeat("a", 60)) {
+		t.Errorf("long names should not be truncated on wide terminals, got %q", lines[2])
+	}
+	if sep := strings.TrimRight(lines[1], " "); len(sep) != runewidth.StringWidth(lines[0]) {
+		t.Errorf("separator width %d should match the header width %d", len(sep), runewidth.StringWidth(lines[0]))
+	}
+}
+
+func TestView_PodListWideCharacters(t *testing.T) {
+	m := New()
+	m = makeReadyWithPods(m)
+	m.loadingK8s = false
+	m.pods = append(m.pods, k8s.PodInfo{Name: "日本語のポッド名前がとても長いです-abc", Namespace: "default", Status: k8s.PodStatusRunning})
+	m.selectedPodIndex = 1
+
+	lines := podListLines(t, m)
+	header, ascii, wide := lines[0], lines[2], lines[3]
+
+	if !utf8.ValidString(wide) {
+		t.Fatalf("truncation should not split runes, got %q", wide)
+	}
+	// Columns line up in terminal cells, where CJK characters are two wide
+	want := runewidth.StringWidth(header[:strings.Index(header, "STATUS")])
+	for _, line := range []string{ascii, wide} {
+		idx := strings.Index(line, "Running")
+		if got := runewidth.StringWidth(line[:idx]); got != want {
+			t.Errorf("STATUS should start at cell %d, got %d in %q", want, got, line)
+		}
+	}
+}
+
+// openSearch opens the cross-view search and types query
+func openSearch(t *testing.T, m Model, query string) Model {
+	t.Helper()
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlF})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewSearch {
+		t.Fatalf("ctrl+f should open the search, got %v", m.CurrentView())
+	}
+	for _, r := range query {
+		newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = newModel.(Model)
+	}
+	return m
+}
+
+func makeReadyForSearch(m Model) Model {
+	m = makeReadyWithCompletedPods(m)
+	m.k8sClient = &k8s.Client{}
+	m.pods = append(m.pods, k8s.PodInfo{Name: "prod-api", Namespace: "default", Status: k8s.PodStatusSucceeded})
+	m.namespaces = []k8s.NamespaceInfo{{Name: "default", IsCurrent: true}, {Name: "production"}}
+	m.contexts = []k8s.ContextInfo{{Name: "prod", Cluster: "prod-cluster"}, {Name: "dev", IsCurrent: true}}
+	return m
+}
+
+func TestUpdate_SearchRanksAcrossSources(t *testing.T) {
+	m := New()
+	m = makeReadyForSearch(m)
+	m = openSearch(t, m, "prod")
+
+	results := m.search.Results()
+	if len(results) != 3 {
+		t.Fatalf("expected the context, namespace and pod, got %+v", results)
+	}
+	if results[0].Kind != ui.SearchContext || results[0].Name != "prod" {
+		t.Errorf("the exact match should rank first, got %+v", results[0])
+	}
+
+	// Typed keys go to the query, not global bindings
+	if m.CurrentView() != model.ViewSearch || m.search.Query() != "prod" {
+		t.Errorf("expected query prod in the search view, got %q in %v", m.search.Query(), m.CurrentView())
+	}
+	view := m.View()
+	if !containsString(view, "[namespace] production") || !containsString(view, "[pod]") {
+		t.Errorf("results should be labeled by type, got:\n%s", view)
+	}
+}
+
+func TestUpdate_SearchSelectsPod(t *testing.T) {
+	m := New()
+	m.hideCompleted = true
+	m = makeReadyForSearch(m)
+	m = openSearch(t, m, "prod-api")
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+
+	if m.CurrentView() != model.ViewPodList {
+		t.Fatalf("selecting a pod should show the pod list, got %v", m.CurrentView())
+	}
+	if m.HideCompleted() {
+		t.Error("a completed pod that was found should be unhidden")
+	}
+	if pod, _ := m.selectedPod(); pod.Name != "prod-api" {
+		t.Errorf("expected prod-api selected, got %q", pod.Name)
+	}
+}
+
+func TestUpdate_SearchSwitchesNamespace(t *testing.T) {
+	m := New()
+	m = makeReadyForSearch(m)
+	m = openSearch(t, m, "production")
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+
+	if m.k8sClient.CurrentNamespace() != "production" {
+		t.Errorf("expected namespace production, got %q", m.k8sClient.CurrentNamespace())
+	}
+	if m.CurrentView() != model.ViewPodList || !m.loadingPods || cmd == nil {
+		t.Errorf("switching namespace should reload pods, view=%v loading=%v", m.CurrentView(), m.loadingPods)
+	}
+}
+
+func TestUpdate_SearchSwitchesContext(t *testing.T) {
+	m := New()
+	m = makeReadyForSearch(m)
+	m = openSearch(t, m, "prod")
+
+	// The context ranks first; the test client has no kubeconfig so the
+	// switch fails and the old context stays active
+	client := m.k8sClient
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+
+	if m.CurrentView() != model.ViewPodList {
+		t.Errorf("selecting a context should return to the pod list, got %v", m.CurrentView())
+	}
+	if cmd == nil || !containsString(m.View(), "Switching context to prod...") {
+		t.Fatalf("the switch should run in the background, got:\n%s", m.View())
+	}
+
+	newModel, _ = m.Update(cmd())
+	m = newModel.(Model)
+	if !containsString(m.statusMessage, `context "prod"`) {
+		t.Errorf("expected the failed switch to prod in the status, got %q", m.statusMessage)
+	}
+	if m.k8sClient != client || m.K8sError() != nil || m.loadingPods {
+		t.Error("a failed switch should keep the old client without reloading")
+	}
+	if containsString(m.View(), "Switching context") {
+		t.Error("the progress message should clear once the switch finished")
+	}
+}
+
+func TestUpdate_StaleContextSwitchDropped(t *testing.T) {
+	m := makeReadyForSearch(New())
+	client := m.k8sClient
+	m.contextSwitchID = 2
+	m.switchingContext = "prod"
+
+	newModel, _ := m.Update(contextSwitchedMsg{id: 1, name: "dev", client: &k8s.Client{}})
+	m = newModel.(Model)
+	if m.k8sClient != client || m.switchingContext != "prod" {
+		t.Error("a superseded switch should be dropped")
+	}
+}
+
+func TestUpdate_ContextSwitchResetsNamespaces(t *testing.T) {
+	m := makeReadyForSearch(New())
+	m.selectedNamespaceIndex = 1
+	m.contextSwitchID = 1
+	summaryID := m.namespaceSummaryID
+	oldID := m.namespacesID
+	oldPodsID := m.podsID
+
+	newModel, cmd := m.Update(contextSwitchedMsg{id: 1, name: "prod", client: &k8s.Client{}})
+	m = newModel.(Model)
+	if cmd == nil {
+		t.Fatal("expected the new cluster to load")
+	}
+	if m.namespaces != nil || m.selectedNamespaceIndex != 0 || m.namespaceSummaryID == summaryID {
+		t.Errorf("namespaces of the old cluster should be dropped, got %+v at %d", m.namespaces, m.selectedNamespaceIndex)
+	}
+
+	// A listing still loading from the old cluster is dropped
+	newModel, _ = m.Update(namespacesLoadedMsg{id: oldID, namespaces: []k8s.NamespaceInfo{{Name: "old-cluster", IsCurrent: true}}})
+	m = newModel.(Model)
+	if m.namespaces != nil {
+		t.Errorf("the old cluster's namespaces should not come back, got %+v", m.namespaces)
+	}
+	newModel, _ = m.Update(podsLoadedMsg{id: oldPodsID, pods: []k8s.PodInfo{{Name: "old-cluster-pod", Namespace: "default"}}})
+	m = newModel.(Model)
+	if len(m.pods) != 0 {
+		t.Errorf("the old cluster's pods should not come back, got %+v", m.pods)
+	}
+
+	// The new cluster's listing marks its own default namespace as current
+	newModel, _ = m.Update(namespacesLoadedMsg{id: m.namespacesID, namespaces: []k8s.NamespaceInfo{
+		{Name: "default"},
+		{Name: "payments", IsCurrent: true},
+	}})
+	m = newModel.(Model)
+	if m.selectedNamespaceIndex != 1 {
+		t.Errorf("expected the new current namespace selected, got %d", m.selectedNamespaceIndex)
+	}
+}
+
+func TestUpdate_SearchEscCancels(t *testing.T) {
+	m := New()
+	m = makeReadyForSearch(m)
+	m = openSearch(t, m, "q")
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewPodList {
+		t.Errorf("esc should close the search, got %v", m.CurrentView())
+	}
+}
+
+func TestUpdate_NoCurrentContextOpensSelector(t *testing.T) {
+	kubeconfigPath := filepath.Join(t.TempDir(), "config")
+	kubeconfig := `apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://dev.example.com:6443
+  name: dev-cluster
+contexts:
+- context:
+    cluster: dev-cluster
+    user: admin
+  name: dev
+users:
+- name: admin
+  user:
+    token: token
+`
+	if err := os.WriteFile(kubeconfigPath, []byte(kubeconfig), 0600); err != nil {
+		t.Fatalf("failed to write kubeconfig: %v", err)
+	}
+	t.Setenv("KUBECONFIG", kubeconfigPath)
+
+	m := makeReady(New())
+	m.loadingK8s = true
+	ready, ok := m.initK8sClient().(k8sClientReadyMsg)
+	if !ok || !errors.Is(ready.err, k8s.ErrNoCurrentContext) {
+		t.Fatalf("expected ErrNoCurrentContext, got %+v", ready)
+	}
+
+	newModel, cmd := m.Update(ready)
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewContextSelector || cmd == nil {
+		t.Fatalf("a missing context should open the context selector, got %v", m.CurrentView())
+	}
+
+	newModel, _ = m.Update(cmd())
+	m = newModel.(Model)
+	view := m.View()
+	if !containsString(view, "No current context set — please select one") || !containsString(view, "dev") {
+		t.Errorf("selector should explain and list the contexts, got:\n%s", view)
+	}
+
+	// Choosing a context builds the first client
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	m = newModel.(Model)
+	newModel, cmd = m.Update(cmd())
+	m = newModel.(Model)
+	if cmd == nil || m.switchingContext != "dev" {
+		t.Fatal("expected the switch to dev to start")
+	}
+	switched, ok := cmd().(contextSwitchedMsg)
+	if !ok || switched.err != nil {
+		t.Fatalf("expected a client for dev, got %+v", switched)
+	}
+	newModel, _ = m.Update(switched)
+	m = newModel.(Model)
+	if m.k8sClient == nil || m.k8sClient.CurrentContext() != "dev" || m.k8sErr != nil || !m.loadingPods {
+		t.Errorf("expected dev to load, got err %v", m.k8sErr)
+	}
+}
+
+func TestOptions_Validate(t *testing.T) {
+	kubeconfigPath := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(kubeconfigPath, []byte("apiVersion: v1\nkind: Config\n"), 0600); err != nil {
+		t.Fatalf("failed to write kubeconfig: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		opts    Options
+		wantErr bool
+	}{
+		{"defaults", Options{}, false},
+		{"all set", Options{Kubeconfig: kubeconfigPath, Context: "dev", Namespace: "team-a"}, false},
+		{"invalid namespace", Options{Namespace: "Team_A"}, true},
+		{"all namespaces", Options{Namespace: "all"}, false},
+		{"missing kubeconfig", Options{Kubeconfig: filepath.Join(t.TempDir(), "missing")}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.opts.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestInitK8sClient_UsesOptions(t *testing.T) {
+	kubeconfigPath := filepath.Join(t.TempDir(), "config")
+	kubeconfig := `apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://prod.example.com:6443
+  name: prod-cluster
+- cluster:
+    server: https://dev.example.com:6443
+  name: dev-cluster
+contexts:
+- context:
+    cluster: prod-cluster
+    user: admin
+  name: prod
+- context:
+    cluster: dev-cluster
+    user: admin
+  name: dev
+current-context: dev
+users:
+- name: admin
+  user:
+    token: token
+`
+	if err := os.WriteFile(kubeconfigPath, []byte(kubeconfig), 0600); err != nil {
+		t.Fatalf("failed to write kubeconfig: %v", err)
+	}
+	t.Setenv("KUBECONFIG", filepath.Join(t.TempDir(), "missing"))
+
+	m := NewWithOptions(Options{Kubeconfig: kubeconfigPath, Context: "prod", Namespace: "team-a"})
+	ready, ok := m.initK8sClient().(k8sClientReadyMsg)
+	if !ok || ready.err != nil {
+		t.Fatalf("expected a client, got %+v", ready)
+	}
+	if ready.client.CurrentContext() != "prod" || ready.client.CurrentNamespace() != "team-a" {
+		t.Errorf("expected prod/team-a, got %s/%s", ready.client.CurrentContext(), ready.client.CurrentNamespace())
+	}
+
+	// Without a client the selector reads the same kubeconfig
+	loaded, ok := m.loadContexts().(contextsLoadedMsg)
+	if !ok || loaded.err != nil || len(loaded.contexts) != 2 {
+		t.Errorf("expected both contexts from the kubeconfig flag, got %+v", loaded)
+	}
+
+	// "all" lists every namespace rather than naming one
+	for _, opts := range []Options{{Namespace: "all"}, {AllNamespaces: true}} {
+		opts.Kubeconfig, opts.Context = kubeconfigPath, "prod"
+		m = NewWithOptions(opts)
+		ready := m.initK8sClient().(k8sClientReadyMsg)
+		if ready.err != nil {
+			t.Fatalf("expected a client for %+v, got %v", opts, ready.err)
+		}
+		if !m.allNamespaces || ready.client.CurrentNamespace() != "default" {
+			t.Errorf("expected all namespaces from %+v, got %v in %q", opts, m.allNamespaces, ready.client.CurrentNamespace())
+		}
+	}
+
+	m = NewWithOptions(Options{Kubeconfig: kubeconfigPath, Context: "staging"})
+	if ready := m.initK8sClient().(k8sClientReadyMsg); ready.err == nil {
+		t.Error("an unknown context should fail")
+	}
+}
+
+func TestUpdate_RestartOwningWorkload(t *testing.T) {
+	m := makeReadyWithPods(New())
+	m.k8sClient = &k8s.Client{}
+
+	// Standalone pods have nothing to restart
+	m = typeKeys(m, "x")
+	if m.CurrentView() != model.ViewPodList || !containsString(m.statusMessage, "not managed by") {
+		t.Fatalf("expected a hint for a standalone pod, got view %v status %q", m.CurrentView(), m.statusMessage)
+	}
+
+	m.pods[0].Owner = k8s.OwnerRef{Kind: "Deployment", Name: "web"}
+	m = typeKeys(m, "x")
+	if m.CurrentView() != model.ViewConfirm || !containsString(m.View(), "Restart Deployment/web in default?") {
+		t.Fatalf("expected a confirmation, got:\n%s", m.View())
+	}
+
+	// Declining cancels
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	m = newModel.(Model)
+	if cmd != nil || m.CurrentView() != model.ViewPodList {
+		t.Fatalf("expected the restart cancelled, got view %v", m.CurrentView())
+	}
+
+	m = typeKeys(m, "x")
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = newModel.(Model)
+	if cmd == nil || m.CurrentView() != model.ViewPodList {
+		t.Fatal("confirming should restart the owning deployment")
+	}
+
+	newModel, cmd = m.Update(workloadRestartedMsg{status: "Restarting Deployment/web"})
+	m = newModel.(Model)
+	if cmd == nil || m.statusMessage != "Restarting Deployment/web" {
+		t.Errorf("expected the restart reported and pods refreshed, got %q", m.statusMessage)
+	}
+
+	newModel, _ = m.Update(workloadRestartedMsg{err: errors.New(`failed to restart deployment "web": forbidden`)})
+	m = newModel.(Model)
+	if !containsString(m.statusMessage, "failed to restart deployment") {
+		t.Errorf("expected the failure reported, got %q", m.statusMessage)
+	}
+}
+
+func TestUpdate_AboutShowsConnection(t *testing.T) {
+	kubeconfigPath := filepath.Join(t.TempDir(), "config")
+	kubeconfig := `apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://prod.example.com:6443
+  name: prod-cluster
+contexts:
+- context:
+    cluster: prod-cluster
+    user: admin
+    namespace: shop
+  name: prod
+current-context: prod
+users:
+- name: admin
+  user:
+    token: token
+`
+	if err := os.WriteFile(kubeconfigPath, []byte(kubeconfig), 0600); err != nil {
+		t.Fatalf("failed to write kubeconfig: %v", err)
+	}
+	client, err := k8s.NewClient(k8s.WithKubeconfig(kubeconfigPath))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	m := makeReady(New())
+	m.k8sClient = client
+	m = typeKeys(m, "i")
+	if m.CurrentView() != model.ViewAbout {
+		t.Fatalf("i should open the connection info, got %v", m.CurrentView())
+	}
+
+	view := m.View()
+	for _, want := range []string{
+		"Context:     prod",
+		"Cluster:     prod-cluster (https://prod.example.com:6443)",
+		"Kubeconfig:  " + kubeconfigPath,
+		"API server:  https://prod.example.com:6443",
+		"Namespace:   shop",
+	} {
+		if !containsString(view, want) {
+			t.Errorf("expected %q in the connection info, got:\n%s", want, view)
+		}
+	}
+
+	m = typeKeys(m, "x")
+	if m.CurrentView() != model.ViewPodList {
+		t.Errorf("any key should close the connection info, got %v", m.CurrentView())
+	}
+}
+
+type confirmedMsg struct{}
+
+func TestUpdate_ConfirmRunsCmd(t *testing.T) {
+	m := makeReadyWithPods(New())
+	m.view = model.ViewLogs
+	m.askConfirm("Delete everything?", func() tea.Msg { return confirmedMsg{} }, nil)
+	if m.CurrentView() != model.ViewConfirm || !containsString(m.View(), "Delete everything?") {
+		t.Fatalf("expected the question shown, got:\n%s", m.View())
+	}
+
+	// Keys bound to global actions answer nothing while the question is open
+	m = typeKeys(m, "q")
+	if m.CurrentView() != model.ViewConfirm {
+		t.Fatalf("other keys shouldn't close the question, got %v", m.CurrentView())
+	}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewLogs {
+		t.Errorf("expected a return to the log view, got %v", m.CurrentView())
+	}
+	if cmd == nil {
+		t.Fatal("confirming should run the command")
+	}
+	if _, ok := cmd().(confirmedMsg); !ok {
+		t.Error("expected the confirmed command to run")
+	}
+}
+
+func TestUpdate_ConfirmCancelDoesNothing(t *testing.T) {
+	for _, k := range []tea.KeyMsg{
+		{Type: tea.KeyEsc},
+		{Type: tea.KeyRunes, Runes: []rune{'n'}},
+	} {
+		m := makeReadyWithPods(New())
+		ran := false
+		m.askConfirm("Delete everything?", func() tea.Msg { ran = true; return nil }, nil)
+
+		newModel, cmd := m.Update(k)
+		m = newModel.(Model)
+		if cmd != nil || ran {
+			t.Errorf("%s: expected nothing to run", k)
+		}
+		if m.CurrentView() != model.ViewPodList {
+			t.Errorf("%s: expected a return to the pod list, got %v", k, m.CurrentView())
+		}
+	}
+}
+
+func TestUpdate_ContextSelectorAsksToPersist(t *testing.T) {
+	// The switch checks that the new cluster answers
+	prod := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"major":"1","minor":"31"}`))
+	}))
+	t.Cleanup(prod.Close)
+
+	kubeconfigPath := filepath.Join(t.TempDir(), "config")
+	kubeconfig := `apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: ` + prod.URL + `
+  name: prod-cluster
+- cluster:
+    server: https://dev.example.com:6443
+  name: dev-cluster
+contexts:
+- context:
+    cluster: prod-cluster
+    user: admin
+  name: prod
+- context:
+    cluster: dev-cluster
+    user: admin
+  name: dev
+current-context: dev
+users:
+- name: admin
+  user:
+    token: token
+`
+	if err := os.WriteFile(kubeconfigPath, []byte(kubeconfig), 0600); err != nil {
+		t.Fatalf("failed to write kubeconfig: %v", err)
+	}
+	client, err := k8s.NewClient(k8s.WithKubeconfig(kubeconfigPath))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	m := New()
+	m = makeReady(m)
+	m.k8sClient = client
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	m = newModel.(Model)
+	m.contexts = client.ListContexts()
+	m.selectedContextIndex = 1 // prod
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewConfirm {
+		t.Fatalf("selecting a context should ask whether to make it the default, got %v", m.CurrentView())
+	}
+	if client.CurrentContext() != "dev" {
+		t.Error("the context should not switch until the question is answered")
+	}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewPodList {
+		t.Errorf("answering should return to the pod list, got %v", m.CurrentView())
+	}
+	if cmd == nil {
+		t.Fatal("expected the switch to be requested")
+	}
+
+	newModel, cmd = m.Update(cmd())
+	m = newModel.(Model)
+	if cmd == nil || m.switchingContext != "prod" {
+		t.Fatal("expected the switch to prod to start")
+	}
+
+	switched, ok := cmd().(contextSwitchedMsg)
+	if !ok || switched.err != nil || !switched.persist {
+		t.Fatalf("expected a persisting switch, got %+v", switched)
+	}
+	newModel, cmd = m.Update(switched)
+	m = newModel.(Model)
+	if m.k8sClient.CurrentContext() != "prod" || !m.loadingPods {
+		t.Errorf("expected context prod to load, got %q", m.k8sClient.CurrentContext())
+	}
+	if client.CurrentContext() != "dev" {
+		t.Error("the old client should be replaced, not modified")
+	}
+	if cmd == nil {
+		t.Fatal("expected commands to reload and persist")
+	}
+
+	msg := m.persistContext()()
+	if persisted, ok := msg.(contextPersistedMsg); !ok || persisted.err != nil || persisted.name != "prod" {
+		t.Fatalf("expected prod to be persisted, got %+v", msg)
+	}
+	data, err := os.ReadFile(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("failed to read kubeconfig: %v", err)
+	}
+	if !strings.Contains(string(data), "current-context: prod") {
+		t.Errorf("expected the kubeconfig current-context to be prod, got:\n%s", data)
+	}
+
+	newModel, _ = m.Update(msg)
+	m = newModel.(Model)
+	if !containsString(m.View(), "Saved prod") {
+		t.Error("expected a status confirming the saved context")
+	}
+}
+
+func TestUpdate_ExecStdinFromFile(t *testing.T) {
+	m := New()
+	m = makeReadyWithPods(m)
+	m.k8sClient = &k8s.Client{}
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	m = newModel.(Model)
+
+	missing := filepath.Join(t.TempDir(), "missing.txt")
+	newModel, cmd := m.runExecCommand(`sh -c "cat > /tmp/x" < ` + missing)
+	m = newModel.(Model)
+	if cmd != nil || m.execRunning {
+		t.Fatal("a missing stdin file should not start the exec")
+	}
+	if !containsString(m.View(), "failed to open stdin file") {
+		t.Errorf("expected the open error to be shown, got:\n%s", m.View())
+	}
+
+	input := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(input, []byte("hello"), 0o600); err != nil {
+		t.Fatalf("failed to write input: %v", err)
+	}
+	newModel, cmd = m.runExecCommand(`sh -c "cat > /tmp/x" < ` + input)
+	m = newModel.(Model)
+	if cmd == nil || !m.execRunning {
+		t.Error("an existing stdin file should start the exec")
+	}
+	m.execCancel()
+}
+
+func TestUpdate_ExecStreamsOutput(t *testing.T) {
+	m := New()
+	m = makeReadyWithPods(m)
+	m.k8sClient = &k8s.Client{}
+	m = typeKeys(m, "e")
+	m.execRunning = true
+	m.execView.SetState(ui.ExecViewStateRunning)
+
+	chunks := make(chan k8s.ExecChunk, 3)
+	chunks <- k8s.ExecChunk{Data: "==> app.log <==\n"}
+	chunks <- k8s.ExecChunk{Data: "tail: file truncated\n", Stderr: true}
+	chunks <- k8s.ExecChunk{Done: true, ExitCode: 130}
+	close(chunks)
+
+	newModel, cmd := m.Update(execStreamMsg{id: m.execID, chunks: chunks})
+	m = newModel.(Model)
+
+	// Each line shows as it arrives, before the command ends
+	for range 2 {
+		newModel, cmd = m.Update(cmd())
+		m = newModel.(Model)
+		if !m.execRunning {
+			t.Fatal("the command should still be running")
+		}
+	}
+	output := strings.Join(m.execView.Output(), "\n")
+	if !containsString(output, "==> app.log <==") || !containsString(output, "[stderr] tail: file truncated") {
+		t.Errorf("expected streamed output, got:\n%s", output)
+	}
+
+	newModel, _ = m.Update(cmd())
+	m = newModel.(Model)
+	if m.execRunning || m.execChunks != nil {
+		t.Error("the final chunk should end the command")
+	}
+	if code, ok := m.execView.ExitCode(); !ok || code != 130 {
+		t.Errorf("expected exit code 130, got %d", code)
+	}
+
+	// Output of an interrupted run is dropped
+	newModel, _ = m.Update(execOutputMsg{id: m.execID - 1, chunk: k8s.ExecChunk{Data: "late\n"}})
+	m = newModel.(Model)
+	if containsString(strings.Join(m.execView.Output(), "\n"), "late") {
+		t.Error("stale output should be dropped")
+	}
+}
+
+func TestUpdate_ExecShowsExitCode(t *testing.T) {
+	m := New()
+	m = makeReadyWithPods(m)
+	m.k8sClient = &k8s.Client{}
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	m = newModel.(Model)
+
+	// A command that ran and failed is not a connection error
+	newModel, _ = m.Update(execResultMsg{id: m.execID, result: k8s.ExecResult{Stderr: "grep: no match", ExitCode: 2}})
+	m = newModel.(Model)
+
+	view := m.View()
+	if !containsString(view, "[EXIT: 2]") {
+		t.Errorf("expected the exit code in the status bar, got:\n%s", view)
+	}
+	if containsString(view, "[ERROR") {
+		t.Error("a non-zero exit should not be reported as an exec error")
+	}
+	if !containsString(view, "grep: no match") {
+		t.Error("stderr should still be shown")
+	}
+}
+
+// stubClipboard captures clipboard writes, failing with err if set
+func stubClipboard(t *testing.T, err error) *string {
+	t.Helper()
+	var copied string
+	prev := clipboardWrite
+	clipboardWrite = func(text string) error {
+		copied = text
+		return err
+	}
+	t.Cleanup(func() { clipboardWrite = prev })
+	return &copied
+}
+
+func TestUpdate_CopyPodName(t *testing.T) {
+	copied := stubClipboard(t, nil)
+	m := New()
+	m = makeReadyWithPods(m)
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'Y'}})
+	m = newModel.(Model)
+	if cmd == nil {
+		t.Fatal("Y should copy the selected pod")
+	}
+	newModel, _ = m.Update(cmd())
+	m = newModel.(Model)
+
+	if *copied != "default/test-pod" {
+		t.Errorf("expected namespace/pod on the clipboard, got %q", *copied)
+	}
+	if !containsString(m.View(), "Copied test-pod") {
+		t.Errorf("expected a copied status, got:\n%s", m.View())
+	}
+}
+
+func TestUpdate_CopyKubectlCommand(t *testing.T) {
+	copied := stubClipboard(t, nil)
+	m := makeReadyWithPods(New())
+	m.k8sClient = &k8s.Client{}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'K'}})
+	m = newModel.(Model)
+	if cmd == nil {
+		t.Fatal("K should copy a kubectl command")
+	}
+	newModel, _ = m.Update(cmd())
+	m = newModel.(Model)
+
+	want := "kubectl -n default exec -it test-pod -c main -- sh"
+	if *copied != want {
+		t.Errorf("expected %q on the clipboard, got %q", want, *copied)
+	}
+	if !containsString(m.statusMessage, want) {
+		t.Errorf("expected the command in the status, got %q", m.statusMessage)
+	}
+}
+
+func TestKubectlCommandForView(t *testing.T) {
+	base := makeReadyWithPods(New())
+	base.k8sClient = &k8s.Client{}
+
+	tests := []struct {
+		name  string
+		setup func(m *Model)
+		want  string
+	}{
+		{"pod list", func(m *Model) {}, "kubectl -n default exec -it test-pod -c main -- sh"},
+		{"logs", func(m *Model) {
+			m.view = model.ViewLogs
+			m.logNamespace, m.logPod, m.selectedContainer = "default", "test-pod", "main"
+		}, "kubectl -n default logs test-pod -c main -f --tail 100"},
+		{"previous logs", func(m *Model) {
+			m.view = model.ViewLogs
+			m.logNamespace, m.logPod, m.selectedContainer = "default", "test-pod", "main"
+			m.logView.SetPrevious(true)
+		}, "kubectl -n default logs test-pod -c main --previous --tail 100"},
+		{"all containers", func(m *Model) {
+			m.view = model.ViewLogs
+			m.logNamespace, m.logPod, m.logAllContainers = "default", "test-pod", true
+		}, "kubectl -n default logs test-pod --all-containers --prefix -f --tail 100"},
+		{"deployment logs", func(m *Model) {
+			m.view = model.ViewLogs
+			m.logNamespace, m.logSelector = "default", "app=web,tier in (frontend)"
+			m.logTailLines = 0
+		}, "kubectl -n default logs -l 'app=web,tier in (frontend)' --all-containers --prefix -f"},
+		{"deployment", func(m *Model) {
+			m.view = model.ViewDeployments
+			m.deployments = []k8s.DeploymentInfo{{Name: "web", Namespace: "shop"}}
+		}, "kubectl -n shop describe deployment web"},
+		{"directory", func(m *Model) {
+			m.view = model.ViewFiles
+			m.filesView.SetPodInfo("default", "test-pod", "main")
+			m.filesView.SetCurrentPath("/etc/nginx")
+		}, "kubectl -n default exec test-pod -c main -- ls -la /etc/nginx"},
+		{"file", func(m *Model) {
+			m.view = model.ViewFiles
+			m.filesView.SetPodInfo("default", "test-pod", "main")
+			m.filesView.SetCurrentPath("/etc/nginx")
+			m.filesView.SetFileContent("nginx.conf", "events {}")
+		}, "kubectl -n default cp test-pod:/etc/nginx/nginx.conf ./nginx.conf -c main"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := base
+			m.logView = ui.NewLogViewModel()
+			tt.setup(&m)
+			got, ok := m.kubectlCommandForView()
+			if !ok || got != tt.want {
+				t.Errorf("got %q (ok %v), want %q", got, ok, tt.want)
+			}
+		})
+	}
+
+	// Nothing to copy without a selection
+	m := base
+	m.pods = nil
+	if _, ok := m.kubectlCommandForView(); ok {
+		t.Error("expected no command without a selected pod")
+	}
+}
+
+func TestKubectlCommand(t *testing.T) {
+	if got := kubectlCommand("prod", "shop", "get", "pods"); got != "kubectl --context prod -n shop get pods" {
+		t.Errorf("unexpected command %q", got)
+	}
+	if got := kubectlCommand(k8s.InClusterContext, "shop", "get", "pods"); got != "kubectl -n shop get pods" {
+		t.Errorf("the in-cluster context should be left out, got %q", got)
+	}
+	if got := kubectlCommand("arn:aws:eks:eu-west-1:1:cluster/prod", "shop", "get", "pods"); got !=
+		"kubectl --context arn:aws:eks:eu-west-1:1:cluster/prod -n shop get pods" {
+		t.Errorf("EKS context names need no quoting, got %q", got)
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"test-pod", "test-pod"},
+		{"/var/log/app.log", "/var/log/app.log"},
+		{"", "''"},
+		{"my file", "'my file'"},
+		{"it's", `'it'\''s'`},
+		{"$HOME", "'$HOME'"},
+		{"app in (a,b)", "'app in (a,b)'"},
+	}
+
+	for _, tt := range tests {
+		if got := shellQuote(tt.in); got != tt.want {
+			t.Errorf("shellQuote(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestUpdate_ExecCopyAndClearOutput(t *testing.T) {
+	copied := stubClipboard(t, nil)
+	m := New()
+	m = makeReadyWithPods(m)
+	m.view = model.ViewExec
+	m.execView.AddToHistory("ls")
+	m.execView.AddOutput("bin\netc\n", false)
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlY})
+	m = newModel.(Model)
+	if cmd == nil {
+		t.Fatal("Ctrl+Y should copy the output")
+	}
+	newModel, _ = m.Update(cmd())
+	m = newModel.(Model)
+	if *copied != "bin\netc" {
+		t.Errorf("expected the output on the clipboard, got %q", *copied)
+	}
+	if !containsString(m.View(), "Copied 2 exec output lines") {
+		t.Errorf("expected a copied status, got:\n%s", m.View())
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlL})
+	m = newModel.(Model)
+	if len(m.execView.Output()) != 0 {
+		t.Errorf("Ctrl+L should clear the output, got %q", m.execView.Output())
+	}
+	if !containsString(m.View(), "Cleared exec output") || !containsString(m.View(), "History: 1") {
+		t.Errorf("expected a cleared status with the history kept, got:\n%s", m.View())
+	}
+
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyCtrlY})
+	m = newModel.(Model)
+	if cmd == nil || !containsString(m.View(), "No exec output to copy") {
+		t.Errorf("copying nothing should say so, got:\n%s", m.View())
+	}
+}
+
+func TestUpdate_CopyLogsWithoutClipboard(t *testing.T) {
+	copied := stubClipboard(t, errors.New("no clipboard available"))
+	m := New()
+	m = makeReadyWithPods(m)
+	m.view = model.ViewLogs
+	for _, line := range []string{"first", "second"} {
+		m.logView.AddLine(line)
+	}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'Y'}})
+	m = newModel.(Model)
+	if cmd == nil {
+		t.Fatal("Y should copy the visible log lines")
+	}
+	newModel, _ = m.Update(cmd())
+	m = newModel.(Model)
+
+	if *copied != "first\nsecond" {
+		t.Errorf("expected the visible lines to be copied, got %q", *copied)
+	}
+	if m.CurrentView() != model.ViewLogs || !containsString(m.View(), "Could not copy 2 log lines: no clipboard available") {
+		t.Errorf("a missing clipboard should only show a status, got view %v:\n%s", m.CurrentView(), m.View())
+	}
+}
+
+func TestView_StatusBarKeepsLayout(t *testing.T) {
+	m := makeReadyWithPods(New())
+	newModel, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = newModel.(Model)
+	m.view = model.ViewLogs
+	for i := 0; i < 100; i++ {
+		m.logView.AddLine(fmt.Sprintf("line %d", i))
+	}
+
+	height := func(m Model) int { return strings.Count(m.View(), "\n") + 1 }
+	quiet := height(m)
+	if quiet > 24 {
+		t.Fatalf("log view should fit the window, got %d lines", quiet)
+	}
+
+	m.statusMessage = "failed:\n" + strings.Repeat("very long error ", 20)
+	m.switchingContext = "prod"
+	if got := height(m); got != quiet {
+		t.Errorf("notifications should not change the layout, got %d lines, want %d", got, quiet)
+	}
+
+	bar := m.statusBar()
+	if !strings.HasPrefix(bar, "Switching context to prod... | failed: very long") {
+		t.Errorf("notifications should share one line, got %q", bar)
+	}
+	if width := runewidth.StringWidth(bar); width > 80 {
+		t.Errorf("status bar should be truncated to the window width, got %d columns", width)
+	}
+}
+
+func TestSetStatusFor(t *testing.T) {
+	m := makeReady(New())
+
+	cmd := m.setStatusFor("Saved", time.Minute)
+	if cmd == nil || m.statusMessage != "Saved" {
+		t.Fatal("setStatusFor should show the message and schedule its removal")
+	}
+	first := m.statusID
+
+	m.setStatus("Copied")
+	newModel, _ := m.Update(clearStatusMsg{id: first})
+	m = newModel.(Model)
+	if m.statusMessage != "Copied" {
+		t.Error("an older timer should not clear a newer message")
+	}
+
+	newModel, _ = m.Update(clearStatusMsg{id: m.statusID})
+	m = newModel.(Model)
+	if m.statusMessage != "" {
+		t.Error("the message should clear when its timer fires")
+	}
+}
+
+func TestUpdate_AllNamespaces(t *testing.T) {
+	m := makeReadyWithPods(New())
+	m.k8sClient = &k8s.Client{}
+	m.loadingK8s = false
+	m.width = 120
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'A'}})
+	m = newModel.(Model)
+	if !m.allNamespaces || !m.loadingPods || cmd == nil {
+		t.Fatal("A should reload the pods across all namespaces")
+	}
+
+	// A list from before the toggle is dropped
+	newModel, _ = m.Update(podsLoadedMsg{pods: m.pods})
+	m = newModel.(Model)
+	if !m.loadingPods {
+		t.Error("a single-namespace list should not end the all-namespaces load")
+	}
+
+	newModel, _ = m.Update(podsLoadedMsg{id: m.podsID, allNamespaces: true, pods: []k8s.PodInfo{
+		{Name: "web", Namespace: "default", Status: k8s.PodStatusRunning},
+		{Name: "coredns", Namespace: "kube-system", Status: k8s.PodStatusRunning},
+	}})
+	m = newModel.(Model)
+
+	view := m.View()
+	if !containsString(view, "Namespace: all") {
+		t.Error("header should show that all namespaces are listed")
+	}
+	lines := podListLines(t, m)
+	if !strings.HasPrefix(strings.TrimSpace(lines[0]), "NAMESPACE") {
+		t.Errorf("expected a leading NAMESPACE column, got %q", lines[0])
+	}
+	if !containsString(lines[3], "kube-system") || !containsString(lines[3], "coredns") {
+		t.Errorf("expected the namespace next to the pod, got %q", lines[3])
+	}
+
+	// Picking a namespace goes back to listing just that one
+	m.k8sClient = nil
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'A'}})
+	m = newModel.(Model)
+	if m.allNamespaces {
+		t.Error("A should toggle back to a single namespace")
+	}
+}
+
+func TestUpdate_AllNamespacesForbidden(t *testing.T) {
+	m := makeReadyWithPods(New())
+	m.k8sClient = &k8s.Client{}
+	m = typeKeys(m, "A")
+
+	forbidden := apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", errors.New("cluster-wide list denied"))
+	newModel, cmd := m.Update(podsLoadedMsg{id: m.podsID, allNamespaces: true, err: fmt.Errorf("failed to list pods in all namespaces: %w", forbidden)})
+	m = newModel.(Model)
+
+	if m.allNamespaces || !m.loadingPods || cmd == nil {
+		t.Error("a Forbidden error should revert to listing the current namespace")
+	}
+	if m.k8sErr != nil {
+		t.Errorf("the denial should not show as a connection error, got %v", m.k8sErr)
+	}
+	if !containsString(m.statusMessage, "Not allowed to list pods in all namespaces") {
+		t.Errorf("expected an explanation, got %q", m.statusMessage)
+	}
+}
+
+func TestUpdate_LogStreamReconnect(t *testing.T) {
+	m := makeReadyWithPods(New())
+	m.k8sClient = &k8s.Client{}
+	m = typeKeys(m, "l")
+
+	received := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	newModel, _ := m.Update(logLineMsg{id: m.logStreamID, line: k8s.LogLine{Content: "before", Timestamp: received}})
+	m = newModel.(Model)
+
+	endedID := m.logStreamID
+	newModel, cmd := m.Update(logStreamEndedMsg{id: endedID})
+	m = newModel.(Model)
+	if cmd == nil || m.logReconnects != 1 || m.logStreamID == endedID {
+		t.Fatal("an unexpected end while following should schedule a reconnect")
+	}
+	if m.logView.State() == ui.LogViewStateEnded {
+		t.Error("the view should not show the stream as ended while reconnecting")
+	}
+	if !containsString(m.statusBar(), "reconnecting") {
+		t.Errorf("status bar should mention the reconnect, got %q", m.statusBar())
+	}
+
+	newModel, cmd = m.Update(logReconnectMsg{id: m.logStreamID})
+	m = newModel.(Model)
+	if cmd == nil || !m.logStreamActive {
+		t.Fatal("the reconnect should reopen the stream")
+	}
+	if !m.logLastLineAt.Equal(received) {
+		t.Errorf("the reconnect should resume from the last line, got %v", m.logLastLineAt)
+	}
+	if !containsString(m.View(), "before") {
+		t.Error("lines shown before the reconnect should be kept")
+	}
+
+	// The resumed stream repeats the last second; lines up to the last
+	// one shown are dropped
+	newModel, _ = m.Update(logLineMsg{id: m.logStreamID, line: k8s.LogLine{Content: "before", Timestamp: received}})
+	m = newModel.(Model)
+	if m.logView.LineCount() != 1 || m.logReconnects != 1 {
+		t.Errorf("a repeated line should be dropped, got %d lines", m.logView.LineCount())
+	}
+
+	// A line resets the attempts
+	newModel, _ = m.Update(logLineMsg{id: m.logStreamID, line: k8s.LogLine{Content: "after", Timestamp: received.Add(time.Second)}})
+	m = newModel.(Model)
+	if m.logReconnects != 0 || m.logView.LineCount() != 2 {
+		t.Errorf("a new line should be shown and reset the attempts, got %d", m.logReconnects)
+	}
+}
+
+func TestUpdate_LogStreamNoReconnect(t *testing.T) {
+	m := makeReadyWithPods(New())
+	m.k8sClient = &k8s.Client{}
+	m = typeKeys(m, "l")
+
+	// Leaving the view stops the stream; a pending reconnect is dropped
+	newModel, _ := m.Update(logStreamEndedMsg{id: m.logStreamID})
+	m = newModel.(Model)
+	pending := m.logStreamID
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(Model)
+	newModel, cmd := m.Update(logReconnectMsg{id: pending})
+	m = newModel.(Model)
+	if cmd != nil || m.logStreamActive {
+		t.Error("a reconnect must not run after the user stopped the stream")
+	}
+
+	// Without follow the end is final
+	m = typeKeys(m, "l")
+	m.logView.ToggleFollow()
+	newModel, cmd = m.Update(logStreamEndedMsg{id: m.logStreamID})
+	m = newModel.(Model)
+	if cmd != nil || m.logView.State() != ui.LogViewStateEnded {
+		t.Error("a stream that isn't followed should end without reconnecting")
+	}
+
+	// Nor once the attempts are used up
+	m.logView.ToggleFollow()
+	m.logReconnects = maxLogReconnects
+	newModel, cmd = m.Update(logStreamEndedMsg{id: m.logStreamID})
+	m = newModel.(Model)
+	if cmd != nil || m.logView.State() != ui.LogViewStateEnded {
+		t.Error("reconnects should stop after maxLogReconnects attempts")
+	}
+}
+
+func TestUpdate_CrashLoopingPodShowsPreviousLogs(t *testing.T) {
+	m := makeReadyWithPods(New())
+	m.k8sClient = &k8s.Client{}
+	m.pods[0].Containers = []k8s.ContainerStatus{
+		{Name: "main", State: "Waiting", StateReason: "CrashLoopBackOff", RestartCount: 5},
+	}
+
+	m = typeKeys(m, "l")
+	if !m.logView.IsPrevious() {
+		t.Fatal("a crash-looping container should open its previous logs")
+	}
+	if view := m.View(); !containsString(view, "main (previous)") || !containsString(view, "previous instance") {
+		t.Errorf("expected a banner about the previous instance, got:\n%s", view)
+	}
+
+	// The previous instance has exited, so its end is final
+	newModel, cmd := m.Update(logStreamEndedMsg{id: m.logStreamID})
+	m = newModel.(Model)
+	if cmd != nil || m.logView.State() != ui.LogViewStateEnded {
+		t.Error("previous logs should end without reconnecting")
+	}
+
+	// Combined logs follow every container's current instance
+	m = typeKeys(m, "a")
+	if m.logView.IsPrevious() || containsString(m.View(), "previous instance") {
+		t.Error("combined logs should not show a previous instance")
+	}
+
+	// A running container streams its current logs
+	m = typeKeys(m, "a")
+	m.pods[0].Containers[0] = k8s.ContainerStatus{Name: "main", Ready: true, State: "Running"}
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = typeKeys(newModel.(Model), "l")
+	if m.CurrentView() != model.ViewLogs || m.logView.IsPrevious() {
+		t.Error("a running container should stream its current logs")
+	}
+}
+
+func TestUpdate_ExpandPodContainers(t *testing.T) {
+	m := makeReady(New())
+	m.loadingK8s = false
+	m.width = 160
+	m.pods = []k8s.PodInfo{
+		{
+			Name: "web", Namespace: "default", Status: k8s.PodStatusRunning,
+			InitContainers: []k8s.ContainerStatus{
+				{Name: "migrate", State: "Terminated", StateReason: "Completed", Image: "migrate:1"},
+			},
+			Containers: []k8s.ContainerStatus{
+				{Name: "app", Ready: true, State: "Running", RestartCount: 3, Image: "web:2",
+					ImageID:               "registry.example.com/web@sha256:4c0fdaa8b6341bfdeecb7fa8c6e9f0a1",
+					LastTerminationReason: "OOMKilled", LastTerminationExitCode: 137},
+				{Name: "proxy", State: "Waiting", StateReason: "CrashLoopBackOff", Image: "envoy:1"},
+			},
+		},
+		{Name: "worker", Namespace: "default", Status: k8s.PodStatusRunning},
+	}
+
+	if containsString(m.View(), "└ app") {
+		t.Fatal("containers should be hidden until the pod is expanded")
+	}
+
+	press := func(m Model, keyType tea.KeyType) Model {
+		newModel, _ := m.Update(tea.KeyMsg{Type: keyType})
+		return newModel.(Model)
+	}
+
+	m = press(m, tea.KeyEnter)
+	view := m.View()
+	for _, want := range []string{"└ migrate (init)", "└ app", "image web:2 (sha256:4c0fdaa8b634)",
+		"last OOMKilled (exit 137)", "Waiting: CrashLoopBackOff", "image envoy:1 (not pulled)"} {
+		if !containsString(view, want) {
+			t.Errorf("expanded pod should show %q, got:\n%s", want, view)
+		}
+	}
+
+	// The container rows sit right below the selected pod
+	lines := podListLines(t, m)
+	if !containsString(lines[2], "web") || !containsString(lines[3], "migrate") || !containsString(lines[6], "worker") {
+		t.Errorf("container rows should follow their pod, got:\n%s", strings.Join(lines, "\n"))
+	}
+
+	m = press(m, tea.KeyEnter)
+	if containsString(m.View(), "└ app") {
+		t.Error("enter again should collapse the pod")
+	}
+
+	// Moving the cursor collapses the expanded pod
+	m = press(m, tea.KeyEnter)
+	m = press(m, tea.KeyDown)
+	if m.podExpanded || containsString(m.View(), "└ app") {
+		t.Error("moving the selection should collapse the pod")
+	}
+}
+
+func TestUpdate_ExecInterrupt(t *testing.T) {
+	m := makeReadyWithPods(New())
+	m.k8sClient = &k8s.Client{}
+	m = typeKeys(m, "e")
+	m = typeKeys(m, "sleep 100")
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+	if cmd == nil || !m.execRunning {
+		t.Fatal("enter should start the command")
+	}
+	running := m.execID
+
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	m = newModel.(Model)
+	if cmd != nil {
+		t.Fatal("Ctrl+C should interrupt the command, not quit")
+	}
+	if m.execRunning || m.execCancel != nil || m.execView.State() == ui.ExecViewStateRunning {
+		t.Error("the exec should leave the running state")
+	}
+	if !containsString(m.View(), "[interrupted]") {
+		t.Errorf("expected an interrupted marker, got:\n%s", m.View())
+	}
+
+	// The cancelled command still resolves; its result is dropped
+	newModel, _ = m.Update(execResultMsg{id: running, result: k8s.ExecResult{Error: context.Canceled}})
+	m = newModel.(Model)
+	if m.execView.State() == ui.ExecViewStateError {
+		t.Error("the interrupted command's result should be ignored")
+	}
+
+	// With nothing running Ctrl+C quits as before
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	if cmd == nil {
+		t.Error("Ctrl+C should quit when no command is running")
+	}
+}
+
+func TestUpdate_ContextSelectorReload(t *testing.T) {
+	kubeconfigPath := filepath.Join(t.TempDir(), "config")
+	kubeconfig := `apiVersion: v1
+kind: Config
+current-context: dev
+clusters:
+- cluster:
+    server: https://dev.example.com:6443
+  name: dev-cluster
+contexts:
+- context:
+    cluster: dev-cluster
+    user: admin
+  name: dev
+users:
+- name: admin
+  user:
+    token: token
+`
+	if err := os.WriteFile(kubeconfigPath, []byte(kubeconfig), 0600); err != nil {
+		t.Fatalf("failed to write kubeconfig: %v", err)
+	}
+	client, err := k8s.NewClient(k8s.WithKubeconfig(kubeconfigPath))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	m := makeReadyWithPods(New())
+	m.k8sClient = client
+	m = typeKeys(m, "c")
+	newModel, _ := m.Update(m.loadContexts())
+	m = newModel.(Model)
+	if len(m.contexts) != 1 {
+		t.Fatalf("expected one context, got %+v", m.contexts)
+	}
+
+	// Another tool adds a context while the selector is open
+	updated := strings.Replace(kubeconfig, "contexts:\n", `contexts:
+- context:
+    cluster: dev-cluster
+    user: admin
+  name: staging
+`, 1)
+	if err := os.WriteFile(kubeconfigPath, []byte(updated), 0600); err != nil {
+		t.Fatalf("failed to write kubeconfig: %v", err)
+	}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'R'}})
+	m = newModel.(Model)
+	if cmd == nil {
+		t.Fatal("R should reload the kubeconfig from the context selector")
+	}
+	reloaded, ok := cmd().(configReloadedMsg)
+	if !ok || reloaded.err != nil {
+		t.Fatalf("expected a reloaded client, got %+v", reloaded)
+	}
+	newModel, _ = m.Update(reloaded)
+	m = newModel.(Model)
+	newModel, _ = m.Update(m.loadContexts())
+	m = newModel.(Model)
+
+	if m.CurrentView() != model.ViewContextSelector {
+		t.Errorf("the selector should stay open, got %v", m.CurrentView())
+	}
+	if !containsString(m.View(), "staging") {
+		t.Errorf("the selector should list the new context, got:\n%s", m.View())
+	}
+}
+
+func TestView_HelpListsCurrentViewKeys(t *testing.T) {
+	m := makeReadyWithPods(New())
+	m.k8sClient = &k8s.Client{}
+	m = typeKeys(m, "l")
+
+	m = typeKeys(m, "?")
+	view := m.View()
+	if !containsString(view, "Help: Log view") || !containsString(view, "follow") {
+		t.Errorf("help should list the log view keys, got:\n%s", view)
+	}
+	if containsString(view, "jump to pod") {
+		t.Error("help should not list pod list keys in the log view")
+	}
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewLogs {
+		t.Errorf("closing help should return to the log view, got %v", m.CurrentView())
+	}
+}
+
+func TestUpdate_HelpFromOverlayKeepsItsReturnView(t *testing.T) {
+	m := makeReadyWithServices(New())
+	m = typeKeys(m, "s")
+	if m.CurrentView() != model.ViewServices {
+		t.Fatalf("expected the services view, got %v", m.CurrentView())
+	}
+
+	m = typeKeys(m, "?")
+	if !containsString(m.View(), "Help: Services") {
+		t.Errorf("help should list the services keys, got:\n%s", m.View())
+	}
+	m = typeKeys(m, "x")
+	if m.CurrentView() != model.ViewServices {
+		t.Fatalf("closing help should return to the services view, got %v", m.CurrentView())
+	}
+
+	// The overlay still goes back to where it was opened from
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(Model)
+	if m.CurrentView() != model.ViewPodList {
+		t.Errorf("esc should close the services view, got %v", m.CurrentView())
+	}
+}
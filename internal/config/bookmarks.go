@@ -0,0 +1,61 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// BookmarksPath returns where file browser bookmarks are kept
+// (~/.config/k8s-tui/bookmarks)
+func BookmarksPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".config", "k8s-tui", "bookmarks"), nil
+}
+
+// LoadBookmarks reads bookmarked paths, one per line, skipping blank lines
+// and duplicates. A missing file is not an error and yields no bookmarks.
+func LoadBookmarks(path string) ([]string, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // Path is the user's own bookmarks file
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read bookmarks %s: %w", path, err)
+	}
+
+	var bookmarks []string
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || seen[line] {
+			continue
+		}
+		seen[line] = true
+		bookmarks = append(bookmarks, line)
+	}
+	return bookmarks, nil
+}
+
+// SaveBookmarks writes bookmarked paths one per line, creating the config
+// directory if needed
+func SaveBookmarks(path string, bookmarks []string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create bookmarks directory: %w", err)
+	}
+
+	var b strings.Builder
+	for _, bookmark := range bookmarks {
+		b.WriteString(bookmark)
+		b.WriteString("\n")
+	}
+	if err := os.WriteFile(path, []byte(b.String()), 0o600); err != nil {
+		return fmt.Errorf("failed to write bookmarks %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestBookmarksPath(t *testing.T) {
+	t.Setenv("HOME", "/home/test")
+
+	path, err := BookmarksPath()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if path != "/home/test/.config/k8s-tui/bookmarks" {
+		t.Errorf("unexpected path %q", path)
+	}
+}
+
+func TestLoadBookmarks_Missing(t *testing.T) {
+	bookmarks, err := LoadBookmarks(filepath.Join(t.TempDir(), "bookmarks"))
+	if err != nil {
+		t.Fatalf("a missing file should not be an error: %v", err)
+	}
+	if len(bookmarks) != 0 {
+		t.Errorf("expected no bookmarks, got %v", bookmarks)
+	}
+}
+
+func TestSaveBookmarks_RoundTrip(t *testing.T) {
+	// The config directory doesn't exist yet
+	path := filepath.Join(t.TempDir(), "k8s-tui", "bookmarks")
+	want := []string{"/var/log", "/etc/app"}
+
+	if err := SaveBookmarks(path, want); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("bookmarks file not written: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("expected mode 0600, got %o", perm)
+	}
+
+	got, err := LoadBookmarks(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	// Removing every bookmark leaves an empty file
+	if err := SaveBookmarks(path, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, _ := LoadBookmarks(path); len(got) != 0 {
+		t.Errorf("expected no bookmarks, got %v", got)
+	}
+}
+
+func TestLoadBookmarks_SkipsBlankAndDuplicateLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bookmarks")
+	if err := os.WriteFile(path, []byte("/var/log\n\n  /etc/app  \n/var/log\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := LoadBookmarks(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"/var/log", "/etc/app"}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
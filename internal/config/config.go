@@ -0,0 +1,176 @@
+// Package config loads user configuration for the TUI from a YAML file.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// ExecPreset is a named command that can be run from the exec view
+type ExecPreset struct {
+	Name    string `json:"name"`
+	Command string `json:"command"`
+}
+
+// LogLevel colors log lines matching Pattern, a regular expression, with
+// one of the log view's level styles: "error", "warn" or "dim"
+type LogLevel struct {
+	Pattern string `json:"pattern"`
+	Style   string `json:"style"`
+}
+
+// Config holds user configurable settings
+type Config struct {
+	// ExecPresets are commands offered in the exec view presets menu
+	ExecPresets []ExecPreset `json:"execPresets,omitempty"`
+
+	// HideCompleted hides Succeeded and Failed pods from the pod list on startup
+	HideCompleted bool `json:"hideCompleted,omitempty"`
+
+	// AlertOnFailure rings the terminal bell and highlights the status line
+	// when a pod fails, starts crash looping or restarts
+	AlertOnFailure bool `json:"alertOnFailure,omitempty"`
+
+	// StickySelection keeps the cursor on the same workload when switching
+	// namespaces, e.g. from the web deployment in dev to web in staging
+	StickySelection bool `json:"stickySelection,omitempty"`
+
+	// Timeout bounds API requests such as listing pods, e.g. "30s".
+	// Zero uses the client default of 10s.
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+
+	// ExecTimeout bounds commands run in containers, including file
+	// browsing and starting debug containers. Zero uses the client default
+	// of 30s.
+	ExecTimeout metav1.Duration `json:"execTimeout,omitempty"`
+
+	// LogTailLines is how many existing lines the log view starts with.
+	// Non-positive values use the default of 100, larger ones are capped
+	// at MaxLogTailLines.
+	LogTailLines int64 `json:"logTailLines,omitempty"`
+
+	// LogLevels color log lines by level when enabled in the log view; the
+	// first matching pattern wins
+	LogLevels []LogLevel `json:"logLevels,omitempty"`
+
+	// Keys overrides keybindings by action name (e.g. "logs": ["L"])
+	Keys map[string][]string `json:"keys,omitempty"`
+}
+
+// DefaultLogTailLines is how many log lines are shown when opening logs
+const DefaultLogTailLines = 100
+
+// MaxLogTailLines caps the log tail so a typo cannot fetch a huge backlog
+const MaxLogTailLines = 100000
+
+// Default returns the built-in configuration
+func Default() Config {
+	return Config{
+		ExecPresets: []ExecPreset{
+			{Name: "DNS config", Command: "cat /etc/resolv.conf"},
+			{Name: "Environment", Command: "env"},
+			{Name: "Disk usage", Command: "df -h"},
+			{Name: "Processes", Command: "ps aux"},
+		},
+		LogTailLines: DefaultLogTailLines,
+		LogLevels: []LogLevel{
+			{Pattern: `\b(ERROR|FATAL|panic)\b`, Style: "error"},
+			{Pattern: `\bWARN(ING)?\b`, Style: "warn"},
+			{Pattern: `\b(INFO|DEBUG)\b`, Style: "dim"},
+		},
+	}
+}
+
+// DefaultPath returns the default config file location (~/.config/k8s-tui/config.yaml)
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".config", "k8s-tui", "config.yaml"), nil
+}
+
+// Load reads the config file at path on top of the defaults.
+// A missing file is not an error and yields the default configuration.
+func Load(path string) (Config, error) {
+	cfg := Default()
+
+	data, err := os.ReadFile(path) //nolint:gosec // Path is the user's own config file
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return cfg, nil
+		}
+		return cfg, fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+
+	// Lists replace the defaults rather than being merged element-wise
+	defaultPresets, defaultLevels := cfg.ExecPresets, cfg.LogLevels
+	cfg.ExecPresets, cfg.LogLevels = nil, nil
+
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return Default(), fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+
+	if cfg.ExecPresets == nil {
+		cfg.ExecPresets = defaultPresets
+	}
+	cfg.ExecPresets = normalizePresets(cfg.ExecPresets)
+
+	if cfg.LogLevels == nil {
+		cfg.LogLevels = defaultLevels
+	}
+	cfg.LogLevels = normalizeLogLevels(cfg.LogLevels)
+
+	if cfg.LogTailLines <= 0 {
+		cfg.LogTailLines = DefaultLogTailLines
+	}
+	cfg.LogTailLines = min(cfg.LogTailLines, MaxLogTailLines)
+
+	return cfg, nil
+}
+
+// LoadDefault loads the config from DefaultPath
+func LoadDefault() (Config, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return Default(), err
+	}
+	return Load(path)
+}
+
+// normalizePresets drops presets without a command and fills in missing names
+func normalizePresets(presets []ExecPreset) []ExecPreset {
+	result := make([]ExecPreset, 0, len(presets))
+	for _, p := range presets {
+		if p.Command == "" {
+			continue
+		}
+		if p.Name == "" {
+			p.Name = p.Command
+		}
+		result = append(result, p)
+	}
+	return result
+}
+
+// normalizeLogLevels drops log level rules whose pattern is empty or not a
+// valid regular expression
+func normalizeLogLevels(levels []LogLevel) []LogLevel {
+	result := make([]LogLevel, 0, len(levels))
+	for _, l := range levels {
+		if l.Pattern == "" {
+			continue
+		}
+		if _, err := regexp.Compile(l.Pattern); err != nil {
+			continue
+		}
+		result = append(result, l)
+	}
+	return result
+}
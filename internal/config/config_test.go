@@ -0,0 +1,206 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	return path
+}
+
+func TestDefault(t *testing.T) {
+	cfg := Default()
+
+	if len(cfg.ExecPresets) == 0 {
+		t.Fatal("expected default exec presets")
+	}
+
+	for _, p := range cfg.ExecPresets {
+		if p.Name == "" || p.Command == "" {
+			t.Errorf("default preset should have name and command, got %+v", p)
+		}
+	}
+}
+
+func TestLoad_MissingFile(t *testing.T) {
+	cfg, err := Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	if err != nil {
+		t.Fatalf("missing file should not be an error, got %v", err)
+	}
+
+	if len(cfg.ExecPresets) != len(Default().ExecPresets) {
+		t.Errorf("expected default presets, got %d", len(cfg.ExecPresets))
+	}
+}
+
+func TestLoad_ExecPresets(t *testing.T) {
+	path := writeConfig(t, `
+execPresets:
+  - name: Resolver
+    command: cat /etc/resolv.conf
+  - command: df -h
+  - name: Broken
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(cfg.ExecPresets) != 2 {
+		t.Fatalf("expected 2 presets (empty command dropped), got %d", len(cfg.ExecPresets))
+	}
+
+	if cfg.ExecPresets[0].Name != "Resolver" || cfg.ExecPresets[0].Command != "cat /etc/resolv.conf" {
+		t.Errorf("unexpected first preset: %+v", cfg.ExecPresets[0])
+	}
+
+	// Name defaults to the command
+	if cfg.ExecPresets[1].Name != "df -h" {
+		t.Errorf("expected name to default to command, got %q", cfg.ExecPresets[1].Name)
+	}
+}
+
+func TestLoad_InvalidYAML(t *testing.T) {
+	path := writeConfig(t, "execPresets: [unclosed")
+
+	cfg, err := Load(path)
+	if err == nil {
+		t.Fatal("expected error for invalid YAML")
+	}
+
+	if len(cfg.ExecPresets) != len(Default().ExecPresets) {
+		t.Error("expected defaults to be returned on parse error")
+	}
+}
+
+func TestLoad_AlertOnFailure(t *testing.T) {
+	cfg, err := Load(writeConfig(t, "alertOnFailure: true\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cfg.AlertOnFailure {
+		t.Error("expected alertOnFailure to be loaded")
+	}
+	if Default().AlertOnFailure {
+		t.Error("alerts should be off by default")
+	}
+}
+
+func TestLoad_HideCompleted(t *testing.T) {
+	path := writeConfig(t, "hideCompleted: true\n")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !cfg.HideCompleted {
+		t.Error("expected hideCompleted to be loaded")
+	}
+	if len(cfg.ExecPresets) != len(Default().ExecPresets) {
+		t.Error("unset presets should keep the defaults")
+	}
+}
+
+func TestLoad_StickySelection(t *testing.T) {
+	path := writeConfig(t, "stickySelection: true\n")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !cfg.StickySelection {
+		t.Error("expected stickySelection to be loaded")
+	}
+	if Default().StickySelection {
+		t.Error("sticky selection should be opt-in")
+	}
+}
+
+func TestLoad_Timeouts(t *testing.T) {
+	path := writeConfig(t, "timeout: 30s\nexecTimeout: 2m\n")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Timeout.Duration != 30*time.Second {
+		t.Errorf("expected timeout 30s, got %v", cfg.Timeout.Duration)
+	}
+	if cfg.ExecTimeout.Duration != 2*time.Minute {
+		t.Errorf("expected exec timeout 2m, got %v", cfg.ExecTimeout.Duration)
+	}
+	if Default().Timeout.Duration != 0 {
+		t.Error("the default timeout should be left to the client")
+	}
+}
+
+func TestLoad_InvalidTimeout(t *testing.T) {
+	path := writeConfig(t, "timeout: soon\n")
+
+	if _, err := Load(path); err == nil {
+		t.Fatal("expected error for an invalid duration")
+	}
+}
+
+func TestLoad_LogTailLines(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    int64
+	}{
+		{"unset uses default", "hideCompleted: true\n", DefaultLogTailLines},
+		{"custom", "logTailLines: 5000\n", 5000},
+		{"negative uses default", "logTailLines: -10\n", DefaultLogTailLines},
+		{"capped", "logTailLines: 999999999\n", MaxLogTailLines},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := Load(writeConfig(t, tt.content))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cfg.LogTailLines != tt.want {
+				t.Errorf("expected logTailLines %d, got %d", tt.want, cfg.LogTailLines)
+			}
+		})
+	}
+}
+
+func TestLoad_LogLevels(t *testing.T) {
+	cfg, err := Load(writeConfig(t, "hideCompleted: true\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.LogLevels) != len(Default().LogLevels) {
+		t.Errorf("expected the default log levels, got %+v", cfg.LogLevels)
+	}
+
+	path := writeConfig(t, `
+logLevels:
+  - pattern: "(?i)level=error"
+    style: error
+  - pattern: "[unclosed"
+    style: warn
+  - style: dim
+`)
+	cfg, err = Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.LogLevels) != 1 || cfg.LogLevels[0].Pattern != "(?i)level=error" {
+		t.Errorf("expected the configured levels to replace the defaults, invalid ones dropped, got %+v", cfg.LogLevels)
+	}
+}
@@ -0,0 +1,18 @@
+package k8s
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// FormatAge renders how old a resource is the way kubectl does: seconds
+// under two minutes, then one or two units depending on size, e.g. "90s",
+// "5m30s", "3h15m", "3d4h", "2y70d". Negative ages from clock skew show
+// as "0s".
+func FormatAge(d time.Duration) string {
+	if d < 0 {
+		return "0s"
+	}
+	return duration.HumanDuration(d)
+}
@@ -0,0 +1,34 @@
+package k8s
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatAge(t *testing.T) {
+	day := 24 * time.Hour
+	tests := []struct {
+		age  time.Duration
+		want string
+	}{
+		{-5 * time.Second, "0s"},
+		{0, "0s"},
+		{45 * time.Second, "45s"},
+		{90 * time.Second, "90s"},
+		{5*time.Minute + 30*time.Second, "5m30s"},
+		{5 * time.Minute, "5m"},
+		{90 * time.Minute, "90m"},
+		{3*time.Hour + 15*time.Minute, "3h15m"},
+		{25 * time.Hour, "25h"},
+		{3 * day, "3d"},
+		{3*day + 4*time.Hour, "3d4h"},
+		{100 * day, "100d"},
+		{800 * day, "2y70d"},
+		{3000 * day, "8y"},
+	}
+	for _, tt := range tests {
+		if got := FormatAge(tt.age); got != tt.want {
+			t.Errorf("FormatAge(%v) = %q, want %q", tt.age, got, tt.want)
+		}
+	}
+}
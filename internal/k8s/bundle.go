@@ -0,0 +1,232 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultBundleLogLines is the number of log lines per container in a bundle
+const DefaultBundleLogLines = 200
+
+// BundleParts are the pieces gathered for a troubleshooting bundle
+type BundleParts struct {
+	Pod       *corev1.Pod
+	Events    []EventInfo
+	EventsErr error // Set when events couldn't be listed
+	Logs      []ContainerLogs
+	TailLines int64
+	Collected time.Time
+}
+
+// ContainerLogs holds the recent log lines of one container
+type ContainerLogs struct {
+	Container string
+	Lines     []string
+	Err       error // Set when the logs couldn't be fetched
+}
+
+// CollectBundle gathers the pod, its events and the last tailLines log lines
+// of each container. Only a missing pod is an error; event and log failures
+// are recorded in the parts so the rest of the bundle is still useful.
+func (c *Client) CollectBundle(ctx context.Context, namespace, name string, tailLines int64) (BundleParts, error) {
+	if namespace == "" {
+		namespace = c.currentNamespace
+	}
+
+	pod, err := c.clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return BundleParts{}, fmt.Errorf("failed to get pod %q in namespace %q: %w", name, namespace, err)
+	}
+
+	parts := BundleParts{
+		Pod:       pod,
+		TailLines: tailLines,
+		Collected: time.Now(),
+	}
+
+	parts.Events, parts.EventsErr = c.ListPodEvents(ctx, namespace, name)
+
+	for i := range pod.Spec.Containers {
+		container := pod.Spec.Containers[i].Name
+		logs := ContainerLogs{Container: container}
+
+		raw, err := c.clientset.CoreV1().Pods(namespace).GetLogs(name, &corev1.PodLogOptions{
+			Container: container,
+			TailLines: &tailLines,
+		}).DoRaw(ctx)
+		if err != nil {
+			logs.Err = fmt.Errorf("failed to get logs: %w", err)
+		} else if text := strings.TrimRight(string(raw), "\n"); text != "" {
+			logs.Lines = strings.Split(text, "\n")
+		}
+
+		parts.Logs = append(parts.Logs, logs)
+	}
+
+	return parts, nil
+}
+
+// BuildBundle renders the bundle parts as plain text. Secret env values are
+// never included: secret references show only their source, and literal
+// values with sensitive-looking names are redacted.
+func BuildBundle(parts BundleParts) string {
+	var b strings.Builder
+	pod := parts.Pod
+
+	fmt.Fprintf(&b, "=== Troubleshooting bundle: %s/%s ===\n", pod.Namespace, pod.Name)
+	fmt.Fprintf(&b, "Collected: %s\n\n", parts.Collected.Format(time.RFC3339))
+
+	b.WriteString("=== Describe ===\n")
+	b.WriteString(describePod(pod))
+	b.WriteString("\n")
+
+	b.WriteString("=== Events ===\n")
+	switch {
+	case parts.EventsErr != nil:
+		fmt.Fprintf(&b, "Error: %v\n", parts.EventsErr)
+	case len(parts.Events) == 0:
+		b.WriteString("(none)\n")
+	default:
+		for _, ev := range parts.Events {
+			fmt.Fprintf(&b, "%s  %-8s %-20s x%d  %s\n",
+				ev.LastSeen.Format(time.RFC3339), ev.Type, ev.Reason, ev.Count, ev.Message)
+		}
+	}
+
+	for _, logs := range parts.Logs {
+		fmt.Fprintf(&b, "\n=== Logs: %s (last %d lines) ===\n", logs.Container, parts.TailLines)
+		switch {
+		case logs.Err != nil:
+			fmt.Fprintf(&b, "Error: %v\n", logs.Err)
+		case len(logs.Lines) == 0:
+			b.WriteString("(no output)\n")
+		default:
+			b.WriteString(strings.Join(logs.Lines, "\n"))
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String()
+}
+
+// describePod renders the key pod and container fields, similar to kubectl describe
+func describePod(pod *corev1.Pod) string {
+	var b strings.Builder
+
+	status, reason := determinePodStatus(pod)
+	if reason != "" {
+		status = PodStatus(fmt.Sprintf("%s (%s)", status, reason))
+	}
+
+	fmt.Fprintf(&b, "Name:        %s\n", pod.Name)
+	fmt.Fprintf(&b, "Namespace:   %s\n", pod.Namespace)
+	fmt.Fprintf(&b, "Node:        %s\n", pod.Spec.NodeName)
+	fmt.Fprintf(&b, "Status:      %s\n", status)
+	fmt.Fprintf(&b, "IP:          %s\n", pod.Status.PodIP)
+	if pod.Status.StartTime != nil {
+		fmt.Fprintf(&b, "Start Time:  %s\n", pod.Status.StartTime.Format(time.RFC3339))
+	}
+	fmt.Fprintf(&b, "QoS Class:   %s\n", pod.Status.QOSClass)
+	fmt.Fprintf(&b, "Labels:      %s\n", formatLabels(pod.Labels))
+
+	if len(pod.Status.Conditions) > 0 {
+		b.WriteString("Conditions:\n")
+		for _, cond := range pod.Status.Conditions {
+			fmt.Fprintf(&b, "  %s: %s", cond.Type, cond.Status)
+			if cond.Reason != "" {
+				fmt.Fprintf(&b, " (%s)", cond.Reason)
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	statuses := make(map[string]corev1.ContainerStatus, len(pod.Status.ContainerStatuses))
+	for _, cs := range pod.Status.ContainerStatuses {
+		statuses[cs.Name] = cs
+	}
+
+	b.WriteString("Containers:\n")
+	for i := range pod.Spec.Containers {
+		container := &pod.Spec.Containers[i]
+		fmt.Fprintf(&b, "  %s:\n", container.Name)
+		fmt.Fprintf(&b, "    Image:     %s\n", container.Image)
+
+		if cs, ok := statuses[container.Name]; ok {
+			state, stateReason := parseContainerState(cs.State)
+			if stateReason != "" {
+				state = fmt.Sprintf("%s (%s)", state, stateReason)
+			}
+			fmt.Fprintf(&b, "    State:     %s\n", state)
+			fmt.Fprintf(&b, "    Ready:     %t\n", cs.Ready)
+			fmt.Fprintf(&b, "    Restarts:  %d\n", cs.RestartCount)
+		}
+
+		if len(container.Resources.Requests) > 0 {
+			fmt.Fprintf(&b, "    Requests:  %s\n", formatResourceList(container.Resources.Requests))
+		}
+		if len(container.Resources.Limits) > 0 {
+			fmt.Fprintf(&b, "    Limits:    %s\n", formatResourceList(container.Resources.Limits))
+		}
+
+		if len(container.Env) > 0 {
+			b.WriteString("    Env:\n")
+			for _, env := range container.Env {
+				fmt.Fprintf(&b, "      %s=%s\n", env.Name, redactEnvValue(env))
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// redactEnvValue returns an env var's value safe for sharing
+func redactEnvValue(env corev1.EnvVar) string {
+	if src := env.ValueFrom; src != nil {
+		switch {
+		case src.SecretKeyRef != nil:
+			return fmt.Sprintf("<secret %s/%s>", src.SecretKeyRef.Name, src.SecretKeyRef.Key)
+		case src.ConfigMapKeyRef != nil:
+			return fmt.Sprintf("<configmap %s/%s>", src.ConfigMapKeyRef.Name, src.ConfigMapKeyRef.Key)
+		case src.FieldRef != nil:
+			return fmt.Sprintf("<field %s>", src.FieldRef.FieldPath)
+		case src.ResourceFieldRef != nil:
+			return fmt.Sprintf("<resource %s>", src.ResourceFieldRef.Resource)
+		}
+		return "<from source>"
+	}
+
+	if SensitiveEnvName(env.Name) {
+		return "<redacted>"
+	}
+	return env.Value
+}
+
+// formatLabels renders labels as sorted key=value pairs
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return "<none>"
+	}
+
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
+
+// formatResourceList renders resource quantities as sorted name=value pairs
+func formatResourceList(resources corev1.ResourceList) string {
+	pairs := make([]string, 0, len(resources))
+	for name, q := range resources {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", name, q.String()))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
@@ -0,0 +1,156 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func createBundleTestPod() *corev1.Pod {
+	pod := createTestPod("web-1", "default", corev1.PodRunning, true)
+	pod.Labels = map[string]string{"app": "web", "tier": "frontend"}
+	pod.Spec.Containers[0].Image = "nginx:1.25"
+	pod.Spec.Containers[0].Resources.Requests = corev1.ResourceList{
+		corev1.ResourceCPU: resource.MustParse("100m"),
+	}
+	pod.Spec.Containers[0].Env = []corev1.EnvVar{
+		{Name: "LOG_LEVEL", Value: "debug"},
+		{Name: "DB_PASSWORD", Value: "hunter2"},
+		{Name: "API_TOKEN", ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			},
+		}},
+	}
+	return pod
+}
+
+func TestBuildBundle(t *testing.T) {
+	parts := BundleParts{
+		Pod: createBundleTestPod(),
+		Events: []EventInfo{
+			{Type: "Warning", Reason: "BackOff", Message: "Back-off restarting failed container", Count: 3},
+		},
+		Logs: []ContainerLogs{
+			{Container: "main", Lines: []string{"starting", "listening on :8080"}},
+			{Container: "sidecar", Err: errors.New("container not started")},
+		},
+		TailLines: 200,
+		Collected: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	bundle := BuildBundle(parts)
+
+	expected := []string{
+		"=== Troubleshooting bundle: default/web-1 ===",
+		"Collected: 2024-01-01T12:00:00Z",
+		"=== Describe ===",
+		"Status:      Running",
+		"Labels:      app=web, tier=frontend",
+		"Image:     nginx:1.25",
+		"Requests:  cpu=100m",
+		"LOG_LEVEL=debug",
+		"DB_PASSWORD=<redacted>",
+		"API_TOKEN=<secret api/token>",
+		"=== Events ===",
+		"BackOff",
+		"x3  Back-off restarting failed container",
+		"=== Logs: main (last 200 lines) ===\nstarting\nlistening on :8080",
+		"=== Logs: sidecar (last 200 lines) ===\nError: container not started",
+	}
+	for _, want := range expected {
+		if !strings.Contains(bundle, want) {
+			t.Errorf("bundle should contain %q, got:\n%s", want, bundle)
+		}
+	}
+
+	if strings.Contains(bundle, "hunter2") {
+		t.Error("bundle must not contain secret values")
+	}
+}
+
+func TestBuildBundle_NoEvents(t *testing.T) {
+	bundle := BuildBundle(BundleParts{Pod: createBundleTestPod()})
+	if !strings.Contains(bundle, "=== Events ===\n(none)") {
+		t.Errorf("expected empty events marker, got:\n%s", bundle)
+	}
+
+	bundle = BuildBundle(BundleParts{Pod: createBundleTestPod(), EventsErr: errors.New("forbidden")})
+	if !strings.Contains(bundle, "=== Events ===\nError: forbidden") {
+		t.Errorf("expected events error, got:\n%s", bundle)
+	}
+}
+
+func TestRedactEnvValue(t *testing.T) {
+	tests := []struct {
+		env  corev1.EnvVar
+		want string
+	}{
+		{corev1.EnvVar{Name: "PORT", Value: "8080"}, "8080"},
+		{corev1.EnvVar{Name: "aws_secret_access_key", Value: "abc"}, "<redacted>"},
+		{corev1.EnvVar{Name: "GITHUB_TOKEN", Value: "ghp_x"}, "<redacted>"},
+		{corev1.EnvVar{Name: "BASIC_AUTH", Value: "user:pass"}, "<redacted>"},
+		{corev1.EnvVar{Name: "CONFIG", ValueFrom: &corev1.EnvVarSource{
+			ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "app"},
+				Key:                  "config",
+			},
+		}}, "<configmap app/config>"},
+		{corev1.EnvVar{Name: "POD_IP", ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{FieldPath: "status.podIP"},
+		}}, "<field status.podIP>"},
+	}
+
+	for _, tt := range tests {
+		if got := redactEnvValue(tt.env); got != tt.want {
+			t.Errorf("redactEnvValue(%s) = %q, want %q", tt.env.Name, got, tt.want)
+		}
+	}
+}
+
+func TestClient_CollectBundle(t *testing.T) {
+	now := time.Now()
+	client := &Client{
+		clientset: fake.NewClientset(
+			createBundleTestPod(),
+			createTestEvent("e1", "web-1", "BackOff", now),
+		),
+		currentNamespace: "default",
+	}
+
+	parts, err := client.CollectBundle(context.Background(), "", "web-1", 50)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if parts.Pod.Name != "web-1" {
+		t.Errorf("expected pod web-1, got %q", parts.Pod.Name)
+	}
+	if len(parts.Events) != 1 {
+		t.Errorf("expected 1 event, got %d", len(parts.Events))
+	}
+	if len(parts.Logs) != 1 || parts.Logs[0].Container != "main" {
+		t.Fatalf("expected logs for container main, got %+v", parts.Logs)
+	}
+	if parts.Logs[0].Err != nil || len(parts.Logs[0].Lines) == 0 {
+		t.Errorf("expected log lines from the fake client, got %+v", parts.Logs[0])
+	}
+	if parts.TailLines != 50 {
+		t.Errorf("expected tail lines 50, got %d", parts.TailLines)
+	}
+}
+
+func TestClient_CollectBundle_PodNotFound(t *testing.T) {
+	client := &Client{clientset: fake.NewClientset(), currentNamespace: "default"}
+
+	if _, err := client.CollectBundle(context.Background(), "", "missing", 50); err == nil {
+		t.Error("expected error for missing pod")
+	}
+}
@@ -3,9 +3,13 @@
 package k8s
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -22,15 +26,58 @@ type Client struct {
 	kubeconfigPath   string
 	currentContext   string
 	currentNamespace string
+	inCluster        bool
+	timeout          time.Duration
+	execTimeout      time.Duration
+	readOnly         bool
+
+	// Controllers of ReplicaSets seen so far, to show pod owners
+	owners ownerCache
+
+	// Shell and file utilities found in each container browsed so far
+	shells shellCache
 }
 
+// InClusterContext is the context name reported when using the pod's service account
+const InClusterContext = "(in-cluster)"
+
+// ErrContextSwitchUnavailable is returned when switching contexts without a kubeconfig
+var ErrContextSwitchUnavailable = errors.New("context switching is not available with in-cluster configuration")
+
+// ErrNoCurrentContext is returned when the kubeconfig sets no current
+// context and none was requested, so one has to be chosen first
+var ErrNoCurrentContext = errors.New("no current context set in kubeconfig")
+
+// ErrReadOnly is returned by operations that would change the cluster or the
+// kubeconfig when the client was created WithReadOnly
+var ErrReadOnly = errors.New("read-only mode")
+
+// Default timeouts for API requests and for commands run in containers
+const (
+	DefaultTimeout     = 10 * time.Second
+	DefaultExecTimeout = 30 * time.Second
+)
+
+// serviceAccountNamespaceFile holds the namespace of the pod's service
+// account, mounted next to its credentials
+const serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
 // ClientOption allows configuring the client
 type ClientOption func(*clientOptions)
 
 type clientOptions struct {
-	kubeconfig string
-	context    string
-	namespace  string
+	kubeconfig  string
+	context     string
+	namespace   string
+	inCluster   bool
+	timeout     time.Duration
+	execTimeout time.Duration
+	readOnly    bool
+
+	// Builds the in-cluster config and names the service account's
+	// namespace file; replaced in tests
+	inClusterConfig func() (*rest.Config, error)
+	namespaceFile   string
 }
 
 // WithKubeconfig sets a custom kubeconfig path
@@ -54,13 +101,51 @@ func WithNamespace(ns string) ClientOption {
 	}
 }
 
-// NewClient creates a new Kubernetes client
+// WithInCluster uses the pod's service account instead of a kubeconfig
+func WithInCluster() ClientOption {
+	return func(o *clientOptions) {
+		o.inCluster = true
+	}
+}
+
+// WithTimeout bounds API requests such as listing pods. Zero uses DefaultTimeout.
+func WithTimeout(d time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		o.timeout = d
+	}
+}
+
+// WithExecTimeout bounds commands run in containers, including file
+// browsing. Zero uses DefaultExecTimeout.
+func WithExecTimeout(d time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		o.execTimeout = d
+	}
+}
+
+// WithReadOnly refuses every operation that would change the cluster or the
+// kubeconfig, such as scaling, restarting or writing files
+func WithReadOnly() ClientOption {
+	return func(o *clientOptions) {
+		o.readOnly = true
+	}
+}
+
+// NewClient creates a new Kubernetes client. Without a kubeconfig it falls
+// back to in-cluster configuration when running inside a pod.
 func NewClient(opts ...ClientOption) (*Client, error) {
-	options := &clientOptions{}
+	options := &clientOptions{
+		inClusterConfig: rest.InClusterConfig,
+		namespaceFile:   serviceAccountNamespaceFile,
+	}
 	for _, opt := range opts {
 		opt(options)
 	}
 
+	if options.inCluster {
+		return newInClusterClient(options)
+	}
+
 	// Determine kubeconfig path
 	kubeconfigPath := options.kubeconfig
 	if kubeconfigPath == "" {
@@ -75,14 +160,15 @@ func NewClient(opts ...ClientOption) (*Client, error) {
 	}
 
 	// Check if kubeconfig exists
-	if _, err := os.Stat(kubeconfigPath); os.IsNotExist(err) {
+	if !kubeconfigExists(kubeconfigPath) {
+		if options.kubeconfig == "" && inClusterEnvPresent() {
+			return newInClusterClient(options)
+		}
 		return nil, fmt.Errorf("kubeconfig not found at %s", kubeconfigPath)
 	}
 
 	// Build config loader with overrides
-	loadingRules := &clientcmd.ClientConfigLoadingRules{
-		ExplicitPath: kubeconfigPath,
-	}
+	loadingRules := kubeconfigLoadingRules(kubeconfigPath)
 	configOverrides := &clientcmd.ConfigOverrides{}
 	if options.context != "" {
 		configOverrides.CurrentContext = options.context
@@ -108,8 +194,12 @@ func NewClient(opts ...ClientOption) (*Client, error) {
 		currentContext = options.context
 	}
 
+	if currentContext == "" {
+		return nil, ErrNoCurrentContext
+	}
+
 	// Validate context exists
-	if _, exists := rawConfig.Contexts[currentContext]; !exists && currentContext != "" {
+	if _, exists := rawConfig.Contexts[currentContext]; !exists {
 		return nil, fmt.Errorf("context %q not found in kubeconfig", currentContext)
 	}
 
@@ -142,9 +232,83 @@ func NewClient(opts ...ClientOption) (*Client, error) {
 		kubeconfigPath:   kubeconfigPath,
 		currentContext:   currentContext,
 		currentNamespace: namespace,
+		timeout:          options.timeout,
+		execTimeout:      options.execTimeout,
+		readOnly:         options.readOnly,
 	}, nil
 }
 
+// newInClusterClient creates a client from the mounted service account
+func newInClusterClient(options *clientOptions) (*Client, error) {
+	restConfig, err := options.inClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load in-cluster config: %w", err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	namespace := options.namespace
+	if namespace == "" {
+		namespace = "default"
+		data, err := os.ReadFile(options.namespaceFile) //nolint:gosec // Fixed service account path
+		if err == nil && strings.TrimSpace(string(data)) != "" {
+			namespace = strings.TrimSpace(string(data))
+		}
+	}
+
+	return &Client{
+		clientset:        clientset,
+		config:           restConfig,
+		currentContext:   InClusterContext,
+		currentNamespace: namespace,
+		inCluster:        true,
+		timeout:          options.timeout,
+		execTimeout:      options.execTimeout,
+		readOnly:         options.readOnly,
+	}, nil
+}
+
+// inClusterEnvPresent reports whether the Kubernetes service env vars are set
+func inClusterEnvPresent() bool {
+	return os.Getenv("KUBERNETES_SERVICE_HOST") != "" && os.Getenv("KUBERNETES_SERVICE_PORT") != ""
+}
+
+// kubeconfigLoadingRules loads path the way kubectl does: a single file is
+// read as given, while a KUBECONFIG-style list is merged with earlier files
+// taking precedence
+func kubeconfigLoadingRules(path string) *clientcmd.ClientConfigLoadingRules {
+	paths := kubeconfigPaths(path)
+	if len(paths) == 1 {
+		return &clientcmd.ClientConfigLoadingRules{ExplicitPath: paths[0]}
+	}
+	return &clientcmd.ClientConfigLoadingRules{Precedence: paths}
+}
+
+// kubeconfigPaths splits a path list on the OS separator, dropping empty
+// entries
+func kubeconfigPaths(path string) []string {
+	var paths []string
+	for _, p := range filepath.SplitList(path) {
+		if p != "" {
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
+
+// kubeconfigExists reports whether any file of a kubeconfig path list exists
+func kubeconfigExists(path string) bool {
+	for _, p := range kubeconfigPaths(path) {
+		if _, err := os.Stat(p); !os.IsNotExist(err) {
+			return true
+		}
+	}
+	return false
+}
+
 // Clientset returns the underlying kubernetes clientset
 func (c *Client) Clientset() kubernetes.Interface {
 	return c.clientset
@@ -165,7 +329,105 @@ func (c *Client) SetNamespace(namespace string) {
 	c.currentNamespace = namespace
 }
 
+// ReloadConfig re-reads the kubeconfig and returns a fresh client for the
+// current context and namespace. If the context was removed from the
+// kubeconfig, the kubeconfig's current context is used instead.
+func (c *Client) ReloadConfig() (*Client, error) {
+	inherited := c.inheritedOptions()
+	if c.inCluster {
+		return NewClient(append(inherited, WithInCluster(), WithNamespace(c.currentNamespace))...)
+	}
+
+	client, err := NewClient(append(inherited,
+		WithKubeconfig(c.kubeconfigPath),
+		WithContext(c.currentContext),
+		WithNamespace(c.currentNamespace),
+	)...)
+	if err == nil {
+		return client, nil
+	}
+
+	// Fall back to the kubeconfig defaults if the context no longer exists
+	fallback, fallbackErr := NewClient(append(inherited, WithKubeconfig(c.kubeconfigPath))...)
+	if fallbackErr != nil {
+		return nil, err
+	}
+	return fallback, nil
+}
+
+// inheritedOptions carries the timeouts and read-only mode over to clients
+// derived from this one
+func (c *Client) inheritedOptions() []ClientOption {
+	opts := []ClientOption{WithTimeout(c.timeout), WithExecTimeout(c.execTimeout)}
+	if c.readOnly {
+		opts = append(opts, WithReadOnly())
+	}
+	return opts
+}
+
+// Timeout returns how long API requests may take
+func (c *Client) Timeout() time.Duration {
+	if c.timeout <= 0 {
+		return DefaultTimeout
+	}
+	return c.timeout
+}
+
+// ExecTimeout returns how long commands run in containers may take
+func (c *Client) ExecTimeout() time.Duration {
+	if c.execTimeout <= 0 {
+		return DefaultExecTimeout
+	}
+	return c.execTimeout
+}
+
+// RequestContext returns a context bounded by the API request timeout
+func (c *Client) RequestContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), c.Timeout())
+}
+
+// ExecContext returns a context bounded by the exec timeout
+func (c *Client) ExecContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), c.ExecTimeout())
+}
+
+// InCluster reports whether the client uses in-cluster configuration
+func (c *Client) InCluster() bool {
+	return c.inCluster
+}
+
+// KubeconfigPath returns the kubeconfig the client was loaded from; empty
+// with in-cluster configuration. It may list several files separated by
+// the path list separator, as KUBECONFIG does.
+func (c *Client) KubeconfigPath() string {
+	if c.inCluster {
+		return ""
+	}
+	return c.kubeconfigPath
+}
+
+// ServerURL returns the address of the API server the client talks to
+func (c *Client) ServerURL() string {
+	if c.config == nil {
+		return ""
+	}
+	return c.config.Host
+}
+
 // RawConfig returns the raw kubeconfig for inspection
 func (c *Client) RawConfig() api.Config {
 	return c.rawConfig
 }
+
+// ReadOnly reports whether the client refuses to change the cluster
+func (c *Client) ReadOnly() bool {
+	return c.readOnly
+}
+
+// checkWritable returns an error naming action in read-only mode
+func (c *Client) checkWritable(action string) error {
+	if c.readOnly {
+		return fmt.Errorf("cannot %s: %w", action, ErrReadOnly)
+	}
+	return nil
+}
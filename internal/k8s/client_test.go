@@ -1,9 +1,17 @@
 package k8s
 
 import (
+	"context"
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
 )
 
 func TestNewClient_NoKubeconfig(t *testing.T) {
@@ -13,6 +21,7 @@ func TestNewClient_NoKubeconfig(t *testing.T) {
 	// Override HOME to use temp dir - t.Setenv automatically restores on cleanup
 	t.Setenv("HOME", tmpDir)
 	t.Setenv("KUBECONFIG", "")
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
 
 	_, err := NewClient()
 	if err == nil {
@@ -25,6 +34,11 @@ func TestNewClient_InvalidKubeconfigPath(t *testing.T) {
 	if err == nil {
 		t.Error("expected error for nonexistent kubeconfig path")
 	}
+
+	list := "/nonexistent/a" + string(os.PathListSeparator) + "/nonexistent/b"
+	if _, err := NewClient(WithKubeconfig(list)); err == nil {
+		t.Error("expected error when no file in the kubeconfig list exists")
+	}
 }
 
 func TestNewClient_WithValidKubeconfig(t *testing.T) {
@@ -200,6 +214,53 @@ users:
 	}
 }
 
+func TestNewClient_NoCurrentContext(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+
+	kubeconfig := `
+apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://localhost:6443
+    insecure-skip-tls-verify: true
+  name: test-cluster
+contexts:
+- context:
+    cluster: test-cluster
+    user: test-user
+    namespace: apps
+  name: test-context
+users:
+- name: test-user
+  user:
+    token: test-token
+`
+	if err := os.WriteFile(kubeconfigPath, []byte(kubeconfig), 0o600); err != nil {
+		t.Fatalf("failed to write test kubeconfig: %v", err)
+	}
+
+	client, err := NewClient(WithKubeconfig(kubeconfigPath))
+	if !errors.Is(err, ErrNoCurrentContext) || client != nil {
+		t.Fatalf("expected ErrNoCurrentContext, got client=%v err=%v", client, err)
+	}
+
+	// The contexts can still be listed and chosen
+	contexts, current, err := ListContextsFromConfig(kubeconfigPath)
+	if err != nil || current != "" || len(contexts) != 1 || contexts[0].IsCurrent {
+		t.Fatalf("expected one non-current context, got %+v, %q, %v", contexts, current, err)
+	}
+
+	client, err = NewClient(WithKubeconfig(kubeconfigPath), WithContext("test-context"))
+	if err != nil {
+		t.Fatalf("choosing a context should work, got %v", err)
+	}
+	if client.CurrentContext() != "test-context" || client.CurrentNamespace() != "apps" {
+		t.Errorf("expected test-context in apps, got %q in %q", client.CurrentContext(), client.CurrentNamespace())
+	}
+}
+
 func TestClient_SetNamespace(t *testing.T) {
 	tmpDir := t.TempDir()
 	kubeconfigPath := filepath.Join(tmpDir, "config")
@@ -276,3 +337,290 @@ users:
 		t.Error("expected non-nil clientset")
 	}
 }
+
+// withFakeInCluster stands in for the service account mount: the config is
+// built from a fake token file and the namespace read from dir
+func withFakeInCluster(dir string) ClientOption {
+	return func(o *clientOptions) {
+		o.inClusterConfig = func() (*rest.Config, error) {
+			tokenFile := filepath.Join(dir, "token")
+			if _, err := os.Stat(tokenFile); err != nil {
+				return nil, err
+			}
+			return &rest.Config{Host: "https://10.0.0.1:443", BearerTokenFile: tokenFile}, nil
+		}
+		o.namespaceFile = filepath.Join(dir, "namespace")
+	}
+}
+
+// setupInCluster fakes the service account mount and service env vars
+func setupInCluster(t *testing.T, namespace string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "token"), []byte("test-token"), 0o600); err != nil {
+		t.Fatalf("failed to write token: %v", err)
+	}
+	if namespace != "" {
+		if err := os.WriteFile(filepath.Join(dir, "namespace"), []byte(namespace+"\n"), 0o600); err != nil {
+			t.Fatalf("failed to write namespace: %v", err)
+		}
+	}
+
+	t.Setenv("KUBERNETES_SERVICE_HOST", "10.0.0.1")
+	t.Setenv("KUBERNETES_SERVICE_PORT", "443")
+	return dir
+}
+
+func TestNewClient_WithInCluster(t *testing.T) {
+	dir := setupInCluster(t, "team-a")
+
+	client, err := NewClient(WithInCluster(), withFakeInCluster(dir))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !client.InCluster() {
+		t.Error("expected in-cluster client")
+	}
+	if client.CurrentContext() != InClusterContext {
+		t.Errorf("expected context %q, got %q", InClusterContext, client.CurrentContext())
+	}
+	if client.CurrentNamespace() != "team-a" {
+		t.Errorf("expected namespace from service account, got %q", client.CurrentNamespace())
+	}
+	if client.ServerURL() != "https://10.0.0.1:443" {
+		t.Errorf("unexpected host %q", client.ServerURL())
+	}
+	if client.KubeconfigPath() != "" {
+		t.Errorf("expected no kubeconfig in-cluster, got %q", client.KubeconfigPath())
+	}
+	if client.config.BearerTokenFile != filepath.Join(dir, "token") {
+		t.Errorf("unexpected token file %q", client.config.BearerTokenFile)
+	}
+}
+
+func TestNewClient_FallsBackToInCluster(t *testing.T) {
+	dir := setupInCluster(t, "")
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("KUBECONFIG", "")
+
+	client, err := NewClient(WithNamespace("override"), withFakeInCluster(dir))
+	if err != nil {
+		t.Fatalf("expected in-cluster fallback without kubeconfig, got %v", err)
+	}
+
+	if !client.InCluster() {
+		t.Error("expected in-cluster client")
+	}
+	if client.CurrentNamespace() != "override" {
+		t.Errorf("namespace option should win, got %q", client.CurrentNamespace())
+	}
+}
+
+func TestNewClient_InClusterMissingToken(t *testing.T) {
+	dir := setupInCluster(t, "")
+	if err := os.Remove(filepath.Join(dir, "token")); err != nil {
+		t.Fatalf("failed to remove token: %v", err)
+	}
+
+	if _, err := NewClient(WithInCluster(), withFakeInCluster(dir)); err == nil {
+		t.Error("expected error without a service account token")
+	}
+}
+
+func TestClient_InClusterContexts(t *testing.T) {
+	dir := setupInCluster(t, "team-a")
+
+	client, err := NewClient(WithInCluster(), withFakeInCluster(dir))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	contexts := client.ListContexts()
+	if len(contexts) != 1 || contexts[0].Name != InClusterContext || !contexts[0].IsCurrent {
+		t.Errorf("expected a single current in-cluster context, got %+v", contexts)
+	}
+
+	if _, err := client.ForContext("other"); !errors.Is(err, ErrContextSwitchUnavailable) {
+		t.Errorf("expected ErrContextSwitchUnavailable, got %v", err)
+	}
+}
+
+func TestClient_ReloadConfig(t *testing.T) {
+	kubeconfigPath := createTestKubeconfig(t)
+
+	client, err := NewClient(WithKubeconfig(kubeconfigPath), WithContext("context-alpha"))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	client.SetNamespace("custom-ns")
+
+	// Add a context to the kubeconfig after the client was created
+	data, err := os.ReadFile(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("failed to read kubeconfig: %v", err)
+	}
+	updated := strings.Replace(string(data), "contexts:\n", `contexts:
+- context:
+    cluster: cluster-1
+    user: user-1
+  name: context-new
+`, 1)
+	if err := os.WriteFile(kubeconfigPath, []byte(updated), 0o600); err != nil {
+		t.Fatalf("failed to write kubeconfig: %v", err)
+	}
+
+	reloaded, err := client.ReloadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if reloaded.CurrentContext() != "context-alpha" {
+		t.Errorf("expected context to be kept, got %q", reloaded.CurrentContext())
+	}
+	if reloaded.CurrentNamespace() != "custom-ns" {
+		t.Errorf("expected namespace to be kept, got %q", reloaded.CurrentNamespace())
+	}
+	if _, err := reloaded.GetContextInfo("context-new"); err != nil {
+		t.Errorf("reloaded client should see the new context: %v", err)
+	}
+}
+
+func TestClient_ReloadConfig_ContextRemoved(t *testing.T) {
+	kubeconfigPath := createTestKubeconfig(t)
+
+	client, err := NewClient(WithKubeconfig(kubeconfigPath))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	client.currentContext = "deleted-context"
+
+	reloaded, err := client.ReloadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if reloaded.CurrentContext() != "context-beta" {
+		t.Errorf("expected fallback to kubeconfig current context, got %q", reloaded.CurrentContext())
+	}
+}
+
+func TestClient_Timeouts(t *testing.T) {
+	kubeconfigPath := createTestKubeconfig(t)
+
+	client, err := NewClient(WithKubeconfig(kubeconfigPath), WithTimeout(3*time.Second), WithExecTimeout(time.Minute))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		context func() (context.Context, context.CancelFunc)
+		want    time.Duration
+	}{
+		{"request", client.RequestContext, 3 * time.Second},
+		{"exec", client.ExecContext, time.Minute},
+		{"default request", (&Client{}).RequestContext, DefaultTimeout},
+		{"default exec", (&Client{}).ExecContext, DefaultExecTimeout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now()
+			ctx, cancel := tt.context()
+			defer cancel()
+
+			deadline, ok := ctx.Deadline()
+			if !ok {
+				t.Fatal("expected a deadline")
+			}
+			if got := deadline.Sub(start); got < tt.want || got > tt.want+time.Second {
+				t.Errorf("expected a deadline about %v away, got %v", tt.want, got)
+			}
+		})
+	}
+
+	// Reloading keeps the configured timeouts
+	reloaded, err := client.ReloadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reloaded.Timeout() != 3*time.Second || reloaded.ExecTimeout() != time.Minute {
+		t.Errorf("expected timeouts 3s/1m after reload, got %v/%v", reloaded.Timeout(), reloaded.ExecTimeout())
+	}
+}
+
+func TestClient_ReadOnly(t *testing.T) {
+	kubeconfigPath := createTestKubeconfig(t)
+	serveCluster(t, kubeconfigPath, "cluster-1")
+	client, err := NewClient(WithKubeconfig(kubeconfigPath), WithReadOnly())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if !client.ReadOnly() {
+		t.Fatal("expected a read-only client")
+	}
+
+	// Clients derived from it stay read-only
+	other, err := client.ForContext("context-alpha")
+	if err != nil || !other.ReadOnly() {
+		t.Fatalf("expected ForContext to keep read-only mode, got %v", err)
+	}
+	reloaded, err := client.ReloadConfig()
+	if err != nil || !reloaded.ReadOnly() {
+		t.Errorf("expected ReloadConfig to keep read-only mode, got %v", err)
+	}
+
+	if err := other.PersistCurrentContext(); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("PersistCurrentContext() error = %v, want ErrReadOnly", err)
+	}
+	config, err := clientcmd.LoadFromFile(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("failed to read kubeconfig: %v", err)
+	}
+	if config.CurrentContext != "context-beta" {
+		t.Errorf("expected the kubeconfig untouched, got current-context %q", config.CurrentContext)
+	}
+}
+
+func TestClient_ReadOnly_RefusesMutations(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		call func(c *Client) error
+	}{
+		{"ScaleDeployment", func(c *Client) error {
+			return c.ScaleDeployment(ctx, "default", "web", 0)
+		}},
+		{"RestartDeployment", func(c *Client) error {
+			return c.RestartDeployment(ctx, "default", "web")
+		}},
+		{"RestartWorkload", func(c *Client) error {
+			return c.RestartWorkload(ctx, "default", "StatefulSet", "db")
+		}},
+		{"WriteFile", func(c *Client) error {
+			opts := FileOptions{Namespace: "default", Pod: "web", Container: "app", Path: "/etc/app.conf"}
+			return c.WriteFile(ctx, opts, []byte("x"))
+		}},
+		{"CreateEphemeralDebugContainer", func(c *Client) error {
+			_, err := c.CreateEphemeralDebugContainer(ctx, "default", "web", DefaultDebugImage)
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientset(createTestDeployment("web", "default", 3, 3))
+			client := &Client{clientset: fakeClient, currentNamespace: "default", readOnly: true}
+
+			err := tt.call(client)
+			if !errors.Is(err, ErrReadOnly) {
+				t.Fatalf("expected ErrReadOnly, got %v", err)
+			}
+			if !strings.Contains(err.Error(), "read-only mode") {
+				t.Errorf("expected the error to mention read-only mode, got %q", err)
+			}
+			if actions := fakeClient.Actions(); len(actions) != 0 {
+				t.Errorf("expected no API calls, got %v", actions)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConfigMapInfo contains a ConfigMap's keys and values. Data merges the
+// text and binary data so both can be browsed the same way.
+type ConfigMapInfo struct {
+	Name      string
+	Namespace string
+	Data      map[string][]byte
+	Age       time.Duration
+}
+
+// Keys returns the ConfigMap's keys in sorted order
+func (c ConfigMapInfo) Keys() []string {
+	return sortedKeys(c.Data)
+}
+
+// ListConfigMaps returns ConfigMaps in the specified namespace (or current namespace if empty)
+func (c *Client) ListConfigMaps(ctx context.Context, namespace string) ([]ConfigMapInfo, error) {
+	if namespace == "" {
+		namespace = c.currentNamespace
+	}
+
+	configMaps, err := c.clientset.CoreV1().ConfigMaps(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list configmaps in namespace %q: %w", namespace, err)
+	}
+
+	result := make([]ConfigMapInfo, 0, len(configMaps.Items))
+	for i := range configMaps.Items {
+		result = append(result, configMapToInfo(&configMaps.Items[i]))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+// configMapToInfo converts a ConfigMap to ConfigMapInfo
+func configMapToInfo(cm *corev1.ConfigMap) ConfigMapInfo {
+	data := make(map[string][]byte, len(cm.Data)+len(cm.BinaryData))
+	for key, value := range cm.Data {
+		data[key] = []byte(value)
+	}
+	for key, value := range cm.BinaryData {
+		data[key] = value
+	}
+
+	return ConfigMapInfo{
+		Name:      cm.Name,
+		Namespace: cm.Namespace,
+		Data:      data,
+		Age:       time.Since(cm.CreationTimestamp.Time),
+	}
+}
+
+// sortedKeys returns the keys of a data map in sorted order
+func sortedKeys(data map[string][]byte) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,63 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestClient_ListConfigMaps(t *testing.T) {
+	fakeClient := fake.NewClientset(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-config", Namespace: "default"},
+			Data:       map[string]string{"LOG_LEVEL": "info", "app.yaml": "port: 8080\n"},
+			BinaryData: map[string][]byte{"logo.png": {0x89, 'P', 'N', 'G'}},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "api-config", Namespace: "default"},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "kube-system"},
+		},
+	)
+	client := &Client{clientset: fakeClient, currentNamespace: "default"}
+
+	configMaps, err := client.ListConfigMaps(context.Background(), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(configMaps) != 2 {
+		t.Fatalf("expected 2 configmaps in default, got %d", len(configMaps))
+	}
+	if configMaps[0].Name != "api-config" || configMaps[1].Name != "web-config" {
+		t.Errorf("expected configmaps sorted by name, got %s, %s", configMaps[0].Name, configMaps[1].Name)
+	}
+
+	web := configMaps[1]
+	if keys := web.Keys(); !reflect.DeepEqual(keys, []string{"LOG_LEVEL", "app.yaml", "logo.png"}) {
+		t.Errorf("expected text and binary keys, got %v", keys)
+	}
+	if string(web.Data["LOG_LEVEL"]) != "info" || len(web.Data["logo.png"]) != 4 {
+		t.Errorf("unexpected data: %q", web.Data)
+	}
+}
+
+func TestClient_ListConfigMaps_Error(t *testing.T) {
+	fakeClient := fake.NewClientset()
+	fakeClient.PrependReactor("list", "configmaps", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("connection refused")
+	})
+	client := &Client{clientset: fakeClient, currentNamespace: "default"}
+
+	if _, err := client.ListConfigMaps(context.Background(), ""); err == nil {
+		t.Error("expected an error")
+	}
+}
@@ -1,12 +1,12 @@
 package k8s
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
 
-	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/clientcmd/api"
 )
@@ -15,6 +15,7 @@ import (
 type ContextInfo struct {
 	Name      string
 	Cluster   string
+	Server    string // API server of Cluster, from the kubeconfig
 	User      string
 	Namespace string
 	IsCurrent bool
@@ -22,6 +23,16 @@ type ContextInfo struct {
 
 // ListContexts returns all available contexts from the kubeconfig
 func (c *Client) ListContexts() []ContextInfo {
+	if c.inCluster {
+		return []ContextInfo{{
+			Name:      InClusterContext,
+			Cluster:   c.config.Host,
+			Server:    c.config.Host,
+			Namespace: c.currentNamespace,
+			IsCurrent: true,
+		}}
+	}
+
 	contexts := make([]ContextInfo, 0, len(c.rawConfig.Contexts))
 
 	for name, ctx := range c.rawConfig.Contexts {
@@ -33,6 +44,7 @@ func (c *Client) ListContexts() []ContextInfo {
 		contexts = append(contexts, ContextInfo{
 			Name:      name,
 			Cluster:   ctx.Cluster,
+			Server:    clusterServer(c.rawConfig, ctx.Cluster),
 			User:      ctx.AuthInfo,
 			Namespace: namespace,
 			IsCurrent: name == c.currentContext,
@@ -47,50 +59,69 @@ func (c *Client) ListContexts() []ContextInfo {
 	return contexts
 }
 
-// SwitchContext switches to a different context and reinitializes the client
-func (c *Client) SwitchContext(contextName string) error {
-	// Validate context exists
-	if _, exists := c.rawConfig.Contexts[contextName]; !exists {
-		return fmt.Errorf("context %q not found", contextName)
+// ForContext builds a separate client for another context, leaving c
+// untouched, so it can run in the background while c stays in use. The
+// new client starts in the context's namespace, and is only returned once
+// its API server answered, so an unreachable context fails the switch.
+func (c *Client) ForContext(contextName string) (*Client, error) {
+	if c.inCluster {
+		return nil, ErrContextSwitchUnavailable
 	}
 
-	// Build new config with the selected context using the stored kubeconfig path
-	loadingRules := &clientcmd.ClientConfigLoadingRules{
-		ExplicitPath: c.kubeconfigPath,
+	if _, exists := c.rawConfig.Contexts[contextName]; !exists {
+		return nil, fmt.Errorf("context %q not found", contextName)
 	}
-	configOverrides := &clientcmd.ConfigOverrides{
-		CurrentContext: contextName,
+
+	client, err := NewClient(append(c.inheritedOptions(),
+		WithKubeconfig(c.kubeconfigPath),
+		WithContext(contextName),
+	)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to switch to context %q: %w", contextName, err)
 	}
 
-	configLoader := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
-		loadingRules,
-		configOverrides,
-	)
+	ctx, cancel := client.RequestContext()
+	defer cancel()
+	if err := client.checkServer(ctx); err != nil {
+		return nil, fmt.Errorf("failed to reach context %q: %w", contextName, err)
+	}
+	return client, nil
+}
 
-	// Build REST config for new context
-	restConfig, err := configLoader.ClientConfig()
-	if err != nil {
-		return fmt.Errorf("failed to build config for context %q: %w", contextName, err)
+// checkServer asks the API server for its version, as discovery's
+// ServerVersion does, but bounded by ctx
+func (c *Client) checkServer(ctx context.Context) error {
+	restClient := c.clientset.Discovery().RESTClient()
+	if restClient == nil {
+		_, err := c.clientset.Discovery().ServerVersion()
+		return err
 	}
+	_, err := restClient.Get().AbsPath("/version").DoRaw(ctx)
+	return err
+}
 
-	// Create new clientset
-	clientset, err := kubernetes.NewForConfig(restConfig)
-	if err != nil {
-		return fmt.Errorf("failed to create client for context %q: %w", contextName, err)
+// PersistCurrentContext writes the current context to the kubeconfig's
+// current-context field so kubectl and other tools follow the switch. With
+// several kubeconfig files, like kubectl it goes to the first one that
+// exists. Other fields and the file's permissions are left as they are.
+func (c *Client) PersistCurrentContext() error {
+	if c.inCluster {
+		return ErrContextSwitchUnavailable
+	}
+	if err := c.checkWritable("update kubeconfig"); err != nil {
+		return err
 	}
 
-	// Get namespace for new context
-	namespace, _, err := configLoader.Namespace()
+	access := kubeconfigLoadingRules(c.kubeconfigPath)
+	config, err := access.GetStartingConfig()
 	if err != nil {
-		namespace = "default"
+		return fmt.Errorf("failed to load kubeconfig: %w", err)
 	}
 
-	// Update client state
-	c.clientset = clientset
-	c.config = restConfig
-	c.configLoader = configLoader
-	c.currentContext = contextName
-	c.currentNamespace = namespace
+	config.CurrentContext = c.currentContext
+	if err := clientcmd.ModifyConfig(access, *config, false); err != nil {
+		return fmt.Errorf("failed to update kubeconfig: %w", err)
+	}
 
 	return nil
 }
@@ -110,12 +141,22 @@ func (c *Client) GetContextInfo(contextName string) (ContextInfo, error) {
 	return ContextInfo{
 		Name:      contextName,
 		Cluster:   ctx.Cluster,
+		Server:    clusterServer(c.rawConfig, ctx.Cluster),
 		User:      ctx.AuthInfo,
 		Namespace: namespace,
 		IsCurrent: contextName == c.currentContext,
 	}, nil
 }
 
+// clusterServer returns the API server of a kubeconfig cluster, or "" if
+// the cluster is not defined
+func clusterServer(config api.Config, cluster string) string {
+	if info, ok := config.Clusters[cluster]; ok && info != nil {
+		return info.Server
+	}
+	return ""
+}
+
 // getKubeconfigPath returns the kubeconfig path from env or default location
 func getKubeconfigPath() string {
 	if path := os.Getenv("KUBECONFIG"); path != "" {
@@ -134,13 +175,11 @@ func ListContextsFromConfig(kubeconfigPath string) ([]ContextInfo, string, error
 		kubeconfigPath = getKubeconfigPath()
 	}
 
-	if kubeconfigPath == "" {
+	if len(kubeconfigPaths(kubeconfigPath)) == 0 {
 		return nil, "", fmt.Errorf("no kubeconfig path found")
 	}
 
-	loadingRules := &clientcmd.ClientConfigLoadingRules{
-		ExplicitPath: kubeconfigPath,
-	}
+	loadingRules := kubeconfigLoadingRules(kubeconfigPath)
 
 	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
 		loadingRules,
@@ -166,6 +205,7 @@ func contextsFromRawConfig(config api.Config, currentContext string) []ContextIn
 		contexts = append(contexts, ContextInfo{
 			Name:      name,
 			Cluster:   ctx.Cluster,
+			Server:    clusterServer(config, ctx.Cluster),
 			User:      ctx.AuthInfo,
 			Namespace: namespace,
 			IsCurrent: name == currentContext,
@@ -1,9 +1,16 @@
 package k8s
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"k8s.io/client-go/tools/clientcmd"
 )
 
 func createTestKubeconfig(t *testing.T) string {
@@ -62,6 +69,86 @@ users:
 	return kubeconfigPath
 }
 
+// createSecondKubeconfig writes a kubeconfig with one more context, to be
+// merged after createTestKubeconfig's file
+func createSecondKubeconfig(t *testing.T) string {
+	t.Helper()
+
+	kubeconfigPath := filepath.Join(t.TempDir(), "config")
+	kubeconfig := `
+apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://cluster-4.example.com:6443
+    insecure-skip-tls-verify: true
+  name: cluster-4
+contexts:
+- context:
+    cluster: cluster-4
+    user: user-4
+    namespace: namespace-4
+  name: context-delta
+current-context: context-delta
+users:
+- name: user-4
+  user:
+    token: token-4
+`
+	if err := os.WriteFile(kubeconfigPath, []byte(kubeconfig), 0600); err != nil {
+		t.Fatalf("failed to write test kubeconfig: %v", err)
+	}
+
+	return kubeconfigPath
+}
+
+func TestNewClient_MergesKubeconfigList(t *testing.T) {
+	first := createTestKubeconfig(t)
+	second := createSecondKubeconfig(t)
+	missing := filepath.Join(t.TempDir(), "missing")
+	paths := strings.Join([]string{first, missing, second}, string(os.PathListSeparator))
+
+	client, err := NewClient(WithKubeconfig(paths))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	var names []string
+	for _, ctx := range client.ListContexts() {
+		names = append(names, ctx.Name)
+	}
+	want := []string{"context-alpha", "context-beta", "context-delta", "context-gamma"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("expected contexts %v from both files, got %v", want, names)
+	}
+
+	// The first file's current-context wins
+	if client.CurrentContext() != "context-beta" {
+		t.Errorf("expected current context 'context-beta', got %q", client.CurrentContext())
+	}
+
+	client, err = NewClient(WithKubeconfig(paths), WithContext("context-delta"))
+	if err != nil {
+		t.Fatalf("failed to use a context from the second file: %v", err)
+	}
+	if client.CurrentNamespace() != "namespace-4" {
+		t.Errorf("expected namespace 'namespace-4', got %q", client.CurrentNamespace())
+	}
+
+	// Like kubectl, the new current-context goes to the first file
+	if err := client.PersistCurrentContext(); err != nil {
+		t.Fatalf("PersistCurrentContext() error = %v", err)
+	}
+	config, err := clientcmd.LoadFromFile(first)
+	if err != nil {
+		t.Fatalf("failed to read kubeconfig: %v", err)
+	}
+	if config.CurrentContext != "context-delta" || len(config.Contexts) != 3 {
+		t.Errorf("expected only current-context to change in the first file, got %q with %d contexts",
+			config.CurrentContext, len(config.Contexts))
+	}
+}
+
 func TestClient_ListContexts(t *testing.T) {
 	kubeconfigPath := createTestKubeconfig(t)
 
@@ -125,6 +212,9 @@ func TestClient_GetContextInfo(t *testing.T) {
 	if info.Cluster != "cluster-1" {
 		t.Errorf("expected cluster 'cluster-1', got %q", info.Cluster)
 	}
+	if info.Server != "https://cluster-1.example.com:6443" {
+		t.Errorf("expected the cluster-1 server, got %q", info.Server)
+	}
 	if info.User != "user-1" {
 		t.Errorf("expected user 'user-1', got %q", info.User)
 	}
@@ -136,6 +226,28 @@ func TestClient_GetContextInfo(t *testing.T) {
 	}
 }
 
+func TestClient_ConnectionDetails(t *testing.T) {
+	kubeconfigPath := createTestKubeconfig(t)
+
+	client, err := NewClient(WithKubeconfig(kubeconfigPath))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if client.KubeconfigPath() != kubeconfigPath {
+		t.Errorf("expected kubeconfig %q, got %q", kubeconfigPath, client.KubeconfigPath())
+	}
+	if client.ServerURL() != "https://cluster-2.example.com:6443" {
+		t.Errorf("expected the current context's server, got %q", client.ServerURL())
+	}
+
+	for _, info := range client.ListContexts() {
+		if !strings.HasSuffix(info.Server, ".example.com:6443") {
+			t.Errorf("expected a server for %s, got %q", info.Name, info.Server)
+		}
+	}
+}
+
 func TestClient_GetContextInfo_NotFound(t *testing.T) {
 	kubeconfigPath := createTestKubeconfig(t)
 
@@ -150,61 +262,136 @@ func TestClient_GetContextInfo_NotFound(t *testing.T) {
 	}
 }
 
-func TestClient_SwitchContext(t *testing.T) {
+// serveCluster points cluster in the kubeconfig at a local server that
+// answers version requests like an API server
+func serveCluster(t *testing.T, kubeconfigPath, cluster string) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/version" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"major":"1","minor":"31","gitVersion":"v1.31.0"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	config, err := clientcmd.LoadFromFile(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("failed to read kubeconfig: %v", err)
+	}
+	config.Clusters[cluster].Server = server.URL
+	if err := clientcmd.WriteToFile(*config, kubeconfigPath); err != nil {
+		t.Fatalf("failed to write kubeconfig: %v", err)
+	}
+}
+
+func TestClient_ForContext(t *testing.T) {
 	kubeconfigPath := createTestKubeconfig(t)
+	serveCluster(t, kubeconfigPath, "cluster-1")
 
-	client, err := NewClient(WithKubeconfig(kubeconfigPath))
+	client, err := NewClient(WithKubeconfig(kubeconfigPath), WithTimeout(3*time.Second))
 	if err != nil {
 		t.Fatalf("failed to create client: %v", err)
 	}
 
-	// Initial context should be context-beta
+	next, err := client.ForContext("context-alpha")
+	if err != nil {
+		t.Fatalf("ForContext() error = %v", err)
+	}
+	if next.CurrentContext() != "context-alpha" || next.CurrentNamespace() != "namespace-1" {
+		t.Errorf("expected context-alpha in namespace-1, got %q in %q", next.CurrentContext(), next.CurrentNamespace())
+	}
+	if next.Timeout() != 3*time.Second {
+		t.Errorf("the new client should keep the timeout, got %v", next.Timeout())
+	}
+
+	// The original client is left as it was
 	if client.CurrentContext() != "context-beta" {
-		t.Errorf("expected initial context 'context-beta', got %q", client.CurrentContext())
+		t.Errorf("ForContext should not change the original client, got %q", client.CurrentContext())
 	}
+}
 
-	// Switch to context-alpha
-	if err := client.SwitchContext("context-alpha"); err != nil {
-		t.Fatalf("failed to switch context: %v", err)
+func TestClient_ForContext_NotFound(t *testing.T) {
+	client, err := NewClient(WithKubeconfig(createTestKubeconfig(t)))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
 	}
 
-	if client.CurrentContext() != "context-alpha" {
-		t.Errorf("expected context 'context-alpha' after switch, got %q", client.CurrentContext())
+	if _, err := client.ForContext("nonexistent"); err == nil {
+		t.Error("expected error for nonexistent context")
 	}
+}
 
-	// Namespace should update to the new context's namespace
-	if client.CurrentNamespace() != "namespace-1" {
-		t.Errorf("expected namespace 'namespace-1', got %q", client.CurrentNamespace())
+func TestClient_ForContext_Unreachable(t *testing.T) {
+	kubeconfigPath := createTestKubeconfig(t)
+
+	// A server that closed has nothing listening on its address
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	config, err := clientcmd.LoadFromFile(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("failed to read kubeconfig: %v", err)
+	}
+	config.Clusters["cluster-1"].Server = server.URL
+	if err := clientcmd.WriteToFile(*config, kubeconfigPath); err != nil {
+		t.Fatalf("failed to write kubeconfig: %v", err)
 	}
 
-	// Verify ListContexts reflects the change
-	contexts := client.ListContexts()
-	for _, ctx := range contexts {
-		if ctx.Name == "context-alpha" && !ctx.IsCurrent {
-			t.Error("expected context-alpha to be marked as current after switch")
-		}
-		if ctx.Name == "context-beta" && ctx.IsCurrent {
-			t.Error("expected context-beta to not be current after switch")
-		}
+	client, err := NewClient(WithKubeconfig(kubeconfigPath), WithTimeout(3*time.Second))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if _, err := client.ForContext("context-alpha"); err == nil || !strings.Contains(err.Error(), "failed to reach context") {
+		t.Errorf("expected the switch to fail for an unreachable cluster, got %v", err)
 	}
 }
 
-func TestClient_SwitchContext_NotFound(t *testing.T) {
+func TestClient_PersistCurrentContext(t *testing.T) {
 	kubeconfigPath := createTestKubeconfig(t)
+	if err := os.Chmod(kubeconfigPath, 0640); err != nil {
+		t.Fatalf("failed to chmod kubeconfig: %v", err)
+	}
 
-	client, err := NewClient(WithKubeconfig(kubeconfigPath))
+	client, err := NewClient(WithKubeconfig(kubeconfigPath), WithContext("context-alpha"))
 	if err != nil {
 		t.Fatalf("failed to create client: %v", err)
 	}
 
-	err = client.SwitchContext("nonexistent")
-	if err == nil {
-		t.Error("expected error for nonexistent context")
+	// Switching alone must not touch the file
+	config, err := clientcmd.LoadFromFile(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("failed to read kubeconfig: %v", err)
+	}
+	if config.CurrentContext != "context-beta" {
+		t.Errorf("expected file current-context 'context-beta' before persisting, got %q", config.CurrentContext)
 	}
 
-	// Context should remain unchanged
-	if client.CurrentContext() != "context-beta" {
-		t.Errorf("context should remain 'context-beta' after failed switch, got %q", client.CurrentContext())
+	if err := client.PersistCurrentContext(); err != nil {
+		t.Fatalf("PersistCurrentContext() error = %v", err)
+	}
+
+	config, err = clientcmd.LoadFromFile(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("failed to read kubeconfig: %v", err)
+	}
+	if config.CurrentContext != "context-alpha" {
+		t.Errorf("expected file current-context 'context-alpha', got %q", config.CurrentContext)
+	}
+	if len(config.Contexts) != 3 || len(config.Clusters) != 3 || len(config.AuthInfos) != 3 {
+		t.Errorf("expected other kubeconfig fields to be kept, got %d contexts, %d clusters, %d users",
+			len(config.Contexts), len(config.Clusters), len(config.AuthInfos))
+	}
+	if ns := config.Contexts["context-alpha"].Namespace; ns != "namespace-1" {
+		t.Errorf("expected context-alpha namespace 'namespace-1', got %q", ns)
+	}
+
+	info, err := os.Stat(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("failed to stat kubeconfig: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0640 {
+		t.Errorf("expected permissions 0640 to be kept, got %o", perm)
 	}
 }
 
@@ -225,6 +412,21 @@ func TestListContextsFromConfig(t *testing.T) {
 	}
 }
 
+func TestListContextsFromConfig_KubeconfigList(t *testing.T) {
+	paths := createTestKubeconfig(t) + string(os.PathListSeparator) + createSecondKubeconfig(t)
+
+	contexts, currentContext, err := ListContextsFromConfig(paths)
+	if err != nil {
+		t.Fatalf("failed to list contexts: %v", err)
+	}
+	if len(contexts) != 4 {
+		t.Errorf("expected 4 contexts, got %d", len(contexts))
+	}
+	if currentContext != "context-beta" {
+		t.Errorf("expected current context 'context-beta', got %q", currentContext)
+	}
+}
+
 func TestListContextsFromConfig_NoKubeconfig(t *testing.T) {
 	tmpDir := t.TempDir()
 	nonexistentPath := filepath.Join(tmpDir, "nonexistent")
@@ -0,0 +1,149 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// DefaultDebugImage is the image suggested for ephemeral debug containers
+const DefaultDebugImage = "busybox:1.36"
+
+// debugPollInterval is how often WaitForEphemeralContainer checks the pod
+const debugPollInterval = time.Second
+
+// CreateEphemeralDebugContainer adds an ephemeral debug container to a pod,
+// like kubectl debug. It targets the pod's first container so the debug
+// container shares its process namespace. Returns the new container's name;
+// wait for it with WaitForEphemeralContainer before exec'ing into it.
+func (c *Client) CreateEphemeralDebugContainer(ctx context.Context, namespace, pod, image string) (string, error) {
+	if namespace == "" {
+		namespace = c.currentNamespace
+	}
+	if err := c.checkWritable(fmt.Sprintf("add a debug container to pod %q", pod)); err != nil {
+		return "", err
+	}
+
+	current, err := c.clientset.CoreV1().Pods(namespace).Get(ctx, pod, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get pod %q in namespace %q: %w", pod, namespace, err)
+	}
+
+	name := debugContainerName(current)
+	patch, err := buildEphemeralContainerPatch(current, name, image)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = c.clientset.CoreV1().Pods(namespace).Patch(ctx, pod,
+		types.StrategicMergePatchType, patch, metav1.PatchOptions{}, "ephemeralcontainers")
+	if err != nil {
+		return "", fmt.Errorf("failed to add debug container to pod %q: %w", pod, err)
+	}
+
+	return name, nil
+}
+
+// WaitForEphemeralContainer blocks until the named ephemeral container is
+// running. It fails early if the container terminates or its image can't
+// be pulled.
+func (c *Client) WaitForEphemeralContainer(ctx context.Context, namespace, pod, container string) error {
+	if namespace == "" {
+		namespace = c.currentNamespace
+	}
+
+	return wait.PollUntilContextCancel(ctx, debugPollInterval, true, func(ctx context.Context) (bool, error) {
+		current, err := c.clientset.CoreV1().Pods(namespace).Get(ctx, pod, metav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Errorf("failed to get pod %q in namespace %q: %w", pod, namespace, err)
+		}
+		return ephemeralContainerReady(current, container)
+	})
+}
+
+// ephemeralContainerReady reports whether the container is running, or an
+// error if it can no longer start
+func ephemeralContainerReady(pod *corev1.Pod, container string) (bool, error) {
+	idx := slices.IndexFunc(pod.Status.EphemeralContainerStatuses, func(cs corev1.ContainerStatus) bool {
+		return cs.Name == container
+	})
+	if idx < 0 {
+		return false, nil
+	}
+
+	state := pod.Status.EphemeralContainerStatuses[idx].State
+	switch {
+	case state.Running != nil:
+		return true, nil
+	case state.Terminated != nil:
+		return false, fmt.Errorf("debug container %q exited: %s", container, state.Terminated.Reason)
+	case state.Waiting != nil:
+		switch state.Waiting.Reason {
+		case "ErrImagePull", "ImagePullBackOff", "InvalidImageName":
+			return false, fmt.Errorf("debug container %q can't start: %s", container, state.Waiting.Reason)
+		}
+	}
+	return false, nil
+}
+
+// buildEphemeralContainerPatch returns a strategic merge patch that adds a
+// debug container. Stdin and TTY keep the image's shell alive so commands
+// can be exec'd into it, mirroring kubectl debug -it.
+func buildEphemeralContainerPatch(pod *corev1.Pod, name, image string) ([]byte, error) {
+	debug := corev1.EphemeralContainer{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+			Name:                     name,
+			Image:                    image,
+			ImagePullPolicy:          corev1.PullIfNotPresent,
+			Stdin:                    true,
+			TTY:                      true,
+			TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+		},
+	}
+	if target := defaultContainer(pod); target != "" {
+		debug.TargetContainerName = target
+	} else if len(pod.Spec.Containers) > 0 {
+		debug.TargetContainerName = pod.Spec.Containers[0].Name
+	}
+
+	patch := map[string]any{
+		"spec": map[string]any{
+			"ephemeralContainers": []corev1.EphemeralContainer{debug},
+		},
+	}
+
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build debug container patch: %w", err)
+	}
+	return data, nil
+}
+
+// debugContainerName picks a debugger-xxxxx name not used by the pod
+func debugContainerName(pod *corev1.Pod) string {
+	taken := make(map[string]bool)
+	for i := range pod.Spec.Containers {
+		taken[pod.Spec.Containers[i].Name] = true
+	}
+	for i := range pod.Spec.InitContainers {
+		taken[pod.Spec.InitContainers[i].Name] = true
+	}
+	for i := range pod.Spec.EphemeralContainers {
+		taken[pod.Spec.EphemeralContainers[i].Name] = true
+	}
+
+	for {
+		name := "debugger-" + utilrand.String(5)
+		if !taken[name] {
+			return name
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestBuildEphemeralContainerPatch(t *testing.T) {
+	pod := createTestPod("app", "default", corev1.PodRunning, true)
+
+	data, err := buildEphemeralContainerPatch(pod, "debugger-abcde", "busybox:1.36")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var patch struct {
+		Spec struct {
+			EphemeralContainers []corev1.EphemeralContainer `json:"ephemeralContainers"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal(data, &patch); err != nil {
+		t.Fatalf("patch is not valid JSON: %v", err)
+	}
+
+	if len(patch.Spec.EphemeralContainers) != 1 {
+		t.Fatalf("expected 1 ephemeral container, got %d", len(patch.Spec.EphemeralContainers))
+	}
+	ec := patch.Spec.EphemeralContainers[0]
+	if ec.Name != "debugger-abcde" || ec.Image != "busybox:1.36" {
+		t.Errorf("unexpected container %s (%s)", ec.Name, ec.Image)
+	}
+	if ec.TargetContainerName != "main" {
+		t.Errorf("expected target container main, got %q", ec.TargetContainerName)
+	}
+	if !ec.Stdin || !ec.TTY {
+		t.Error("debug container needs stdin and a TTY to keep its shell alive")
+	}
+}
+
+func TestBuildEphemeralContainerPatch_DefaultContainer(t *testing.T) {
+	data, err := buildEphemeralContainerPatch(createAnnotatedPod("main"), "debugger-abcde", "busybox:1.36")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(data), `"targetContainerName":"main"`) {
+		t.Errorf("debug container should target the annotated default container, got %s", data)
+	}
+}
+
+func TestDebugContainerName(t *testing.T) {
+	pod := createTestPod("app", "default", corev1.PodRunning, true)
+
+	name := debugContainerName(pod)
+	if !strings.HasPrefix(name, "debugger-") || len(name) != len("debugger-")+5 {
+		t.Errorf("unexpected debug container name %q", name)
+	}
+}
+
+func TestClient_CreateEphemeralDebugContainer(t *testing.T) {
+	fakeClient := fake.NewClientset(createTestPod("app", "default", corev1.PodRunning, true))
+	client := &Client{clientset: fakeClient, currentNamespace: "default"}
+
+	name, err := client.CreateEphemeralDebugContainer(context.Background(), "", "app", DefaultDebugImage)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var patched bool
+	for _, action := range fakeClient.Actions() {
+		if action.GetVerb() == "patch" && action.GetSubresource() == "ephemeralcontainers" {
+			patched = true
+		}
+	}
+	if !patched {
+		t.Error("expected a patch of the ephemeralcontainers subresource")
+	}
+	if !strings.HasPrefix(name, "debugger-") {
+		t.Errorf("unexpected container name %q", name)
+	}
+}
+
+func TestClient_CreateEphemeralDebugContainer_PodNotFound(t *testing.T) {
+	client := &Client{clientset: fake.NewClientset(), currentNamespace: "default"}
+
+	if _, err := client.CreateEphemeralDebugContainer(context.Background(), "", "missing", DefaultDebugImage); err == nil {
+		t.Error("expected error for a missing pod")
+	}
+}
+
+func TestEphemeralContainerReady(t *testing.T) {
+	status := func(state corev1.ContainerState) *corev1.Pod {
+		pod := createTestPod("app", "default", corev1.PodRunning, true)
+		pod.Status.EphemeralContainerStatuses = []corev1.ContainerStatus{
+			{Name: "debugger-abcde", State: state},
+		}
+		return pod
+	}
+
+	tests := []struct {
+		name      string
+		pod       *corev1.Pod
+		wantReady bool
+		wantErr   bool
+	}{
+		{"not reported yet", createTestPod("app", "default", corev1.PodRunning, true), false, false},
+		{"creating", status(corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}}), false, false},
+		{"running", status(corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}), true, false},
+		{"image pull", status(corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}}), false, true},
+		{"exited", status(corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Completed"}}), false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ready, err := ephemeralContainerReady(tt.pod, "debugger-abcde")
+			if ready != tt.wantReady || (err != nil) != tt.wantErr {
+				t.Errorf("got ready=%v err=%v, want ready=%v err=%v", ready, err, tt.wantReady, tt.wantErr)
+			}
+		})
+	}
+}
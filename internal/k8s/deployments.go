@@ -0,0 +1,173 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// RestartedAtAnnotation is the pod template annotation kubectl rollout
+// restart sets to trigger a new rollout
+const RestartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// DeploymentInfo contains information about a Kubernetes deployment
+type DeploymentInfo struct {
+	Name              string
+	Namespace         string
+	Replicas          int32 // Desired
+	ReadyReplicas     int32
+	UpdatedReplicas   int32
+	AvailableReplicas int32
+	Age               time.Duration
+	Selector          string // Label selector of the deployment's pods; empty if unset
+}
+
+// Ready returns the ready/desired replica count, e.g. "2/3"
+func (d DeploymentInfo) Ready() string {
+	return fmt.Sprintf("%d/%d", d.ReadyReplicas, d.Replicas)
+}
+
+// ListDeployments returns deployments in the specified namespace (or current namespace if empty)
+func (c *Client) ListDeployments(ctx context.Context, namespace string) ([]DeploymentInfo, error) {
+	if namespace == "" {
+		namespace = c.currentNamespace
+	}
+
+	deployments, err := c.clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments in namespace %q: %w", namespace, err)
+	}
+
+	result := make([]DeploymentInfo, 0, len(deployments.Items))
+	for i := range deployments.Items {
+		result = append(result, deploymentToInfo(&deployments.Items[i]))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+// ScaleDeployment sets the desired replica count through the scale subresource
+func (c *Client) ScaleDeployment(ctx context.Context, namespace, name string, replicas int32) error {
+	if namespace == "" {
+		namespace = c.currentNamespace
+	}
+	if replicas < 0 {
+		return fmt.Errorf("replica count must not be negative, got %d", replicas)
+	}
+	if err := c.checkWritable(fmt.Sprintf("scale deployment %q", name)); err != nil {
+		return err
+	}
+
+	deployments := c.clientset.AppsV1().Deployments(namespace)
+	scale, err := deployments.GetScale(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get scale of deployment %q: %w", name, err)
+	}
+
+	scale.Spec.Replicas = replicas
+	if _, err := deployments.UpdateScale(ctx, name, scale, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to scale deployment %q: %w", name, err)
+	}
+
+	return nil
+}
+
+// RestartDeployment triggers a rolling restart like kubectl rollout restart,
+// by stamping the pod template with the current time
+func (c *Client) RestartDeployment(ctx context.Context, namespace, name string) error {
+	return c.RestartWorkload(ctx, namespace, "Deployment", name)
+}
+
+// RestartWorkload triggers a rolling restart of a Deployment, StatefulSet
+// or DaemonSet, e.g. the owner of a pod, like kubectl rollout restart
+func (c *Client) RestartWorkload(ctx context.Context, namespace, kind, name string) error {
+	if namespace == "" {
+		namespace = c.currentNamespace
+	}
+	workload := strings.ToLower(kind)
+	if !(OwnerRef{Kind: kind, Name: name}).Restartable() {
+		return fmt.Errorf("cannot restart %s %q: only deployments, statefulsets and daemonsets can be restarted", workload, name)
+	}
+	if err := c.checkWritable(fmt.Sprintf("restart %s %q", workload, name)); err != nil {
+		return err
+	}
+
+	patch, err := restartPatch(time.Now())
+	if err != nil {
+		return err
+	}
+
+	apps := c.clientset.AppsV1()
+	switch kind {
+	case "Deployment":
+		_, err = apps.Deployments(namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	case "StatefulSet":
+		_, err = apps.StatefulSets(namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	case "DaemonSet":
+		_, err = apps.DaemonSets(namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to restart %s %q: %w", workload, name, err)
+	}
+
+	return nil
+}
+
+// restartPatch builds the pod template annotation patch for a restart
+func restartPatch(now time.Time) ([]byte, error) {
+	patch := map[string]any{
+		"spec": map[string]any{
+			"template": map[string]any{
+				"metadata": map[string]any{
+					"annotations": map[string]string{
+						RestartedAtAnnotation: now.Format(time.RFC3339),
+					},
+				},
+			},
+		},
+	}
+
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build restart patch: %w", err)
+	}
+	return data, nil
+}
+
+// deploymentToInfo converts a deployment to DeploymentInfo
+func deploymentToInfo(d *appsv1.Deployment) DeploymentInfo {
+	// An unset replica count defaults to 1
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+
+	selector := ""
+	if d.Spec.Selector != nil {
+		if s, err := metav1.LabelSelectorAsSelector(d.Spec.Selector); err == nil && !s.Empty() {
+			selector = s.String()
+		}
+	}
+
+	return DeploymentInfo{
+		Name:              d.Name,
+		Namespace:         d.Namespace,
+		Replicas:          replicas,
+		ReadyReplicas:     d.Status.ReadyReplicas,
+		UpdatedReplicas:   d.Status.UpdatedReplicas,
+		AvailableReplicas: d.Status.AvailableReplicas,
+		Age:               time.Since(d.CreationTimestamp.Time),
+		Selector:          selector,
+	}
+}
@@ -0,0 +1,208 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func createTestDeployment(name, namespace string, replicas, ready int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			CreationTimestamp: metav1.Time{Time: time.Now().Add(-2 * time.Hour)},
+		},
+		Spec: appsv1.DeploymentSpec{Replicas: &replicas},
+		Status: appsv1.DeploymentStatus{
+			Replicas:          replicas,
+			ReadyReplicas:     ready,
+			UpdatedReplicas:   replicas,
+			AvailableReplicas: ready,
+		},
+	}
+}
+
+func TestClient_ListDeployments(t *testing.T) {
+	fakeClient := fake.NewClientset(
+		createTestDeployment("web", "default", 3, 2),
+		createTestDeployment("api", "default", 1, 1),
+		createTestDeployment("other", "kube-system", 1, 1),
+	)
+	client := &Client{clientset: fakeClient, currentNamespace: "default"}
+
+	deployments, err := client.ListDeployments(context.Background(), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(deployments) != 2 {
+		t.Fatalf("expected 2 deployments in default, got %d", len(deployments))
+	}
+	if deployments[0].Name != "api" || deployments[1].Name != "web" {
+		t.Errorf("expected deployments sorted by name, got %s, %s", deployments[0].Name, deployments[1].Name)
+	}
+
+	web := deployments[1]
+	if web.Ready() != "2/3" || web.AvailableReplicas != 2 || web.UpdatedReplicas != 3 {
+		t.Errorf("unexpected replica counts: %+v", web)
+	}
+	if web.Age < time.Hour {
+		t.Errorf("expected age of about 2h, got %v", web.Age)
+	}
+}
+
+func TestDeploymentToInfo_DefaultReplicas(t *testing.T) {
+	d := createTestDeployment("web", "default", 0, 0)
+	d.Spec.Replicas = nil
+
+	if info := deploymentToInfo(d); info.Replicas != 1 {
+		t.Errorf("unset replicas should default to 1, got %d", info.Replicas)
+	}
+}
+
+func TestDeploymentToInfo_Selector(t *testing.T) {
+	d := createTestDeployment("web", "default", 1, 1)
+	if info := deploymentToInfo(d); info.Selector != "" {
+		t.Errorf("expected no selector, got %q", info.Selector)
+	}
+
+	d.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web", "tier": "frontend"}}
+	if info := deploymentToInfo(d); info.Selector != "app=web,tier=frontend" {
+		t.Errorf("expected the pod selector, got %q", info.Selector)
+	}
+}
+
+// addScaleReactors serves the scale subresource, which the fake object
+// tracker doesn't implement, and records the last scale update
+func addScaleReactors(fakeClient *fake.Clientset, replicas int32) *autoscalingv1.Scale {
+	updated := &autoscalingv1.Scale{}
+	fakeClient.PrependReactor("get", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "scale" {
+			return false, nil, nil
+		}
+		name := action.(k8stesting.GetAction).GetName()
+		return true, &autoscalingv1.Scale{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: action.GetNamespace()},
+			Spec:       autoscalingv1.ScaleSpec{Replicas: replicas},
+		}, nil
+	})
+	fakeClient.PrependReactor("update", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "scale" {
+			return false, nil, nil
+		}
+		scale := action.(k8stesting.UpdateAction).GetObject().(*autoscalingv1.Scale)
+		*updated = *scale
+		return true, scale, nil
+	})
+	return updated
+}
+
+func TestClient_ScaleDeployment(t *testing.T) {
+	fakeClient := fake.NewClientset(createTestDeployment("web", "default", 3, 3))
+	updated := addScaleReactors(fakeClient, 3)
+	client := &Client{clientset: fakeClient, currentNamespace: "default"}
+
+	if err := client.ScaleDeployment(context.Background(), "", "web", 5); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if updated.Name != "web" || updated.Spec.Replicas != 5 {
+		t.Errorf("expected web scaled to 5, got %s to %d", updated.Name, updated.Spec.Replicas)
+	}
+}
+
+func TestClient_ScaleDeployment_Errors(t *testing.T) {
+	client := &Client{clientset: fake.NewClientset(), currentNamespace: "default"}
+
+	if err := client.ScaleDeployment(context.Background(), "", "missing", 2); err == nil {
+		t.Error("expected error for a missing deployment")
+	}
+	if err := client.ScaleDeployment(context.Background(), "", "missing", -1); err == nil {
+		t.Error("expected error for a negative replica count")
+	}
+}
+
+func TestClient_RestartDeployment(t *testing.T) {
+	fakeClient := fake.NewClientset(createTestDeployment("web", "default", 3, 3))
+	client := &Client{clientset: fakeClient, currentNamespace: "default"}
+
+	if err := client.RestartDeployment(context.Background(), "", "web"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	d, err := fakeClient.AppsV1().Deployments("default").Get(context.Background(), "web", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get deployment: %v", err)
+	}
+	if d.Spec.Template.Annotations[RestartedAtAnnotation] == "" {
+		t.Error("restart should stamp the pod template annotation")
+	}
+}
+
+func TestClient_RestartWorkload(t *testing.T) {
+	fakeClient := fake.NewClientset(
+		createTestDeployment("web", "shop", 3, 3),
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "shop"}},
+	)
+	client := &Client{clientset: fakeClient, currentNamespace: "default"}
+	ctx := context.Background()
+
+	if err := client.RestartWorkload(ctx, "shop", "Deployment", "web"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	d, err := fakeClient.AppsV1().Deployments("shop").Get(ctx, "web", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get deployment: %v", err)
+	}
+	if d.Spec.Template.Annotations[RestartedAtAnnotation] == "" {
+		t.Error("restart should stamp the deployment's pod template")
+	}
+
+	if err := client.RestartWorkload(ctx, "shop", "StatefulSet", "db"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sts, err := fakeClient.AppsV1().StatefulSets("shop").Get(ctx, "db", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get statefulset: %v", err)
+	}
+	if sts.Spec.Template.Annotations[RestartedAtAnnotation] == "" {
+		t.Error("restart should stamp the statefulset's pod template")
+	}
+
+	if err := client.RestartWorkload(ctx, "shop", "DaemonSet", "missing"); err == nil {
+		t.Error("expected an error for a missing daemonset")
+	}
+	before := len(fakeClient.Actions())
+	if err := client.RestartWorkload(ctx, "shop", "Job", "migrate"); err == nil {
+		t.Error("expected an error for a kind that can't be restarted")
+	}
+	if len(fakeClient.Actions()) != before {
+		t.Error("unsupported kinds should not reach the API")
+	}
+}
+
+func TestRestartPatch(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	data, err := restartPatch(now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var d appsv1.Deployment
+	if err := json.Unmarshal(data, &d); err != nil {
+		t.Fatalf("patch is not valid JSON: %v", err)
+	}
+	if got := d.Spec.Template.Annotations[RestartedAtAnnotation]; got != "2026-01-02T03:04:05Z" {
+		t.Errorf("unexpected restartedAt %q", got)
+	}
+}
@@ -0,0 +1,200 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ErrNoEnvCommand is returned when the container has no env binary to
+// print its runtime environment with, e.g. a distroless image
+var ErrNoEnvCommand = errors.New("no env command in container")
+
+// sensitiveEnvNameHints mark variable names whose values are likely
+// credentials
+var sensitiveEnvNameHints = []string{
+	"PASSWORD", "PASSWD", "SECRET", "TOKEN", "KEY", "CREDENTIAL", "AUTH", "PRIVATE",
+}
+
+// SensitiveEnvName reports whether a variable's name suggests its value is
+// a credential. The env view masks such values and bundles redact them.
+func SensitiveEnvName(name string) bool {
+	name = strings.ToUpper(name)
+	for _, hint := range sensitiveEnvNameHints {
+		if strings.Contains(name, hint) {
+			return true
+		}
+	}
+	return false
+}
+
+// EnvVar is one environment variable of a container
+type EnvVar struct {
+	Name  string
+	Value string
+
+	// Source describes where a declared value comes from, e.g.
+	// "secret db/password"; empty for literal and runtime values
+	Source string
+
+	// Secret is set for values taken from a Secret
+	Secret bool
+}
+
+// GetContainerEnv returns the environment declared in the container spec,
+// sorted by name. References to Secrets, ConfigMaps and fields are listed
+// with their source rather than resolved; envFrom entries are listed as
+// their prefix followed by "*".
+func (c *Client) GetContainerEnv(ctx context.Context, namespace, pod, container string) ([]EnvVar, error) {
+	spec, err := c.getContainerSpec(ctx, namespace, pod, container)
+	if err != nil {
+		return nil, err
+	}
+	return declaredEnv(spec), nil
+}
+
+// GetRuntimeEnv returns the environment the container's processes see by
+// running env in it, through busybox when the image has no env on PATH,
+// sorted by name. Variables declared from a Secret are marked as such.
+// Without an env binary it returns ErrNoEnvCommand.
+func (c *Client) GetRuntimeEnv(ctx context.Context, namespace, pod, container string) ([]EnvVar, error) {
+	if namespace == "" {
+		namespace = c.currentNamespace
+	}
+
+	command, err := c.envCommand(ctx, namespace, pod, container)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read environment of %s/%s: %w", pod, container, err)
+	}
+	result := c.Exec(ctx, ExecOptions{
+		Namespace: namespace,
+		Pod:       pod,
+		Container: container,
+		Command:   command,
+	})
+	if IsExecNotFound(result.Error) {
+		return nil, ErrNoEnvCommand
+	}
+	if err := result.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read environment of %s/%s: %w", pod, container, err)
+	}
+	vars := ParseEnv(result.Stdout)
+
+	// The spec tells which values came from Secrets; without it the
+	// runtime values are still worth showing
+	spec, err := c.getContainerSpec(ctx, namespace, pod, container)
+	if err != nil {
+		return vars, nil
+	}
+	secret := make(map[string]bool)
+	for _, v := range declaredEnv(spec) {
+		if v.Secret {
+			secret[v.Name] = true
+		}
+	}
+	for i := range vars {
+		vars[i].Secret = secret[vars[i].Name]
+	}
+	return vars, nil
+}
+
+// envCommand builds the command printing the container's environment. The
+// container's tools are probed like for the file browser; one without file
+// utilities may still have env, so that isn't an error here.
+func (c *Client) envCommand(ctx context.Context, namespace, pod, container string) ([]string, error) {
+	shell, err := c.ProbeShell(ctx, FileOptions{Namespace: namespace, Pod: pod, Container: container})
+	if err != nil && !errors.Is(err, errNoFileUtils) {
+		return nil, err
+	}
+	return shell.Command("env"), nil
+}
+
+// getContainerSpec returns the spec of a container, or of the pod's first
+// container when none is named
+func (c *Client) getContainerSpec(ctx context.Context, namespace, pod, container string) (corev1.Container, error) {
+	if namespace == "" {
+		namespace = c.currentNamespace
+	}
+
+	p, err := c.clientset.CoreV1().Pods(namespace).Get(ctx, pod, metav1.GetOptions{})
+	if err != nil {
+		return corev1.Container{}, fmt.Errorf("failed to get pod %q in namespace %q: %w", pod, namespace, err)
+	}
+	for _, ct := range p.Spec.Containers {
+		if container == "" || ct.Name == container {
+			return ct, nil
+		}
+	}
+	for _, ct := range p.Spec.InitContainers {
+		if ct.Name == container {
+			return ct, nil
+		}
+	}
+	return corev1.Container{}, fmt.Errorf("container %q not found in pod %q", container, pod)
+}
+
+// declaredEnv lists a container's env and envFrom entries by name
+func declaredEnv(container corev1.Container) []EnvVar {
+	vars := make([]EnvVar, 0, len(container.Env)+len(container.EnvFrom))
+	for _, e := range container.Env {
+		v := EnvVar{Name: e.Name, Value: e.Value}
+		if from := e.ValueFrom; from != nil {
+			switch {
+			case from.SecretKeyRef != nil:
+				v.Source = fmt.Sprintf("secret %s/%s", from.SecretKeyRef.Name, from.SecretKeyRef.Key)
+				v.Secret = true
+			case from.ConfigMapKeyRef != nil:
+				v.Source = fmt.Sprintf("configmap %s/%s", from.ConfigMapKeyRef.Name, from.ConfigMapKeyRef.Key)
+			case from.FieldRef != nil:
+				v.Source = "field " + from.FieldRef.FieldPath
+			case from.ResourceFieldRef != nil:
+				v.Source = "resource " + from.ResourceFieldRef.Resource
+			}
+		}
+		vars = append(vars, v)
+	}
+	for _, e := range container.EnvFrom {
+		v := EnvVar{Name: e.Prefix + "*"}
+		switch {
+		case e.SecretRef != nil:
+			v.Source = "all keys of secret " + e.SecretRef.Name
+			v.Secret = true
+		case e.ConfigMapRef != nil:
+			v.Source = "all keys of configmap " + e.ConfigMapRef.Name
+		}
+		vars = append(vars, v)
+	}
+	sortEnv(vars)
+	return vars
+}
+
+// ParseEnv parses the output of env into variables sorted by name. A line
+// that doesn't start a new NAME=value pair continues the previous value,
+// as values may contain newlines.
+func ParseEnv(output string) []EnvVar {
+	var vars []EnvVar
+	for _, line := range strings.Split(strings.TrimRight(output, "\n"), "\n") {
+		name, value, ok := strings.Cut(line, "=")
+		if !ok || name == "" || strings.ContainsAny(name, " \t") {
+			if len(vars) > 0 {
+				vars[len(vars)-1].Value += "\n" + line
+			}
+			continue
+		}
+		vars = append(vars, EnvVar{Name: name, Value: value})
+	}
+	sortEnv(vars)
+	return vars
+}
+
+// sortEnv orders variables by name, keeping duplicates in their order
+func sortEnv(vars []EnvVar) {
+	slices.SortStableFunc(vars, func(a, b EnvVar) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+}
@@ -0,0 +1,142 @@
+package k8s
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestClient_GetContainerEnv(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "app",
+					Env: []corev1.EnvVar{
+						{Name: "LOG_LEVEL", Value: "info"},
+						{Name: "DB_PASSWORD", ValueFrom: &corev1.EnvVarSource{
+							SecretKeyRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "db"},
+								Key:                  "password",
+							},
+						}},
+						{Name: "POD_IP", ValueFrom: &corev1.EnvVarSource{
+							FieldRef: &corev1.ObjectFieldSelector{FieldPath: "status.podIP"},
+						}},
+						{Name: "FEATURES", ValueFrom: &corev1.EnvVarSource{
+							ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "app-config"},
+								Key:                  "features",
+							},
+						}},
+					},
+					EnvFrom: []corev1.EnvFromSource{
+						{Prefix: "APP_", ConfigMapRef: &corev1.ConfigMapEnvSource{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "app-config"},
+						}},
+					},
+				},
+				{Name: "sidecar", Env: []corev1.EnvVar{{Name: "PROXY", Value: "on"}}},
+			},
+		},
+	}
+	client := &Client{clientset: fake.NewClientset(pod), currentNamespace: "default"}
+
+	vars, err := client.GetContainerEnv(context.Background(), "", "web", "app")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []EnvVar{
+		{Name: "APP_*", Source: "all keys of configmap app-config"},
+		{Name: "DB_PASSWORD", Source: "secret db/password", Secret: true},
+		{Name: "FEATURES", Source: "configmap app-config/features"},
+		{Name: "LOG_LEVEL", Value: "info"},
+		{Name: "POD_IP", Source: "field status.podIP"},
+	}
+	if !reflect.DeepEqual(vars, want) {
+		t.Errorf("expected %+v, got %+v", want, vars)
+	}
+
+	vars, err = client.GetContainerEnv(context.Background(), "default", "web", "sidecar")
+	if err != nil || len(vars) != 1 || vars[0].Name != "PROXY" {
+		t.Errorf("expected the sidecar's env, got %+v (%v)", vars, err)
+	}
+
+	if _, err := client.GetContainerEnv(context.Background(), "default", "web", "missing"); err == nil {
+		t.Error("expected an error for an unknown container")
+	}
+	if _, err := client.GetContainerEnv(context.Background(), "default", "gone", "app"); err == nil {
+		t.Error("expected an error for an unknown pod")
+	}
+}
+
+func TestClient_EnvCommand(t *testing.T) {
+	// Probe results are cached, so no exec is needed
+	c := &Client{}
+	c.shells.set("default/web/app", ShellInfo{Shell: "sh", Coreutils: true})
+	c.shells.set("default/web/hardened", ShellInfo{Busybox: busyboxPath})
+	c.shells.set("default/web/distroless", ShellInfo{})
+
+	tests := []struct {
+		container string
+		want      []string
+	}{
+		{"app", []string{"env"}},
+		{"hardened", []string{busyboxPath, "env"}},
+		{"distroless", []string{"env"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.container, func(t *testing.T) {
+			got, err := c.envCommand(context.Background(), "default", "web", tt.container)
+			if err != nil {
+				t.Fatalf("envCommand() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("envCommand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSensitiveEnvName(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"DB_PASSWORD", true},
+		{"api_token", true},
+		{"AWS_SECRET_ACCESS_KEY", true},
+		{"BASIC_AUTH", true},
+		{"LOG_LEVEL", false},
+		{"PATH", false},
+	}
+	for _, tt := range tests {
+		if got := SensitiveEnvName(tt.name); got != tt.want {
+			t.Errorf("SensitiveEnvName(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseEnv(t *testing.T) {
+	output := "PATH=/usr/bin:/bin\nHOSTNAME=web-1\nCERT=-----BEGIN-----\nabc def\n-----END-----\nEMPTY=\nA=b=c\n"
+
+	want := []EnvVar{
+		{Name: "A", Value: "b=c"},
+		{Name: "CERT", Value: "-----BEGIN-----\nabc def\n-----END-----"},
+		{Name: "EMPTY", Value: ""},
+		{Name: "HOSTNAME", Value: "web-1"},
+		{Name: "PATH", Value: "/usr/bin:/bin"},
+	}
+	if got := ParseEnv(output); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+
+	if got := ParseEnv(""); len(got) != 0 {
+		t.Errorf("expected no variables, got %+v", got)
+	}
+}
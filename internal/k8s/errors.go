@@ -0,0 +1,96 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"syscall"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// ErrorKind is a coarse classification of API errors, for messages that
+// tell the user what to do about them
+type ErrorKind int
+
+const (
+	ErrorOther        ErrorKind = iota // Anything not classified below
+	ErrorForbidden                     // RBAC denied the request
+	ErrorUnauthorized                  // Credentials missing, invalid or expired
+	ErrorNotFound                      // The resource does not exist
+	ErrorTimeout                       // The request or the server timed out
+	ErrorUnreachable                   // Other transient network failures
+)
+
+// ClassifyError returns the kind of err. Timeouts and unreachable servers
+// are worth retrying, the other kinds are not.
+func ClassifyError(err error) ErrorKind {
+	var netErr net.Error
+	switch {
+	case err == nil:
+		return ErrorOther
+	case apierrors.IsForbidden(err):
+		return ErrorForbidden
+	case apierrors.IsUnauthorized(err):
+		return ErrorUnauthorized
+	case apierrors.IsNotFound(err):
+		return ErrorNotFound
+	case apierrors.IsServerTimeout(err), apierrors.IsTimeout(err),
+		errors.Is(err, context.DeadlineExceeded),
+		errors.As(err, &netErr) && netErr.Timeout():
+		return ErrorTimeout
+	case IsRetryable(err):
+		return ErrorUnreachable
+	}
+	return ErrorOther
+}
+
+// IsForbidden reports whether err is an RBAC denial, e.g. when listing
+// pods across all namespaces is not allowed
+func IsForbidden(err error) bool {
+	return apierrors.IsForbidden(err)
+}
+
+// IsRetryable reports whether err looks transient (network blips, timeouts,
+// overloaded API server) so the request is worth retrying. Auth and
+// validation errors are not retryable.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	switch {
+	case apierrors.IsUnauthorized(err), apierrors.IsForbidden(err),
+		apierrors.IsNotFound(err), apierrors.IsBadRequest(err), apierrors.IsInvalid(err):
+		return false
+	case apierrors.IsServerTimeout(err), apierrors.IsTimeout(err),
+		apierrors.IsTooManyRequests(err), apierrors.IsServiceUnavailable(err),
+		apierrors.IsInternalError(err):
+		return true
+	case errors.Is(err, context.DeadlineExceeded),
+		errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF),
+		errors.Is(err, syscall.ECONNREFUSED), errors.Is(err, syscall.ECONNRESET),
+		errors.Is(err, syscall.EHOSTUNREACH), errors.Is(err, syscall.ENETUNREACH):
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+
+	// Some client-go paths flatten the cause into the message
+	msg := strings.ToLower(err.Error())
+	for _, s := range []string{"connection refused", "connection reset", "i/o timeout", "eof", "tls handshake timeout"} {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
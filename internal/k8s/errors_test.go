@@ -0,0 +1,74 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"syscall"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestIsRetryable(t *testing.T) {
+	podsResource := schema.GroupResource{Resource: "pods"}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"connection refused", fmt.Errorf("failed to list pods: %w",
+			&net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}), true},
+		{"deadline exceeded", fmt.Errorf("failed to list pods: %w", context.DeadlineExceeded), true},
+		{"unexpected EOF", fmt.Errorf("failed to list pods: %w", io.ErrUnexpectedEOF), true},
+		{"flattened message", errors.New(`Get "https://10.0.0.1/api": dial tcp: connection refused`), true},
+		{"server timeout", apierrors.NewServerTimeout(podsResource, "list", 1), true},
+		{"service unavailable", apierrors.NewServiceUnavailable("overloaded"), true},
+		{"unauthorized", apierrors.NewUnauthorized("token expired"), false},
+		{"forbidden", apierrors.NewForbidden(podsResource, "", errors.New("no access")), false},
+		{"not found", apierrors.NewNotFound(podsResource, "web"), false},
+		{"other", errors.New("context \"prod\" not found"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	podsResource := schema.GroupResource{Resource: "pods"}
+
+	tests := []struct {
+		name string
+		err  error
+		want ErrorKind
+	}{
+		{"nil", nil, ErrorOther},
+		{"forbidden", fmt.Errorf("failed to list pods: %w",
+			apierrors.NewForbidden(podsResource, "", errors.New("no access"))), ErrorForbidden},
+		{"unauthorized", apierrors.NewUnauthorized("token expired"), ErrorUnauthorized},
+		{"not found", apierrors.NewNotFound(podsResource, "web"), ErrorNotFound},
+		{"server timeout", apierrors.NewServerTimeout(podsResource, "list", 1), ErrorTimeout},
+		{"deadline exceeded", fmt.Errorf("failed to list pods: %w", context.DeadlineExceeded), ErrorTimeout},
+		{"connection refused", fmt.Errorf("failed to list pods: %w",
+			&net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}), ErrorUnreachable},
+		{"other", errors.New("invalid label selector"), ErrorOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyError(tt.err); got != tt.want {
+				t.Errorf("ClassifyError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// EventInfo contains information about a Kubernetes event
+type EventInfo struct {
+	Type     string // Normal or Warning
+	Reason   string
+	Message  string
+	Count    int32
+	LastSeen time.Time
+	Source   string // Reporting component, e.g. kubelet
+	Object   string // Involved object, e.g. Pod/web-1
+}
+
+// IsWarning reports whether the event is a Warning rather than Normal
+func (e EventInfo) IsWarning() bool {
+	return e.Type == corev1.EventTypeWarning
+}
+
+// ListEvents returns the events in a namespace (or current namespace if
+// empty), oldest first. A non-empty involvedObject limits them to events
+// about objects of that name.
+func (c *Client) ListEvents(ctx context.Context, namespace, involvedObject string) ([]EventInfo, error) {
+	return c.listEvents(ctx, namespace, "", involvedObject)
+}
+
+// ListPodEvents returns the events for a pod, oldest first
+func (c *Client) ListPodEvents(ctx context.Context, namespace, pod string) ([]EventInfo, error) {
+	return c.listEvents(ctx, namespace, "Pod", pod)
+}
+
+// listEvents lists events filtered by involved object kind and name; empty
+// values match everything
+func (c *Client) listEvents(ctx context.Context, namespace, kind, name string) ([]EventInfo, error) {
+	if namespace == "" {
+		namespace = c.currentNamespace
+	}
+
+	var selectors []fields.Selector
+	if kind != "" {
+		selectors = append(selectors, fields.OneTermEqualSelector("involvedObject.kind", kind))
+	}
+	if name != "" {
+		selectors = append(selectors, fields.OneTermEqualSelector("involvedObject.name", name))
+	}
+	events, err := c.clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.AndSelectors(selectors...).String(),
+	})
+	if err != nil {
+		if name == "" {
+			return nil, fmt.Errorf("failed to list events in namespace %q: %w", namespace, err)
+		}
+		return nil, fmt.Errorf("failed to list events for %q: %w", name, err)
+	}
+
+	result := make([]EventInfo, 0, len(events.Items))
+	for i := range events.Items {
+		ev := &events.Items[i]
+		// Field selectors aren't applied by every API implementation (e.g. fakes)
+		if (kind != "" && ev.InvolvedObject.Kind != kind) || (name != "" && ev.InvolvedObject.Name != name) {
+			continue
+		}
+		result = append(result, eventToInfo(ev))
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].LastSeen.Before(result[j].LastSeen)
+	})
+
+	return result, nil
+}
+
+// eventToInfo converts an event, falling back through the timestamps
+// populated by the different event APIs
+func eventToInfo(ev *corev1.Event) EventInfo {
+	lastSeen := ev.LastTimestamp.Time
+	if lastSeen.IsZero() {
+		lastSeen = ev.EventTime.Time
+	}
+	if lastSeen.IsZero() {
+		lastSeen = ev.CreationTimestamp.Time
+	}
+
+	source := ev.Source.Component
+	if source == "" {
+		source = ev.ReportingController
+	}
+
+	count := ev.Count
+	if count == 0 {
+		count = 1
+	}
+
+	return EventInfo{
+		Type:     ev.Type,
+		Reason:   ev.Reason,
+		Message:  ev.Message,
+		Count:    count,
+		LastSeen: lastSeen,
+		Source:   source,
+		Object:   ev.InvolvedObject.Kind + "/" + ev.InvolvedObject.Name,
+	}
+}
@@ -0,0 +1,112 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func createTestEvent(name, pod, reason string, lastSeen time.Time) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		InvolvedObject: corev1.ObjectReference{
+			Kind:      "Pod",
+			Name:      pod,
+			Namespace: "default",
+		},
+		Type:          corev1.EventTypeWarning,
+		Reason:        reason,
+		Message:       reason + " happened",
+		LastTimestamp: metav1.Time{Time: lastSeen},
+		Source:        corev1.EventSource{Component: "kubelet"},
+	}
+}
+
+func TestClient_ListPodEvents(t *testing.T) {
+	now := time.Now()
+	objects := []runtime.Object{
+		createTestEvent("e1", "web-1", "BackOff", now),
+		createTestEvent("e2", "web-1", "Pulled", now.Add(-time.Minute)),
+		createTestEvent("e3", "other-pod", "Killing", now),
+	}
+
+	client := &Client{clientset: fake.NewClientset(objects...), currentNamespace: "default"}
+
+	events, err := client.ListPodEvents(context.Background(), "", "web-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events for web-1, got %d", len(events))
+	}
+
+	// Oldest first
+	if events[0].Reason != "Pulled" || events[1].Reason != "BackOff" {
+		t.Errorf("expected events sorted by last seen, got %s, %s", events[0].Reason, events[1].Reason)
+	}
+	if events[0].Count != 1 {
+		t.Errorf("missing count should default to 1, got %d", events[0].Count)
+	}
+	if events[0].Source != "kubelet" {
+		t.Errorf("expected source kubelet, got %q", events[0].Source)
+	}
+}
+
+func TestClient_ListEvents(t *testing.T) {
+	now := time.Now()
+	rsEvent := createTestEvent("e4", "web", "SuccessfulCreate", now.Add(-2*time.Minute))
+	rsEvent.InvolvedObject.Kind = "ReplicaSet"
+	rsEvent.Type = corev1.EventTypeNormal
+	objects := []runtime.Object{
+		createTestEvent("e1", "web-1", "BackOff", now),
+		createTestEvent("e2", "web-1", "Pulled", now.Add(-time.Minute)),
+		createTestEvent("e3", "other-pod", "Killing", now.Add(-30*time.Second)),
+		rsEvent,
+	}
+
+	client := &Client{clientset: fake.NewClientset(objects...), currentNamespace: "default"}
+
+	all, err := client.ListEvents(context.Background(), "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(all) != 4 {
+		t.Fatalf("expected all 4 events in the namespace, got %d", len(all))
+	}
+	if all[0].Reason != "SuccessfulCreate" || all[3].Reason != "BackOff" {
+		t.Errorf("expected events sorted by last seen, got %s first and %s last", all[0].Reason, all[3].Reason)
+	}
+	if all[0].Object != "ReplicaSet/web" || all[0].IsWarning() {
+		t.Errorf("unexpected first event %+v", all[0])
+	}
+
+	pod, err := client.ListEvents(context.Background(), "", "web-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pod) != 2 || !pod[1].IsWarning() {
+		t.Errorf("expected 2 warning events for web-1, got %+v", pod)
+	}
+}
+
+func TestEventToInfo_TimestampFallback(t *testing.T) {
+	eventTime := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	ev := &corev1.Event{
+		EventTime:           metav1.MicroTime{Time: eventTime},
+		ReportingController: "scheduler",
+	}
+
+	info := eventToInfo(ev)
+	if !info.LastSeen.Equal(eventTime) {
+		t.Errorf("expected EventTime fallback, got %v", info.LastSeen)
+	}
+	if info.Source != "scheduler" {
+		t.Errorf("expected reporting controller fallback, got %q", info.Source)
+	}
+}
@@ -1,14 +1,19 @@
 package k8s
 
 import (
+	"bufio"
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"strings"
+	"sync"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
 )
 
 // ExecOptions configures command execution in a pod
@@ -17,9 +22,12 @@ type ExecOptions struct {
 	Pod       string
 	Container string
 	Command   []string
+	Stdin     io.Reader // Optional input piped to the command
 }
 
-// ExecResult holds the output of a command execution
+// ExecResult holds the output of a command execution. Error is only set
+// when the command could not be run; a command that ran and exited
+// non-zero reports its status in ExitCode.
 type ExecResult struct {
 	Stdout   string
 	Stderr   string
@@ -27,6 +35,18 @@ type ExecResult struct {
 	Error    error
 }
 
+// Err returns the error that stopped the command, or one describing its
+// non-zero exit, for callers that treat any failure alike
+func (r ExecResult) Err() error {
+	if r.Error != nil {
+		return r.Error
+	}
+	if r.ExitCode != 0 {
+		return fmt.Errorf("command exited with code %d", r.ExitCode)
+	}
+	return nil
+}
+
 // Validate checks that the exec options are valid
 func (o ExecOptions) Validate() error {
 	if o.Namespace == "" {
@@ -46,13 +66,62 @@ func (o ExecOptions) CommandString() string {
 	return strings.Join(o.Command, " ")
 }
 
+// ExecChunk is a piece of output from a command started with ExecStream.
+// The last chunk has Done set and reports how the command ended, like
+// ExecResult's ExitCode and Error.
+type ExecChunk struct {
+	Data   string
+	Stderr bool
+
+	Done     bool
+	ExitCode int
+	Error    error
+}
+
 // Exec executes a command in a pod and returns the result.
 // This is a synchronous operation - it blocks until the command completes.
 func (c *Client) Exec(ctx context.Context, opts ExecOptions) ExecResult {
-	if err := opts.Validate(); err != nil {
+	exec, err := c.executor(opts)
+	if err != nil {
 		return ExecResult{Error: err}
 	}
 
+	// Capture stdout and stderr
+	var stdout, stderr bytes.Buffer
+
+	// Execute the command
+	err = exec.StreamWithContext(ctx, streamOptions(opts, &stdout, &stderr))
+
+	result := ExecResult{
+		Stdout: stdout.String(),
+		Stderr: stderr.String(),
+	}
+	result.ExitCode, result.Error = execStatus(err)
+
+	return result
+}
+
+// ExecStream starts a command in a pod and returns its output as it is
+// written, a line at a time, so slow or endless commands like tail -f show
+// progress. The channel ends with a Done chunk and is closed; cancelling
+// ctx stops the command.
+func (c *Client) ExecStream(ctx context.Context, opts ExecOptions) (<-chan ExecChunk, error) {
+	exec, err := c.executor(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return streamExec(ctx, func(stdout, stderr io.Writer) error {
+		return exec.StreamWithContext(ctx, streamOptions(opts, stdout, stderr))
+	}), nil
+}
+
+// executor validates opts and prepares the exec request for the pod
+func (c *Client) executor(opts ExecOptions) (remotecommand.Executor, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
 	// Build the exec request
 	req := c.clientset.CoreV1().RESTClient().Post().
 		Resource("pods").
@@ -60,46 +129,116 @@ func (c *Client) Exec(ctx context.Context, opts ExecOptions) ExecResult {
 		Namespace(opts.Namespace).
 		SubResource("exec")
 
-	// Set up exec options
-	execOpts := &corev1.PodExecOptions{
-		Container: opts.Container,
-		Command:   opts.Command,
-		Stdin:     false,
-		Stdout:    true,
-		Stderr:    true,
-		TTY:       false,
-	}
-
-	req.VersionedParams(execOpts, scheme.ParameterCodec)
+	req.VersionedParams(execParams(opts), scheme.ParameterCodec)
 
-	// Create the executor
 	exec, err := remotecommand.NewSPDYExecutor(c.config, "POST", req.URL())
 	if err != nil {
-		return ExecResult{Error: fmt.Errorf("failed to create executor: %w", err)}
+		return nil, fmt.Errorf("failed to create executor: %w", err)
 	}
+	return exec, nil
+}
 
-	// Capture stdout and stderr
-	var stdout, stderr bytes.Buffer
+// streamExec runs stream with pipes feeding the returned channel. Once ctx
+// is cancelled chunks are dropped, but the pipes are still drained so the
+// executor never blocks writing to them.
+func streamExec(ctx context.Context, stream func(stdout, stderr io.Writer) error) <-chan ExecChunk {
+	chunks := make(chan ExecChunk, 100)
+	send := func(chunk ExecChunk) {
+		select {
+		case chunks <- chunk:
+		case <-ctx.Done():
+		}
+	}
 
-	// Execute the command
-	err = exec.StreamWithContext(ctx, remotecommand.StreamOptions{
-		Stdout: &stdout,
-		Stderr: &stderr,
-		Tty:    false,
-	})
+	stdoutReader, stdoutWriter := io.Pipe()
+	stderrReader, stderrWriter := io.Pipe()
 
-	result := ExecResult{
-		Stdout: stdout.String(),
-		Stderr: stderr.String(),
+	var readers sync.WaitGroup
+	read := func(r io.Reader, isStderr bool) {
+		defer readers.Done()
+		br := bufio.NewReader(r)
+		for {
+			line, err := br.ReadString('\n')
+			if line != "" {
+				send(ExecChunk{Data: line, Stderr: isStderr})
+			}
+			if err != nil {
+				return
+			}
+		}
 	}
+	readers.Add(2)
+	go read(stdoutReader, false)
+	go read(stderrReader, true)
+
+	go func() {
+		defer close(chunks)
+		err := stream(stdoutWriter, stderrWriter)
+		_ = stdoutWriter.Close()
+		_ = stderrWriter.Close()
+		readers.Wait()
 
+		code, err := execStatus(err)
+		send(ExecChunk{Done: true, ExitCode: code, Error: err})
+	}()
+
+	return chunks
+}
+
+// execStatus splits the executor's error into the exit code of a command
+// that ran and the error that kept it from running
+func execStatus(err error) (int, error) {
+	if code, ok := exitCodeFromError(err); ok {
+		return code, nil
+	}
 	if err != nil {
-		// Try to extract exit code from error message
-		result.Error = err
-		result.ExitCode = 1 // Default non-zero exit code for errors
+		return 1, err // Default non-zero exit code for errors
 	}
+	return 0, nil
+}
 
-	return result
+// execParams builds the exec request parameters. Stdin is only attached
+// when the options supply a reader.
+func execParams(opts ExecOptions) *corev1.PodExecOptions {
+	return &corev1.PodExecOptions{
+		Container: opts.Container,
+		Command:   opts.Command,
+		Stdin:     opts.Stdin != nil,
+		Stdout:    true,
+		Stderr:    true,
+		TTY:       false,
+	}
+}
+
+// streamOptions wires the command's streams to the given buffers
+func streamOptions(opts ExecOptions, stdout, stderr io.Writer) remotecommand.StreamOptions {
+	return remotecommand.StreamOptions{
+		Stdin:  opts.Stdin,
+		Stdout: stdout,
+		Stderr: stderr,
+		Tty:    false,
+	}
+}
+
+// exitCodeFromError extracts the exit status of a command that ran to
+// completion from the error returned by the executor
+func exitCodeFromError(err error) (int, bool) {
+	var exitErr utilexec.CodeExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code, true
+	}
+	return 0, false
+}
+
+// IsExecNotFound reports whether an exec failed because the command
+// doesn't exist in the container, e.g. a distroless image without a shell
+func IsExecNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "executable file not found") ||
+		strings.Contains(msg, "no such file or directory")
 }
 
 // ParseCommand splits a command string into arguments.
@@ -134,3 +273,24 @@ func ParseCommand(cmd string) []string {
 
 	return args
 }
+
+// SplitStdinRedirect separates a trailing "< path" from a command line, so
+// `sh -c "cat > /tmp/x" < notes.txt` pipes the local notes.txt to the
+// command. A '<' inside double quotes belongs to the command.
+func SplitStdinRedirect(cmd string) (command, stdinPath string) {
+	redirect := -1
+	inQuotes := false
+	for i, r := range cmd {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case r == '<' && !inQuotes:
+			redirect = i
+		}
+	}
+
+	if redirect < 0 || strings.TrimSpace(cmd[redirect+1:]) == "" {
+		return strings.TrimSpace(cmd), ""
+	}
+	return strings.TrimSpace(cmd[:redirect]), strings.TrimSpace(cmd[redirect+1:])
+}
@@ -1,7 +1,16 @@
 package k8s
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
 	"testing"
+	"time"
+
+	"go.uber.org/goleak"
+	utilexec "k8s.io/client-go/util/exec"
 )
 
 func TestExecOptions_Validate(t *testing.T) {
@@ -130,6 +139,22 @@ func TestExecResult(t *testing.T) {
 	}
 }
 
+func TestExecResult_Err(t *testing.T) {
+	if err := (ExecResult{}).Err(); err != nil {
+		t.Errorf("Err() = %v, want nil for a clean exit", err)
+	}
+
+	runErr := errors.New("connection refused")
+	if err := (ExecResult{Error: runErr, ExitCode: 1}).Err(); err != runErr {
+		t.Errorf("Err() = %v, want %v", err, runErr)
+	}
+
+	err := (ExecResult{ExitCode: 2, Stderr: "ls: /nope: No such file or directory"}).Err()
+	if err == nil || !strings.Contains(err.Error(), "code 2") {
+		t.Errorf("Err() = %v, want an error mentioning the exit code", err)
+	}
+}
+
 func TestParseCommand(t *testing.T) {
 	tests := []struct {
 		name string
@@ -197,3 +222,172 @@ func TestParseCommand(t *testing.T) {
 // Note: Testing the actual Exec method requires a real Kubernetes cluster
 // or integration tests, as the SPDY executor is difficult to mock.
 // The Client.Exec method is tested via manual/integration testing.
+
+func TestIsExecNotFound(t *testing.T) {
+	notFound := errors.New(`OCI runtime exec failed: exec failed: unable to start container process: exec: "sh": executable file not found in $PATH: unknown`)
+	if !IsExecNotFound(notFound) {
+		t.Error("missing executable should be detected")
+	}
+	if IsExecNotFound(errors.New("command terminated with non-zero exit code: exit status 1")) {
+		t.Error("a failing command is not a missing executable")
+	}
+	if IsExecNotFound(nil) {
+		t.Error("nil error is not a missing executable")
+	}
+}
+
+func TestExecParams_Stdin(t *testing.T) {
+	opts := ExecOptions{Namespace: "default", Pod: "web", Command: []string{"cat"}}
+
+	if params := execParams(opts); params.Stdin {
+		t.Error("stdin should not be requested without a reader")
+	}
+	if stream := streamOptions(opts, io.Discard, io.Discard); stream.Stdin != nil {
+		t.Error("no stdin stream should be attached without a reader")
+	}
+
+	input := strings.NewReader("hello")
+	opts.Stdin = input
+	params := execParams(opts)
+	if !params.Stdin || !params.Stdout || !params.Stderr || params.TTY {
+		t.Errorf("expected stdin, stdout and stderr without a TTY, got %+v", params)
+	}
+	if stream := streamOptions(opts, io.Discard, io.Discard); stream.Stdin != input {
+		t.Error("the reader should be wired to the stdin stream")
+	}
+}
+
+func TestSplitStdinRedirect(t *testing.T) {
+	tests := []struct {
+		name        string
+		cmd         string
+		wantCommand string
+		wantPath    string
+	}{
+		{"no redirect", "ls -la", "ls -la", ""},
+		{"redirect", `sh -c "cat > /tmp/x" < ~/notes.txt`, `sh -c "cat > /tmp/x"`, "~/notes.txt"},
+		{"quoted less-than", `sh -c "sort < /tmp/x"`, `sh -c "sort < /tmp/x"`, ""},
+		{"missing path", "cat <", "cat <", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			command, path := SplitStdinRedirect(tt.cmd)
+			if command != tt.wantCommand || path != tt.wantPath {
+				t.Errorf("SplitStdinRedirect(%q) = %q, %q; want %q, %q",
+					tt.cmd, command, path, tt.wantCommand, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestExitCodeFromError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+		wantOK   bool
+	}{
+		{"nil", nil, 0, false},
+		{"exit 2", utilexec.CodeExitError{Err: errors.New("command terminated with exit code 2"), Code: 2}, 2, true},
+		{"wrapped exit 127", fmt.Errorf("exec: %w", utilexec.CodeExitError{Err: errors.New("not found"), Code: 127}), 127, true},
+		{"connection error", errors.New("error dialing backend: connection refused"), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, ok := exitCodeFromError(tt.err)
+			if code != tt.wantCode || ok != tt.wantOK {
+				t.Errorf("exitCodeFromError() = %d, %v; want %d, %v", code, ok, tt.wantCode, tt.wantOK)
+			}
+		})
+	}
+}
+
+// collectChunks reads chunks until the channel closes
+func collectChunks(t *testing.T, chunks <-chan ExecChunk) []ExecChunk {
+	t.Helper()
+	var got []ExecChunk
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case chunk, ok := <-chunks:
+			if !ok {
+				return got
+			}
+			got = append(got, chunk)
+		case <-timeout:
+			t.Fatalf("channel not closed, got %+v so far", got)
+		}
+	}
+}
+
+func TestStreamExec(t *testing.T) {
+	chunks := streamExec(context.Background(), func(stdout, stderr io.Writer) error {
+		_, _ = io.WriteString(stdout, "one\ntwo\n")
+		_, _ = io.WriteString(stderr, "warning\n")
+		_, _ = io.WriteString(stdout, "partial")
+		return utilexec.CodeExitError{Err: errors.New("command terminated with exit code 3"), Code: 3}
+	})
+
+	var stdout, stderr []string
+	got := collectChunks(t, chunks)
+	for _, chunk := range got[:len(got)-1] {
+		if chunk.Stderr {
+			stderr = append(stderr, chunk.Data)
+		} else {
+			stdout = append(stdout, chunk.Data)
+		}
+	}
+	if strings.Join(stdout, "|") != "one\n|two\n|partial" {
+		t.Errorf("expected stdout a line at a time, got %q", stdout)
+	}
+	if strings.Join(stderr, "|") != "warning\n" {
+		t.Errorf("expected stderr flagged, got %q", stderr)
+	}
+
+	last := got[len(got)-1]
+	if !last.Done || last.ExitCode != 3 || last.Error != nil {
+		t.Errorf("expected a final chunk with exit code 3, got %+v", last)
+	}
+}
+
+func TestStreamExec_Error(t *testing.T) {
+	chunks := streamExec(context.Background(), func(stdout, stderr io.Writer) error {
+		return errors.New("error dialing backend: connection refused")
+	})
+
+	got := collectChunks(t, chunks)
+	if len(got) != 1 || !got[0].Done || got[0].Error == nil || got[0].ExitCode != 1 {
+		t.Errorf("expected only a final chunk with the error, got %+v", got)
+	}
+}
+
+func TestStreamExec_CancelWhileWriting(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	// An endless command keeps writing until the context is cancelled
+	ctx, cancel := context.WithCancel(context.Background())
+	chunks := streamExec(ctx, func(stdout, stderr io.Writer) error {
+		for ctx.Err() == nil {
+			if _, err := io.WriteString(stdout, "tick\n"); err != nil {
+				return err
+			}
+		}
+		return ctx.Err()
+	})
+
+	if chunk := <-chunks; chunk.Data != "tick\n" {
+		t.Errorf("expected output before cancel, got %+v", chunk)
+	}
+
+	// Nobody reads the channel after cancelling; the goroutines still end
+	cancel()
+}
+
+func TestClient_ExecStream_InvalidOptions(t *testing.T) {
+	c := &Client{}
+	if _, err := c.ExecStream(context.Background(), ExecOptions{Namespace: "default", Pod: "web"}); err == nil {
+		t.Error("expected an error without a command")
+	}
+}
@@ -1,11 +1,15 @@
 package k8s
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 // FileInfo represents a file or directory entry
@@ -17,8 +21,13 @@ type FileInfo struct {
 	Permissions string
 	Owner       string
 	Group       string
-	ModTime     string
-	LinkTarget  string // For symlinks
+	ModTime     string    // As ls printed it, e.g. "Jan 1 12:00"
+	Modified    time.Time // ModTime parsed for sorting; zero if unrecognized
+	LinkTarget  string    // For symlinks
+
+	// HasExtendedAttrs is set when the permissions carry a trailing ACL (+),
+	// SELinux context (.) or macOS extended attribute (@) marker
+	HasExtendedAttrs bool
 }
 
 // FileOptions configures file operations
@@ -48,17 +57,21 @@ func (c *Client) ListDir(ctx context.Context, opts FileOptions) ([]FileInfo, err
 	if err := opts.Validate(); err != nil {
 		return nil, err
 	}
+	shell, err := c.ProbeShell(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
 
 	// Run ls -la command
 	execOpts := ExecOptions{
 		Namespace: opts.Namespace,
 		Pod:       opts.Pod,
 		Container: opts.Container,
-		Command:   []string{"ls", "-la", opts.Path},
+		Command:   shell.Command("ls", "-la", opts.Path),
 	}
 
 	result := c.Exec(ctx, execOpts)
-	if result.Error != nil {
+	if err := result.Err(); err != nil {
 		// Check for common errors in stderr
 		if strings.Contains(result.Stderr, "No such file or directory") {
 			return nil, fmt.Errorf("directory not found: %s", opts.Path)
@@ -69,7 +82,7 @@ func (c *Client) ListDir(ctx context.Context, opts FileOptions) ([]FileInfo, err
 		if strings.Contains(result.Stderr, "Not a directory") {
 			return nil, fmt.Errorf("not a directory: %s", opts.Path)
 		}
-		return nil, fmt.Errorf("failed to list directory: %w", result.Error)
+		return nil, fmt.Errorf("failed to list directory: %w", err)
 	}
 
 	// Check stderr for errors even if command "succeeded"
@@ -85,13 +98,17 @@ func (c *Client) ReadFile(ctx context.Context, opts FileOptions, maxBytes int) (
 	if err := opts.Validate(); err != nil {
 		return "", err
 	}
+	shell, err := c.ProbeShell(ctx, opts)
+	if err != nil {
+		return "", err
+	}
 
 	// Use head -c to limit output size
 	var command []string
 	if maxBytes > 0 {
-		command = []string{"head", "-c", strconv.Itoa(maxBytes), opts.Path}
+		command = shell.Command("head", "-c", strconv.Itoa(maxBytes), opts.Path)
 	} else {
-		command = []string{"cat", opts.Path}
+		command = shell.Command("cat", opts.Path)
 	}
 
 	execOpts := ExecOptions{
@@ -102,42 +119,192 @@ func (c *Client) ReadFile(ctx context.Context, opts FileOptions, maxBytes int) (
 	}
 
 	result := c.Exec(ctx, execOpts)
-	if result.Error != nil {
-		if strings.Contains(result.Stderr, "No such file or directory") {
-			return "", fmt.Errorf("file not found: %s", opts.Path)
-		}
-		if strings.Contains(result.Stderr, "Permission denied") {
-			return "", fmt.Errorf("permission denied: %s", opts.Path)
-		}
-		if strings.Contains(result.Stderr, "Is a directory") {
-			return "", fmt.Errorf("is a directory: %s", opts.Path)
-		}
-		return "", fmt.Errorf("failed to read file: %w", result.Error)
+	if err := readFileError(result, opts.Path); err != nil {
+		return "", err
 	}
 
 	return result.Stdout, nil
 }
 
+// ErrNoShell is returned by file operations that need a shell in the
+// container, such as paging through a file, when there is none
+var ErrNoShell = errors.New("no shell in container")
+
+// readFileError turns a failed read into a message naming the cause
+func readFileError(result ExecResult, path string) error {
+	err := result.Err()
+	if err == nil {
+		return nil
+	}
+	switch {
+	case strings.Contains(result.Stderr, "No such file or directory"):
+		return fmt.Errorf("file not found: %s", path)
+	case strings.Contains(result.Stderr, "Permission denied"):
+		return fmt.Errorf("permission denied: %s", path)
+	case strings.Contains(result.Stderr, "Is a directory"):
+		return fmt.Errorf("is a directory: %s", path)
+	}
+	return fmt.Errorf("failed to read file: %w", err)
+}
+
+// FileChunk is a range of a file read by ReadFileChunk
+type FileChunk struct {
+	Content string
+	Offset  int64 // Where Content starts in the file
+	Size    int64 // The file's size when the chunk was read
+}
+
+// End returns the offset just past the chunk
+func (c FileChunk) End() int64 {
+	return c.Offset + int64(len(c.Content))
+}
+
+// EOF reports whether the chunk reaches the end of the file as it was
+// when read. A file that grows afterwards has more to read.
+func (c FileChunk) EOF() bool {
+	return c.End() >= c.Size
+}
+
+// ReadFileChunk reads up to length bytes of a file starting at offset,
+// along with the file's current size, so large files can be paged through
+// without reading them whole. It needs a shell to pipe head into tail.
+func (c *Client) ReadFileChunk(ctx context.Context, opts FileOptions, offset int64, length int) (FileChunk, error) {
+	if err := opts.Validate(); err != nil {
+		return FileChunk{}, err
+	}
+	if offset < 0 || length <= 0 {
+		return FileChunk{}, fmt.Errorf("invalid range: %d bytes at offset %d", length, offset)
+	}
+	shell, err := c.ProbeShell(ctx, opts)
+	if err != nil {
+		return FileChunk{}, err
+	}
+	command := readChunkCommand(shell, opts.Path, offset, length)
+	if command == nil {
+		return FileChunk{}, fmt.Errorf("%w, cannot page through %s", ErrNoShell, opts.Path)
+	}
+
+	result := c.Exec(ctx, ExecOptions{
+		Namespace: opts.Namespace,
+		Pod:       opts.Pod,
+		Container: opts.Container,
+		Command:   command,
+	})
+	if err := readFileError(result, opts.Path); err != nil {
+		return FileChunk{}, err
+	}
+	return parseFileChunk(result.Stdout, offset)
+}
+
+// readChunkCommand prints the size of path on the first line, then length
+// bytes from offset, or returns nil without a shell. The size is read in
+// the same command so it matches the chunk of a file being written to.
+func readChunkCommand(shell ShellInfo, path string, offset int64, length int) []string {
+	wc := strings.Join(shell.Command("wc"), " ")
+	head := strings.Join(shell.Command("head"), " ")
+	tail := strings.Join(shell.Command("tail"), " ")
+	script := fmt.Sprintf(`%s -c < "$1" && %s -c "$2" "$1" | %s -c +"$3"`, wc, head, tail)
+	return shell.ShellCommand(script, path, strconv.FormatInt(offset+int64(length), 10), strconv.FormatInt(offset+1, 10))
+}
+
+// parseFileChunk splits the output of readChunkCommand into the size line
+// and the chunk read at offset
+func parseFileChunk(output string, offset int64) (FileChunk, error) {
+	sizeLine, content, ok := strings.Cut(output, "\n")
+	if !ok {
+		return FileChunk{}, fmt.Errorf("failed to read file: unexpected output %q", output)
+	}
+	size, err := strconv.ParseInt(strings.TrimSpace(sizeLine), 10, 64)
+	if err != nil {
+		return FileChunk{}, fmt.Errorf("failed to read file size %q: %w", sizeLine, err)
+	}
+	chunk := FileChunk{Content: content, Offset: offset, Size: size}
+	// The file grew between measuring and reading it
+	chunk.Size = max(chunk.Size, chunk.End())
+	return chunk, nil
+}
+
+// WriteFile replaces a file's contents by piping them through a shell in the
+// container, which fails on distroless images and read-only filesystems
+func (c *Client) WriteFile(ctx context.Context, opts FileOptions, content []byte) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	if err := c.checkWritable("write " + opts.Path); err != nil {
+		return err
+	}
+	shell, err := c.ProbeShell(ctx, opts)
+	if err != nil {
+		return err
+	}
+	command := writeFileCommand(shell, opts.Path)
+	if command == nil {
+		return fmt.Errorf("no shell in container, cannot write %s", opts.Path)
+	}
+
+	execOpts := ExecOptions{
+		Namespace: opts.Namespace,
+		Pod:       opts.Pod,
+		Container: opts.Container,
+		Command:   command,
+		Stdin:     bytes.NewReader(content),
+	}
+
+	return writeFileError(c.Exec(ctx, execOpts), opts.Path)
+}
+
+// writeFileCommand truncates and writes path from stdin, or returns nil
+// without a shell. The path is passed as a positional argument so it is
+// never parsed by the shell.
+func writeFileCommand(shell ShellInfo, path string) []string {
+	cat := strings.Join(shell.Command("cat"), " ")
+	return shell.ShellCommand(cat+` > "$1"`, path)
+}
+
+// writeFileError turns a failed write into a message naming the cause
+func writeFileError(result ExecResult, path string) error {
+	err := result.Err()
+	if err == nil {
+		return nil
+	}
+	switch {
+	case IsExecNotFound(result.Error):
+		return fmt.Errorf("no shell in container, cannot write %s", path)
+	case strings.Contains(result.Stderr, "Read-only file system"):
+		return fmt.Errorf("read-only file system: %s", path)
+	case strings.Contains(result.Stderr, "Permission denied"):
+		return fmt.Errorf("permission denied: %s", path)
+	case strings.Contains(result.Stderr, "No such file or directory"),
+		strings.Contains(result.Stderr, "nonexistent directory"):
+		return fmt.Errorf("directory not found: %s", ParentPath(path))
+	}
+	return fmt.Errorf("failed to write file: %w", err)
+}
+
 // StatFile gets file info for a single path
 func (c *Client) StatFile(ctx context.Context, opts FileOptions) (*FileInfo, error) {
 	if err := opts.Validate(); err != nil {
 		return nil, err
 	}
+	shell, err := c.ProbeShell(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
 
 	// Run ls -la on the specific file
 	execOpts := ExecOptions{
 		Namespace: opts.Namespace,
 		Pod:       opts.Pod,
 		Container: opts.Container,
-		Command:   []string{"ls", "-la", "-d", opts.Path},
+		Command:   shell.Command("ls", "-la", "-d", opts.Path),
 	}
 
 	result := c.Exec(ctx, execOpts)
-	if result.Error != nil {
+	if err := result.Err(); err != nil {
 		if strings.Contains(result.Stderr, "No such file or directory") {
 			return nil, fmt.Errorf("file not found: %s", opts.Path)
 		}
-		return nil, fmt.Errorf("failed to stat file: %w", result.Error)
+		return nil, fmt.Errorf("failed to stat file: %w", err)
 	}
 
 	entries, err := ParseLsOutput(result.Stdout)
@@ -152,6 +319,36 @@ func (c *Client) StatFile(ctx context.Context, opts FileOptions) (*FileInfo, err
 	return &entries[0], nil
 }
 
+// binarySniffBytes is how much of a file IsBinary inspects
+const binarySniffBytes = 8 * 1024
+
+// IsBinary reports whether content looks like binary data rather than
+// text: it contains a NUL byte, or more than 10% of the first few KB are
+// control characters or invalid UTF-8.
+func IsBinary(content []byte) bool {
+	sample := content[:min(len(content), binarySniffBytes)]
+
+	var nonText int
+	for i := 0; i < len(sample); {
+		r, size := utf8.DecodeRune(sample[i:])
+		switch {
+		case r == 0:
+			return true
+		case r == utf8.RuneError && size == 1:
+			// A rune cut off by the sample or a head -c limit is still text
+			if len(sample)-i < utf8.UTFMax && !utf8.FullRune(sample[i:]) {
+				i = len(sample)
+				continue
+			}
+			nonText++
+		case r < 0x20 && !strings.ContainsRune("\t\n\r\f\b\x1b", r), r == 0x7f:
+			nonText++
+		}
+		i += size
+	}
+	return nonText*10 > len(sample)
+}
+
 // ParseLsOutput parses the output of ls -la into FileInfo entries
 func ParseLsOutput(output string) ([]FileInfo, error) {
 	lines := strings.Split(output, "\n")
@@ -187,10 +384,11 @@ func ParseLsOutput(output string) ([]FileInfo, error) {
 // Or with symlink: lrwxrwxrwx 1 root root 10 Jan  1 12:00 link -> target
 func parseLsLine(line string) (FileInfo, error) {
 	// Use regex to handle variable whitespace and different ls formats
-	// Permissions can include extra chars like @ . + for extended attributes
+	// Permissions are a file type plus 9 mode chars, optionally followed by
+	// @ . or + for extended attributes
 	// Date/time field can be "HH:MM" or "YYYY" depending on age
 	// BusyBox ls may have different spacing
-	pattern := `^([drwxlstST@.+-]{10,11})\s+(\d+)\s+(\S+)\s+(\S+)\s+(\d+)\s+(\w+)\s+(\d+)\s+(\S+)\s+(.+)$`
+	pattern := `^([-bcdlps][rwxsStT-]{9}[@.+]?)\s+(\d+)\s+(\S+)\s+(\S+)\s+(\d+)\s+(\w+)\s+(\d+)\s+(\S+)\s+(.+)$`
 	re := regexp.MustCompile(pattern)
 
 	matches := re.FindStringSubmatch(line)
@@ -206,6 +404,7 @@ func parseLsLine(line string) (FileInfo, error) {
 		size = 0 // Default to 0 if parse fails
 	}
 	modTime := fmt.Sprintf("%s %s %s", matches[6], matches[7], matches[8])
+	modified := parseLsTime(matches[6], matches[7], matches[8], time.Now())
 	name := matches[9]
 
 	entry := FileInfo{
@@ -214,8 +413,11 @@ func parseLsLine(line string) (FileInfo, error) {
 		Group:       group,
 		Size:        size,
 		ModTime:     modTime,
+		Modified:    modified,
 		IsDir:       permissions[0] == 'd',
 		IsSymlink:   permissions[0] == 'l',
+
+		HasExtendedAttrs: len(permissions) > 10,
 	}
 
 	// Handle symlinks: name -> target
@@ -232,6 +434,31 @@ func parseLsLine(line string) (FileInfo, error) {
 	return entry, nil
 }
 
+// parseLsTime parses the date columns of ls -l. Recent files show a
+// time of day and no year, so the year is taken from now, or the one
+// before if that would put the file in the future. Returns the zero time
+// if the columns aren't recognized.
+func parseLsTime(month, day, clockOrYear string, now time.Time) time.Time {
+	if strings.Contains(clockOrYear, ":") {
+		t, err := time.ParseInLocation("Jan 2 2006 15:04",
+			fmt.Sprintf("%s %s %d %s", month, day, now.Year(), clockOrYear), now.Location())
+		if err != nil {
+			return time.Time{}
+		}
+		// Allow a day of clock skew between the container and us
+		if t.After(now.Add(24 * time.Hour)) {
+			t = t.AddDate(-1, 0, 0)
+		}
+		return t
+	}
+
+	t, err := time.ParseInLocation("Jan 2 2006", fmt.Sprintf("%s %s %s", month, day, clockOrYear), now.Location())
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
 // FormatSize formats a file size in human-readable format
 func FormatSize(size int64) string {
 	const (
@@ -1,7 +1,11 @@
 package k8s
 
 import (
+	"context"
+	"errors"
+	"reflect"
 	"testing"
+	"time"
 )
 
 func TestFileOptionsValidate(t *testing.T) {
@@ -243,6 +247,40 @@ func TestParseLsLine(t *testing.T) {
 	}
 }
 
+func TestParseLsTime(t *testing.T) {
+	now := time.Date(2024, time.March, 10, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		month, day  string
+		clockOrYear string
+		want        time.Time
+	}{
+		{"recent file", "Mar", "9", "12:30", time.Date(2024, time.March, 9, 12, 30, 0, 0, time.UTC)},
+		{"from last year", "Dec", "15", "09:30", time.Date(2023, time.December, 15, 9, 30, 0, 0, time.UTC)},
+		{"with a year", "Jan", "1", "2023", time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"unrecognized", "Foo", "1", "12:00", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseLsTime(tt.month, tt.day, tt.clockOrYear, now)
+			if !got.Equal(tt.want) {
+				t.Errorf("parseLsTime(%q, %q, %q) = %v, want %v", tt.month, tt.day, tt.clockOrYear, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLsLine_Modified(t *testing.T) {
+	got, err := parseLsLine("-rw-r--r-- 1 root root 5678 Jan  1  2023 oldfile.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.Local); !got.Modified.Equal(want) {
+		t.Errorf("Modified = %v, want %v", got.Modified, want)
+	}
+}
+
 func TestFormatSize(t *testing.T) {
 	tests := []struct {
 		size int64
@@ -407,3 +445,227 @@ func TestParseLsOutputWithSpacesInFilename(t *testing.T) {
 		t.Errorf("Name = %q, want %q", entries[0].Name, "file with spaces.txt")
 	}
 }
+
+func TestParseLsLineExtendedAttrs(t *testing.T) {
+	tests := []struct {
+		name        string
+		line        string
+		permissions string
+		extended    bool
+	}{
+		{"ACL marker", "-rw-rw-r--+ 1 app app 220 Jan  1 12:00 shared.txt", "-rw-rw-r--+", true},
+		{"SELinux marker", "-rw-r--r--. 1 root root 1234 Jan  1 12:00 config.yaml", "-rw-r--r--.", true},
+		{"xattr marker", "drwxr-xr-x@ 3 root root 96 Jan  1 12:00 data", "drwxr-xr-x@", true},
+		{"sticky dir with ACL", "drwxrwxrwt+ 2 root root 4096 Jan  1 12:00 tmp", "drwxrwxrwt+", true},
+		{"plain", "-rw-r--r-- 1 root root 1234 Jan  1 12:00 plain.txt", "-rw-r--r--", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseLsLine(tt.line)
+			if err != nil {
+				t.Fatalf("parseLsLine() error = %v", err)
+			}
+			if got.Permissions != tt.permissions {
+				t.Errorf("Permissions = %q, want %q", got.Permissions, tt.permissions)
+			}
+			if got.HasExtendedAttrs != tt.extended {
+				t.Errorf("HasExtendedAttrs = %v, want %v", got.HasExtendedAttrs, tt.extended)
+			}
+		})
+	}
+}
+
+func TestIsBinary(t *testing.T) {
+	tests := []struct {
+		name    string
+		content []byte
+		want    bool
+	}{
+		{"empty", nil, false},
+		{"ascii", []byte("apiVersion: v1\nkind: Pod\n\tindented\r\n"), false},
+		{"utf-8 text", []byte("héllo wörld — 日本語 ✓\n"), false},
+		{"ansi colored log", []byte("\x1b[32mINFO\x1b[0m started\n"), false},
+		{"utf-8 cut at the end", []byte("café")[:4], false},
+		{"png header", []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x01\x00"), true},
+		{"elf header", []byte("\x7fELF\x02\x01\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x02\x00>\x00"), true},
+		{"control characters", []byte("\x01\x02\x03\x04abcdef"), true},
+		{"latin-1 bytes", []byte("\xe9\xe8\xe0\xf9\xe7\xe9\xe8\xe0\xf9\xe7 text"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsBinary(tt.content); got != tt.want {
+				t.Errorf("IsBinary(%q) = %v, want %v", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteFileCommand(t *testing.T) {
+	got := writeFileCommand(ShellInfo{Shell: "sh", Coreutils: true}, "/etc/app/my config.yaml")
+	want := []string{"sh", "-c", `cat > "$1"`, "sh", "/etc/app/my config.yaml"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("writeFileCommand() = %q, want %q", got, want)
+	}
+
+	// Busybox without applet links provides both the shell and cat
+	got = writeFileCommand(ShellInfo{Busybox: "/bin/busybox"}, "/etc/app.conf")
+	want = []string{"/bin/busybox", "sh", "-c", `/bin/busybox cat > "$1"`, "sh", "/etc/app.conf"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("writeFileCommand() = %q, want %q", got, want)
+	}
+
+	if got := writeFileCommand(ShellInfo{Coreutils: true}, "/etc/app.conf"); got != nil {
+		t.Errorf("expected no command without a shell, got %q", got)
+	}
+}
+
+func TestWriteFileError(t *testing.T) {
+	tests := []struct {
+		name   string
+		result ExecResult
+		want   string
+	}{
+		{"success", ExecResult{}, ""},
+		{
+			name:   "no shell",
+			result: ExecResult{Error: errors.New(`exec: "sh": executable file not found in $PATH`), ExitCode: 1},
+			want:   "no shell in container, cannot write /etc/app.conf",
+		},
+		{
+			name:   "read-only",
+			result: ExecResult{ExitCode: 1, Stderr: "sh: can't create /etc/app.conf: Read-only file system"},
+			want:   "read-only file system: /etc/app.conf",
+		},
+		{
+			name:   "permission denied",
+			result: ExecResult{ExitCode: 1, Stderr: "sh: /etc/app.conf: Permission denied"},
+			want:   "permission denied: /etc/app.conf",
+		},
+		{
+			name:   "missing directory",
+			result: ExecResult{ExitCode: 2, Stderr: "sh: 1: cannot create /etc/app.conf: No such file or directory"},
+			want:   "directory not found: /etc",
+		},
+		{
+			name:   "other exit",
+			result: ExecResult{ExitCode: 1, Stderr: "sh: out of space"},
+			want:   "failed to write file: command exited with code 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := writeFileError(tt.result, "/etc/app.conf")
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("writeFileError() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("writeFileError() = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteFile_Validate(t *testing.T) {
+	c := &Client{}
+	if err := c.WriteFile(context.Background(), FileOptions{Namespace: "default", Pod: "p"}, nil); err == nil {
+		t.Error("expected an error for a missing path")
+	}
+}
+
+func TestReadChunkCommand(t *testing.T) {
+	got := readChunkCommand(ShellInfo{Shell: "sh", Coreutils: true}, "/var/log/app.log", 102400, 102400)
+	want := []string{"sh", "-c", `wc -c < "$1" && head -c "$2" "$1" | tail -c +"$3"`, "sh", "/var/log/app.log", "204800", "102401"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readChunkCommand() = %q, want %q", got, want)
+	}
+
+	got = readChunkCommand(ShellInfo{Busybox: "/bin/busybox"}, "/app.log", 0, 10)
+	want = []string{"/bin/busybox", "sh", "-c",
+		`/bin/busybox wc -c < "$1" && /bin/busybox head -c "$2" "$1" | /bin/busybox tail -c +"$3"`, "sh", "/app.log", "10", "1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readChunkCommand() = %q, want %q", got, want)
+	}
+
+	if got := readChunkCommand(ShellInfo{Coreutils: true}, "/app.log", 0, 10); got != nil {
+		t.Errorf("expected no command without a shell, got %q", got)
+	}
+}
+
+func TestParseFileChunk(t *testing.T) {
+	chunk, err := parseFileChunk("  250\nline 1\nline 2\n", 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if chunk.Content != "line 1\nline 2\n" || chunk.Offset != 100 || chunk.Size != 250 {
+		t.Errorf("unexpected chunk %+v", chunk)
+	}
+	if chunk.End() != 114 || chunk.EOF() {
+		t.Errorf("expected more to read after byte %d", chunk.End())
+	}
+
+	// The last chunk reaches the size
+	chunk, _ = parseFileChunk("14\nline 1\nline 2\n", 0)
+	if !chunk.EOF() {
+		t.Errorf("expected EOF, got %+v", chunk)
+	}
+
+	// A file appended to between measuring and reading
+	chunk, _ = parseFileChunk("5\nline 1\n", 0)
+	if chunk.Size != 7 || !chunk.EOF() {
+		t.Errorf("expected the size to cover the chunk, got %+v", chunk)
+	}
+
+	// Past the end of a truncated file
+	chunk, _ = parseFileChunk("10\n", 100)
+	if chunk.Content != "" || !chunk.EOF() {
+		t.Errorf("expected an empty chunk at EOF, got %+v", chunk)
+	}
+
+	for _, output := range []string{"", "not a size\ndata"} {
+		if _, err := parseFileChunk(output, 0); err == nil {
+			t.Errorf("expected an error for %q", output)
+		}
+	}
+}
+
+func TestReadFileError(t *testing.T) {
+	tests := []struct {
+		stderr string
+		want   string
+	}{
+		{"wc: can't open '/app.log': No such file or directory", "file not found: /app.log"},
+		{"sh: /app.log: Permission denied", "permission denied: /app.log"},
+		{"wc: read error: Is a directory", "is a directory: /app.log"},
+		{"", "failed to read file: command exited with code 1"},
+	}
+
+	for _, tt := range tests {
+		err := readFileError(ExecResult{ExitCode: 1, Stderr: tt.stderr}, "/app.log")
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("readFileError(%q) = %v, want %q", tt.stderr, err, tt.want)
+		}
+	}
+	if err := readFileError(ExecResult{}, "/app.log"); err != nil {
+		t.Errorf("expected no error for a successful read, got %v", err)
+	}
+}
+
+func TestReadFileChunk_Validate(t *testing.T) {
+	c := &Client{}
+	opts := FileOptions{Namespace: "default", Pod: "web", Path: "/app.log"}
+	if _, err := c.ReadFileChunk(context.Background(), FileOptions{}, 0, 10); err == nil {
+		t.Error("expected an error for missing options")
+	}
+	if _, err := c.ReadFileChunk(context.Background(), opts, -1, 10); err == nil {
+		t.Error("expected an error for a negative offset")
+	}
+	if _, err := c.ReadFileChunk(context.Background(), opts, 0, 0); err == nil {
+		t.Error("expected an error for an empty range")
+	}
+}
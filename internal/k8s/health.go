@@ -0,0 +1,27 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Ping checks that the API server answers with a cheap read of the current
+// namespace and returns the round trip time. The server refusing the read,
+// e.g. because RBAC forbids getting namespaces, still counts as an answer;
+// network errors and transient server errors don't.
+func (c *Client) Ping(ctx context.Context) (time.Duration, error) {
+	start := time.Now()
+	_, err := c.clientset.CoreV1().Namespaces().Get(ctx, c.currentNamespace, metav1.GetOptions{})
+	latency := time.Since(start)
+
+	var status apierrors.APIStatus
+	if err != nil && (!errors.As(err, &status) || IsRetryable(err)) {
+		return latency, fmt.Errorf("failed to reach the API server: %w", err)
+	}
+	return latency, nil
+}
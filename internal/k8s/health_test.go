@@ -0,0 +1,66 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestClient_Ping(t *testing.T) {
+	fakeClient := fake.NewClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}})
+	client := &Client{clientset: fakeClient, currentNamespace: "default"}
+
+	latency, err := client.Ping(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if latency < 0 {
+		t.Errorf("expected a non-negative latency, got %v", latency)
+	}
+
+	actions := fakeClient.Actions()
+	if len(actions) != 1 || actions[0].GetVerb() != "get" || actions[0].GetResource().Resource != "namespaces" {
+		t.Errorf("expected a single namespace get, got %v", actions)
+	}
+}
+
+func TestClient_Ping_Errors(t *testing.T) {
+	gr := schema.GroupResource{Resource: "namespaces"}
+
+	tests := []struct {
+		name    string
+		err     error
+		wantErr bool
+	}{
+		{"forbidden still answers", apierrors.NewForbidden(gr, "default", errors.New("rbac")), false},
+		{"not found still answers", apierrors.NewNotFound(gr, "default"), false},
+		{"unavailable", apierrors.NewServiceUnavailable("overloaded"), true},
+		{"network error", errors.New("dial tcp: connection refused"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientset()
+			fakeClient.PrependReactor("get", "namespaces", func(k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, tt.err
+			})
+			client := &Client{clientset: fakeClient, currentNamespace: "default"}
+
+			_, err := client.Ping(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if err != nil && !errors.Is(err, tt.err) {
+				t.Errorf("expected the cause wrapped, got %v", err)
+			}
+		})
+	}
+}
@@ -5,15 +5,23 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
+	"sync"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // LogLine represents a single line of log output
 type LogLine struct {
-	Content   string
+	Content string
+
+	// Timestamp is when the line was logged, as stamped by the API server
+	// when streamed with Timestamps; otherwise when it was received. Zero
+	// for a timestamped line whose stamp couldn't be parsed.
 	Timestamp time.Time
 	Error     error
 }
@@ -25,8 +33,9 @@ type LogOptions struct {
 	Container  string
 	Follow     bool
 	TailLines  int64
-	Timestamps bool
+	Timestamps bool // Stamp each line with the time the API server has for it
 	SinceTime  *time.Time
+	Previous   bool // Logs of the previous, terminated instance of the container
 }
 
 // StreamLogs streams logs from a pod container and sends them to a channel.
@@ -42,6 +51,7 @@ func (c *Client) StreamLogs(ctx context.Context, opts LogOptions) (<-chan LogLin
 		Container:  opts.Container,
 		Follow:     opts.Follow,
 		Timestamps: opts.Timestamps,
+		Previous:   opts.Previous,
 	}
 
 	if opts.TailLines > 0 {
@@ -60,45 +70,331 @@ func (c *Client) StreamLogs(ctx context.Context, opts LogOptions) (<-chan LogLin
 		return nil, fmt.Errorf("failed to open log stream for pod %q: %w", opts.Pod, err)
 	}
 
-	// Create the output channel
+	return readLogStream(ctx, stream, opts.Timestamps), nil
+}
+
+// readLogStream sends the lines of stream on the returned channel until it
+// ends or ctx is cancelled, then closes the channel. With timestamps, each
+// line starts with the server's timestamp, which is moved into the LogLine.
+func readLogStream(ctx context.Context, stream io.ReadCloser, timestamps bool) <-chan LogLine {
 	logChan := make(chan LogLine, 100)
 
-	// Start goroutine to read from stream and send to channel
+	// Sends give up once the context is cancelled so the goroutine can't
+	// block forever on a reader that has gone away
+	send := func(line LogLine) bool {
+		select {
+		case logChan <- line:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	newLine := func(content string) LogLine {
+		if timestamps {
+			at, rest := parseTimestampedLine(content)
+			return LogLine{Content: rest, Timestamp: at}
+		}
+		return LogLine{Content: content, Timestamp: time.Now()}
+	}
+
+	// Closing the stream on cancel unblocks a read waiting for new lines
+	stopClose := context.AfterFunc(ctx, func() {
+		stream.Close() //nolint:errcheck // Only unblocks the reader
+	})
+
 	go func() {
 		defer close(logChan)
 		defer stream.Close() //nolint:errcheck // Cleanup code in goroutine, error cannot be usefully handled
+		defer stopClose()
 
 		reader := bufio.NewReader(stream)
 		for {
-			select {
-			case <-ctx.Done():
+			line, err := reader.ReadString('\n')
+			if ctx.Err() != nil {
 				return
-			default:
-				line, err := reader.ReadString('\n')
-				if err != nil {
-					if err == io.EOF {
-						// Send any remaining content
-						if line != "" {
-							logChan <- LogLine{Content: line, Timestamp: time.Now()}
-						}
-						return
+			}
+			if err != nil {
+				if err == io.EOF {
+					// Send any remaining content
+					if line != "" {
+						send(newLine(line))
 					}
-					// Send error and exit
-					logChan <- LogLine{Error: fmt.Errorf("error reading log stream: %w", err)}
 					return
 				}
+				// Send error and exit
+				send(LogLine{Error: fmt.Errorf("error reading log stream: %w", err)})
+				return
+			}
+
+			// Remove trailing newline
+			if line != "" && line[len(line)-1] == '\n' {
+				line = line[:len(line)-1]
+			}
+
+			if !send(newLine(line)) {
+				return
+			}
+		}
+	}()
+
+	return logChan
+}
+
+// parseTimestampedLine splits the RFC 3339 timestamp the API server puts
+// in front of a line streamed with timestamps from its content. A line
+// without one is returned whole with a zero time.
+func parseTimestampedLine(line string) (time.Time, string) {
+	stamp, rest, ok := strings.Cut(line, " ")
+	if !ok {
+		stamp, rest = line, ""
+	}
+	at, err := time.Parse(time.RFC3339Nano, stamp)
+	if err != nil {
+		return time.Time{}, line
+	}
+	return at, rest
+}
+
+// StreamLogsAllContainers streams the logs of every container in a pod
+// into one channel, prefixing each line with [container]. A container whose
+// stream can't be opened or fails is reported as a line rather than ending
+// the others. The channel is closed once every stream has finished; all
+// goroutines exit when ctx is cancelled.
+func (c *Client) StreamLogsAllContainers(ctx context.Context, namespace, pod string, opts LogOptions) (<-chan LogLine, error) {
+	containers, err := c.GetContainers(ctx, namespace, pod)
+	if err != nil {
+		return nil, err
+	}
 
-				// Remove trailing newline
-				if line != "" && line[len(line)-1] == '\n' {
-					line = line[:len(line)-1]
+	opts.Namespace = namespace
+	opts.Pod = pod
+
+	// Buffered so open failures can be queued before anyone reads
+	merged := make(chan LogLine, 100+len(containers))
+	var wg sync.WaitGroup
+	opened := 0
+
+	for _, container := range containers {
+		containerOpts := opts
+		containerOpts.Container = container
+		prefix := "[" + container + "] "
+
+		source, err := c.StreamLogs(ctx, containerOpts)
+		if err != nil {
+			merged <- LogLine{Content: prefix + err.Error(), Timestamp: time.Now()}
+			continue
+		}
+		opened++
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for line := range source {
+				if line.Error != nil {
+					line = LogLine{Content: prefix + line.Error.Error(), Timestamp: time.Now()}
+				} else {
+					line.Content = prefix + line.Content
 				}
 
-				logChan <- LogLine{Content: line, Timestamp: time.Now()}
+				select {
+				case merged <- line:
+				case <-ctx.Done():
+					return
+				}
 			}
+		}()
+	}
+
+	if opened == 0 {
+		return nil, fmt.Errorf("failed to open log streams for any container in pod %q", pod)
+	}
+
+	go func() {
+		wg.Wait()
+		close(merged)
+	}()
+
+	return merged, nil
+}
+
+// StreamLogsMultiPod tails every pod matching selector in one channel, like
+// stern: each container of each started pod gets a stream whose lines are
+// prefixed with [pod/container]. While following, pods that start later are
+// picked up and the streams of deleted pods are stopped. The channel is
+// closed once ctx is cancelled or, without Follow, once every stream has
+// finished; all goroutines exit when ctx is cancelled.
+func (c *Client) StreamLogsMultiPod(ctx context.Context, namespace string, selector labels.Selector, opts LogOptions) (<-chan LogLine, error) {
+	if namespace == "" {
+		namespace = c.currentNamespace
+	}
+	pods := c.clientset.CoreV1().Pods(namespace)
+
+	listOpts := metav1.ListOptions{LabelSelector: selector.String()}
+	list, err := pods.List(ctx, listOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods matching %q: %w", selector, err)
+	}
+
+	var watcher watch.Interface
+	if opts.Follow {
+		// Start where the list left off so no pod is missed in between
+		watchOpts := listOpts
+		watchOpts.ResourceVersion = list.ResourceVersion
+		watcher, err = pods.Watch(ctx, watchOpts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to watch pods matching %q: %w", selector, err)
 		}
+	}
+
+	opts.Namespace = namespace
+	tail := &multiPodTail{
+		client:   c,
+		ctx:      ctx,
+		selector: selector,
+		opts:     opts,
+		out:      make(chan LogLine, 100),
+		streams:  make(map[string]context.CancelFunc),
+	}
+
+	go func() {
+		defer close(tail.out)
+		for i := range list.Items {
+			tail.start(&list.Items[i])
+		}
+		if watcher != nil {
+			tail.follow(watcher, func() (watch.Interface, error) {
+				return pods.Watch(ctx, listOpts)
+			})
+		}
+		tail.wg.Wait()
+		tail.stopAll()
 	}()
 
-	return logChan, nil
+	return tail.out, nil
+}
+
+// multiPodTail tracks the per-pod streams of StreamLogsMultiPod. streams is
+// only touched by the goroutine that starts and stops them.
+type multiPodTail struct {
+	client   *Client
+	ctx      context.Context
+	selector labels.Selector
+	opts     LogOptions
+	out      chan LogLine
+	wg       sync.WaitGroup
+	streams  map[string]context.CancelFunc // Keyed by pod name
+}
+
+// send queues a line unless ctx is cancelled first
+func (t *multiPodTail) send(line LogLine) bool {
+	select {
+	case t.out <- line:
+		return true
+	case <-t.ctx.Done():
+		return false
+	}
+}
+
+// start opens a stream per container of pod, unless the pod is already
+// streaming, has no logs yet or doesn't match the selector
+func (t *multiPodTail) start(pod *corev1.Pod) {
+	if _, ok := t.streams[pod.Name]; ok || !t.selector.Matches(labels.Set(pod.Labels)) {
+		return
+	}
+	if pod.DeletionTimestamp != nil || pod.Status.Phase == corev1.PodPending || pod.Status.Phase == corev1.PodUnknown {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(t.ctx)
+	t.streams[pod.Name] = cancel
+
+	for _, container := range pod.Spec.Containers {
+		opts := t.opts
+		opts.Pod = pod.Name
+		opts.Container = container.Name
+		prefix := "[" + pod.Name + "/" + container.Name + "] "
+
+		source, err := t.client.StreamLogs(ctx, opts)
+		if err != nil {
+			t.send(LogLine{Content: prefix + err.Error(), Timestamp: time.Now()})
+			continue
+		}
+
+		t.wg.Add(1)
+		go func() {
+			defer t.wg.Done()
+			for line := range source {
+				if line.Error != nil {
+					line = LogLine{Content: prefix + line.Error.Error(), Timestamp: time.Now()}
+				} else {
+					line.Content = prefix + line.Content
+				}
+				if !t.send(line) {
+					return
+				}
+			}
+		}()
+	}
+}
+
+// stop ends the streams of a pod, e.g. once it is deleted
+func (t *multiPodTail) stop(name string) {
+	if cancel, ok := t.streams[name]; ok {
+		cancel()
+		delete(t.streams, name)
+	}
+}
+
+// stopAll releases the contexts of every stream
+func (t *multiPodTail) stopAll() {
+	for name := range t.streams {
+		t.stop(name)
+	}
+}
+
+// follow starts and stops streams as pods come and go until ctx is
+// cancelled. A watch closed by the server is reopened with rewatch; it
+// replays the current pods as additions, which start ignores for pods
+// already streaming.
+func (t *multiPodTail) follow(watcher watch.Interface, rewatch func() (watch.Interface, error)) {
+	defer func() { watcher.Stop() }()
+
+	for {
+		select {
+		case <-t.ctx.Done():
+			return
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				if t.ctx.Err() != nil {
+					return
+				}
+				next, err := rewatch()
+				if err != nil {
+					// The open streams carry on; only new pods are missed
+					t.send(LogLine{Content: "failed to watch for new pods: " + err.Error(), Timestamp: time.Now()})
+					return
+				}
+				watcher = next
+				continue
+			}
+
+			pod, isPod := event.Object.(*corev1.Pod)
+			if !isPod {
+				continue
+			}
+			switch event.Type {
+			case watch.Added, watch.Modified:
+				if pod.DeletionTimestamp != nil {
+					t.stop(pod.Name)
+				} else {
+					t.start(pod)
+				}
+			case watch.Deleted:
+				t.stop(pod.Name)
+			}
+		}
+	}
 }
 
 // GetContainers returns the list of containers in a pod
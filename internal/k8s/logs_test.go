@@ -2,13 +2,18 @@ package k8s
 
 import (
 	"context"
+	"io"
+	"strings"
 	"testing"
 	"time"
 
+	"go.uber.org/goleak"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 func createTestPodWithContainers(name, namespace string, containers []string) *corev1.Pod {
@@ -233,3 +238,261 @@ func TestLogLine_Error(t *testing.T) {
 // 3. Sends lines to a channel
 // 4. Closes the channel when the stream ends or context is cancelled
 // 5. Handles errors by sending a LogLine with Error field set
+
+func TestClient_StreamLogs_Previous(t *testing.T) {
+	fakeClient := fake.NewClientset(createTestPodWithContainers("crashy", "default", []string{"app"}))
+	client := &Client{clientset: fakeClient, currentNamespace: "default"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if _, err := client.StreamLogs(ctx, LogOptions{Pod: "crashy", Container: "app", Previous: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var found bool
+	for _, action := range fakeClient.Actions() {
+		if action.GetSubresource() != "log" {
+			continue
+		}
+		generic, ok := action.(k8stesting.GenericAction)
+		if !ok {
+			t.Fatalf("unexpected log action %T", action)
+		}
+		opts, ok := generic.GetValue().(*corev1.PodLogOptions)
+		if !ok || !opts.Previous || opts.Container != "app" {
+			t.Errorf("expected previous logs of app requested, got %+v", generic.GetValue())
+		}
+		found = true
+	}
+	if !found {
+		t.Error("expected a log request")
+	}
+}
+
+func TestClient_StreamLogsAllContainers(t *testing.T) {
+	fakeClient := fake.NewClientset(
+		createTestPodWithContainers("multi", "default", []string{"app", "sidecar"}),
+	)
+	client := &Client{clientset: fakeClient, currentNamespace: "default"}
+
+	logChan, err := client.StreamLogsAllContainers(context.Background(), "default", "multi", LogOptions{Follow: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := make(map[string]bool)
+	timeout := time.After(2 * time.Second)
+	for done := false; !done; {
+		select {
+		case line, ok := <-logChan:
+			if !ok {
+				done = true
+				break
+			}
+			got[line.Content] = true
+		case <-timeout:
+			t.Fatal("combined stream should close once every container stream ends")
+		}
+	}
+
+	// The fake clientset serves "fake logs" for every container
+	for _, want := range []string{"[app] fake logs", "[sidecar] fake logs"} {
+		if !got[want] {
+			t.Errorf("expected line %q, got %v", want, got)
+		}
+	}
+}
+
+func TestClient_StreamLogsAllContainers_CancelClosesChannel(t *testing.T) {
+	fakeClient := fake.NewClientset(
+		createTestPodWithContainers("multi", "default", []string{"app", "sidecar"}),
+	)
+	client := &Client{clientset: fakeClient, currentNamespace: "default"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	logChan, err := client.StreamLogsAllContainers(ctx, "default", "multi", LogOptions{Follow: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cancel()
+
+	// Without a reader, the forwarders must still exit and close the channel
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case _, ok := <-logChan:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("channel should close after cancel")
+		}
+	}
+}
+
+// labeledPod returns a running single-container pod with an app label
+func labeledPod(name, app string, phase corev1.PodPhase) *corev1.Pod {
+	pod := createTestPodWithContainers(name, "default", []string{"app"})
+	pod.Labels = map[string]string{"app": app}
+	pod.Status.Phase = phase
+	return pod
+}
+
+// collectLogLines reads logChan until it closes or until want lines
+// arrived, failing the test on timeout
+func collectLogLines(t *testing.T, logChan <-chan LogLine, want int) map[string]bool {
+	t.Helper()
+	got := make(map[string]bool)
+	timeout := time.After(2 * time.Second)
+	for len(got) < want || want == 0 {
+		select {
+		case line, ok := <-logChan:
+			if !ok {
+				return got
+			}
+			got[line.Content] = true
+		case <-timeout:
+			t.Fatalf("timed out waiting for log lines, got %v", got)
+		}
+	}
+	return got
+}
+
+func TestClient_StreamLogsMultiPod(t *testing.T) {
+	fakeClient := fake.NewClientset(
+		labeledPod("web-1", "web", corev1.PodRunning),
+		labeledPod("web-2", "web", corev1.PodRunning),
+		labeledPod("web-3", "web", corev1.PodPending),
+		labeledPod("db-1", "db", corev1.PodRunning),
+	)
+	client := &Client{clientset: fakeClient, currentNamespace: "default"}
+
+	selector := labels.SelectorFromSet(labels.Set{"app": "web"})
+	logChan, err := client.StreamLogsMultiPod(context.Background(), "", selector, LogOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Without follow the channel closes once every stream has ended
+	got := collectLogLines(t, logChan, 0)
+	if len(got) != 2 || !got["[web-1/app] fake logs"] || !got["[web-2/app] fake logs"] {
+		t.Errorf("expected lines from the running web pods only, got %v", got)
+	}
+}
+
+func TestClient_StreamLogsMultiPod_FollowsNewPods(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	fakeClient := fake.NewClientset(labeledPod("web-1", "web", corev1.PodRunning))
+	client := &Client{clientset: fakeClient, currentNamespace: "default"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	selector := labels.SelectorFromSet(labels.Set{"app": "web"})
+	logChan, err := client.StreamLogsMultiPod(ctx, "default", selector, LogOptions{Follow: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := collectLogLines(t, logChan, 1); !got["[web-1/app] fake logs"] {
+		t.Fatalf("expected the existing pod's logs, got %v", got)
+	}
+
+	// A pod started by the rollout is picked up; other pods are not
+	for _, pod := range []*corev1.Pod{labeledPod("db-1", "db", corev1.PodRunning), labeledPod("web-2", "web", corev1.PodRunning)} {
+		if _, err := fakeClient.CoreV1().Pods("default").Create(ctx, pod, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("failed to create pod: %v", err)
+		}
+	}
+	if got := collectLogLines(t, logChan, 1); !got["[web-2/app] fake logs"] {
+		t.Fatalf("expected only the new web pod's logs, got %v", got)
+	}
+
+	// Cancelling stops the watch and every stream, then closes the channel
+	cancel()
+	if got := collectLogLines(t, logChan, 0); got["[db-1/app] fake logs"] {
+		t.Errorf("pods outside the selector should not be tailed, got %v", got)
+	}
+}
+
+func TestClient_StreamLogsMultiPod_ListError(t *testing.T) {
+	fakeClient := fake.NewClientset()
+	fakeClient.PrependReactor("list", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, io.ErrUnexpectedEOF
+	})
+	client := &Client{clientset: fakeClient, currentNamespace: "default"}
+
+	if _, err := client.StreamLogsMultiPod(context.Background(), "", labels.Everything(), LogOptions{}); err == nil {
+		t.Error("expected an error when the pods can't be listed")
+	}
+}
+
+func TestReadLogStream_CancelStopsBlockedRead(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	// A followed stream with no new lines blocks the reader
+	reader, writer := io.Pipe()
+	defer writer.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	logChan := readLogStream(ctx, reader, false)
+
+	if _, err := writer.Write([]byte("first\n")); err != nil {
+		t.Fatalf("failed to write: %v", err)
+	}
+	if line := <-logChan; line.Content != "first" {
+		t.Errorf("expected the first line, got %+v", line)
+	}
+
+	cancel()
+	select {
+	case _, ok := <-logChan:
+		if ok {
+			t.Error("expected no lines after cancel")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("cancel should end a read waiting for new lines")
+	}
+}
+
+func TestParseTimestampedLine(t *testing.T) {
+	tests := []struct {
+		line    string
+		at      time.Time
+		content string
+	}{
+		{"2026-10-15T09:30:00.123456789Z GET /healthz 200", time.Date(2026, 10, 15, 9, 30, 0, 123456789, time.UTC), "GET /healthz 200"},
+		{"2026-10-15T09:30:01Z  indented", time.Date(2026, 10, 15, 9, 30, 1, 0, time.UTC), " indented"},
+		{"2026-10-15T09:30:02Z", time.Date(2026, 10, 15, 9, 30, 2, 0, time.UTC), ""},
+		{"no timestamp here", time.Time{}, "no timestamp here"},
+	}
+
+	for _, tt := range tests {
+		at, content := parseTimestampedLine(tt.line)
+		if !at.Equal(tt.at) || content != tt.content {
+			t.Errorf("parseTimestampedLine(%q) = %v, %q; want %v, %q", tt.line, at, content, tt.at, tt.content)
+		}
+	}
+}
+
+func TestReadLogStream_Timestamps(t *testing.T) {
+	stream := io.NopCloser(strings.NewReader("2026-10-15T09:30:00.5Z first\n2026-10-15T09:30:01Z second"))
+
+	var lines []LogLine
+	for line := range readLogStream(context.Background(), stream, true) {
+		lines = append(lines, line)
+	}
+	if len(lines) != 2 || lines[0].Content != "first" || lines[1].Content != "second" {
+		t.Fatalf("expected the timestamps stripped, got %+v", lines)
+	}
+	if want := time.Date(2026, 10, 15, 9, 30, 0, 5e8, time.UTC); !lines[0].Timestamp.Equal(want) {
+		t.Errorf("expected the server's timestamp %v, got %v", want, lines[0].Timestamp)
+	}
+}
+
+func TestClient_StreamLogsAllContainers_PodNotFound(t *testing.T) {
+	client := &Client{clientset: fake.NewClientset(), currentNamespace: "default"}
+
+	if _, err := client.StreamLogsAllContainers(context.Background(), "default", "missing", LogOptions{}); err == nil {
+		t.Error("expected error for a missing pod")
+	}
+}
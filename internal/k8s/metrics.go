@@ -0,0 +1,81 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// metricsAPIPath is where metrics-server serves the resource metrics API
+const metricsAPIPath = "/apis/metrics.k8s.io/v1beta1"
+
+// ErrMetricsUnavailable is returned when the cluster serves no resource
+// metrics, usually because metrics-server is not installed
+var ErrMetricsUnavailable = errors.New("metrics API not available (is metrics-server installed?)")
+
+// PodMetrics is the current resource usage of a pod, summed across its
+// containers
+type PodMetrics struct {
+	Name      string
+	Namespace string
+	CPU       resource.Quantity
+	Memory    resource.Quantity
+}
+
+// podMetricsList is the part of the metrics API's PodMetricsList that is
+// used, decoded without depending on the metrics client
+type podMetricsList struct {
+	Items []struct {
+		Metadata struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"metadata"`
+		Containers []struct {
+			Usage corev1.ResourceList `json:"usage"`
+		} `json:"containers"`
+	} `json:"items"`
+}
+
+// GetPodMetrics returns the usage of the pods in a namespace as reported by
+// metrics-server. An empty namespace means the current one.
+func (c *Client) GetPodMetrics(ctx context.Context, namespace string) ([]PodMetrics, error) {
+	if namespace == "" {
+		namespace = c.currentNamespace
+	}
+
+	restClient := c.clientset.Discovery().RESTClient()
+	if restClient == nil {
+		return nil, ErrMetricsUnavailable
+	}
+
+	raw, err := restClient.Get().AbsPath(metricsAPIPath, "namespaces", namespace, "pods").DoRaw(ctx)
+	if err != nil {
+		// The API is missing without metrics-server, and unavailable while
+		// it is down or not ready yet
+		if apierrors.IsNotFound(err) || apierrors.IsServiceUnavailable(err) {
+			return nil, ErrMetricsUnavailable
+		}
+		return nil, fmt.Errorf("failed to get pod metrics in namespace %q: %w", namespace, err)
+	}
+
+	var list podMetricsList
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, fmt.Errorf("failed to decode pod metrics: %w", err)
+	}
+
+	result := make([]PodMetrics, 0, len(list.Items))
+	for _, item := range list.Items {
+		metrics := PodMetrics{Name: item.Metadata.Name, Namespace: item.Metadata.Namespace}
+		for _, container := range item.Containers {
+			metrics.CPU.Add(container.Usage[corev1.ResourceCPU])
+			metrics.Memory.Add(container.Usage[corev1.ResourceMemory])
+		}
+		result = append(result, metrics)
+	}
+	return result, nil
+}
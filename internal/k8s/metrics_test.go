@@ -0,0 +1,110 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+)
+
+// metricsServer serves body for the pod metrics of the default namespace
+// and status for everything else
+func metricsServer(t *testing.T, status int, body string) *Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/apis/metrics.k8s.io/v1beta1/namespaces/default/pods" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	clientset, err := kubernetes.NewForConfig(&rest.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatalf("failed to create clientset: %v", err)
+	}
+	return &Client{clientset: clientset, currentNamespace: "default"}
+}
+
+func TestClient_GetPodMetrics(t *testing.T) {
+	client := metricsServer(t, http.StatusOK, `{
+		"kind": "PodMetricsList",
+		"items": [
+			{"metadata": {"name": "web", "namespace": "default"},
+			 "containers": [
+				{"name": "app", "usage": {"cpu": "250m", "memory": "100Mi"}},
+				{"name": "sidecar", "usage": {"cpu": "50m", "memory": "28Mi"}}
+			 ]},
+			{"metadata": {"name": "idle", "namespace": "default"}, "containers": []}
+		]
+	}`)
+
+	metrics, err := client.GetPodMetrics(context.Background(), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(metrics) != 2 {
+		t.Fatalf("expected 2 pods, got %d", len(metrics))
+	}
+
+	web := metrics[0]
+	if web.Name != "web" || web.Namespace != "default" {
+		t.Errorf("unexpected pod %s/%s", web.Namespace, web.Name)
+	}
+	if web.CPU.MilliValue() != 300 {
+		t.Errorf("expected 300m CPU summed across containers, got %s", web.CPU.String())
+	}
+	if web.Memory.Value() != 128*1024*1024 {
+		t.Errorf("expected 128Mi memory, got %s", web.Memory.String())
+	}
+	if !metrics[1].CPU.IsZero() || !metrics[1].Memory.IsZero() {
+		t.Errorf("a pod without containers should use nothing, got %+v", metrics[1])
+	}
+}
+
+func TestClient_GetPodMetrics_Unavailable(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+	}{
+		{"not installed", http.StatusNotFound},
+		{"not ready", http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := metricsServer(t, tt.status, `{"kind": "Status"}`)
+			_, err := client.GetPodMetrics(context.Background(), "default")
+			if !errors.Is(err, ErrMetricsUnavailable) {
+				t.Errorf("expected ErrMetricsUnavailable, got %v", err)
+			}
+		})
+	}
+
+	// Fake clientsets have no REST client to reach the API with
+	client := &Client{clientset: fake.NewClientset(), currentNamespace: "default"}
+	if _, err := client.GetPodMetrics(context.Background(), ""); !errors.Is(err, ErrMetricsUnavailable) {
+		t.Errorf("expected ErrMetricsUnavailable from a fake clientset, got %v", err)
+	}
+}
+
+func TestClient_GetPodMetrics_Forbidden(t *testing.T) {
+	client := metricsServer(t, http.StatusForbidden,
+		`{"kind": "Status", "apiVersion": "v1", "status": "Failure", "reason": "Forbidden", "code": 403}`)
+
+	_, err := client.GetPodMetrics(context.Background(), "default")
+	if err == nil || errors.Is(err, ErrMetricsUnavailable) {
+		t.Fatalf("expected a forbidden error, got %v", err)
+	}
+	if !IsForbidden(err) {
+		t.Errorf("the API error should be wrapped, got %v", err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -16,6 +17,32 @@ type NamespaceInfo struct {
 	Status    string
 	Age       time.Duration
 	IsCurrent bool
+
+	// Filled in by GetNamespaceSummary, which is loaded separately
+	Summarized bool
+	PodCount   int
+	Quotas     []QuotaUsage
+}
+
+// QuotaUsage is one resource limited by a ResourceQuota
+type QuotaUsage struct {
+	Quota    string
+	Resource string
+	Used     string
+	Hard     string
+}
+
+// NamespaceSummary describes how loaded a namespace is
+type NamespaceSummary struct {
+	PodCount int
+	Quotas   []QuotaUsage
+}
+
+// SetSummary records a summary loaded for the namespace
+func (n *NamespaceInfo) SetSummary(summary NamespaceSummary) {
+	n.Summarized = true
+	n.PodCount = summary.PodCount
+	n.Quotas = summary.Quotas
 }
 
 // ListNamespaces returns all namespaces in the current cluster
@@ -28,6 +55,74 @@ func (c *Client) ListNamespaces(ctx context.Context) ([]NamespaceInfo, error) {
 	return c.namespacesToInfo(namespaces.Items), nil
 }
 
+// GetNamespaceSummary counts the pods in a namespace and reads its resource
+// quotas. Namespaces without quotas, or whose quotas can't be read, report
+// none.
+func (c *Client) GetNamespaceSummary(ctx context.Context, name string) (NamespaceSummary, error) {
+	count, err := c.countPods(ctx, name)
+	if err != nil {
+		return NamespaceSummary{}, err
+	}
+
+	quotas, err := c.clientset.CoreV1().ResourceQuotas(name).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if apierrors.IsForbidden(err) {
+			return NamespaceSummary{PodCount: count}, nil
+		}
+		return NamespaceSummary{}, fmt.Errorf("failed to list resource quotas: %w", err)
+	}
+
+	return NamespaceSummary{PodCount: count, Quotas: quotaUsage(quotas.Items)}, nil
+}
+
+// countPods asks for a single pod and reads the total from the remaining
+// item count, falling back to a full list if the server doesn't report it
+func (c *Client) countPods(ctx context.Context, namespace string) (int, error) {
+	pods := c.clientset.CoreV1().Pods(namespace)
+	list, err := pods.List(ctx, metav1.ListOptions{Limit: 1})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count pods: %w", err)
+	}
+	if list.RemainingItemCount != nil {
+		return len(list.Items) + int(*list.RemainingItemCount), nil
+	}
+	if list.Continue == "" {
+		return len(list.Items), nil
+	}
+
+	list, err = pods.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count pods: %w", err)
+	}
+	return len(list.Items), nil
+}
+
+// quotaUsage flattens quotas into one entry per limited resource, sorted
+// by quota then resource name
+func quotaUsage(quotas []corev1.ResourceQuota) []QuotaUsage {
+	var result []QuotaUsage
+	for i := range quotas {
+		quota := &quotas[i]
+		for resource, hard := range quota.Status.Hard {
+			used := quota.Status.Used[resource]
+			result = append(result, QuotaUsage{
+				Quota:    quota.Name,
+				Resource: string(resource),
+				Used:     used.String(),
+				Hard:     hard.String(),
+			})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Quota != result[j].Quota {
+			return result[i].Quota < result[j].Quota
+		}
+		return result[i].Resource < result[j].Resource
+	})
+	return result
+}
+
 // namespacesToInfo converts namespace objects to NamespaceInfo
 func (c *Client) namespacesToInfo(namespaces []corev1.Namespace) []NamespaceInfo {
 	now := time.Now()
@@ -2,13 +2,19 @@ package k8s
 
 import (
 	"context"
+	"errors"
+	"reflect"
 	"testing"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 func TestClient_ListNamespaces(t *testing.T) {
@@ -176,3 +182,90 @@ func TestClient_namespacesToInfo_MarksCurrentNamespace(t *testing.T) {
 		}
 	}
 }
+
+func TestClient_GetNamespaceSummary(t *testing.T) {
+	pod := func(ns, name string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns}}
+	}
+	quota := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "production"},
+		Status: corev1.ResourceQuotaStatus{
+			Hard: corev1.ResourceList{
+				corev1.ResourcePods:        resource.MustParse("10"),
+				corev1.ResourceRequestsCPU: resource.MustParse("4"),
+			},
+			Used: corev1.ResourceList{
+				corev1.ResourcePods:        resource.MustParse("3"),
+				corev1.ResourceRequestsCPU: resource.MustParse("1500m"),
+			},
+		},
+	}
+
+	client := &Client{
+		clientset: fake.NewClientset(
+			pod("production", "a"), pod("production", "b"), pod("production", "c"),
+			pod("staging", "d"), quota,
+		),
+		currentNamespace: "default",
+	}
+
+	summary, err := client.GetNamespaceSummary(context.Background(), "production")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.PodCount != 3 {
+		t.Errorf("expected 3 pods, got %d", summary.PodCount)
+	}
+	want := []QuotaUsage{
+		{Quota: "compute", Resource: "pods", Used: "3", Hard: "10"},
+		{Quota: "compute", Resource: "requests.cpu", Used: "1500m", Hard: "4"},
+	}
+	if !reflect.DeepEqual(summary.Quotas, want) {
+		t.Errorf("quotas = %+v, want %+v", summary.Quotas, want)
+	}
+
+	// A namespace without quotas just has a pod count
+	summary, err = client.GetNamespaceSummary(context.Background(), "staging")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.PodCount != 1 || len(summary.Quotas) != 0 {
+		t.Errorf("expected 1 pod and no quotas, got %+v", summary)
+	}
+}
+
+func TestClient_GetNamespaceSummary_QuotasForbidden(t *testing.T) {
+	clientset := fake.NewClientset(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "production"}})
+	clientset.PrependReactor("list", "resourcequotas", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "resourcequotas"}, "", errors.New("no access"))
+	})
+	client := &Client{clientset: clientset, currentNamespace: "default"}
+
+	summary, err := client.GetNamespaceSummary(context.Background(), "production")
+	if err != nil {
+		t.Fatalf("forbidden quotas should not fail the summary: %v", err)
+	}
+	if summary.PodCount != 1 || summary.Quotas != nil {
+		t.Errorf("expected 1 pod and no quotas, got %+v", summary)
+	}
+}
+
+func TestClient_GetNamespaceSummary_PodsError(t *testing.T) {
+	clientset := fake.NewClientset()
+	clientset.PrependReactor("list", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("connection refused")
+	})
+	client := &Client{clientset: clientset, currentNamespace: "default"}
+
+	if _, err := client.GetNamespaceSummary(context.Background(), "production"); err == nil {
+		t.Error("expected an error when pods can't be listed")
+	}
+}
+
+func TestNamespaceInfo_SetSummary(t *testing.T) {
+	ns := NamespaceInfo{Name: "production"}
+	ns.SetSummary(NamespaceSummary{PodCount: 42})
+	if !ns.Summarized || ns.PodCount != 42 {
+		t.Errorf("expected a summarized namespace with 42 pods, got %+v", ns)
+	}
+}
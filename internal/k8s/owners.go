@@ -0,0 +1,123 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OwnerRef identifies the workload that controls a pod
+type OwnerRef struct {
+	Kind string // e.g. Deployment, StatefulSet, Job
+	Name string
+}
+
+// String renders the owner as Kind/Name, or "-" for standalone pods
+func (o OwnerRef) String() string {
+	if o.Name == "" {
+		return "-"
+	}
+	return o.Kind + "/" + o.Name
+}
+
+// Restartable reports whether the owner supports rolling restarts
+func (o OwnerRef) Restartable() bool {
+	switch o.Kind {
+	case "Deployment", "StatefulSet", "DaemonSet":
+		return o.Name != ""
+	}
+	return false
+}
+
+// ownerCache remembers the controller of each ReplicaSet so converting a
+// pod never needs an API call. Safe for use from the watch goroutine.
+type ownerCache struct {
+	mu          sync.Mutex
+	replicaSets map[string]OwnerRef // Keyed by namespace/name
+}
+
+func (oc *ownerCache) get(namespace, name string) (OwnerRef, bool) {
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+	owner, ok := oc.replicaSets[namespace+"/"+name]
+	return owner, ok
+}
+
+func (oc *ownerCache) set(namespace, name string, owner OwnerRef) {
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+	if oc.replicaSets == nil {
+		oc.replicaSets = make(map[string]OwnerRef)
+	}
+	oc.replicaSets[namespace+"/"+name] = owner
+}
+
+// podOwner returns the workload controlling a pod. ReplicaSets are resolved
+// to their owner from the cache, falling back to the Deployment naming
+// convention (<deployment>-<pod-template-hash>) for ones not yet cached.
+func (c *Client) podOwner(pod *corev1.Pod) OwnerRef {
+	ref := metav1.GetControllerOf(pod)
+	if ref == nil {
+		if len(pod.OwnerReferences) == 0 {
+			return OwnerRef{}
+		}
+		ref = &pod.OwnerReferences[0]
+	}
+
+	owner := OwnerRef{Kind: ref.Kind, Name: ref.Name}
+	if owner.Kind != "ReplicaSet" {
+		return owner
+	}
+
+	if cached, ok := c.owners.get(pod.Namespace, owner.Name); ok {
+		return cached
+	}
+
+	if hash := pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]; hash != "" {
+		if name, ok := strings.CutSuffix(owner.Name, "-"+hash); ok && name != "" {
+			return OwnerRef{Kind: "Deployment", Name: name}
+		}
+	}
+
+	return owner
+}
+
+// resolveReplicaSetOwners caches the controllers of the ReplicaSets that
+// own the given pods using a single list call, and only when one of them
+// isn't cached yet. namespace may be metav1.NamespaceAll for pods from
+// several namespaces. Errors (e.g. no access to ReplicaSets) are ignored;
+// podOwner then falls back to naming conventions.
+func (c *Client) resolveReplicaSetOwners(ctx context.Context, namespace string, pods []corev1.Pod) {
+	missing := false
+	for i := range pods {
+		ref := metav1.GetControllerOf(&pods[i])
+		if ref == nil || ref.Kind != "ReplicaSet" {
+			continue
+		}
+		if _, ok := c.owners.get(pods[i].Namespace, ref.Name); !ok {
+			missing = true
+			break
+		}
+	}
+	if !missing {
+		return
+	}
+
+	replicaSets, err := c.clientset.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return
+	}
+
+	for i := range replicaSets.Items {
+		rs := &replicaSets.Items[i]
+		owner := OwnerRef{Kind: "ReplicaSet", Name: rs.Name}
+		if ref := metav1.GetControllerOf(rs); ref != nil {
+			owner = OwnerRef{Kind: ref.Kind, Name: ref.Name}
+		}
+		c.owners.set(rs.Namespace, rs.Name, owner)
+	}
+}
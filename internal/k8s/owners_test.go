@@ -0,0 +1,138 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func controllerRef(kind, name string) metav1.OwnerReference {
+	controller := true
+	return metav1.OwnerReference{Kind: kind, Name: name, Controller: &controller}
+}
+
+func createOwnedPod(name string, owner metav1.OwnerReference, hash string) *corev1.Pod {
+	pod := createTestPod(name, "default", corev1.PodRunning, true)
+	pod.OwnerReferences = []metav1.OwnerReference{owner}
+	if hash != "" {
+		pod.Labels = map[string]string{appsv1.DefaultDeploymentUniqueLabelKey: hash}
+	}
+	return pod
+}
+
+func createTestReplicaSet(name string, owner *metav1.OwnerReference) *appsv1.ReplicaSet {
+	rs := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+	}
+	if owner != nil {
+		rs.OwnerReferences = []metav1.OwnerReference{*owner}
+	}
+	return rs
+}
+
+func TestOwnerRef_String(t *testing.T) {
+	if got := (OwnerRef{}).String(); got != "-" {
+		t.Errorf("expected '-' for standalone pods, got %q", got)
+	}
+	if got := (OwnerRef{Kind: "StatefulSet", Name: "db"}).String(); got != "StatefulSet/db" {
+		t.Errorf("expected 'StatefulSet/db', got %q", got)
+	}
+}
+
+func TestOwnerRef_Restartable(t *testing.T) {
+	tests := []struct {
+		owner OwnerRef
+		want  bool
+	}{
+		{OwnerRef{}, false},
+		{OwnerRef{Kind: "Deployment", Name: "web"}, true},
+		{OwnerRef{Kind: "StatefulSet", Name: "db"}, true},
+		{OwnerRef{Kind: "DaemonSet", Name: "agent"}, true},
+		{OwnerRef{Kind: "Job", Name: "migrate"}, false},
+		{OwnerRef{Kind: "ReplicaSet", Name: "web-abc"}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.owner.Restartable(); got != tt.want {
+			t.Errorf("%s.Restartable() = %v, want %v", tt.owner, got, tt.want)
+		}
+	}
+}
+
+func TestClient_ListPods_ResolvesOwners(t *testing.T) {
+	deployment := controllerRef("Deployment", "web")
+	rollout := controllerRef("Rollout", "canary")
+
+	fakeClient := fake.NewClientset(
+		createTestReplicaSet("web-7d9f8", &deployment),
+		createTestReplicaSet("canary-abc12", &rollout),
+		createTestReplicaSet("bare", nil),
+		createOwnedPod("web-7d9f8-x1", controllerRef("ReplicaSet", "web-7d9f8"), "7d9f8"),
+		createOwnedPod("canary-abc12-x1", controllerRef("ReplicaSet", "canary-abc12"), "abc12"),
+		createOwnedPod("bare-x1", controllerRef("ReplicaSet", "bare"), ""),
+		createOwnedPod("db-0", controllerRef("StatefulSet", "db"), ""),
+		createTestPod("standalone", "default", corev1.PodRunning, true),
+	)
+	client := &Client{clientset: fakeClient, currentNamespace: "default"}
+
+	pods, err := client.ListPods(context.Background(), "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]string{
+		"web-7d9f8-x1":    "Deployment/web",
+		"canary-abc12-x1": "Rollout/canary",
+		"bare-x1":         "ReplicaSet/bare",
+		"db-0":            "StatefulSet/db",
+		"standalone":      "-",
+	}
+	for _, pod := range pods {
+		if got := pod.Owner.String(); got != expected[pod.Name] {
+			t.Errorf("pod %s: expected owner %q, got %q", pod.Name, expected[pod.Name], got)
+		}
+	}
+}
+
+func TestClient_ListPods_CachesReplicaSetOwners(t *testing.T) {
+	deployment := controllerRef("Deployment", "web")
+	fakeClient := fake.NewClientset(
+		createTestReplicaSet("web-7d9f8", &deployment),
+		createOwnedPod("web-7d9f8-x1", controllerRef("ReplicaSet", "web-7d9f8"), "7d9f8"),
+		createOwnedPod("web-7d9f8-x2", controllerRef("ReplicaSet", "web-7d9f8"), "7d9f8"),
+	)
+	client := &Client{clientset: fakeClient, currentNamespace: "default"}
+
+	for range 3 {
+		if _, err := client.ListPods(context.Background(), "", ""); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	rsLists := 0
+	for _, action := range fakeClient.Actions() {
+		if action.GetVerb() == "list" && action.GetResource().Resource == "replicasets" {
+			rsLists++
+		}
+	}
+	if rsLists != 1 {
+		t.Errorf("expected 1 ReplicaSet list across refreshes, got %d", rsLists)
+	}
+}
+
+func TestClient_PodOwner_FallsBackToNamingConvention(t *testing.T) {
+	client := &Client{currentNamespace: "default"}
+
+	pod := createOwnedPod("api-5c6b-x1", controllerRef("ReplicaSet", "api-5c6b"), "5c6b")
+	if got := client.podOwner(pod); got != (OwnerRef{Kind: "Deployment", Name: "api"}) {
+		t.Errorf("expected Deployment/api from pod-template-hash, got %v", got)
+	}
+
+	pod = createOwnedPod("job-x1", metav1.OwnerReference{Kind: "Job", Name: "migrate"}, "")
+	if got := client.podOwner(pod); got != (OwnerRef{Kind: "Job", Name: "migrate"}) {
+		t.Errorf("expected non-controller owner to be used, got %v", got)
+	}
+}
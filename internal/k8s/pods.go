@@ -3,11 +3,14 @@ package k8s
 import (
 	"context"
 	"fmt"
-	"sort"
+	"slices"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
 )
 
 // PodStatus represents the high-level status of a pod
@@ -23,6 +26,10 @@ const (
 	PodStatusTerminating PodStatus = "Terminating"
 )
 
+// DefaultContainerAnnotation names the container kubectl picks when none
+// is given
+const DefaultContainerAnnotation = "kubectl.kubernetes.io/default-container"
+
 // ContainerStatus represents the status of a container within a pod
 type ContainerStatus struct {
 	Name         string
@@ -30,6 +37,76 @@ type ContainerStatus struct {
 	RestartCount int32
 	State        string // Running, Waiting, Terminated
 	StateReason  string // Reason for Waiting/Terminated state
+	ContainerID  string // Runtime ID (e.g. containerd://...), empty until started
+	Image        string
+	ImageID      string // Resolved image the container runs, e.g. nginx@sha256:...; empty until pulled
+
+	// Why the previous instance ended (e.g. OOMKilled, Error); empty until
+	// the container has restarted
+	LastTerminationReason   string
+	LastTerminationExitCode int32
+}
+
+// LastTermination describes how the previous instance of the container
+// ended, e.g. "OOMKilled (exit 137)", or "" if it never restarted
+func (c ContainerStatus) LastTermination() string {
+	if c.LastTerminationReason == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s (exit %d)", c.LastTerminationReason, c.LastTerminationExitCode)
+}
+
+// IsCrashLooping reports whether the container keeps dying and waits in
+// CrashLoopBackOff to be restarted, so its current instance has little or
+// no log output
+func (c ContainerStatus) IsCrashLooping() bool {
+	return !c.Ready && c.State == "Waiting" && c.StateReason == "CrashLoopBackOff"
+}
+
+// shortDigestLength is how many hex digits Digest keeps, like docker's
+// short image IDs
+const shortDigestLength = 12
+
+// Digest returns the shortened digest of the running image, e.g.
+// "sha256:4c0fdaa8b634", or "" before the image was pulled. Comparing it
+// across pods shows whether they run the same build of a moving tag.
+func (c ContainerStatus) Digest() string {
+	digest := c.ImageID
+	if at := strings.LastIndex(digest, "@"); at >= 0 {
+		digest = digest[at+1:]
+	}
+	algorithm, hex, ok := strings.Cut(digest, ":")
+	if !ok || !strings.HasPrefix(algorithm, "sha") {
+		return ""
+	}
+	return algorithm + ":" + hex[:min(len(hex), shortDigestLength)]
+}
+
+// ContainerChange classifies how a container changed between two observations
+type ContainerChange int
+
+// Container change kinds returned by CompareContainer.
+const (
+	ContainerUnchanged ContainerChange = iota
+	ContainerRestarted                 // Same container, restart count went up
+	ContainerRecreated                 // Replaced container whose restart count starts over
+)
+
+// CompareContainer classifies the change from prev to curr and returns the
+// number of new restarts. A recreated container (e.g. its pod was replaced
+// under the same name) gets a new ContainerID and its RestartCount resets,
+// which must not be read as a negative restart delta.
+func CompareContainer(prev, curr ContainerStatus) (ContainerChange, int32) {
+	switch {
+	case curr.RestartCount > prev.RestartCount:
+		return ContainerRestarted, curr.RestartCount - prev.RestartCount
+	case curr.RestartCount < prev.RestartCount:
+		return ContainerRecreated, 0
+	case prev.ContainerID != "" && curr.ContainerID != "" && prev.ContainerID != curr.ContainerID:
+		return ContainerRecreated, 0
+	default:
+		return ContainerUnchanged, 0
+	}
 }
 
 // PodInfo contains information about a Kubernetes pod
@@ -43,22 +120,76 @@ type PodInfo struct {
 	Age            time.Duration
 	IP             string
 	Node           string
+	Image          string   // Image of the first container in the spec
+	Owner          OwnerRef // Controlling workload; zero for standalone pods
+	Labels         map[string]string
 	Containers     []ContainerStatus
+	InitContainers []ContainerStatus // Run to completion before Containers start
 	ContainerCount int
 	ReadyCount     int
+
+	// Container named by DefaultContainerAnnotation; empty when the
+	// annotation is absent or names no container of the pod
+	DefaultContainer string
+
+	// Resource requests and limits summed across containers (zero if unset)
+	CPURequest resource.Quantity
+	CPULimit   resource.Quantity
+	MemRequest resource.Quantity
+	MemLimit   resource.Quantity
+	QoSClass   string // Guaranteed, Burstable or BestEffort
+}
+
+// PrimaryContainer returns the container to use when none is chosen: the
+// annotated default container, otherwise the first one
+func (p PodInfo) PrimaryContainer() string {
+	if p.DefaultContainer != "" {
+		return p.DefaultContainer
+	}
+	if len(p.Containers) > 0 {
+		return p.Containers[0].Name
+	}
+	return ""
+}
+
+// Container returns the status of the named container
+func (p PodInfo) Container(name string) (ContainerStatus, bool) {
+	for _, c := range p.Containers {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return ContainerStatus{}, false
 }
 
-// ListPods returns pods in the specified namespace (or current namespace if empty)
-func (c *Client) ListPods(ctx context.Context, namespace string) ([]PodInfo, error) {
+// ListPods returns pods in the specified namespace (or current namespace if
+// empty). A non-empty labelSelector such as "app=web" is applied by the
+// server.
+func (c *Client) ListPods(ctx context.Context, namespace, labelSelector string) ([]PodInfo, error) {
 	if namespace == "" {
 		namespace = c.currentNamespace
 	}
 
-	pods, err := c.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	pods, err := c.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list pods in namespace %q: %w", namespace, err)
 	}
 
+	c.resolveReplicaSetOwners(ctx, namespace, pods.Items)
+
+	return c.podsToInfo(pods.Items), nil
+}
+
+// ListAllPods returns pods across all namespaces, sorted by namespace and
+// name, filtered by labelSelector like ListPods
+func (c *Client) ListAllPods(ctx context.Context, labelSelector string) ([]PodInfo, error) {
+	pods, err := c.clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods in all namespaces: %w", err)
+	}
+
+	c.resolveReplicaSetOwners(ctx, metav1.NamespaceAll, pods.Items)
+
 	return c.podsToInfo(pods.Items), nil
 }
 
@@ -77,6 +208,38 @@ func (c *Client) GetPod(ctx context.Context, namespace, name string) (*PodInfo,
 	return &info, nil
 }
 
+// GetPodYAML returns the full pod manifest rendered as YAML.
+// Managed fields are stripped since they are noisy and rarely useful to read.
+func (c *Client) GetPodYAML(ctx context.Context, namespace, name string) (string, error) {
+	if namespace == "" {
+		namespace = c.currentNamespace
+	}
+
+	pod, err := c.clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get pod %q in namespace %q: %w", name, namespace, err)
+	}
+
+	return podToYAML(pod)
+}
+
+// podToYAML marshals a pod to YAML without managed fields
+func podToYAML(pod *corev1.Pod) (string, error) {
+	pod = pod.DeepCopy()
+	pod.ManagedFields = nil
+
+	// Typed objects from the clientset don't carry TypeMeta
+	pod.APIVersion = "v1"
+	pod.Kind = "Pod"
+
+	data, err := yaml.Marshal(pod)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal pod %q: %w", pod.Name, err)
+	}
+
+	return string(data), nil
+}
+
 // podsToInfo converts pod objects to PodInfo slice
 func (c *Client) podsToInfo(pods []corev1.Pod) []PodInfo {
 	result := make([]PodInfo, 0, len(pods))
@@ -85,14 +248,19 @@ func (c *Client) podsToInfo(pods []corev1.Pod) []PodInfo {
 		result = append(result, c.podToInfo(&pods[i]))
 	}
 
-	// Sort by name
-	sort.Slice(result, func(i, j int) bool {
-		return result[i].Name < result[j].Name
-	})
+	slices.SortFunc(result, ComparePods)
 
 	return result
 }
 
+// ComparePods orders pods by namespace, then name, like kubectl
+func ComparePods(a, b PodInfo) int {
+	if c := strings.Compare(a.Namespace, b.Namespace); c != 0 {
+		return c
+	}
+	return strings.Compare(a.Name, b.Name)
+}
+
 // podToInfo converts a single pod to PodInfo
 func (c *Client) podToInfo(pod *corev1.Pod) PodInfo {
 	now := time.Now()
@@ -104,6 +272,9 @@ func (c *Client) podToInfo(pod *corev1.Pod) PodInfo {
 	// Determine pod status
 	status, statusMessage := determinePodStatus(pod)
 
+	// Sum resource requests/limits from the spec
+	resources := sumContainerResources(pod)
+
 	return PodInfo{
 		Name:           pod.Name,
 		Namespace:      pod.Namespace,
@@ -114,10 +285,120 @@ func (c *Client) podToInfo(pod *corev1.Pod) PodInfo {
 		Age:            age,
 		IP:             pod.Status.PodIP,
 		Node:           pod.Spec.NodeName,
+		Image:          podImage(pod),
+		Owner:          c.podOwner(pod),
+		Labels:         pod.Labels,
 		Containers:     containers,
+		InitContainers: parseInitContainerStatuses(pod),
 		ContainerCount: len(containers),
 		ReadyCount:     readyCount,
+		CPURequest:     resources.cpuRequest,
+		CPULimit:       resources.cpuLimit,
+		MemRequest:     resources.memRequest,
+		MemLimit:       resources.memLimit,
+		QoSClass:       string(podQOSClass(pod)),
+
+		DefaultContainer: defaultContainer(pod),
+	}
+}
+
+// podImage returns the image of the pod's first container
+func podImage(pod *corev1.Pod) string {
+	if len(pod.Spec.Containers) == 0 {
+		return ""
+	}
+	return pod.Spec.Containers[0].Image
+}
+
+// defaultContainer returns the container named by the default-container
+// annotation if the pod has it
+func defaultContainer(pod *corev1.Pod) string {
+	name := pod.Annotations[DefaultContainerAnnotation]
+	for i := range pod.Spec.Containers {
+		if pod.Spec.Containers[i].Name == name {
+			return name
+		}
 	}
+	return ""
+}
+
+// podResources holds summed resource requests and limits for a pod
+type podResources struct {
+	cpuRequest resource.Quantity
+	cpuLimit   resource.Quantity
+	memRequest resource.Quantity
+	memLimit   resource.Quantity
+}
+
+// sumContainerResources sums CPU/memory requests and limits across containers.
+// Containers without a value for a resource simply don't contribute to it.
+func sumContainerResources(pod *corev1.Pod) podResources {
+	var r podResources
+
+	for i := range pod.Spec.Containers {
+		res := &pod.Spec.Containers[i].Resources
+		if q, ok := res.Requests[corev1.ResourceCPU]; ok {
+			r.cpuRequest.Add(q)
+		}
+		if q, ok := res.Limits[corev1.ResourceCPU]; ok {
+			r.cpuLimit.Add(q)
+		}
+		if q, ok := res.Requests[corev1.ResourceMemory]; ok {
+			r.memRequest.Add(q)
+		}
+		if q, ok := res.Limits[corev1.ResourceMemory]; ok {
+			r.memLimit.Add(q)
+		}
+	}
+
+	return r
+}
+
+// podQOSClass returns the pod's QoS class as reported by the API server,
+// or derives it from the container resources when the status lacks it
+func podQOSClass(pod *corev1.Pod) corev1.PodQOSClass {
+	if pod.Status.QOSClass != "" {
+		return pod.Status.QOSClass
+	}
+
+	containers := make([]corev1.Container, 0, len(pod.Spec.InitContainers)+len(pod.Spec.Containers))
+	containers = append(containers, pod.Spec.InitContainers...)
+	containers = append(containers, pod.Spec.Containers...)
+
+	compute := []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory}
+	anySet := false
+	guaranteed := true
+	for i := range containers {
+		res := &containers[i].Resources
+		for _, name := range compute {
+			request, hasRequest := res.Requests[name]
+			limit, hasLimit := res.Limits[name]
+			if hasRequest || hasLimit {
+				anySet = true
+			}
+			// Guaranteed needs limits everywhere; an unset request defaults to the limit
+			if !hasLimit || (hasRequest && request.Cmp(limit) != 0) {
+				guaranteed = false
+			}
+		}
+	}
+
+	switch {
+	case !anySet:
+		return corev1.PodQOSBestEffort
+	case guaranteed:
+		return corev1.PodQOSGuaranteed
+	default:
+		return corev1.PodQOSBurstable
+	}
+}
+
+// FormatQuantity formats a resource quantity the way kubectl does, or "-" if unset
+func FormatQuantity(q resource.Quantity) string {
+	if q.IsZero() {
+		return "-"
+	}
+	return q.String()
 }
 
 // parseContainerStatuses extracts container status info from a pod
@@ -136,13 +417,7 @@ func parseContainerStatuses(pod *corev1.Pod) ([]ContainerStatus, int, int32) {
 		cs := &pod.Status.ContainerStatuses[i]
 		state, reason := parseContainerState(cs.State)
 
-		containers = append(containers, ContainerStatus{
-			Name:         cs.Name,
-			Ready:        cs.Ready,
-			RestartCount: cs.RestartCount,
-			State:        state,
-			StateReason:  reason,
-		})
+		containers = append(containers, containerStatus(cs, state, reason))
 
 		if cs.Ready {
 			readyCount++
@@ -157,6 +432,7 @@ func parseContainerStatuses(pod *corev1.Pod) ([]ContainerStatus, int, int32) {
 				Name:  pod.Spec.Containers[i].Name,
 				Ready: false,
 				State: "Waiting",
+				Image: pod.Spec.Containers[i].Image,
 			})
 		}
 	}
@@ -164,6 +440,148 @@ func parseContainerStatuses(pod *corev1.Pod) ([]ContainerStatus, int, int32) {
 	return containers, readyCount, totalRestarts
 }
 
+// containerStatus converts a container's API status given its parsed state
+func containerStatus(cs *corev1.ContainerStatus, state, reason string) ContainerStatus {
+	status := ContainerStatus{
+		Name:         cs.Name,
+		Ready:        cs.Ready,
+		RestartCount: cs.RestartCount,
+		State:        state,
+		StateReason:  reason,
+		ContainerID:  cs.ContainerID,
+		Image:        cs.Image,
+		ImageID:      cs.ImageID,
+	}
+	if last := cs.LastTerminationState.Terminated; last != nil {
+		status.LastTerminationReason = last.Reason
+		status.LastTerminationExitCode = last.ExitCode
+		if status.LastTerminationReason == "" {
+			status.LastTerminationReason = "Terminated"
+		}
+	}
+	return status
+}
+
+// parseInitContainerStatuses extracts init container status info from a pod
+func parseInitContainerStatuses(pod *corev1.Pod) []ContainerStatus {
+	if len(pod.Spec.InitContainers) == 0 {
+		return nil
+	}
+
+	containers := make([]ContainerStatus, 0, len(pod.Spec.InitContainers))
+	for i := range pod.Status.InitContainerStatuses {
+		cs := &pod.Status.InitContainerStatuses[i]
+		state, reason := parseContainerState(cs.State)
+		containers = append(containers, containerStatus(cs, state, reason))
+	}
+
+	// If no status yet, create entries from spec
+	if len(containers) == 0 {
+		for i := range pod.Spec.InitContainers {
+			containers = append(containers, ContainerStatus{
+				Name:  pod.Spec.InitContainers[i].Name,
+				State: "Waiting",
+				Image: pod.Spec.InitContainers[i].Image,
+			})
+		}
+	}
+	return containers
+}
+
+// initStatus reports init container progress the way kubectl does:
+// "Init:1/2" while they run, "Init:<reason>" when one is stuck or failed,
+// and "" once all have completed. Started sidecars (init containers with
+// restartPolicy Always) count as completed.
+func initStatus(pod *corev1.Pod) string {
+	total := len(pod.Spec.InitContainers)
+	sidecars := sidecarNames(pod)
+
+	for i := range pod.Status.InitContainerStatuses {
+		cs := &pod.Status.InitContainerStatuses[i]
+		switch {
+		case cs.State.Terminated != nil && cs.State.Terminated.ExitCode == 0:
+			continue
+		case sidecars[cs.Name] && cs.Started != nil && *cs.Started:
+			continue
+		case cs.State.Terminated != nil:
+			if cs.State.Terminated.Reason != "" {
+				return "Init:" + cs.State.Terminated.Reason
+			}
+			return fmt.Sprintf("Init:ExitCode:%d", cs.State.Terminated.ExitCode)
+		case cs.State.Waiting != nil && cs.State.Waiting.Reason != "" && cs.State.Waiting.Reason != "PodInitializing":
+			return "Init:" + cs.State.Waiting.Reason
+		default:
+			return fmt.Sprintf("Init:%d/%d", i, total)
+		}
+	}
+	return ""
+}
+
+// sidecarNames returns the init containers with restartPolicy Always,
+// which keep running next to the regular containers
+func sidecarNames(pod *corev1.Pod) map[string]bool {
+	sidecars := make(map[string]bool)
+	for i := range pod.Spec.InitContainers {
+		c := &pod.Spec.InitContainers[i]
+		if c.RestartPolicy != nil && *c.RestartPolicy == corev1.ContainerRestartPolicyAlways {
+			sidecars[c.Name] = true
+		}
+	}
+	return sidecars
+}
+
+// runningStatuses returns the statuses of the regular containers followed
+// by those of sidecars
+func runningStatuses(pod *corev1.Pod) []corev1.ContainerStatus {
+	statuses := slices.Clone(pod.Status.ContainerStatuses)
+	sidecars := sidecarNames(pod)
+	for _, cs := range pod.Status.InitContainerStatuses {
+		if sidecars[cs.Name] {
+			statuses = append(statuses, cs)
+		}
+	}
+	return statuses
+}
+
+// IsInitializing reports whether the pod's init containers are still
+// running or stuck, i.e. StatusMessage is an "Init:" progress or reason
+func (p PodInfo) IsInitializing() bool {
+	return p.Status == PodStatusPending && strings.HasPrefix(p.StatusMessage, "Init:")
+}
+
+// stuckReasons are container waiting reasons that need attention rather
+// than patience
+var stuckReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"ImagePullBackOff":           true,
+	"ErrImagePull":               true,
+	"InvalidImageName":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+}
+
+// IsStuckReason reports whether a container waiting reason, optionally
+// prefixed with "Init:", means the container won't start on its own
+func IsStuckReason(reason string) bool {
+	return stuckReasons[strings.TrimPrefix(reason, "Init:")]
+}
+
+// IsStuck reports whether a container of the pod is stuck, e.g. in
+// CrashLoopBackOff, even though the pod may be Running
+func (p PodInfo) IsStuck() bool {
+	return (p.Status == PodStatusRunning || p.Status == PodStatusPending) && IsStuckReason(p.StatusMessage)
+}
+
+// DisplayStatus returns the status shown in the pod list: the init
+// progress while initializing, e.g. "Init:1/2", the reason a container is
+// stuck, e.g. "CrashLoopBackOff", otherwise the status
+func (p PodInfo) DisplayStatus() string {
+	if p.IsInitializing() || p.IsStuck() {
+		return p.StatusMessage
+	}
+	return string(p.Status)
+}
+
 // parseContainerState determines the state of a container
 func parseContainerState(state corev1.ContainerState) (string, string) {
 	if state.Running != nil {
@@ -192,6 +610,9 @@ func determinePodStatus(pod *corev1.Pod) (PodStatus, string) {
 	case corev1.PodFailed:
 		return PodStatusFailed, getFailureReason(pod)
 	case corev1.PodPending:
+		if init := initStatus(pod); init != "" {
+			return PodStatusPending, init
+		}
 		reason := getPendingReason(pod)
 		return PodStatusPending, reason
 	case corev1.PodRunning:
@@ -207,13 +628,14 @@ func determinePodStatus(pod *corev1.Pod) (PodStatus, string) {
 	}
 }
 
-// areAllContainersReady checks if all containers in a pod are ready
+// areAllContainersReady checks if all containers in a pod, including
+// sidecars, are ready
 func areAllContainersReady(pod *corev1.Pod) bool {
 	if len(pod.Status.ContainerStatuses) == 0 {
 		return false
 	}
-	for i := range pod.Status.ContainerStatuses {
-		if !pod.Status.ContainerStatuses[i].Ready {
+	for _, cs := range runningStatuses(pod) {
+		if !cs.Ready {
 			return false
 		}
 	}
@@ -254,15 +676,20 @@ func getPendingReason(pod *corev1.Pod) string {
 	return ""
 }
 
-// getNotReadyReason extracts the reason why containers aren't ready
+// getNotReadyReason extracts the reason why containers aren't ready,
+// preferring a stuck container, including sidecars, over one still starting
 func getNotReadyReason(pod *corev1.Pod) string {
-	for i := range pod.Status.ContainerStatuses {
-		cs := &pod.Status.ContainerStatuses[i]
-		if !cs.Ready {
-			if cs.State.Waiting != nil && cs.State.Waiting.Reason != "" {
-				return cs.State.Waiting.Reason
-			}
+	reason := ""
+	for _, cs := range runningStatuses(pod) {
+		if cs.Ready || cs.State.Waiting == nil || cs.State.Waiting.Reason == "" {
+			continue
+		}
+		if IsStuckReason(cs.State.Waiting.Reason) {
+			return cs.State.Waiting.Reason
+		}
+		if reason == "" {
+			reason = cs.State.Waiting.Reason
 		}
 	}
-	return ""
+	return reason
 }
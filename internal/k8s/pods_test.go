@@ -2,13 +2,20 @@ package k8s
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 func createTestPod(name, namespace string, phase corev1.PodPhase, ready bool) *corev1.Pod {
@@ -65,7 +72,7 @@ func TestClient_ListPods(t *testing.T) {
 	ctx := context.Background()
 
 	// List pods in default namespace
-	result, err := client.ListPods(ctx, "default")
+	result, err := client.ListPods(ctx, "default", "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -80,7 +87,7 @@ func TestClient_ListPods(t *testing.T) {
 	}
 
 	// List pods in kube-system namespace
-	result, err = client.ListPods(ctx, "kube-system")
+	result, err = client.ListPods(ctx, "kube-system", "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -105,7 +112,7 @@ func TestClient_ListPods_UsesCurrentNamespace(t *testing.T) {
 	ctx := context.Background()
 
 	// Empty namespace should use current namespace
-	result, err := client.ListPods(ctx, "")
+	result, err := client.ListPods(ctx, "", "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -115,6 +122,47 @@ func TestClient_ListPods_UsesCurrentNamespace(t *testing.T) {
 	}
 }
 
+func TestClient_ListPods_LabelSelector(t *testing.T) {
+	web := createTestPod("web-1", "default", corev1.PodRunning, true)
+	web.Labels = map[string]string{"app": "web"}
+	db := createTestPod("db-1", "default", corev1.PodRunning, true)
+	db.Labels = map[string]string{"app": "db"}
+
+	fakeClient := fake.NewClientset(web, db)
+	client := &Client{
+		clientset:        fakeClient,
+		currentNamespace: "default",
+	}
+
+	result, err := client.ListPods(context.Background(), "", "app=web")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result) != 1 || result[0].Name != "web-1" {
+		t.Errorf("expected only web-1, got %v", result)
+	}
+
+	var selectors []string
+	for _, action := range fakeClient.Actions() {
+		if list, ok := action.(k8stesting.ListAction); ok && action.GetResource().Resource == "pods" {
+			selectors = append(selectors, list.GetListRestrictions().Labels.String())
+		}
+	}
+	if !slices.Equal(selectors, []string{"app=web"}) {
+		t.Errorf("expected the selector passed to the pod list, got %q", selectors)
+	}
+
+	// All namespaces pass it too
+	fakeClient.ClearActions()
+	if _, err := client.ListAllPods(context.Background(), "app=db"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	list, ok := fakeClient.Actions()[0].(k8stesting.ListAction)
+	if !ok || list.GetListRestrictions().Labels.String() != "app=db" {
+		t.Errorf("expected the selector passed to the all-namespaces list, got %v", fakeClient.Actions()[0])
+	}
+}
+
 func TestClient_GetPod(t *testing.T) {
 	pods := []runtime.Object{
 		createTestPod("my-pod", "default", corev1.PodRunning, true),
@@ -161,6 +209,49 @@ func TestClient_GetPod_NotFound(t *testing.T) {
 	}
 }
 
+func TestClient_GetPodYAML(t *testing.T) {
+	pod := createTestPod("my-pod", "default", corev1.PodRunning, true)
+	pod.ManagedFields = []metav1.ManagedFieldsEntry{
+		{Manager: "kubectl-client-side-apply", Operation: metav1.ManagedFieldsOperationUpdate},
+	}
+
+	client := &Client{
+		clientset:        fake.NewClientset(pod),
+		currentNamespace: "default",
+	}
+
+	out, err := client.GetPodYAML(context.Background(), "", "my-pod")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []string{"apiVersion: v1", "kind: Pod", "name: my-pod", "nodeName: node-1"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("YAML should contain %q, got:\n%s", want, out)
+		}
+	}
+
+	if strings.Contains(out, "managedFields") || strings.Contains(out, "kubectl-client-side-apply") {
+		t.Errorf("YAML should not contain managed fields, got:\n%s", out)
+	}
+
+	// The stored object must not be mutated
+	if len(pod.ManagedFields) != 1 {
+		t.Error("GetPodYAML should not modify the original pod")
+	}
+}
+
+func TestClient_GetPodYAML_NotFound(t *testing.T) {
+	client := &Client{
+		clientset:        fake.NewClientset(),
+		currentNamespace: "default",
+	}
+
+	if _, err := client.GetPodYAML(context.Background(), "default", "missing"); err == nil {
+		t.Error("expected error for nonexistent pod")
+	}
+}
+
 func TestPodStatus_Running(t *testing.T) {
 	pod := createTestPod("test", "default", corev1.PodRunning, true)
 
@@ -208,6 +299,155 @@ func TestPodStatus_Terminating(t *testing.T) {
 	}
 }
 
+// createInitPod returns a pending pod with two init containers in the given states
+func createInitPod(first, second corev1.ContainerState) *corev1.Pod {
+	pod := createTestPod("init-pod", "default", corev1.PodPending, false)
+	pod.Spec.InitContainers = []corev1.Container{{Name: "migrate"}, {Name: "warm-cache"}}
+	pod.Status.InitContainerStatuses = []corev1.ContainerStatus{
+		{Name: "migrate", State: first},
+		{Name: "warm-cache", State: second},
+	}
+	pod.Status.ContainerStatuses[0].State = corev1.ContainerState{
+		Waiting: &corev1.ContainerStateWaiting{Reason: "PodInitializing"},
+	}
+	return pod
+}
+
+func TestPodStatus_InitContainers(t *testing.T) {
+	done := corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0, Reason: "Completed"}}
+	running := corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	initializing := corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "PodInitializing"}}
+
+	tests := []struct {
+		name   string
+		first  corev1.ContainerState
+		second corev1.ContainerState
+		want   string
+	}{
+		{"first running", running, initializing, "Init:0/2"},
+		{"second running", done, running, "Init:1/2"},
+		{"crash looping", done, corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}, "Init:CrashLoopBackOff"},
+		{"failed", corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Reason: "Error"}}, initializing, "Init:Error"},
+		{"failed without reason", corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 3}}, initializing, "Init:ExitCode:3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := (&Client{}).podToInfo(createInitPod(tt.first, tt.second))
+
+			if info.Status != PodStatusPending || info.StatusMessage != tt.want {
+				t.Errorf("expected Pending %q, got %s %q", tt.want, info.Status, info.StatusMessage)
+			}
+			if !info.IsInitializing() || info.DisplayStatus() != tt.want {
+				t.Errorf("expected display status %q, got %q", tt.want, info.DisplayStatus())
+			}
+			// Like kubectl, ready counts only regular containers
+			if info.Ready != "0/1" {
+				t.Errorf("expected ready '0/1', got %q", info.Ready)
+			}
+			if len(info.InitContainers) != 2 || info.InitContainers[0].Name != "migrate" {
+				t.Errorf("expected both init containers, got %+v", info.InitContainers)
+			}
+		})
+	}
+}
+
+func TestPodStatus_InitContainersDone(t *testing.T) {
+	done := corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0, Reason: "Completed"}}
+	pod := createInitPod(done, done)
+	pod.Status.Phase = corev1.PodRunning
+	pod.Status.ContainerStatuses[0].State = corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	pod.Status.ContainerStatuses[0].Ready = true
+
+	info := (&Client{}).podToInfo(pod)
+	if info.Status != PodStatusRunning || info.IsInitializing() || info.DisplayStatus() != "Running" {
+		t.Errorf("expected Running once init containers completed, got %q", info.DisplayStatus())
+	}
+}
+
+func TestPodStatus_SidecarInitContainer(t *testing.T) {
+	always := corev1.ContainerRestartPolicyAlways
+	started := true
+	running := corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	pod := createInitPod(running, running)
+	pod.Spec.InitContainers[0].RestartPolicy = &always
+	pod.Status.InitContainerStatuses[0].Started = &started
+
+	// The started sidecar counts as done; the second is still running
+	if status := initStatus(pod); status != "Init:1/2" {
+		t.Errorf("expected Init:1/2 with a started sidecar, got %q", status)
+	}
+}
+
+// createSidecarPod returns a running pod whose ready main container runs
+// next to a sidecar in the given state
+func createSidecarPod(sidecar corev1.ContainerState) *corev1.Pod {
+	pod := createTestPod("with-sidecar", "default", corev1.PodRunning, true)
+	pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: "sidecar"})
+	pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses,
+		corev1.ContainerStatus{Name: "sidecar", RestartCount: 7, State: sidecar})
+	return pod
+}
+
+func TestPodStatus_CrashLoopingSidecar(t *testing.T) {
+	client := &Client{}
+	crashing := corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}
+
+	info := client.podToInfo(createSidecarPod(crashing))
+	if info.Status != PodStatusRunning || info.StatusMessage != "CrashLoopBackOff" {
+		t.Fatalf("expected Running with CrashLoopBackOff, got %s/%q", info.Status, info.StatusMessage)
+	}
+	if !info.IsStuck() || info.DisplayStatus() != "CrashLoopBackOff" {
+		t.Errorf("the crash loop should be displayed, got %q", info.DisplayStatus())
+	}
+
+	// A container that is merely starting keeps the phase on display
+	creating := corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}}
+	info = client.podToInfo(createSidecarPod(creating))
+	if info.IsStuck() || info.DisplayStatus() != "Running" {
+		t.Errorf("a starting container should show Running, got %q", info.DisplayStatus())
+	}
+}
+
+func TestPodStatus_StuckReasonPreferred(t *testing.T) {
+	pod := createSidecarPod(corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}})
+	pod.Status.ContainerStatuses[0].Ready = false
+	pod.Status.ContainerStatuses[0].State = corev1.ContainerState{
+		Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"},
+	}
+
+	if reason := getNotReadyReason(pod); reason != "ImagePullBackOff" {
+		t.Errorf("a stuck container should win over a starting one, got %q", reason)
+	}
+}
+
+func TestPodStatus_CrashLoopingNativeSidecar(t *testing.T) {
+	always := corev1.ContainerRestartPolicyAlways
+	pod := createTestPod("native-sidecar", "default", corev1.PodRunning, true)
+	pod.Spec.InitContainers = []corev1.Container{{Name: "proxy", RestartPolicy: &always}}
+	pod.Status.InitContainerStatuses = []corev1.ContainerStatus{{
+		Name:  "proxy",
+		State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+	}}
+
+	info := (&Client{}).podToInfo(pod)
+	if info.DisplayStatus() != "CrashLoopBackOff" {
+		t.Errorf("a crash-looping native sidecar should be displayed, got %q", info.DisplayStatus())
+	}
+}
+
+func TestPodStatus_PendingImagePull(t *testing.T) {
+	pod := createTestPod("bad-image", "default", corev1.PodPending, false)
+	pod.Status.ContainerStatuses[0].State = corev1.ContainerState{
+		Waiting: &corev1.ContainerStateWaiting{Reason: "ErrImagePull"},
+	}
+
+	info := (&Client{}).podToInfo(pod)
+	if info.DisplayStatus() != "ErrImagePull" {
+		t.Errorf("a failing image pull should be displayed, got %q", info.DisplayStatus())
+	}
+}
+
 func TestPodInfo_ReadyCount(t *testing.T) {
 	now := time.Now()
 	pod := &corev1.Pod{
@@ -247,6 +487,112 @@ func TestPodInfo_ReadyCount(t *testing.T) {
 	}
 }
 
+// createAnnotatedPod returns a two-container pod whose default-container
+// annotation names defaultName
+func createAnnotatedPod(defaultName string) *corev1.Pod {
+	pod := createTestPod("annotated", "default", corev1.PodRunning, true)
+	pod.Annotations = map[string]string{DefaultContainerAnnotation: defaultName}
+	pod.Spec.Containers = append([]corev1.Container{{Name: "istio-proxy"}}, pod.Spec.Containers...)
+	pod.Status.ContainerStatuses = append([]corev1.ContainerStatus{{Name: "istio-proxy", Ready: true}},
+		pod.Status.ContainerStatuses...)
+	return pod
+}
+
+func TestPodInfo_Image(t *testing.T) {
+	client := &Client{}
+	pod := createTestPod("web", "default", corev1.PodRunning, true)
+	pod.Spec.Containers[0].Image = "nginx:1.25"
+
+	if info := client.podToInfo(pod); info.Image != "nginx:1.25" {
+		t.Errorf("expected the first container's spec image, got %q", info.Image)
+	}
+
+	pod.Spec.Containers = nil
+	if info := client.podToInfo(pod); info.Image != "" {
+		t.Errorf("expected no image without containers, got %q", info.Image)
+	}
+}
+
+func TestPodInfo_DefaultContainer(t *testing.T) {
+	client := &Client{}
+
+	info := client.podToInfo(createAnnotatedPod("main"))
+	if info.DefaultContainer != "main" || info.PrimaryContainer() != "main" {
+		t.Errorf("expected the annotated container main, got %q/%q", info.DefaultContainer, info.PrimaryContainer())
+	}
+
+	// A stale annotation falls back to the first container
+	info = client.podToInfo(createAnnotatedPod("removed"))
+	if info.DefaultContainer != "" || info.PrimaryContainer() != "istio-proxy" {
+		t.Errorf("expected fallback to istio-proxy, got %q/%q", info.DefaultContainer, info.PrimaryContainer())
+	}
+
+	info = client.podToInfo(createTestPod("plain", "default", corev1.PodRunning, true))
+	if info.PrimaryContainer() != "main" {
+		t.Errorf("expected the first container without the annotation, got %q", info.PrimaryContainer())
+	}
+
+	if (PodInfo{}).PrimaryContainer() != "" {
+		t.Error("a pod without containers has no primary container")
+	}
+}
+
+func TestClient_ListAllPods(t *testing.T) {
+	deployment := controllerRef("Deployment", "api")
+	rs := createTestReplicaSet("api-5c4b", &deployment)
+	rs.Namespace = "payments"
+	owned := createOwnedPod("api-5c4b-x1", controllerRef("ReplicaSet", "api-5c4b"), "")
+	owned.Namespace = "payments"
+
+	fakeClient := fake.NewClientset(
+		createTestPod("web", "default", corev1.PodRunning, true),
+		createTestPod("coredns", "kube-system", corev1.PodRunning, true),
+		createTestPod("alpha", "kube-system", corev1.PodRunning, true),
+		rs, owned,
+	)
+	client := &Client{clientset: fakeClient, currentNamespace: "default"}
+
+	pods, err := client.ListAllPods(context.Background(), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got []string
+	for _, pod := range pods {
+		got = append(got, pod.Namespace+"/"+pod.Name)
+	}
+	want := []string{"default/web", "kube-system/alpha", "kube-system/coredns", "payments/api-5c4b-x1"}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected pods sorted by namespace and name %v, got %v", want, got)
+	}
+	if owner := pods[3].Owner.String(); owner != "Deployment/api" {
+		t.Errorf("owners should resolve in every namespace, got %q", owner)
+	}
+}
+
+func TestClient_ListAllPods_Forbidden(t *testing.T) {
+	fakeClient := fake.NewClientset()
+	fakeClient.PrependReactor("list", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", errors.New("cluster-wide list denied"))
+	})
+	client := &Client{clientset: fakeClient, currentNamespace: "default"}
+
+	_, err := client.ListAllPods(context.Background(), "")
+	if !IsForbidden(err) {
+		t.Errorf("expected a Forbidden error to survive wrapping, got %v", err)
+	}
+}
+
+func TestPodInfo_Labels(t *testing.T) {
+	pod := createTestPod("web-1", "default", corev1.PodRunning, true)
+	pod.Labels = map[string]string{"app": "web"}
+
+	info := (&Client{}).podToInfo(pod)
+	if info.Labels["app"] != "web" {
+		t.Errorf("expected pod labels to be kept, got %v", info.Labels)
+	}
+}
+
 func TestPodInfo_Restarts(t *testing.T) {
 	now := time.Now()
 	pod := &corev1.Pod{
@@ -315,3 +661,285 @@ func TestParseContainerState(t *testing.T) {
 		})
 	}
 }
+
+func TestSumContainerResources(t *testing.T) {
+	pod := createTestPod("test", "default", corev1.PodRunning, true)
+	pod.Spec.Containers = []corev1.Container{
+		{
+			Name: "app",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("250m"),
+					corev1.ResourceMemory: resource.MustParse("128Mi"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("500m"),
+				},
+			},
+		},
+		{
+			// Partial spec: CPU request only
+			Name: "sidecar",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("100m"),
+				},
+			},
+		},
+		{
+			// No resources at all
+			Name: "helper",
+		},
+	}
+
+	client := &Client{}
+	info := client.podToInfo(pod)
+
+	tests := []struct {
+		name string
+		got  resource.Quantity
+		want string
+	}{
+		{"CPURequest", info.CPURequest, "350m"},
+		{"CPULimit", info.CPULimit, "500m"},
+		{"MemRequest", info.MemRequest, "128Mi"},
+		{"MemLimit", info.MemLimit, "-"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatQuantity(tt.got); got != tt.want {
+				t.Errorf("%s = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSumContainerResources_NoResources(t *testing.T) {
+	pod := createTestPod("test", "default", corev1.PodRunning, true)
+
+	r := sumContainerResources(pod)
+
+	if !r.cpuRequest.IsZero() || !r.cpuLimit.IsZero() || !r.memRequest.IsZero() || !r.memLimit.IsZero() {
+		t.Errorf("expected all zero quantities, got %+v", r)
+	}
+}
+
+func TestPodQOSClass(t *testing.T) {
+	list := func(cpu, mem string) corev1.ResourceList {
+		l := corev1.ResourceList{}
+		if cpu != "" {
+			l[corev1.ResourceCPU] = resource.MustParse(cpu)
+		}
+		if mem != "" {
+			l[corev1.ResourceMemory] = resource.MustParse(mem)
+		}
+		return l
+	}
+	container := func(requests, limits corev1.ResourceList) corev1.Container {
+		return corev1.Container{Name: "c", Resources: corev1.ResourceRequirements{Requests: requests, Limits: limits}}
+	}
+
+	tests := []struct {
+		name       string
+		containers []corev1.Container
+		want       corev1.PodQOSClass
+	}{
+		{"no resources", []corev1.Container{{Name: "c"}}, corev1.PodQOSBestEffort},
+		{"requests without limits", []corev1.Container{container(list("100m", "64Mi"), nil)}, corev1.PodQOSBurstable},
+		{"only cpu request", []corev1.Container{container(list("100m", ""), nil)}, corev1.PodQOSBurstable},
+		{"requests equal limits", []corev1.Container{container(list("1", "1Gi"), list("1", "1Gi"))}, corev1.PodQOSGuaranteed},
+		{"limits only", []corev1.Container{container(nil, list("1", "1Gi"))}, corev1.PodQOSGuaranteed},
+		{"requests below limits", []corev1.Container{container(list("500m", "1Gi"), list("1", "1Gi"))}, corev1.PodQOSBurstable},
+		{"cpu limit without memory limit", []corev1.Container{container(list("1", ""), list("1", ""))}, corev1.PodQOSBurstable},
+		{"one container unbounded", []corev1.Container{
+			container(list("1", "1Gi"), list("1", "1Gi")),
+			{Name: "sidecar"},
+		}, corev1.PodQOSBurstable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := createTestPod("test", "default", corev1.PodRunning, true)
+			pod.Spec.Containers = tt.containers
+			if got := podQOSClass(pod); got != tt.want {
+				t.Errorf("podQOSClass() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPodQOSClass_PrefersStatus(t *testing.T) {
+	pod := createTestPod("test", "default", corev1.PodRunning, true)
+	pod.Status.QOSClass = corev1.PodQOSGuaranteed
+
+	client := &Client{}
+	if info := client.podToInfo(pod); info.QoSClass != "Guaranteed" {
+		t.Errorf("expected the reported QoS class, got %q", info.QoSClass)
+	}
+}
+
+func TestFormatQuantity(t *testing.T) {
+	tests := []struct {
+		in   resource.Quantity
+		want string
+	}{
+		{resource.Quantity{}, "-"},
+		{resource.MustParse("1"), "1"},
+		{resource.MustParse("1500m"), "1500m"},
+		{resource.MustParse("512Mi"), "512Mi"},
+		{resource.MustParse("2Gi"), "2Gi"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatQuantity(tt.in); got != tt.want {
+			t.Errorf("FormatQuantity(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseContainerStatuses_ContainerID(t *testing.T) {
+	pod := createTestPod("test-pod", "default", corev1.PodRunning, true)
+	pod.Status.ContainerStatuses[0].ContainerID = "containerd://abc123"
+
+	containers, _, _ := parseContainerStatuses(pod)
+	if containers[0].ContainerID != "containerd://abc123" {
+		t.Errorf("expected ContainerID to be carried over, got %q", containers[0].ContainerID)
+	}
+}
+
+func TestParseContainerStatuses_ImageAndLastTermination(t *testing.T) {
+	pod := createTestPod("test-pod", "default", corev1.PodRunning, true)
+	pod.Status.ContainerStatuses[0].Image = "nginx:1.25"
+	pod.Status.ContainerStatuses[0].ImageID = "docker.io/library/nginx@sha256:4c0fdaa8b6341bfdeecb7fa8c6e9f0a1"
+	pod.Status.ContainerStatuses[0].LastTerminationState = corev1.ContainerState{
+		Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137},
+	}
+
+	containers, _, _ := parseContainerStatuses(pod)
+	if containers[0].Image != "nginx:1.25" {
+		t.Errorf("expected the image to be carried over, got %q", containers[0].Image)
+	}
+	if got := containers[0].Digest(); got != "sha256:4c0fdaa8b634" {
+		t.Errorf("Digest() = %q, want the short running digest", got)
+	}
+	if got := containers[0].LastTermination(); got != "OOMKilled (exit 137)" {
+		t.Errorf("LastTermination() = %q, want %q", got, "OOMKilled (exit 137)")
+	}
+
+	// A container that never restarted has no last termination
+	pod.Status.ContainerStatuses[0].LastTerminationState = corev1.ContainerState{}
+	containers, _, _ = parseContainerStatuses(pod)
+	if got := containers[0].LastTermination(); got != "" {
+		t.Errorf("expected no last termination, got %q", got)
+	}
+
+	// Before any status is reported the image comes from the spec
+	pod.Spec.Containers[0].Image = "nginx:1.26"
+	pod.Status.ContainerStatuses = nil
+	containers, _, _ = parseContainerStatuses(pod)
+	if containers[0].Image != "nginx:1.26" {
+		t.Errorf("expected the spec image, got %q", containers[0].Image)
+	}
+}
+
+func TestContainerStatus_Digest(t *testing.T) {
+	tests := []struct {
+		imageID string
+		want    string
+	}{
+		{"", ""},
+		{"docker-pullable://nginx@sha256:4c0fdaa8b6341bfdeecb7fa8", "sha256:4c0fdaa8b634"},
+		{"sha256:4c0fdaa8b6341bfdeecb7fa8", "sha256:4c0fdaa8b634"},
+		{"sha256:abc", "sha256:abc"},
+		{"nginx:1.25", ""},
+	}
+	for _, tt := range tests {
+		if got := (ContainerStatus{ImageID: tt.imageID}).Digest(); got != tt.want {
+			t.Errorf("Digest() for %q = %q, want %q", tt.imageID, got, tt.want)
+		}
+	}
+}
+
+func TestContainerStatus_IsCrashLooping(t *testing.T) {
+	tests := []struct {
+		name   string
+		status ContainerStatus
+		want   bool
+	}{
+		{"crash looping", ContainerStatus{State: "Waiting", StateReason: "CrashLoopBackOff", RestartCount: 4}, true},
+		{"running", ContainerStatus{Ready: true, State: "Running", RestartCount: 4}, false},
+		{"pulling", ContainerStatus{State: "Waiting", StateReason: "ContainerCreating"}, false},
+		{"terminated", ContainerStatus{State: "Terminated", StateReason: "Error"}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.status.IsCrashLooping(); got != tt.want {
+			t.Errorf("%s: IsCrashLooping() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPodInfo_Container(t *testing.T) {
+	pod := PodInfo{Containers: []ContainerStatus{{Name: "app"}, {Name: "sidecar", RestartCount: 2}}}
+
+	if c, ok := pod.Container("sidecar"); !ok || c.RestartCount != 2 {
+		t.Errorf("expected the sidecar status, got %+v (found %v)", c, ok)
+	}
+	if _, ok := pod.Container("missing"); ok {
+		t.Error("an unknown container should not be found")
+	}
+}
+
+func TestCompareContainer(t *testing.T) {
+	tests := []struct {
+		name       string
+		prev       ContainerStatus
+		curr       ContainerStatus
+		wantChange ContainerChange
+		wantDelta  int32
+	}{
+		{
+			name:       "unchanged",
+			prev:       ContainerStatus{ContainerID: "containerd://a", RestartCount: 2},
+			curr:       ContainerStatus{ContainerID: "containerd://a", RestartCount: 2},
+			wantChange: ContainerUnchanged,
+		},
+		{
+			name:       "restart gets a new ID and higher count",
+			prev:       ContainerStatus{ContainerID: "containerd://a", RestartCount: 2},
+			curr:       ContainerStatus{ContainerID: "containerd://b", RestartCount: 4},
+			wantChange: ContainerRestarted,
+			wantDelta:  2,
+		},
+		{
+			name:       "recreation resets the count",
+			prev:       ContainerStatus{ContainerID: "containerd://a", RestartCount: 5},
+			curr:       ContainerStatus{ContainerID: "containerd://b", RestartCount: 0},
+			wantChange: ContainerRecreated,
+		},
+		{
+			name:       "recreation with the same count",
+			prev:       ContainerStatus{ContainerID: "containerd://a", RestartCount: 0},
+			curr:       ContainerStatus{ContainerID: "containerd://b", RestartCount: 0},
+			wantChange: ContainerRecreated,
+		},
+		{
+			name:       "first start is not a recreation",
+			prev:       ContainerStatus{RestartCount: 0},
+			curr:       ContainerStatus{ContainerID: "containerd://a", RestartCount: 0},
+			wantChange: ContainerUnchanged,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			change, delta := CompareContainer(tt.prev, tt.curr)
+			if change != tt.wantChange {
+				t.Errorf("change = %v, want %v", change, tt.wantChange)
+			}
+			if delta != tt.wantDelta {
+				t.Errorf("delta = %d, want %d", delta, tt.wantDelta)
+			}
+		})
+	}
+}
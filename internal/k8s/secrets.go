@@ -0,0 +1,60 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SecretInfo contains a Secret's keys and values. The API returns values
+// base64-decoded, so Data holds the raw bytes.
+type SecretInfo struct {
+	Name      string
+	Namespace string
+	Type      string
+	Data      map[string][]byte
+	Age       time.Duration
+}
+
+// Keys returns the Secret's keys in sorted order
+func (s SecretInfo) Keys() []string {
+	return sortedKeys(s.Data)
+}
+
+// ListSecrets returns Secrets in the specified namespace (or current namespace if empty)
+func (c *Client) ListSecrets(ctx context.Context, namespace string) ([]SecretInfo, error) {
+	if namespace == "" {
+		namespace = c.currentNamespace
+	}
+
+	secrets, err := c.clientset.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets in namespace %q: %w", namespace, err)
+	}
+
+	result := make([]SecretInfo, 0, len(secrets.Items))
+	for i := range secrets.Items {
+		result = append(result, secretToInfo(&secrets.Items[i]))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+// secretToInfo converts a Secret to SecretInfo
+func secretToInfo(secret *corev1.Secret) SecretInfo {
+	return SecretInfo{
+		Name:      secret.Name,
+		Namespace: secret.Namespace,
+		Type:      string(secret.Type),
+		Data:      secret.Data,
+		Age:       time.Since(secret.CreationTimestamp.Time),
+	}
+}
@@ -0,0 +1,51 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestClient_ListSecrets(t *testing.T) {
+	fakeClient := fake.NewClientset(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "db-credentials", Namespace: "prod"},
+			Type:       corev1.SecretTypeOpaque,
+			Data:       map[string][]byte{"password": []byte("hunter2"), "username": []byte("app")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "tls", Namespace: "prod"},
+			Type:       corev1.SecretTypeTLS,
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"},
+		},
+	)
+	client := &Client{clientset: fakeClient, currentNamespace: "default"}
+
+	secrets, err := client.ListSecrets(context.Background(), "prod")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(secrets) != 2 {
+		t.Fatalf("expected 2 secrets in prod, got %d", len(secrets))
+	}
+	db := secrets[0]
+	if db.Name != "db-credentials" || db.Type != "Opaque" {
+		t.Errorf("expected db-credentials of type Opaque first, got %s (%s)", db.Name, db.Type)
+	}
+	if keys := db.Keys(); len(keys) != 2 || keys[0] != "password" {
+		t.Errorf("expected sorted keys, got %v", keys)
+	}
+	// Values arrive decoded
+	if string(db.Data["password"]) != "hunter2" {
+		t.Errorf("expected the decoded password, got %q", db.Data["password"])
+	}
+	if secrets[1].Type != "kubernetes.io/tls" {
+		t.Errorf("expected the TLS type, got %q", secrets[1].Type)
+	}
+}
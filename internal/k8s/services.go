@@ -0,0 +1,143 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Service types, as shown in the TYPE column
+const (
+	ServiceTypeClusterIP    = string(corev1.ServiceTypeClusterIP)
+	ServiceTypeNodePort     = string(corev1.ServiceTypeNodePort)
+	ServiceTypeLoadBalancer = string(corev1.ServiceTypeLoadBalancer)
+	ServiceTypeExternalName = string(corev1.ServiceTypeExternalName)
+)
+
+// ServicePort is one port a service exposes
+type ServicePort struct {
+	Name     string
+	Port     int32
+	NodePort int32 // Zero unless the service is a NodePort or LoadBalancer
+	Protocol string
+}
+
+// String formats the port like kubectl, e.g. "80/TCP" or "80:30080/TCP"
+func (p ServicePort) String() string {
+	if p.NodePort != 0 {
+		return fmt.Sprintf("%d:%d/%s", p.Port, p.NodePort, p.Protocol)
+	}
+	return fmt.Sprintf("%d/%s", p.Port, p.Protocol)
+}
+
+// ServiceInfo contains information about a Kubernetes service
+type ServiceInfo struct {
+	Name      string
+	Namespace string
+	Type      string // ClusterIP, NodePort, LoadBalancer or ExternalName
+	ClusterIP string
+	Ports     []ServicePort
+	Selector  map[string]string // Labels of the pods backing the service
+	Age       time.Duration
+}
+
+// PortList returns the ports joined by commas, or "<none>"
+func (s ServiceInfo) PortList() string {
+	if len(s.Ports) == 0 {
+		return "<none>"
+	}
+	ports := make([]string, 0, len(s.Ports))
+	for _, p := range s.Ports {
+		ports = append(ports, p.String())
+	}
+	return strings.Join(ports, ",")
+}
+
+// SelectorString returns the selector as sorted key=value pairs, or "<none>"
+func (s ServiceInfo) SelectorString() string {
+	if len(s.Selector) == 0 {
+		return "<none>"
+	}
+	pairs := make([]string, 0, len(s.Selector))
+	for key, value := range s.Selector {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// Selects reports whether a pod with the given labels backs the service.
+// A service without a selector selects no pods, as its endpoints are
+// managed by hand.
+func (s ServiceInfo) Selects(labels map[string]string) bool {
+	if len(s.Selector) == 0 {
+		return false
+	}
+	for key, value := range s.Selector {
+		if got, ok := labels[key]; !ok || got != value {
+			return false
+		}
+	}
+	return true
+}
+
+// ListServices returns services in the specified namespace (or current namespace if empty)
+func (c *Client) ListServices(ctx context.Context, namespace string) ([]ServiceInfo, error) {
+	if namespace == "" {
+		namespace = c.currentNamespace
+	}
+
+	services, err := c.clientset.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services in namespace %q: %w", namespace, err)
+	}
+
+	result := make([]ServiceInfo, 0, len(services.Items))
+	for i := range services.Items {
+		result = append(result, serviceToInfo(&services.Items[i]))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+// serviceToInfo converts a service to ServiceInfo
+func serviceToInfo(svc *corev1.Service) ServiceInfo {
+	ports := make([]ServicePort, 0, len(svc.Spec.Ports))
+	for _, p := range svc.Spec.Ports {
+		protocol := string(p.Protocol)
+		if protocol == "" {
+			protocol = string(corev1.ProtocolTCP)
+		}
+		ports = append(ports, ServicePort{
+			Name:     p.Name,
+			Port:     p.Port,
+			NodePort: p.NodePort,
+			Protocol: protocol,
+		})
+	}
+
+	// An unset type defaults to ClusterIP
+	serviceType := string(svc.Spec.Type)
+	if serviceType == "" {
+		serviceType = ServiceTypeClusterIP
+	}
+
+	return ServiceInfo{
+		Name:      svc.Name,
+		Namespace: svc.Namespace,
+		Type:      serviceType,
+		ClusterIP: svc.Spec.ClusterIP,
+		Ports:     ports,
+		Selector:  svc.Spec.Selector,
+		Age:       time.Since(svc.CreationTimestamp.Time),
+	}
+}
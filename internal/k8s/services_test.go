@@ -0,0 +1,115 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestClient_ListServices(t *testing.T) {
+	fakeClient := fake.NewClientset(
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec: corev1.ServiceSpec{
+				Type:      corev1.ServiceTypeNodePort,
+				ClusterIP: "10.0.0.10",
+				Selector:  map[string]string{"app": "web"},
+				Ports: []corev1.ServicePort{
+					{Name: "http", Port: 80, NodePort: 30080, Protocol: corev1.ProtocolTCP},
+					{Name: "dns", Port: 53, Protocol: corev1.ProtocolUDP},
+				},
+			},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+			Spec: corev1.ServiceSpec{
+				ClusterIP: "10.0.0.20",
+				Ports:     []corev1.ServicePort{{Port: 8080}},
+			},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "kube-system"},
+		},
+	)
+	client := &Client{clientset: fakeClient, currentNamespace: "default"}
+
+	services, err := client.ListServices(context.Background(), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(services) != 2 {
+		t.Fatalf("expected 2 services in default, got %d", len(services))
+	}
+	if services[0].Name != "api" || services[1].Name != "web" {
+		t.Errorf("expected services sorted by name, got %s, %s", services[0].Name, services[1].Name)
+	}
+
+	api := services[0]
+	if api.Type != ServiceTypeClusterIP {
+		t.Errorf("an unset type should default to ClusterIP, got %q", api.Type)
+	}
+	if api.PortList() != "8080/TCP" {
+		t.Errorf("an unset protocol should default to TCP, got %q", api.PortList())
+	}
+	if api.SelectorString() != "<none>" {
+		t.Errorf("expected no selector, got %q", api.SelectorString())
+	}
+
+	web := services[1]
+	if web.Type != ServiceTypeNodePort || web.ClusterIP != "10.0.0.10" {
+		t.Errorf("unexpected type or cluster IP: %+v", web)
+	}
+	if web.PortList() != "80:30080/TCP,53/UDP" {
+		t.Errorf("unexpected ports: %q", web.PortList())
+	}
+}
+
+func TestClient_ListServices_Error(t *testing.T) {
+	fakeClient := fake.NewClientset()
+	fakeClient.PrependReactor("list", "services", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("connection refused")
+	})
+	client := &Client{clientset: fakeClient, currentNamespace: "default"}
+
+	if _, err := client.ListServices(context.Background(), ""); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestServiceInfo_Selects(t *testing.T) {
+	svc := ServiceInfo{Selector: map[string]string{"app": "web", "tier": "frontend"}}
+
+	tests := []struct {
+		name   string
+		labels map[string]string
+		want   bool
+	}{
+		{"all labels match", map[string]string{"app": "web", "tier": "frontend"}, true},
+		{"extra labels", map[string]string{"app": "web", "tier": "frontend", "version": "v2"}, true},
+		{"value differs", map[string]string{"app": "web", "tier": "backend"}, false},
+		{"label missing", map[string]string{"app": "web"}, false},
+		{"no labels", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := svc.Selects(tt.labels); got != tt.want {
+				t.Errorf("Selects(%v) = %v, want %v", tt.labels, got, tt.want)
+			}
+		})
+	}
+
+	if svc.SelectorString() != "app=web,tier=frontend" {
+		t.Errorf("expected sorted selector, got %q", svc.SelectorString())
+	}
+	if (ServiceInfo{}).Selects(map[string]string{"app": "web"}) {
+		t.Error("a service without a selector should select no pods")
+	}
+}
@@ -0,0 +1,165 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+)
+
+// busyboxPath is where hardened images that ship busybox without applet
+// links usually keep it
+const busyboxPath = "/bin/busybox"
+
+// shellProbeScript prints the path of each tool found on PATH. It always
+// exits 0 so a missing tool isn't mistaken for a failed probe.
+const shellProbeScript = `for c in bash busybox ls cat head; do command -v "$c"; done; exit 0`
+
+// errNoFileUtils is wrapped by the error ProbeShell returns for a container
+// without a shell, file utilities or busybox
+var errNoFileUtils = errors.New("no shell or file utilities (ls, cat, head, busybox) found")
+
+// ShellInfo describes the shell and file utilities available in a
+// container, as found by ProbeShell
+type ShellInfo struct {
+	Shell     string // "bash" or "sh"; empty without a shell
+	Busybox   string // Path to busybox; empty when absent
+	Coreutils bool   // ls, cat and head are on PATH
+}
+
+// HasFileUtils reports whether files can be listed and read, either
+// directly or through busybox
+func (s ShellInfo) HasFileUtils() bool {
+	return s.Coreutils || s.Busybox != ""
+}
+
+// Command builds the command running util, through busybox when the
+// utility isn't on PATH
+func (s ShellInfo) Command(util string, args ...string) []string {
+	if !s.Coreutils && s.Busybox != "" {
+		return append([]string{s.Busybox, util}, args...)
+	}
+	return append([]string{util}, args...)
+}
+
+// ShellCommand builds the command running script in the container's shell
+// with args as its positional parameters, or nil without a shell
+func (s ShellInfo) ShellCommand(script string, args ...string) []string {
+	switch {
+	case s.Shell != "":
+		return append([]string{s.Shell, "-c", script, s.Shell}, args...)
+	case s.Busybox != "":
+		return append([]string{s.Busybox, "sh", "-c", script, "sh"}, args...)
+	default:
+		return nil
+	}
+}
+
+// shellCache remembers the probe result of each container so it runs once
+type shellCache struct {
+	mu     sync.Mutex
+	probed map[string]ShellInfo // Keyed by namespace/pod/container
+}
+
+func (sc *shellCache) get(key string) (ShellInfo, bool) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	info, ok := sc.probed[key]
+	return info, ok
+}
+
+func (sc *shellCache) set(key string, info ShellInfo) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.probed == nil {
+		sc.probed = make(map[string]ShellInfo)
+	}
+	sc.probed[key] = info
+}
+
+// ProbeShell finds the shell and file utilities of the container opts
+// targets. The result is cached per namespace/pod/container; a container
+// with no usable utilities yields a descriptive error.
+func (c *Client) ProbeShell(ctx context.Context, opts FileOptions) (ShellInfo, error) {
+	key := opts.Namespace + "/" + opts.Pod + "/" + opts.Container
+	info, ok := c.shells.get(key)
+	if !ok {
+		var err error
+		info, err = probeShell(func(command []string) ExecResult {
+			return c.Exec(ctx, ExecOptions{
+				Namespace: opts.Namespace,
+				Pod:       opts.Pod,
+				Container: opts.Container,
+				Command:   command,
+			})
+		})
+		if err != nil {
+			return ShellInfo{}, err
+		}
+		c.shells.set(key, info)
+	}
+
+	if !info.HasFileUtils() {
+		return info, noFileUtilsError(opts)
+	}
+	return info, nil
+}
+
+// probeShell asks a shell which tools are on PATH, falling back to running
+// ls and busybox directly when there is no shell. Errors other than a
+// missing executable are returned so that they aren't cached.
+func probeShell(run func(command []string) ExecResult) (ShellInfo, error) {
+	result := run([]string{"sh", "-c", shellProbeScript})
+	if result.Error == nil {
+		return parseShellProbe(result.Stdout), nil
+	}
+	if !IsExecNotFound(result.Error) {
+		return ShellInfo{}, fmt.Errorf("failed to probe container tools: %w", result.Error)
+	}
+
+	var info ShellInfo
+	result = run([]string{"ls", "-d", "/"})
+	if result.Error != nil && !IsExecNotFound(result.Error) {
+		return ShellInfo{}, fmt.Errorf("failed to probe container tools: %w", result.Error)
+	}
+	info.Coreutils = result.Error == nil
+
+	result = run([]string{busyboxPath, "true"})
+	if result.Error != nil && !IsExecNotFound(result.Error) {
+		return ShellInfo{}, fmt.Errorf("failed to probe container tools: %w", result.Error)
+	}
+	if result.Error == nil {
+		info.Busybox = busyboxPath
+	}
+	return info, nil
+}
+
+// parseShellProbe reads the paths printed by shellProbeScript
+func parseShellProbe(output string) ShellInfo {
+	found := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			found[path.Base(line)] = line
+		}
+	}
+
+	info := ShellInfo{Shell: "sh", Busybox: found["busybox"]}
+	if found["bash"] != "" {
+		info.Shell = "bash"
+	}
+	info.Coreutils = found["ls"] != "" && found["cat"] != "" && found["head"] != ""
+	return info
+}
+
+// noFileUtilsError explains why files can't be browsed in a container
+func noFileUtilsError(opts FileOptions) error {
+	container := opts.Container
+	if container == "" {
+		container = opts.Pod
+	}
+	return fmt.Errorf("%w in container %s; files can't be browsed in this image, try a debug container",
+		errNoFileUtils, container)
+}
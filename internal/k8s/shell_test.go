@@ -0,0 +1,142 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestShellInfo_Command(t *testing.T) {
+	tests := []struct {
+		name  string
+		shell ShellInfo
+		want  []string
+	}{
+		{"coreutils", ShellInfo{Shell: "sh", Coreutils: true}, []string{"ls", "-la", "/"}},
+		{"coreutils preferred over busybox", ShellInfo{Busybox: "/bin/busybox", Coreutils: true}, []string{"ls", "-la", "/"}},
+		{"busybox only", ShellInfo{Busybox: "/bin/busybox"}, []string{"/bin/busybox", "ls", "-la", "/"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.shell.Command("ls", "-la", "/"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Command() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShellInfo_ShellCommand(t *testing.T) {
+	tests := []struct {
+		name  string
+		shell ShellInfo
+		want  []string
+	}{
+		{"sh", ShellInfo{Shell: "sh"}, []string{"sh", "-c", "echo $1", "sh", "x"}},
+		{"bash", ShellInfo{Shell: "bash"}, []string{"bash", "-c", "echo $1", "bash", "x"}},
+		{"busybox", ShellInfo{Busybox: "/bin/busybox"}, []string{"/bin/busybox", "sh", "-c", "echo $1", "sh", "x"}},
+		{"no shell", ShellInfo{Coreutils: true}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.shell.ShellCommand("echo $1", "x"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ShellCommand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseShellProbe(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   ShellInfo
+	}{
+		{"debian", "/bin/bash\n/bin/ls\n/bin/cat\n/usr/bin/head\n", ShellInfo{Shell: "bash", Coreutils: true}},
+		{"alpine", "/bin/busybox\n/bin/ls\n/bin/cat\n/usr/bin/head\n", ShellInfo{Shell: "sh", Busybox: "/bin/busybox", Coreutils: true}},
+		{"busybox without links", "/bin/busybox\n", ShellInfo{Shell: "sh", Busybox: "/bin/busybox"}},
+		{"missing head", "/bin/ls\n/bin/cat\n", ShellInfo{Shell: "sh"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseShellProbe(tt.output); got != tt.want {
+				t.Errorf("parseShellProbe() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProbeShell(t *testing.T) {
+	notFound := func(command []string) ExecResult {
+		return ExecResult{Error: errors.New(`exec: "` + command[0] + `": executable file not found in $PATH`), ExitCode: 1}
+	}
+
+	tests := []struct {
+		name    string
+		found   map[string]ExecResult // Result by command name; others are not found
+		want    ShellInfo
+		wantErr bool
+	}{
+		{
+			name:  "shell answers",
+			found: map[string]ExecResult{"sh": {Stdout: "/bin/sh\n/bin/ls\n/bin/cat\n/bin/head\n"}},
+			want:  ShellInfo{Shell: "sh", Coreutils: true},
+		},
+		{
+			name:  "no shell but ls",
+			found: map[string]ExecResult{"ls": {Stdout: "/\n"}},
+			want:  ShellInfo{Coreutils: true},
+		},
+		{
+			name:  "no shell but busybox",
+			found: map[string]ExecResult{busyboxPath: {}},
+			want:  ShellInfo{Busybox: busyboxPath},
+		},
+		{
+			name: "distroless",
+			want: ShellInfo{},
+		},
+		{
+			name:    "exec fails",
+			found:   map[string]ExecResult{"sh": {Error: errors.New("container not running"), ExitCode: 1}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := probeShell(func(command []string) ExecResult {
+				if result, ok := tt.found[command[0]]; ok {
+					return result
+				}
+				return notFound(command)
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("probeShell() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("probeShell() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_ProbeShell_Cached(t *testing.T) {
+	// A client without a clientset would fail to exec, so these must be
+	// answered from the cache
+	c := &Client{}
+	opts := FileOptions{Namespace: "default", Pod: "web", Container: "app", Path: "/"}
+	c.shells.set("default/web/app", ShellInfo{Busybox: busyboxPath})
+
+	info, err := c.ProbeShell(context.Background(), opts)
+	if err != nil || info.Busybox != busyboxPath {
+		t.Errorf("expected the cached probe, got %+v, %v", info, err)
+	}
+
+	// An image without utilities is remembered too, with an explanation
+	c.shells.set("default/web/distroless", ShellInfo{})
+	opts.Container = "distroless"
+	if _, err := c.ListDir(context.Background(), opts); err == nil || !strings.Contains(err.Error(), "no shell or file utilities") {
+		t.Errorf("expected a descriptive error, got %v", err)
+	}
+}
@@ -0,0 +1,118 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// PodEventType describes a change to a watched pod
+type PodEventType string
+
+// Pod event types mirror the watch event types we act on.
+const (
+	PodEventAdded    PodEventType = "Added"
+	PodEventModified PodEventType = "Modified"
+	PodEventDeleted  PodEventType = "Deleted"
+)
+
+// PodEvent is a single change from a pod watch
+type PodEvent struct {
+	Type  PodEventType
+	Pod   PodInfo
+	Error error // Set when the watch reports an error; the channel closes after
+}
+
+// WatchPods watches pods in a namespace and emits an event per change.
+// Existing pods are reported as Added when the watch starts. The channel
+// is closed when ctx is cancelled or the server ends the watch. Only pods
+// matching a non-empty labelSelector are watched.
+func (c *Client) WatchPods(ctx context.Context, namespace, labelSelector string) (<-chan PodEvent, error) {
+	if namespace == "" {
+		namespace = c.currentNamespace
+	}
+
+	watcher, err := c.clientset.CoreV1().Pods(namespace).Watch(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch pods in namespace %q: %w", namespace, err)
+	}
+	return c.podEvents(ctx, watcher), nil
+}
+
+// WatchAllPods is WatchPods across all namespaces
+func (c *Client) WatchAllPods(ctx context.Context, labelSelector string) (<-chan PodEvent, error) {
+	watcher, err := c.clientset.CoreV1().Pods(metav1.NamespaceAll).Watch(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch pods in all namespaces: %w", err)
+	}
+	return c.podEvents(ctx, watcher), nil
+}
+
+// podEvents forwards a watch's events as PodEvents until ctx is cancelled
+// or the watch ends
+func (c *Client) podEvents(ctx context.Context, watcher watch.Interface) <-chan PodEvent {
+	events := make(chan PodEvent, 100)
+
+	go func() {
+		defer close(events)
+		defer watcher.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev, ok := <-watcher.ResultChan():
+				if !ok {
+					return
+				}
+
+				event, ok := c.toPodEvent(ev)
+				if !ok {
+					continue
+				}
+
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+
+				if event.Error != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	return events
+}
+
+// toPodEvent converts a watch event, skipping bookmarks and unknown objects
+func (c *Client) toPodEvent(ev watch.Event) (PodEvent, bool) {
+	if ev.Type == watch.Error {
+		return PodEvent{Error: fmt.Errorf("pod watch failed: %w", apierrors.FromObject(ev.Object))}, true
+	}
+
+	pod, ok := ev.Object.(*corev1.Pod)
+	if !ok {
+		return PodEvent{}, false
+	}
+
+	var eventType PodEventType
+	switch ev.Type {
+	case watch.Added:
+		eventType = PodEventAdded
+	case watch.Modified:
+		eventType = PodEventModified
+	case watch.Deleted:
+		eventType = PodEventDeleted
+	default:
+		return PodEvent{}, false
+	}
+
+	return PodEvent{Type: eventType, Pod: c.podToInfo(pod)}, true
+}
@@ -0,0 +1,151 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func nextPodEvent(t *testing.T, events <-chan PodEvent) PodEvent {
+	t.Helper()
+	select {
+	case ev, ok := <-events:
+		if !ok {
+			t.Fatal("event channel closed unexpectedly")
+		}
+		return ev
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for pod event")
+	}
+	return PodEvent{}
+}
+
+func TestClient_WatchPods(t *testing.T) {
+	fakeClient := fake.NewClientset()
+	client := &Client{clientset: fakeClient, currentNamespace: "default"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := client.WatchPods(ctx, "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pods := fakeClient.CoreV1().Pods("default")
+
+	pod := createTestPod("web-1", "default", corev1.PodPending, false)
+	if _, err := pods.Create(ctx, pod, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create pod: %v", err)
+	}
+	ev := nextPodEvent(t, events)
+	if ev.Type != PodEventAdded || ev.Pod.Name != "web-1" || ev.Pod.Status != PodStatusPending {
+		t.Errorf("expected Added pending web-1, got %+v", ev)
+	}
+
+	pod = createTestPod("web-1", "default", corev1.PodRunning, true)
+	if _, err := pods.Update(ctx, pod, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("failed to update pod: %v", err)
+	}
+	ev = nextPodEvent(t, events)
+	if ev.Type != PodEventModified || ev.Pod.Status != PodStatusRunning {
+		t.Errorf("expected Modified running web-1, got %+v", ev)
+	}
+
+	if err := pods.Delete(ctx, "web-1", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("failed to delete pod: %v", err)
+	}
+	ev = nextPodEvent(t, events)
+	if ev.Type != PodEventDeleted || ev.Pod.Name != "web-1" {
+		t.Errorf("expected Deleted web-1, got %+v", ev)
+	}
+
+	cancel()
+	select {
+	case _, ok := <-events:
+		if ok {
+			// Drain any in-flight event, then expect close
+			if _, ok := <-events; ok {
+				t.Error("channel should close after cancel")
+			}
+		}
+	case <-time.After(2 * time.Second):
+		t.Error("channel should close after cancel")
+	}
+}
+
+func TestClient_WatchPods_LabelSelector(t *testing.T) {
+	fakeClient := fake.NewClientset()
+	client := &Client{clientset: fakeClient, currentNamespace: "default"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if _, err := client.WatchPods(ctx, "", "app=web"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.WatchAllPods(ctx, "app=web"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, action := range fakeClient.Actions() {
+		watch, ok := action.(k8stesting.WatchAction)
+		if !ok {
+			t.Fatalf("unexpected action %v", action)
+		}
+		if selector := watch.GetWatchRestrictions().Labels.String(); selector != "app=web" {
+			t.Errorf("expected the selector passed to the watch, got %q", selector)
+		}
+	}
+}
+
+func TestClient_WatchAllPods(t *testing.T) {
+	fakeClient := fake.NewClientset()
+	client := &Client{clientset: fakeClient, currentNamespace: "default"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := client.WatchAllPods(ctx, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, namespace := range []string{"default", "kube-system"} {
+		pod := createTestPod("web-1", namespace, corev1.PodRunning, true)
+		if _, err := fakeClient.CoreV1().Pods(namespace).Create(ctx, pod, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("failed to create pod: %v", err)
+		}
+		ev := nextPodEvent(t, events)
+		if ev.Type != PodEventAdded || ev.Pod.Namespace != namespace {
+			t.Errorf("expected Added web-1 in %s, got %+v", namespace, ev)
+		}
+	}
+}
+
+func TestClient_ToPodEvent(t *testing.T) {
+	client := &Client{currentNamespace: "default"}
+	pod := createTestPod("web-1", "default", corev1.PodRunning, true)
+
+	if _, ok := client.toPodEvent(watch.Event{Type: watch.Bookmark, Object: pod}); ok {
+		t.Error("bookmark events should be skipped")
+	}
+
+	if _, ok := client.toPodEvent(watch.Event{Type: watch.Added, Object: &corev1.Node{}}); ok {
+		t.Error("non-pod objects should be skipped")
+	}
+
+	ev, ok := client.toPodEvent(watch.Event{
+		Type:   watch.Error,
+		Object: &metav1.Status{Message: "too old resource version", Code: 410},
+	})
+	if !ok || ev.Error == nil {
+		t.Error("error events should be reported")
+	}
+}
@@ -12,6 +12,19 @@ const (
 	ViewNamespaceSelector                  // Namespace selection overlay
 	ViewContextSelector                    // Context selection overlay
 	ViewHelp                               // Help overlay
+	ViewYAML                               // Pod manifest overlay
+	ViewPrompt                             // Text input overlay
+	ViewDeployments                        // Deployment list view
+	ViewEvents                             // Events overlay
+	ViewSearch                             // Cross-view search overlay
+	ViewConfigMaps                         // ConfigMap browser overlay
+	ViewSecrets                            // Secret browser overlay
+	ViewServices                           // Service list overlay
+	ViewContainerSelector                  // Container selection overlay
+	ViewAbout                              // Connection details overlay
+	ViewTop                                // Pod resource usage view
+	ViewConfirm                            // Yes/no confirmation overlay
+	ViewEnv                                // Container environment overlay
 )
 
 // String returns a human-readable name for the view state
@@ -31,6 +44,32 @@ func (v ViewState) String() string {
 		return "Context Selector"
 	case ViewHelp:
 		return "Help"
+	case ViewYAML:
+		return "YAML"
+	case ViewPrompt:
+		return "Prompt"
+	case ViewDeployments:
+		return "Deployments"
+	case ViewEvents:
+		return "Events"
+	case ViewSearch:
+		return "Search"
+	case ViewConfigMaps:
+		return "ConfigMaps"
+	case ViewSecrets:
+		return "Secrets"
+	case ViewServices:
+		return "Services"
+	case ViewContainerSelector:
+		return "Container Selector"
+	case ViewAbout:
+		return "About"
+	case ViewTop:
+		return "Top"
+	case ViewConfirm:
+		return "Confirm"
+	case ViewEnv:
+		return "Env"
 	default:
 		return "Unknown"
 	}
@@ -39,7 +78,8 @@ func (v ViewState) String() string {
 // IsOverlay returns true if this view is displayed as an overlay
 func (v ViewState) IsOverlay() bool {
 	switch v {
-	case ViewNamespaceSelector, ViewContextSelector, ViewHelp:
+	case ViewNamespaceSelector, ViewContextSelector, ViewHelp, ViewYAML, ViewPrompt, ViewEvents,
+		ViewSearch, ViewConfigMaps, ViewSecrets, ViewServices, ViewContainerSelector, ViewAbout, ViewConfirm, ViewEnv:
 		return true
 	default:
 		return false
@@ -14,6 +14,19 @@ func TestViewState_String(t *testing.T) {
 		{ViewNamespaceSelector, "Namespace Selector"},
 		{ViewContextSelector, "Context Selector"},
 		{ViewHelp, "Help"},
+		{ViewYAML, "YAML"},
+		{ViewPrompt, "Prompt"},
+		{ViewDeployments, "Deployments"},
+		{ViewEvents, "Events"},
+		{ViewSearch, "Search"},
+		{ViewConfigMaps, "ConfigMaps"},
+		{ViewSecrets, "Secrets"},
+		{ViewServices, "Services"},
+		{ViewContainerSelector, "Container Selector"},
+		{ViewAbout, "About"},
+		{ViewTop, "Top"},
+		{ViewConfirm, "Confirm"},
+		{ViewEnv, "Env"},
 		{ViewState(99), "Unknown"},
 	}
 
@@ -27,8 +40,8 @@ func TestViewState_String(t *testing.T) {
 }
 
 func TestViewState_IsOverlay(t *testing.T) {
-	overlays := []ViewState{ViewNamespaceSelector, ViewContextSelector, ViewHelp}
-	nonOverlays := []ViewState{ViewPodList, ViewLogs, ViewExec, ViewFiles}
+	overlays := []ViewState{ViewNamespaceSelector, ViewContextSelector, ViewHelp, ViewYAML, ViewPrompt, ViewEvents, ViewSearch, ViewConfigMaps, ViewSecrets, ViewServices, ViewContainerSelector, ViewAbout, ViewConfirm, ViewEnv}
+	nonOverlays := []ViewState{ViewPodList, ViewLogs, ViewExec, ViewFiles, ViewDeployments, ViewTop}
 
 	for _, v := range overlays {
 		t.Run(v.String()+"_is_overlay", func(t *testing.T) {
@@ -70,4 +83,43 @@ func TestViewState_Constants(t *testing.T) {
 	if ViewHelp != 6 {
 		t.Errorf("ViewHelp should be 6, got %d", ViewHelp)
 	}
+	if ViewYAML != 7 {
+		t.Errorf("ViewYAML should be 7, got %d", ViewYAML)
+	}
+	if ViewPrompt != 8 {
+		t.Errorf("ViewPrompt should be 8, got %d", ViewPrompt)
+	}
+	if ViewDeployments != 9 {
+		t.Errorf("ViewDeployments should be 9, got %d", ViewDeployments)
+	}
+	if ViewEvents != 10 {
+		t.Errorf("ViewEvents should be 10, got %d", ViewEvents)
+	}
+	if ViewSearch != 11 {
+		t.Errorf("ViewSearch should be 11, got %d", ViewSearch)
+	}
+	if ViewConfigMaps != 12 {
+		t.Errorf("ViewConfigMaps should be 12, got %d", ViewConfigMaps)
+	}
+	if ViewSecrets != 13 {
+		t.Errorf("ViewSecrets should be 13, got %d", ViewSecrets)
+	}
+	if ViewServices != 14 {
+		t.Errorf("ViewServices should be 14, got %d", ViewServices)
+	}
+	if ViewContainerSelector != 15 {
+		t.Errorf("ViewContainerSelector should be 15, got %d", ViewContainerSelector)
+	}
+	if ViewAbout != 16 {
+		t.Errorf("ViewAbout should be 16, got %d", ViewAbout)
+	}
+	if ViewTop != 17 {
+		t.Errorf("ViewTop should be 17, got %d", ViewTop)
+	}
+	if ViewConfirm != 18 {
+		t.Errorf("ViewConfirm should be 18, got %d", ViewConfirm)
+	}
+	if ViewEnv != 19 {
+		t.Errorf("ViewEnv should be 19, got %d", ViewEnv)
+	}
 }
@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ConfirmModel asks a yes/no question before an action runs. The caller
+// hands over the commands to run for each answer, so the dialog needs no
+// knowledge of what it guards.
+type ConfirmModel struct {
+	question string
+	onYes    tea.Cmd
+	onNo     tea.Cmd
+	open     bool
+
+	styles Styles
+}
+
+// NewConfirmModel creates a new, closed confirmation dialog
+func NewConfirmModel() ConfirmModel {
+	return ConfirmModel{styles: DefaultStyles()}
+}
+
+// SetStyles sets the styles used for rendering
+func (m *ConfirmModel) SetStyles(styles Styles) {
+	m.styles = styles
+}
+
+// Open shows the dialog. onYes runs when the question is confirmed and
+// onNo when it is declined; either may be nil. Cancelling runs neither.
+func (m *ConfirmModel) Open(question string, onYes, onNo tea.Cmd) {
+	m.question = question
+	m.onYes = onYes
+	m.onNo = onNo
+	m.open = true
+}
+
+// IsOpen reports whether the dialog is waiting for an answer
+func (m ConfirmModel) IsOpen() bool {
+	return m.open
+}
+
+// Question returns the question being asked
+func (m ConfirmModel) Question() string {
+	return m.question
+}
+
+// Update answers the dialog. Any answer closes it and returns the command
+// for that answer; other keys are ignored.
+func (m ConfirmModel) Update(msg tea.KeyMsg) (ConfirmModel, tea.Cmd) {
+	if !m.open {
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	switch {
+	case key.Matches(msg, confirmKeys.Yes):
+		cmd = m.onYes
+	case key.Matches(msg, confirmKeys.No):
+		cmd = m.onNo
+	case key.Matches(msg, confirmKeys.Cancel):
+	default:
+		return m, nil
+	}
+
+	m.open = false
+	m.onYes, m.onNo = nil, nil
+	return m, cmd
+}
+
+// View renders the dialog
+func (m ConfirmModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(m.styles.Header.Render(m.question))
+	b.WriteString("\n\n")
+	b.WriteString(m.styles.StatusBar.Render(formatBindings(confirmKeys.Yes, confirmKeys.No, confirmKeys.Cancel)))
+
+	return b.String()
+}
@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type confirmAnswer string
+
+func answer(a confirmAnswer) tea.Cmd {
+	return func() tea.Msg { return a }
+}
+
+func TestConfirmModel_Answers(t *testing.T) {
+	tests := []struct {
+		name string
+		key  tea.KeyMsg
+		want tea.Msg
+	}{
+		{"yes", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")}, confirmAnswer("yes")},
+		{"yes uppercase", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Y")}, confirmAnswer("yes")},
+		{"no", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")}, confirmAnswer("no")},
+		{"cancel", tea.KeyMsg{Type: tea.KeyEsc}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewConfirmModel()
+			m.Open("Restart web?", answer("yes"), answer("no"))
+
+			m, cmd := m.Update(tt.key)
+			if m.IsOpen() {
+				t.Error("answering should close the dialog")
+			}
+			var got tea.Msg
+			if cmd != nil {
+				got = cmd()
+			}
+			if got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestConfirmModel_IgnoresOtherKeys(t *testing.T) {
+	m := NewConfirmModel()
+	m.Open("Restart web?", answer("yes"), nil)
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil || !m.IsOpen() {
+		t.Error("enter shouldn't answer the question")
+	}
+
+	// A declined question without a command does nothing
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if cmd != nil || m.IsOpen() {
+		t.Error("expected the dialog closed without a command")
+	}
+
+	// A closed dialog doesn't answer again
+	if _, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")}); cmd != nil {
+		t.Error("a closed dialog shouldn't run anything")
+	}
+}
+
+func TestConfirmModel_View(t *testing.T) {
+	m := NewConfirmModel()
+	m.Open("Restart Deployment/web in default?", nil, nil)
+
+	view := m.View()
+	for _, want := range []string{"Restart Deployment/web in default?", "y: yes", "n: no", "esc: cancel"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in view:\n%s", want, view)
+		}
+	}
+	if m.Question() != "Restart Deployment/web in default?" {
+		t.Errorf("unexpected question %q", m.Question())
+	}
+}
@@ -0,0 +1,348 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/maxime/k8s-tui/internal/k8s"
+)
+
+// DataEntry is one key of a ConfigMap or Secret
+type DataEntry struct {
+	Key   string
+	Value []byte
+}
+
+// DataItem is a ConfigMap or Secret listed in the data browser
+type DataItem struct {
+	Name    string
+	Detail  string // Shown next to the name, e.g. the Secret type
+	Entries []DataEntry
+}
+
+// dataLevel is how far the data browser has drilled in
+type dataLevel int
+
+const (
+	dataLevelItems dataLevel = iota
+	dataLevelKeys
+	dataLevelValue
+)
+
+// maskedValue stands in for secret values until they are revealed
+const maskedValue = "********"
+
+// DataBrowserModel lists ConfigMaps or Secrets and drills into their keys
+// and values. Secret values stay masked until revealed.
+type DataBrowserModel struct {
+	kind     string // "ConfigMaps" or "Secrets"
+	secret   bool
+	revealed bool
+
+	items     []DataItem
+	itemIndex int
+	keyIndex  int
+	level     dataLevel
+
+	loading  bool
+	errorMsg string
+
+	// Long values scroll in a text view
+	value TextViewModel
+
+	// Dimensions
+	width  int
+	height int
+
+	styles Styles
+}
+
+// NewDataBrowserModel creates a new data browser model
+func NewDataBrowserModel() DataBrowserModel {
+	return DataBrowserModel{
+		value:  NewTextViewModel(),
+		styles: DefaultStyles(),
+	}
+}
+
+// SetStyles sets the styles used for rendering
+func (m *DataBrowserModel) SetStyles(styles Styles) {
+	m.styles = styles
+}
+
+// SetSize updates the browser and value viewport size
+func (m *DataBrowserModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.value.SetSize(width, height)
+}
+
+// Open resets the browser to load a new list. Secret values are masked.
+func (m *DataBrowserModel) Open(kind string, secret bool) {
+	m.kind = kind
+	m.secret = secret
+	m.revealed = false
+	m.items = nil
+	m.itemIndex = 0
+	m.keyIndex = 0
+	m.level = dataLevelItems
+	m.loading = true
+	m.errorMsg = ""
+}
+
+// Kind returns what is being browsed, e.g. "Secrets"
+func (m *DataBrowserModel) Kind() string {
+	return m.kind
+}
+
+// SetItems shows the loaded items
+func (m *DataBrowserModel) SetItems(items []DataItem) {
+	m.items = items
+	m.itemIndex = min(m.itemIndex, max(len(items)-1, 0))
+	m.loading = false
+	m.errorMsg = ""
+}
+
+// SetError shows a loading error
+func (m *DataBrowserModel) SetError(err string) {
+	m.loading = false
+	m.errorMsg = err
+}
+
+// SelectedItem returns the item under the cursor
+func (m *DataBrowserModel) SelectedItem() *DataItem {
+	if m.itemIndex < 0 || m.itemIndex >= len(m.items) {
+		return nil
+	}
+	return &m.items[m.itemIndex]
+}
+
+// SelectedEntry returns the key under the cursor of the opened item
+func (m *DataBrowserModel) SelectedEntry() *DataEntry {
+	item := m.SelectedItem()
+	if m.level == dataLevelItems || item == nil || m.keyIndex >= len(item.Entries) {
+		return nil
+	}
+	return &item.Entries[m.keyIndex]
+}
+
+// Revealed returns whether secret values are shown
+func (m *DataBrowserModel) Revealed() bool {
+	return m.revealed
+}
+
+// ToggleReveal shows or masks secret values
+func (m *DataBrowserModel) ToggleReveal() {
+	if !m.secret {
+		return
+	}
+	m.revealed = !m.revealed
+	if m.level == dataLevelValue {
+		m.showValue()
+	}
+}
+
+// Enter opens the selected item's keys, or the selected key's value
+func (m *DataBrowserModel) Enter() {
+	switch m.level {
+	case dataLevelItems:
+		if item := m.SelectedItem(); item != nil && len(item.Entries) > 0 {
+			m.level = dataLevelKeys
+			m.keyIndex = 0
+		}
+	case dataLevelKeys:
+		if m.SelectedEntry() != nil {
+			m.level = dataLevelValue
+			m.showValue()
+		}
+	}
+}
+
+// Back goes up one level, masking secrets again when leaving an item.
+// It returns false at the top level, where the caller closes the browser.
+func (m *DataBrowserModel) Back() bool {
+	switch m.level {
+	case dataLevelValue:
+		m.level = dataLevelKeys
+		return true
+	case dataLevelKeys:
+		m.level = dataLevelItems
+		m.revealed = false
+		return true
+	}
+	return false
+}
+
+// showValue loads the selected value into the text view
+func (m *DataBrowserModel) showValue() {
+	item, entry := m.SelectedItem(), m.SelectedEntry()
+	if entry == nil {
+		return
+	}
+	m.value.SetTitle(fmt.Sprintf("%s: %s / %s", strings.TrimSuffix(m.kind, "s"), item.Name, entry.Key))
+	m.value.SetContent(m.formatValue(entry.Value))
+	m.value.GotoTop()
+}
+
+// formatValue renders a value, masked for unrevealed secrets and as a
+// notice for binary data
+func (m *DataBrowserModel) formatValue(value []byte) string {
+	if m.secret && !m.revealed {
+		return maskedValue
+	}
+	if k8s.IsBinary(value) {
+		return fmt.Sprintf("[binary, %d bytes]", len(value))
+	}
+	return string(value)
+}
+
+// moveCursor moves the selection at the current level by delta
+func (m *DataBrowserModel) moveCursor(delta int) {
+	switch m.level {
+	case dataLevelItems:
+		m.itemIndex = max(min(m.itemIndex+delta, len(m.items)-1), 0)
+	case dataLevelKeys:
+		if item := m.SelectedItem(); item != nil {
+			m.keyIndex = max(min(m.keyIndex+delta, len(item.Entries)-1), 0)
+		}
+	}
+}
+
+// Update handles navigation keys; values scroll in their viewport
+func (m DataBrowserModel) Update(msg tea.Msg) (DataBrowserModel, tea.Cmd) {
+	if m.level == dataLevelValue {
+		var cmd tea.Cmd
+		m.value, cmd = m.value.Update(msg)
+		return m, cmd
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "j", "down":
+			m.moveCursor(1)
+		case "k", "up":
+			m.moveCursor(-1)
+		case "g", "home":
+			m.moveCursor(-math.MaxInt32)
+		case "G", "end":
+			m.moveCursor(math.MaxInt32)
+		}
+	}
+	return m, nil
+}
+
+// View renders the data browser
+func (m DataBrowserModel) View() string {
+	if m.level == dataLevelValue {
+		return m.value.View()
+	}
+
+	var b strings.Builder
+
+	header := m.kind
+	if m.level == dataLevelKeys {
+		header = fmt.Sprintf("%s: %s", strings.TrimSuffix(m.kind, "s"), m.SelectedItem().Name)
+	}
+	b.WriteString(m.styles.Header.Render(header))
+	b.WriteString("\n")
+	b.WriteString(strings.Repeat("-", min(m.width, 80)))
+	b.WriteString("\n")
+
+	switch {
+	case m.loading:
+		b.WriteString("Loading...")
+		return b.String()
+	case m.errorMsg != "":
+		b.WriteString(m.styles.Error.Render(fmt.Sprintf("Error: %s", m.errorMsg)))
+		b.WriteString("\n\nPress 'esc' to close")
+		return b.String()
+	case len(m.items) == 0:
+		b.WriteString(fmt.Sprintf("No %s in this namespace.", m.kind))
+		b.WriteString("\n\nPress 'esc' to close")
+		return b.String()
+	}
+
+	rows, selected := m.itemRows()
+	if m.level == dataLevelKeys {
+		rows, selected = m.keyRows()
+	}
+
+	// Scroll so the selection stays visible
+	available := max(m.height-6, 1)
+	start := max(selected-available+1, 0)
+	end := min(start+available, len(rows))
+	for i := start; i < end; i++ {
+		prefix := "  "
+		if i == selected {
+			prefix = m.styles.Selected.Render("> ")
+		}
+		b.WriteString(prefix + Truncate(rows[i], max(m.width-2, 1)))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.styles.StatusBar.Render(m.statusLine()))
+	return b.String()
+}
+
+// itemRows renders one row per item and returns the selected row
+func (m DataBrowserModel) itemRows() ([]string, int) {
+	rows := make([]string, 0, len(m.items))
+	for _, item := range m.items {
+		row := PadRight(item.Name, 40) + " " + pluralize(len(item.Entries), "key")
+		if item.Detail != "" {
+			row += "  " + item.Detail
+		}
+		rows = append(rows, row)
+	}
+	return rows, m.itemIndex
+}
+
+// keyRows renders one row per key of the opened item, with the first
+// line of its value inline
+func (m DataBrowserModel) keyRows() ([]string, int) {
+	item := m.SelectedItem()
+	rows := make([]string, 0, len(item.Entries))
+	for _, entry := range item.Entries {
+		value := m.formatValue(entry.Value)
+		if first, _, multiline := strings.Cut(value, "\n"); multiline {
+			value = first + " ..."
+		}
+		rows = append(rows, PadRight(entry.Key, 30)+" "+value)
+	}
+	return rows, m.keyIndex
+}
+
+// statusLine lists the keys available at the current level
+func (m DataBrowserModel) statusLine() string {
+	if m.level == dataLevelItems {
+		if m.styles.Verbose {
+			return fmt.Sprintf("%s, item %d of %d, press Enter to list its keys, Esc to close",
+				pluralize(len(m.items), strings.ToLower(strings.TrimSuffix(m.kind, "s"))), m.itemIndex+1, len(m.items))
+		}
+		return fmt.Sprintf("%d/%d | Enter: keys | Esc: close", m.itemIndex+1, len(m.items))
+	}
+
+	reveal := ""
+	if m.secret {
+		reveal = " | v: reveal"
+		if m.revealed {
+			reveal = " | v: mask"
+		}
+	}
+	if m.styles.Verbose {
+		state := ""
+		if m.secret {
+			state = ", values masked, press v to reveal"
+			if m.revealed {
+				state = ", values revealed, press v to mask"
+			}
+		}
+		return fmt.Sprintf("Key %d of %d%s, press Enter to view the value, Esc to go back",
+			m.keyIndex+1, len(m.SelectedItem().Entries), state)
+	}
+	return fmt.Sprintf("%d/%d | Enter: view value%s | Esc: back", m.keyIndex+1, len(m.SelectedItem().Entries), reveal)
+}
@@ -0,0 +1,198 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func testSecretItems() []DataItem {
+	return []DataItem{
+		{Name: "db-creds", Detail: "Opaque", Entries: []DataEntry{
+			{Key: "password", Value: []byte("hunter2")},
+			{Key: "username", Value: []byte("admin")},
+		}},
+		{Name: "tls", Detail: "kubernetes.io/tls", Entries: []DataEntry{
+			{Key: "tls.key", Value: []byte{0x00, 0x01, 0x02}},
+		}},
+	}
+}
+
+func newTestDataBrowser(secret bool, items []DataItem) DataBrowserModel {
+	m := NewDataBrowserModel()
+	m.SetSize(80, 24)
+	kind := "ConfigMaps"
+	if secret {
+		kind = "Secrets"
+	}
+	m.Open(kind, secret)
+	m.SetItems(items)
+	return m
+}
+
+func TestDataBrowserModel_LoadingErrorEmpty(t *testing.T) {
+	m := NewDataBrowserModel()
+	m.SetSize(80, 24)
+	m.Open("Secrets", true)
+
+	if !strings.Contains(m.View(), "Loading...") {
+		t.Error("view should show loading indicator after Open")
+	}
+
+	m.SetError("forbidden")
+	if !strings.Contains(m.View(), "Error: forbidden") {
+		t.Error("view should show the error")
+	}
+
+	m.SetItems(nil)
+	if !strings.Contains(m.View(), "No Secrets in this namespace.") {
+		t.Errorf("view should show the empty state, got %q", m.View())
+	}
+}
+
+func TestDataBrowserModel_ListsItems(t *testing.T) {
+	m := newTestDataBrowser(true, testSecretItems())
+
+	view := m.View()
+	for _, want := range []string{"Secrets", "db-creds", "2 keys", "Opaque", "tls", "1 key"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q", want)
+		}
+	}
+}
+
+func TestDataBrowserModel_MasksSecretsUntilRevealed(t *testing.T) {
+	m := newTestDataBrowser(true, testSecretItems())
+	m.Enter()
+
+	view := m.View()
+	if !strings.Contains(view, "Secret: db-creds") {
+		t.Error("keys view should name the opened secret")
+	}
+	if strings.Contains(view, "hunter2") {
+		t.Error("secret values should be masked by default")
+	}
+	if !strings.Contains(view, maskedValue) {
+		t.Error("masked values should show the mask")
+	}
+	if !strings.Contains(view, "v: reveal") {
+		t.Error("status line should offer the reveal key")
+	}
+
+	m.ToggleReveal()
+	if !m.Revealed() {
+		t.Fatal("ToggleReveal should reveal secret values")
+	}
+	if !strings.Contains(m.View(), "hunter2") {
+		t.Error("revealed secret values should be shown")
+	}
+
+	// Leaving the secret masks its values again
+	m.Back()
+	if m.Revealed() {
+		t.Error("going back to the list should mask values again")
+	}
+}
+
+func TestDataBrowserModel_ValueView(t *testing.T) {
+	m := newTestDataBrowser(true, testSecretItems())
+	m.Enter()
+	m.Enter()
+
+	view := m.View()
+	if !strings.Contains(view, "Secret: db-creds / password") {
+		t.Error("value view should show the secret and key")
+	}
+	if strings.Contains(view, "hunter2") {
+		t.Error("value view should mask the secret")
+	}
+
+	m.ToggleReveal()
+	if !strings.Contains(m.View(), "hunter2") {
+		t.Error("revealing in the value view should show the value")
+	}
+
+	if !m.Back() || m.SelectedEntry() == nil {
+		t.Error("Back from the value should return to the keys")
+	}
+	if !m.Back() || m.SelectedEntry() != nil {
+		t.Error("Back from the keys should return to the list")
+	}
+	if m.Back() {
+		t.Error("Back at the top level should return false")
+	}
+}
+
+func TestDataBrowserModel_ConfigMapValuesInline(t *testing.T) {
+	items := []DataItem{{Name: "app-config", Entries: []DataEntry{
+		{Key: "app.yaml", Value: []byte("port: 8080\nlevel: debug")},
+		{Key: "mode", Value: []byte("production")},
+	}}}
+	m := newTestDataBrowser(false, items)
+	m.Enter()
+
+	view := m.View()
+	if !strings.Contains(view, "production") {
+		t.Error("configmap values should be shown inline")
+	}
+	if !strings.Contains(view, "port: 8080 ...") || strings.Contains(view, "level: debug") {
+		t.Error("multi-line values should show only their first line inline")
+	}
+	if strings.Contains(view, "v: reveal") {
+		t.Error("configmaps should not offer the reveal key")
+	}
+
+	m.ToggleReveal()
+	if m.Revealed() {
+		t.Error("ToggleReveal should do nothing for configmaps")
+	}
+
+	m.Enter()
+	if !strings.Contains(m.View(), "level: debug") {
+		t.Error("value view should show the whole value")
+	}
+}
+
+func TestDataBrowserModel_BinaryValue(t *testing.T) {
+	m := newTestDataBrowser(true, testSecretItems())
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m.Enter()
+	m.ToggleReveal()
+
+	if !strings.Contains(m.View(), "[binary, 3 bytes]") {
+		t.Errorf("binary values should be summarized, got %q", m.View())
+	}
+}
+
+func TestDataBrowserModel_Navigation(t *testing.T) {
+	m := newTestDataBrowser(true, testSecretItems())
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if m.SelectedItem().Name != "tls" {
+		t.Errorf("down should select the next item, got %q", m.SelectedItem().Name)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if m.SelectedItem().Name != "tls" {
+		t.Error("down should stop at the last item")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	if m.SelectedItem().Name != "db-creds" {
+		t.Error("g should jump to the first item")
+	}
+
+	m.Enter()
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'G'}})
+	if m.SelectedEntry().Key != "username" {
+		t.Errorf("G should jump to the last key, got %q", m.SelectedEntry().Key)
+	}
+}
+
+func TestDataBrowserModel_EnterSkipsEmptyItems(t *testing.T) {
+	m := newTestDataBrowser(false, []DataItem{{Name: "empty"}})
+	m.Enter()
+
+	if m.SelectedEntry() != nil || !strings.Contains(m.View(), "0 keys") {
+		t.Error("Enter on an item without keys should stay on the list")
+	}
+}
@@ -0,0 +1,158 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/maxime/k8s-tui/internal/k8s"
+)
+
+// LooksSecret reports whether a variable should be masked: it was taken
+// from a Secret or its name suggests a credential
+func LooksSecret(v k8s.EnvVar) bool {
+	return v.Secret || k8s.SensitiveEnvName(v.Name)
+}
+
+// EnvViewModel shows a container's environment as a sorted NAME=value
+// list, either as the processes see it or as declared in the pod spec.
+// Secret-looking values stay masked until revealed.
+type EnvViewModel struct {
+	target   string // namespace/pod/container
+	declared bool
+	revealed bool
+	note     string // Why the declared environment is shown instead
+	vars     []k8s.EnvVar
+
+	text TextViewModel
+}
+
+// NewEnvViewModel creates a new environment view
+func NewEnvViewModel() EnvViewModel {
+	return EnvViewModel{text: NewTextViewModel()}
+}
+
+// SetSize updates the viewport size
+func (m *EnvViewModel) SetSize(width, height int) {
+	m.text.SetSize(width, height)
+}
+
+// Open resets the view to load the environment of target, masked
+func (m *EnvViewModel) Open(target string, declared bool) {
+	m.target = target
+	m.declared = declared
+	m.revealed = false
+	m.note = ""
+	m.vars = nil
+	m.setTitle()
+	m.text.SetLoading()
+}
+
+// Declared reports whether the declared environment is shown or wanted
+func (m *EnvViewModel) Declared() bool {
+	return m.declared
+}
+
+// SetDeclared switches between the runtime and the declared environment
+// while the new one loads
+func (m *EnvViewModel) SetDeclared(declared bool) {
+	m.declared = declared
+	m.note = ""
+	m.vars = nil
+	m.setTitle()
+	m.text.SetLoading()
+}
+
+// SetVars shows the loaded variables. A note explains a fallback to the
+// declared environment and is shown above them.
+func (m *EnvViewModel) SetVars(vars []k8s.EnvVar, declared bool, note string) {
+	m.vars = vars
+	m.declared = declared
+	m.note = note
+	m.setTitle()
+	m.render()
+}
+
+// SetError shows a loading error
+func (m *EnvViewModel) SetError(err string) {
+	m.text.SetError(err)
+}
+
+// Revealed reports whether secret-looking values are shown
+func (m *EnvViewModel) Revealed() bool {
+	return m.revealed
+}
+
+// ToggleReveal shows or masks secret-looking values, keeping the scroll
+// position
+func (m *EnvViewModel) ToggleReveal() {
+	m.revealed = !m.revealed
+	m.setTitle()
+	if m.vars != nil {
+		offset := m.text.viewport.YOffset
+		m.render()
+		m.text.viewport.SetYOffset(offset)
+	}
+}
+
+// Content returns the rendered variables
+func (m *EnvViewModel) Content() string {
+	return m.text.Content()
+}
+
+// setTitle names the target, the environment shown and the view's keys
+func (m *EnvViewModel) setTitle() {
+	kind, other := "runtime", "declared"
+	if m.declared {
+		kind, other = other, kind
+	}
+	reveal := "reveal"
+	if m.revealed {
+		reveal = "mask"
+	}
+	m.text.SetTitle(fmt.Sprintf("Env: %s (%s) | d: %s | v: %s", m.target, kind, other, reveal))
+}
+
+// render writes one NAME=value line per variable. Declared references
+// show their source instead of a value.
+func (m *EnvViewModel) render() {
+	var b strings.Builder
+	if m.note != "" {
+		b.WriteString(m.note)
+		b.WriteString("\n\n")
+	}
+	if len(m.vars) == 0 {
+		b.WriteString("No environment variables")
+	}
+	for i, v := range m.vars {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(m.formatVar(v))
+	}
+	m.text.SetContent(b.String())
+}
+
+// formatVar renders one variable, masked unless revealed
+func (m *EnvViewModel) formatVar(v k8s.EnvVar) string {
+	if v.Source != "" && v.Value == "" {
+		return fmt.Sprintf("%s <- %s", v.Name, v.Source)
+	}
+	if LooksSecret(v) && !m.revealed {
+		return v.Name + "=" + maskedValue
+	}
+	return v.Name + "=" + v.Value
+}
+
+// Update handles scrolling
+func (m EnvViewModel) Update(msg tea.Msg) (EnvViewModel, tea.Cmd) {
+	var cmd tea.Cmd
+	m.text, cmd = m.text.Update(msg)
+	return m, cmd
+}
+
+// View renders the environment
+func (m EnvViewModel) View() string {
+	return m.text.View()
+}
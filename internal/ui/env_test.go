@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/maxime/k8s-tui/internal/k8s"
+)
+
+func TestLooksSecret(t *testing.T) {
+	tests := []struct {
+		v    k8s.EnvVar
+		want bool
+	}{
+		{k8s.EnvVar{Name: "DB_PASSWORD"}, true},
+		{k8s.EnvVar{Name: "api_token"}, true},
+		{k8s.EnvVar{Name: "AWS_SECRET_ACCESS_KEY"}, true},
+		{k8s.EnvVar{Name: "DSN", Secret: true}, true},
+		{k8s.EnvVar{Name: "LOG_LEVEL"}, false},
+		{k8s.EnvVar{Name: "PATH"}, false},
+	}
+
+	for _, tt := range tests {
+		if got := LooksSecret(tt.v); got != tt.want {
+			t.Errorf("LooksSecret(%+v) = %v, want %v", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestEnvViewModel_Masking(t *testing.T) {
+	m := NewEnvViewModel()
+	m.SetSize(80, 24)
+	m.Open("default/web/app", false)
+	if !strings.Contains(m.View(), "Loading...") || !strings.Contains(m.View(), "Env: default/web/app (runtime)") {
+		t.Errorf("expected a loading runtime view, got:\n%s", m.View())
+	}
+
+	m.SetVars([]k8s.EnvVar{
+		{Name: "DB_PASSWORD", Value: "hunter2"},
+		{Name: "LOG_LEVEL", Value: "info"},
+	}, false, "")
+
+	content := m.Content()
+	if !strings.Contains(content, "DB_PASSWORD=********") || strings.Contains(content, "hunter2") {
+		t.Errorf("expected the password masked, got:\n%s", content)
+	}
+	if !strings.Contains(content, "LOG_LEVEL=info") {
+		t.Errorf("expected plain values shown, got:\n%s", content)
+	}
+
+	m.ToggleReveal()
+	if !m.Revealed() || !strings.Contains(m.Content(), "DB_PASSWORD=hunter2") {
+		t.Errorf("expected the password revealed, got:\n%s", m.Content())
+	}
+	if !strings.Contains(m.View(), "v: mask") {
+		t.Errorf("expected the title to offer masking, got:\n%s", m.View())
+	}
+
+	// Opening another container masks again
+	m.Open("default/web/sidecar", false)
+	if m.Revealed() {
+		t.Error("opening should mask values again")
+	}
+}
+
+func TestEnvViewModel_Declared(t *testing.T) {
+	m := NewEnvViewModel()
+	m.SetSize(80, 24)
+	m.Open("default/web/app", false)
+
+	m.SetVars([]k8s.EnvVar{
+		{Name: "DB_PASSWORD", Source: "secret db/password", Secret: true},
+		{Name: "MODE", Value: "prod"},
+	}, true, "No env command in the container; showing the declared environment")
+
+	if !m.Declared() || !strings.Contains(m.View(), "(declared) | d: runtime") {
+		t.Errorf("expected the declared environment, got:\n%s", m.View())
+	}
+	content := m.Content()
+	for _, want := range []string{"No env command in the container", "DB_PASSWORD <- secret db/password", "MODE=prod"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected %q in:\n%s", want, content)
+		}
+	}
+
+	m.SetDeclared(false)
+	if m.Declared() || !strings.Contains(m.View(), "Loading...") {
+		t.Errorf("expected the runtime environment loading, got:\n%s", m.View())
+	}
+
+	m.SetVars(nil, false, "")
+	if !strings.Contains(m.Content(), "No environment variables") {
+		t.Errorf("expected an empty notice, got:\n%s", m.Content())
+	}
+}
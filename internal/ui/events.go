@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/maxime/k8s-tui/internal/k8s"
+)
+
+// eventTimeFormat shows when an event was last seen
+const eventTimeFormat = "Jan 02 15:04:05"
+
+// FormatEvents renders events as a table for the events view, with
+// Warning events highlighted. showObject adds the involved object column,
+// for events from a whole namespace.
+func FormatEvents(events []k8s.EventInfo, showObject bool, styles Styles) string {
+	if len(events) == 0 {
+		return "No events found."
+	}
+
+	var b strings.Builder
+
+	header := fmt.Sprintf("%-15s  %-7s  %-20s  ", "LAST SEEN", "TYPE", "REASON")
+	if showObject {
+		header += fmt.Sprintf("%-30s  ", "OBJECT")
+	}
+	header += fmt.Sprintf("%-5s  %s", "COUNT", "MESSAGE")
+	b.WriteString(styles.Header.Render(header))
+
+	for _, ev := range events {
+		row := fmt.Sprintf("%-15s  %-7s  %s  ", ev.LastSeen.Format(eventTimeFormat), ev.Type, PadRight(ev.Reason, 20))
+		if showObject {
+			row += PadRight(ev.Object, 30) + "  "
+		}
+		row += fmt.Sprintf("%-5s  %s", fmt.Sprintf("x%d", ev.Count), ev.Message)
+
+		b.WriteString("\n")
+		if ev.IsWarning() {
+			b.WriteString(styles.EventWarning.Render(row))
+		} else {
+			b.WriteString(row)
+		}
+	}
+
+	return b.String()
+}
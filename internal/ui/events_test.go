@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/maxime/k8s-tui/internal/k8s"
+)
+
+func testEvents() []k8s.EventInfo {
+	seen := time.Date(2026, 3, 4, 10, 20, 30, 0, time.UTC)
+	return []k8s.EventInfo{
+		{Type: "Normal", Reason: "Pulled", Message: "image pulled", Count: 1, LastSeen: seen, Object: "Pod/web-1"},
+		{Type: "Warning", Reason: "BackOff", Message: "back-off restarting", Count: 7, LastSeen: seen, Object: "Pod/web-1"},
+	}
+}
+
+func TestFormatEvents(t *testing.T) {
+	out := FormatEvents(testEvents(), false, PlainStyles())
+
+	lines := strings.Split(out, "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected a header and 2 rows, got %d lines:\n%s", len(lines), out)
+	}
+	if !strings.Contains(lines[2], "Mar 04 10:20:30") || !strings.Contains(lines[2], "BackOff") ||
+		!strings.Contains(lines[2], "x7") || !strings.Contains(lines[2], "back-off restarting") {
+		t.Errorf("unexpected event row %q", lines[2])
+	}
+	if strings.Contains(out, "OBJECT") {
+		t.Error("object column should be hidden for a single pod")
+	}
+}
+
+func TestFormatEvents_ShowObject(t *testing.T) {
+	out := FormatEvents(testEvents(), true, PlainStyles())
+
+	if !strings.Contains(out, "OBJECT") || !strings.Contains(out, "Pod/web-1") {
+		t.Errorf("expected the object column, got:\n%s", out)
+	}
+}
+
+func TestFormatEvents_Empty(t *testing.T) {
+	if out := FormatEvents(nil, false, PlainStyles()); out != "No events found." {
+		t.Errorf("unexpected empty output %q", out)
+	}
+}
+
+func TestFormatEvents_HighlightsWarnings(t *testing.T) {
+	prev := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	t.Cleanup(func() { lipgloss.SetColorProfile(prev) })
+
+	styles := PlainStyles()
+	styles.EventWarning = lipgloss.NewStyle().Foreground(colorYellow)
+
+	lines := strings.Split(FormatEvents(testEvents(), false, styles), "\n")
+	if strings.Contains(lines[1], "\x1b[") {
+		t.Errorf("Normal events should not be styled, got %q", lines[1])
+	}
+	if !strings.Contains(lines[2], "\x1b[") {
+		t.Errorf("Warning events should be highlighted, got %q", lines[2])
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/maxime/k8s-tui/internal/config"
 )
 
 // ExecViewState represents the state of the exec view
@@ -52,12 +54,22 @@ type ExecViewModel struct {
 	history      []string
 	historyIndex int
 
+	// Command presets
+	presets     []config.ExecPreset
+	showPresets bool
+	presetIndex int
+
 	// State
 	state     ExecViewState
 	pod       string
 	container string
 	namespace string
 	errorMsg  string
+	exitCode  int
+	exited    bool // exitCode holds the last command's status
+
+	// Styling
+	styles Styles
 
 	// Dimensions
 	width  int
@@ -79,9 +91,15 @@ func NewExecViewModel() ExecViewModel {
 		history:      make([]string, 0),
 		historyIndex: -1,
 		state:        ExecViewStateIdle,
+		styles:       DefaultStyles(),
 	}
 }
 
+// SetStyles sets the styles used for rendering
+func (m *ExecViewModel) SetStyles(styles Styles) {
+	m.styles = styles
+}
+
 // SetSize updates the viewport size
 func (m *ExecViewModel) SetSize(width, height int) {
 	m.width = width
@@ -114,9 +132,27 @@ func (m *ExecViewModel) SetPodInfo(namespace, pod, container string) {
 	m.container = container
 }
 
+// Container returns the container commands run in
+func (m *ExecViewModel) Container() string {
+	return m.container
+}
+
 // SetState sets the current execution state
 func (m *ExecViewModel) SetState(state ExecViewState) {
 	m.state = state
+	m.exited = false
+}
+
+// SetExitCode marks the command complete with its exit status
+func (m *ExecViewModel) SetExitCode(code int) {
+	m.state = ExecViewStateComplete
+	m.exitCode = code
+	m.exited = true
+}
+
+// ExitCode returns the last command's exit status, if it ran to completion
+func (m *ExecViewModel) ExitCode() (int, bool) {
+	return m.exitCode, m.exited
 }
 
 // SetError sets an error message
@@ -236,12 +272,64 @@ func (m *ExecViewModel) AddCommandMarker(cmd string) {
 	m.updateViewportContent()
 }
 
-// Clear clears all output
+// Output returns the output lines, including command markers
+func (m *ExecViewModel) Output() []string {
+	return m.outputLines
+}
+
+// Clear clears all output. The command history is kept.
 func (m *ExecViewModel) Clear() {
 	m.outputLines = make([]string, 0)
 	m.updateViewportContent()
 }
 
+// SetPresets sets the preset commands offered in the presets menu
+func (m *ExecViewModel) SetPresets(presets []config.ExecPreset) {
+	m.presets = presets
+	m.presetIndex = 0
+}
+
+// Presets returns the configured preset commands
+func (m *ExecViewModel) Presets() []config.ExecPreset {
+	return m.presets
+}
+
+// TogglePresets shows or hides the presets menu
+func (m *ExecViewModel) TogglePresets() {
+	if len(m.presets) == 0 {
+		m.showPresets = false
+		return
+	}
+	m.showPresets = !m.showPresets
+	m.presetIndex = 0
+}
+
+// ClosePresets hides the presets menu
+func (m *ExecViewModel) ClosePresets() {
+	m.showPresets = false
+}
+
+// ShowingPresets returns whether the presets menu is displayed
+func (m *ExecViewModel) ShowingPresets() bool {
+	return m.showPresets
+}
+
+// SelectedPreset returns the highlighted preset in the menu
+func (m *ExecViewModel) SelectedPreset() (config.ExecPreset, bool) {
+	if m.presetIndex < 0 || m.presetIndex >= len(m.presets) {
+		return config.ExecPreset{}, false
+	}
+	return m.presets[m.presetIndex], true
+}
+
+// PresetByNumber returns the preset shown with the given 1-based number
+func (m *ExecViewModel) PresetByNumber(n int) (config.ExecPreset, bool) {
+	if n < 1 || n > len(m.presets) {
+		return config.ExecPreset{}, false
+	}
+	return m.presets[n-1], true
+}
+
 // Focus sets focus on the input field
 func (m *ExecViewModel) Focus() {
 	m.input.Focus()
@@ -279,6 +367,21 @@ func (m ExecViewModel) Update(msg tea.Msg) (ExecViewModel, tea.Cmd) {
 			return m, nil
 		}
 
+		// Presets menu navigation
+		if m.showPresets {
+			switch msg.String() {
+			case "up", "k":
+				if m.presetIndex > 0 {
+					m.presetIndex--
+				}
+			case "down", "j":
+				if m.presetIndex < len(m.presets)-1 {
+					m.presetIndex++
+				}
+			}
+			return m, nil
+		}
+
 		switch msg.String() {
 		case "up":
 			if m.input.Focused() {
@@ -345,13 +448,17 @@ func (m ExecViewModel) View() string {
 	if m.namespace != "" {
 		header = fmt.Sprintf("Exec: %s/%s/%s", m.namespace, m.pod, m.container)
 	}
-	b.WriteString(header)
+	b.WriteString(m.styles.Header.Render(header))
 	b.WriteString("\n")
 	b.WriteString(strings.Repeat("-", min(len(header)+10, m.width)))
 	b.WriteString("\n")
 
-	// Output viewport
-	b.WriteString(m.viewport.View())
+	// Output viewport (replaced by the presets menu when open)
+	if m.showPresets {
+		b.WriteString(m.viewPresets())
+	} else {
+		b.WriteString(m.viewport.View())
+	}
 	b.WriteString("\n")
 
 	// Input prompt
@@ -365,19 +472,55 @@ func (m ExecViewModel) View() string {
 
 	// Status bar
 	statusLine := m.buildStatusLine()
-	b.WriteString(statusLine)
+	if m.state == ExecViewStateError || (m.exited && m.exitCode != 0) {
+		b.WriteString(m.styles.Error.Render(statusLine))
+	} else {
+		b.WriteString(m.styles.StatusBar.Render(statusLine))
+	}
 
 	return b.String()
 }
 
+// viewPresets renders the numbered presets menu
+func (m ExecViewModel) viewPresets() string {
+	var b strings.Builder
+
+	b.WriteString("Presets (enter/1-9: run, esc: close)\n")
+	for i, p := range m.presets {
+		prefix := "  "
+		if i == m.presetIndex {
+			prefix = "> "
+		}
+		number := " "
+		if i < 9 {
+			number = fmt.Sprintf("%d", i+1)
+		}
+		b.WriteString(fmt.Sprintf("%s%s. %s %s\n", prefix, number, PadRight(p.Name, 20), p.Command))
+	}
+
+	// Pad to the viewport height so the layout doesn't jump
+	for i := len(m.presets) + 1; i < m.viewport.Height; i++ {
+		b.WriteString("\n")
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
 // buildStatusLine creates the status line at the bottom
 func (m ExecViewModel) buildStatusLine() string {
+	if m.styles.Verbose {
+		return m.verboseStatusLine()
+	}
+
 	var stateIndicator string
 	switch m.state {
 	case ExecViewStateRunning:
-		stateIndicator = "[RUNNING]"
+		stateIndicator = "[RUNNING] " + formatBindings(execKeys.Interrupt)
 	case ExecViewStateComplete:
 		stateIndicator = "[COMPLETE]"
+		if m.exited {
+			stateIndicator = fmt.Sprintf("[EXIT: %d]", m.exitCode)
+		}
 	case ExecViewStateError:
 		if m.errorMsg != "" {
 			stateIndicator = fmt.Sprintf("[ERROR: %s]", m.errorMsg)
@@ -395,11 +538,42 @@ func (m ExecViewModel) buildStatusLine() string {
 	}
 
 	// Focus info
-	focusInfo := " | Tab: switch focus"
+	focusInfo := " | " + formatBindings(execKeys.Focus)
+	if len(m.presets) > 0 {
+		focusInfo += " | " + formatBindings(execKeys.Presets)
+	}
 
 	return fmt.Sprintf("%s%s%s", stateIndicator, historyInfo, focusInfo)
 }
 
+// verboseStatusLine describes the exec state in words for screen readers
+func (m ExecViewModel) verboseStatusLine() string {
+	var state string
+	switch m.state {
+	case ExecViewStateRunning:
+		state = "Command running, press Ctrl+C to interrupt it"
+	case ExecViewStateComplete:
+		state = "Command complete"
+		if m.exited {
+			state = fmt.Sprintf("Command exited with code %d", m.exitCode)
+		}
+	case ExecViewStateError:
+		state = "Command failed"
+		if m.errorMsg != "" {
+			state = fmt.Sprintf("Command failed: %s", m.errorMsg)
+		}
+	default:
+		state = "Ready for a command"
+	}
+
+	status := fmt.Sprintf("%s, %s in history, press Tab to switch focus",
+		state, pluralize(len(m.history), "command"))
+	if len(m.presets) > 0 {
+		status += ", Ctrl+P for presets"
+	}
+	return status
+}
+
 // ScrollUp scrolls the output viewport up
 func (m *ExecViewModel) ScrollUp(lines int) {
 	m.viewport.ScrollUp(lines)
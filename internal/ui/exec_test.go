@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/maxime/k8s-tui/internal/config"
 )
 
 func TestNewExecViewModel(t *testing.T) {
@@ -42,6 +44,16 @@ func TestExecViewModel_SetSize(t *testing.T) {
 	}
 }
 
+func TestExecViewModel_AddOutputBeforeSetSize(t *testing.T) {
+	m := NewExecViewModel()
+	m.AddOutput("early-output", false)
+	m.SetSize(80, 24)
+
+	if !strings.Contains(m.View(), "early-output") {
+		t.Errorf("output added before SetSize should be shown, got:\n%s", m.View())
+	}
+}
+
 func TestExecViewModel_SetPodInfo(t *testing.T) {
 	m := NewExecViewModel()
 	m.SetPodInfo("default", "my-pod", "main")
@@ -218,13 +230,20 @@ func TestExecViewModel_Clear(t *testing.T) {
 	m := NewExecViewModel()
 	m.SetSize(80, 24)
 
+	m.AddToHistory("ls")
 	m.AddOutput("line 1\n", false)
 	m.AddOutput("line 2\n", false)
+	if got := m.Output(); len(got) != 2 || got[0] != "line 1" {
+		t.Errorf("Output() = %q, want both lines", got)
+	}
 	m.Clear()
 
 	if len(m.outputLines) != 0 {
 		t.Errorf("OutputLines length = %d, want 0 after Clear", len(m.outputLines))
 	}
+	if len(m.history) != 1 {
+		t.Errorf("Clear should keep the command history, got %v", m.history)
+	}
 }
 
 func TestExecViewModel_GetCommand_ClearInput(t *testing.T) {
@@ -290,6 +309,9 @@ func TestExecViewModel_View_Running(t *testing.T) {
 	if !strings.Contains(view, "[RUNNING]") {
 		t.Error("View should contain [RUNNING] status")
 	}
+	if !strings.Contains(view, "Ctrl+C: interrupt") {
+		t.Error("View should say how to interrupt the running command")
+	}
 	if !strings.Contains(view, "* ") {
 		t.Error("View should contain '* ' prompt when running")
 	}
@@ -310,6 +332,34 @@ func TestExecViewModel_View_Error(t *testing.T) {
 	}
 }
 
+func TestExecViewModel_View_ExitCode(t *testing.T) {
+	m := NewExecViewModel()
+	m.SetSize(80, 24)
+
+	m.SetExitCode(2)
+	if code, ok := m.ExitCode(); !ok || code != 2 {
+		t.Errorf("ExitCode() = %d, %v; want 2, true", code, ok)
+	}
+	if m.State() != ExecViewStateComplete {
+		t.Errorf("State = %v, want %v", m.State(), ExecViewStateComplete)
+	}
+	if view := m.View(); !strings.Contains(view, "[EXIT: 2]") {
+		t.Errorf("View should show the exit code, got:\n%s", view)
+	}
+
+	// Starting the next command clears the previous status
+	m.SetState(ExecViewStateRunning)
+	if _, ok := m.ExitCode(); ok {
+		t.Error("exit code should be cleared when a new command runs")
+	}
+
+	m.SetStyles(AccessibleStyles())
+	m.SetExitCode(0)
+	if view := m.View(); !strings.Contains(view, "Command exited with code 0") {
+		t.Errorf("verbose status should describe the exit code, got:\n%s", view)
+	}
+}
+
 func TestExecViewState_String(t *testing.T) {
 	tests := []struct {
 		state ExecViewState
@@ -367,3 +417,94 @@ func TestExecViewModel_Scroll(t *testing.T) {
 	m.GotoTop()
 	m.GotoBottom()
 }
+
+func TestExecViewModel_Presets(t *testing.T) {
+	m := NewExecViewModel()
+	m.SetSize(80, 24)
+	m.SetPresets([]config.ExecPreset{
+		{Name: "DNS", Command: "cat /etc/resolv.conf"},
+		{Name: "Env", Command: "env"},
+	})
+
+	if m.ShowingPresets() {
+		t.Fatal("presets menu should be hidden initially")
+	}
+
+	m.TogglePresets()
+	if !m.ShowingPresets() {
+		t.Fatal("presets menu should be shown after toggle")
+	}
+
+	view := m.View()
+	if !strings.Contains(view, "1. DNS") || !strings.Contains(view, "2. Env") {
+		t.Errorf("view should list numbered presets, got:\n%s", view)
+	}
+
+	// Navigate down to the second preset
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	preset, ok := m.SelectedPreset()
+	if !ok || preset.Command != "env" {
+		t.Errorf("SelectedPreset() = %+v, want env", preset)
+	}
+
+	// Navigation should not leak into the input
+	if m.GetCommand() != "" {
+		t.Errorf("input should be untouched while menu is open, got %q", m.GetCommand())
+	}
+
+	m.ClosePresets()
+	if m.ShowingPresets() {
+		t.Error("presets menu should be hidden after close")
+	}
+}
+
+func TestExecViewModel_PresetByNumber(t *testing.T) {
+	m := NewExecViewModel()
+	m.SetPresets([]config.ExecPreset{
+		{Name: "DNS", Command: "cat /etc/resolv.conf"},
+		{Name: "Env", Command: "env"},
+	})
+
+	tests := []struct {
+		n      int
+		want   string
+		wantOK bool
+	}{
+		{1, "cat /etc/resolv.conf", true},
+		{2, "env", true},
+		{0, "", false},
+		{3, "", false},
+	}
+
+	for _, tt := range tests {
+		preset, ok := m.PresetByNumber(tt.n)
+		if ok != tt.wantOK || preset.Command != tt.want {
+			t.Errorf("PresetByNumber(%d) = %+v, %v; want %q, %v", tt.n, preset, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestExecViewModel_TogglePresets_NoPresets(t *testing.T) {
+	m := NewExecViewModel()
+	m.SetPresets(nil)
+
+	m.TogglePresets()
+	if m.ShowingPresets() {
+		t.Error("presets menu should not open without presets")
+	}
+}
+
+func TestExecViewModel_VerboseStatus(t *testing.T) {
+	m := NewExecViewModel()
+	m.SetStyles(AccessibleStyles())
+	m.SetSize(80, 24)
+	m.SetError("connection failed")
+
+	view := m.View()
+	if !strings.Contains(view, "Command failed: connection failed") {
+		t.Errorf("expected verbose error status, got:\n%s", view)
+	}
+	if strings.Contains(view, "[ERROR") {
+		t.Error("verbose mode should not use bracketed indicators")
+	}
+}
@@ -1,9 +1,12 @@
 package ui
 
 import (
+	"cmp"
 	"fmt"
+	"slices"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 
@@ -43,21 +46,68 @@ const (
 	maxFilePreviewBytes = 100 * 1024 // 100KB max file preview
 )
 
+// FileSortMode orders the directory listing
+type FileSortMode int
+
+// File sort modes, in the order the sort key cycles through them. The
+// sorted modes keep . and .. at the top and list directories first.
+const (
+	FileSortListing FileSortMode = iota // As ls returned the entries
+	FileSortName
+	FileSortSize    // Largest first
+	FileSortModTime // Newest first
+	fileSortModes
+)
+
+func (s FileSortMode) String() string {
+	switch s {
+	case FileSortListing:
+		return "ls order"
+	case FileSortName:
+		return "name"
+	case FileSortSize:
+		return "size"
+	case FileSortModTime:
+		return "modified"
+	default:
+		return "unknown"
+	}
+}
+
 // FileBrowserModel manages the file browser UI component
 type FileBrowserModel struct {
 	// Current location
 	currentPath string
 	pathHistory []string // For backspace navigation
 
-	// Directory contents
+	// Directory contents. visible holds the indices of entries matching
+	// the filter; selectedIndex is a position in visible.
 	entries       []k8s.FileInfo
+	visible       []int
 	selectedIndex int
+	filter        textinput.Model
+	sortMode      FileSortMode
+
+	// Bookmarked directories; the picker replaces the listing while open
+	bookmarks     []string
+	showBookmarks bool
+	bookmarkIndex int
 
 	// File preview
 	previewContent  string
 	previewViewport viewport.Model
 	viewingFile     string // Name of file being viewed
 
+	// Paging through a file read in chunks: fileEnd is where the next
+	// chunk starts and fileSize the file's size when last read. The
+	// unfinished last line of a chunk waits in pendingLine for the rest.
+	paged       bool
+	fileEnd     int64
+	fileSize    int64
+	pendingLine string
+	loadingMore bool
+	wantMore    bool
+
 	// State
 	state    FileBrowserState
 	errorMsg string
@@ -71,18 +121,31 @@ type FileBrowserModel struct {
 	width  int
 	height int
 	ready  bool
+
+	styles Styles
 }
 
 // NewFileBrowserModel creates a new file browser model
 func NewFileBrowserModel() FileBrowserModel {
+	filter := textinput.New()
+	filter.Prompt = "Filter: "
+	filter.CharLimit = 255 // Longest file name on most filesystems
+
 	return FileBrowserModel{
 		currentPath: "/",
 		pathHistory: make([]string, 0),
 		entries:     make([]k8s.FileInfo, 0),
+		filter:      filter,
 		state:       FileBrowserStateIdle,
+		styles:      DefaultStyles(),
 	}
 }
 
+// SetStyles sets the styles used for rendering
+func (m *FileBrowserModel) SetStyles(styles Styles) {
+	m.styles = styles
+}
+
 // SetSize updates the viewport size
 func (m *FileBrowserModel) SetSize(width, height int) {
 	m.width = width
@@ -97,6 +160,8 @@ func (m *FileBrowserModel) SetSize(width, height int) {
 	if !m.ready {
 		m.previewViewport = viewport.New(width, viewportHeight)
 		m.previewViewport.YPosition = 0
+		// Keep a preview loaded before the first size
+		m.previewViewport.SetContent(m.previewContent)
 		m.ready = true
 	} else {
 		m.previewViewport.Width = width
@@ -111,6 +176,11 @@ func (m *FileBrowserModel) SetPodInfo(namespace, pod, container string) {
 	m.container = container
 }
 
+// Container returns the container being browsed
+func (m *FileBrowserModel) Container() string {
+	return m.container
+}
+
 // SetState sets the current browser state
 func (m *FileBrowserModel) SetState(state FileBrowserState) {
 	m.state = state
@@ -137,11 +207,11 @@ func (m *FileBrowserModel) SetCurrentPath(path string) {
 	m.currentPath = path
 }
 
-// SetEntries sets the directory entries
+// SetEntries sets the directory entries and clears the filter
 func (m *FileBrowserModel) SetEntries(entries []k8s.FileInfo) {
 	m.entries = entries
-	m.selectedIndex = 0
 	m.state = FileBrowserStateReady
+	m.ClearFilter()
 }
 
 // Entries returns the current directory entries
@@ -149,26 +219,231 @@ func (m *FileBrowserModel) Entries() []k8s.FileInfo {
 	return m.entries
 }
 
-// SelectedIndex returns the currently selected entry index
+// VisibleEntries returns the entries matching the filter, in display order
+func (m *FileBrowserModel) VisibleEntries() []k8s.FileInfo {
+	entries := make([]k8s.FileInfo, len(m.visible))
+	for i, idx := range m.visible {
+		entries[i] = m.entries[idx]
+	}
+	return entries
+}
+
+// SelectedIndex returns the position of the selection among the visible entries
 func (m *FileBrowserModel) SelectedIndex() int {
 	return m.selectedIndex
 }
 
 // SelectedEntry returns the currently selected entry, if any
 func (m *FileBrowserModel) SelectedEntry() *k8s.FileInfo {
-	if m.selectedIndex < 0 || m.selectedIndex >= len(m.entries) {
+	if m.selectedIndex < 0 || m.selectedIndex >= len(m.visible) {
 		return nil
 	}
-	return &m.entries[m.selectedIndex]
+	return &m.entries[m.visible[m.selectedIndex]]
 }
 
-// SetFileContent sets the file content for preview
+// StartFilter focuses the filter input so typing narrows the listing
+func (m *FileBrowserModel) StartFilter() {
+	m.filter.Focus()
+}
+
+// IsFiltering reports whether keystrokes go to the filter input
+func (m *FileBrowserModel) IsFiltering() bool {
+	return m.filter.Focused()
+}
+
+// AcceptFilter stops typing into the filter but keeps it applied
+func (m *FileBrowserModel) AcceptFilter() {
+	m.filter.Blur()
+}
+
+// Filter returns the text entries are filtered by
+func (m *FileBrowserModel) Filter() string {
+	return m.filter.Value()
+}
+
+// ClearFilter removes the filter and shows all entries again
+func (m *FileBrowserModel) ClearFilter() {
+	m.filter.Blur()
+	m.filter.SetValue("")
+	m.applyFilter()
+}
+
+// applyFilter recomputes the visible entries, matching names by
+// case-insensitive substring, and selects the first match
+func (m *FileBrowserModel) applyFilter() {
+	query := strings.ToLower(m.filter.Value())
+	m.visible = m.visible[:0]
+	for i := range m.entries {
+		if query == "" || strings.Contains(strings.ToLower(m.entries[i].Name), query) {
+			m.visible = append(m.visible, i)
+		}
+	}
+	m.sortVisible()
+	m.selectedIndex = 0
+}
+
+// SortMode returns how the listing is ordered
+func (m *FileBrowserModel) SortMode() FileSortMode {
+	return m.sortMode
+}
+
+// CycleSort switches to the next sort mode, keeping the selected entry
+// selected
+func (m *FileBrowserModel) CycleSort() {
+	selected := -1
+	if m.selectedIndex >= 0 && m.selectedIndex < len(m.visible) {
+		selected = m.visible[m.selectedIndex]
+	}
+
+	m.sortMode = (m.sortMode + 1) % fileSortModes
+	m.sortVisible()
+
+	for i, idx := range m.visible {
+		if idx == selected {
+			m.selectedIndex = i
+			break
+		}
+	}
+}
+
+// sortVisible orders the visible entries by the sort mode. Entries are
+// left in listing order, so going back to it needs no copy.
+func (m *FileBrowserModel) sortVisible() {
+	slices.SortStableFunc(m.visible, func(a, b int) int {
+		return compareFileEntries(m.entries[a], m.entries[b], m.sortMode, a, b)
+	})
+}
+
+// compareFileEntries orders two entries for the sort mode; ia and ib are
+// their positions in the listing
+func compareFileEntries(a, b k8s.FileInfo, mode FileSortMode, ia, ib int) int {
+	if mode == FileSortListing {
+		return cmp.Compare(ia, ib)
+	}
+	// . and .. stay pinned at the top
+	if c := cmp.Compare(pinRank(a.Name), pinRank(b.Name)); c != 0 || pinRank(a.Name) < 2 {
+		return c
+	}
+	if a.IsDir != b.IsDir {
+		if a.IsDir {
+			return -1
+		}
+		return 1
+	}
+
+	var c int
+	switch mode {
+	case FileSortSize:
+		c = cmp.Compare(b.Size, a.Size)
+	case FileSortModTime:
+		c = b.Modified.Compare(a.Modified)
+	}
+	if c != 0 {
+		return c
+	}
+	return cmp.Or(cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)), cmp.Compare(a.Name, b.Name))
+}
+
+// pinRank ranks . and .. ahead of every other entry
+func pinRank(name string) int {
+	switch name {
+	case ".":
+		return 0
+	case "..":
+		return 1
+	default:
+		return 2
+	}
+}
+
+// SetFileContent sets the file content for preview, syntax-highlighted by
+// extension unless colors are disabled. Binary content is replaced by a
+// notice so control characters don't garble the terminal.
 func (m *FileBrowserModel) SetFileContent(filename, content string) {
+	switch {
+	case k8s.IsBinary([]byte(content)):
+		size := int64(len(content))
+		if entry := m.SelectedEntry(); entry != nil && entry.Name == filename {
+			size = entry.Size
+		}
+		content = fmt.Sprintf("[binary file, %d bytes — not shown]", size)
+	case m.styles.Highlight:
+		content = Highlight(filename, content)
+	}
 	m.viewingFile = filename
 	m.previewContent = content
 	m.previewViewport.SetContent(content)
 	m.previewViewport.GotoTop()
 	m.state = FileBrowserStateViewingFile
+	m.resetPaging()
+}
+
+// SetFileChunk shows a chunk read from a file. The chunk at offset 0
+// opens the file; later ones extend the preview of the file being viewed
+// and are dropped for any other.
+func (m *FileBrowserModel) SetFileChunk(filename string, chunk k8s.FileChunk) {
+	if chunk.Offset == 0 {
+		if k8s.IsBinary([]byte(chunk.Content)) {
+			m.SetFileContent(filename, chunk.Content)
+			return
+		}
+		m.viewingFile = filename
+		m.previewContent = ""
+		m.state = FileBrowserStateViewingFile
+		m.resetPaging()
+		m.paged = true
+	} else if !m.paged || filename != m.viewingFile || chunk.Offset != m.fileEnd {
+		return
+	}
+
+	m.loadingMore = false
+	m.fileEnd = chunk.End()
+	m.fileSize = chunk.Size
+
+	// Hold back a partial last line until the rest of it arrives, so
+	// highlighting sees whole lines
+	text := m.pendingLine + chunk.Content
+	m.pendingLine = ""
+	if !chunk.EOF() {
+		if i := strings.LastIndexByte(text, '\n'); i >= 0 {
+			text, m.pendingLine = text[:i+1], text[i+1:]
+		}
+	}
+	if m.styles.Highlight {
+		text = Highlight(filename, text)
+	}
+
+	offset := m.previewViewport.YOffset
+	m.previewContent += text
+	m.previewViewport.SetContent(m.previewContent)
+	m.previewViewport.SetYOffset(offset)
+}
+
+// FailMoreContent stops waiting for a chunk that couldn't be read, so
+// scrolling down asks again
+func (m *FileBrowserModel) FailMoreContent() {
+	m.loadingMore = false
+}
+
+// MoreContentRequest returns the offset of the next chunk to read once
+// scrolling reached the end of the loaded content, and marks it loading
+func (m *FileBrowserModel) MoreContentRequest() (int64, bool) {
+	if !m.wantMore {
+		return 0, false
+	}
+	m.wantMore = false
+	m.loadingMore = true
+	return m.fileEnd, true
+}
+
+// resetPaging forgets the chunks of the previous file
+func (m *FileBrowserModel) resetPaging() {
+	m.paged = false
+	m.fileEnd = 0
+	m.fileSize = 0
+	m.pendingLine = ""
+	m.loadingMore = false
+	m.wantMore = false
 }
 
 // ViewingFile returns the name of the file currently being viewed
@@ -186,6 +461,7 @@ func (m *FileBrowserModel) ExitFileView() {
 	m.state = FileBrowserStateReady
 	m.viewingFile = ""
 	m.previewContent = ""
+	m.resetPaging()
 }
 
 // NavigateUp moves selection up
@@ -197,7 +473,7 @@ func (m *FileBrowserModel) NavigateUp() {
 
 // NavigateDown moves selection down
 func (m *FileBrowserModel) NavigateDown() {
-	if m.selectedIndex < len(m.entries)-1 {
+	if m.selectedIndex < len(m.visible)-1 {
 		m.selectedIndex++
 	}
 }
@@ -209,8 +485,8 @@ func (m *FileBrowserModel) GotoTop() {
 
 // GotoBottom moves to the last entry
 func (m *FileBrowserModel) GotoBottom() {
-	if len(m.entries) > 0 {
-		m.selectedIndex = len(m.entries) - 1
+	if len(m.visible) > 0 {
+		m.selectedIndex = len(m.visible) - 1
 	}
 }
 
@@ -242,8 +518,8 @@ func (m *FileBrowserModel) PageDown() {
 		pageSize = 1
 	}
 	m.selectedIndex += pageSize
-	if m.selectedIndex >= len(m.entries) {
-		m.selectedIndex = len(m.entries) - 1
+	if m.selectedIndex >= len(m.visible) {
+		m.selectedIndex = len(m.visible) - 1
 	}
 	if m.selectedIndex < 0 {
 		m.selectedIndex = 0
@@ -306,14 +582,97 @@ func (m *FileBrowserModel) NavigateToEntry() (path string, isFile bool) {
 	return newPath, true
 }
 
-// Clear resets the file browser state
+// SetBookmarks sets the bookmarked directories
+func (m *FileBrowserModel) SetBookmarks(bookmarks []string) {
+	m.bookmarks = bookmarks
+	m.bookmarkIndex = 0
+}
+
+// Bookmarks returns the bookmarked directories
+func (m *FileBrowserModel) Bookmarks() []string {
+	return slices.Clone(m.bookmarks)
+}
+
+// IsBookmarked reports whether the current directory is bookmarked
+func (m *FileBrowserModel) IsBookmarked() bool {
+	return slices.Contains(m.bookmarks, m.currentPath)
+}
+
+// ToggleBookmark bookmarks the current directory, or removes its bookmark,
+// and reports whether it is bookmarked now
+func (m *FileBrowserModel) ToggleBookmark() bool {
+	if i := slices.Index(m.bookmarks, m.currentPath); i >= 0 {
+		m.bookmarks = slices.Delete(m.bookmarks, i, i+1)
+		return false
+	}
+	m.bookmarks = append(m.bookmarks, m.currentPath)
+	return true
+}
+
+// OpenBookmarks shows the bookmark picker, unless there are no bookmarks
+func (m *FileBrowserModel) OpenBookmarks() bool {
+	if len(m.bookmarks) == 0 {
+		return false
+	}
+	m.showBookmarks = true
+	m.bookmarkIndex = 0
+	return true
+}
+
+// CloseBookmarks hides the bookmark picker
+func (m *FileBrowserModel) CloseBookmarks() {
+	m.showBookmarks = false
+}
+
+// ShowingBookmarks returns whether the bookmark picker is displayed
+func (m *FileBrowserModel) ShowingBookmarks() bool {
+	return m.showBookmarks
+}
+
+// SelectedBookmark returns the highlighted bookmark in the picker
+func (m *FileBrowserModel) SelectedBookmark() (string, bool) {
+	if m.bookmarkIndex < 0 || m.bookmarkIndex >= len(m.bookmarks) {
+		return "", false
+	}
+	return m.bookmarks[m.bookmarkIndex], true
+}
+
+// RemoveSelectedBookmark deletes the highlighted bookmark, closing the
+// picker once none are left
+func (m *FileBrowserModel) RemoveSelectedBookmark() (string, bool) {
+	bookmark, ok := m.SelectedBookmark()
+	if !ok {
+		return "", false
+	}
+	m.bookmarks = slices.Delete(m.bookmarks, m.bookmarkIndex, m.bookmarkIndex+1)
+	m.bookmarkIndex = min(m.bookmarkIndex, len(m.bookmarks)-1)
+	if len(m.bookmarks) == 0 {
+		m.showBookmarks = false
+		m.bookmarkIndex = 0
+	}
+	return bookmark, true
+}
+
+// JumpTo moves to path, keeping the current directory in the history so
+// backspace can return to it
+func (m *FileBrowserModel) JumpTo(path string) {
+	if path == m.currentPath {
+		return
+	}
+	m.PushPath()
+	m.currentPath = path
+}
+
+// Clear resets the file browser state. Bookmarks are kept.
 func (m *FileBrowserModel) Clear() {
 	m.entries = make([]k8s.FileInfo, 0)
-	m.selectedIndex = 0
+	m.ClearFilter()
+	m.showBookmarks = false
 	m.currentPath = "/"
 	m.pathHistory = make([]string, 0)
 	m.previewContent = ""
 	m.viewingFile = ""
+	m.resetPaging()
 	m.errorMsg = ""
 	m.state = FileBrowserStateIdle
 }
@@ -326,26 +685,77 @@ func (m FileBrowserModel) Update(msg tea.Msg) (FileBrowserModel, tea.Cmd) {
 	case tea.KeyMsg:
 		// Handle keys differently based on state
 		if m.state == FileBrowserStateViewingFile {
+			wasAtBottom := m.previewViewport.AtBottom()
+			down := false
 			switch msg.String() {
 			case "j", "down":
 				m.previewViewport.ScrollDown(1)
+				down = true
 			case "k", "up":
 				m.previewViewport.ScrollUp(1)
 			case "g":
 				m.previewViewport.GotoTop()
 			case "G":
 				m.previewViewport.GotoBottom()
+				down = true
 			case "pgdown", " ":
 				m.previewViewport.PageDown()
+				down = true
 			case "pgup":
 				m.previewViewport.PageUp()
 			}
+			// Scrolling to the end of the loaded content asks for the next
+			// chunk; G at the end of the file checks whether it grew
+			if down && m.paged && !m.loadingMore && m.previewViewport.AtBottom() &&
+				(m.fileEnd < m.fileSize || wasAtBottom && msg.String() == "G") {
+				m.wantMore = true
+			}
 			// Esc/Backspace handled by app.go
 			return m, nil
 		}
 
+		// Bookmark picker navigation; Enter, d and Esc are handled by app.go
+		if m.showBookmarks {
+			switch msg.String() {
+			case "up", "k":
+				if m.bookmarkIndex > 0 {
+					m.bookmarkIndex--
+				}
+			case "down", "j":
+				if m.bookmarkIndex < len(m.bookmarks)-1 {
+					m.bookmarkIndex++
+				}
+			}
+			return m, nil
+		}
+
+		// While filtering, arrows move the selection and other keys edit
+		// the filter. Enter and Esc are handled by app.go.
+		if m.filter.Focused() {
+			switch msg.Type {
+			case tea.KeyDown:
+				m.NavigateDown()
+			case tea.KeyUp:
+				m.NavigateUp()
+			default:
+				var cmd tea.Cmd
+				prev := m.filter.Value()
+				m.filter, cmd = m.filter.Update(msg)
+				if m.filter.Value() != prev {
+					m.applyFilter()
+				}
+				return m, cmd
+			}
+			return m, nil
+		}
+
 		// Directory listing navigation
 		switch msg.String() {
+		case "/":
+			m.StartFilter()
+			return m, textinput.Blink
+		case "s":
+			m.CycleSort()
 		case "j", "down":
 			m.NavigateDown()
 		case "k", "up":
@@ -398,10 +808,18 @@ func (m FileBrowserModel) viewDirectoryListing() string {
 
 	// Path
 	b.WriteString(fmt.Sprintf("Path: %s", m.currentPath))
+	if m.IsBookmarked() {
+		b.WriteString(" [bookmarked]")
+	}
 	b.WriteString("\n")
 	b.WriteString(strings.Repeat("-", min(m.width, 80)))
 	b.WriteString("\n")
 
+	if m.showBookmarks {
+		b.WriteString(m.viewBookmarks())
+		return b.String()
+	}
+
 	// Handle different states
 	switch m.state {
 	case FileBrowserStateLoading:
@@ -427,9 +845,17 @@ func (m FileBrowserModel) viewDirectoryListing() string {
 
 	// Calculate how many entries we can show
 	availableHeight := m.height - 7 // header, path, separator, status lines
+	if m.filter.Focused() || m.filter.Value() != "" {
+		b.WriteString(m.filter.View())
+		b.WriteString("\n")
+		availableHeight--
+	}
 	if availableHeight < 1 {
 		availableHeight = 1
 	}
+	if len(m.visible) == 0 {
+		b.WriteString("No matching files\n")
+	}
 
 	// Determine visible range (scrolling)
 	start := 0
@@ -437,13 +863,13 @@ func (m FileBrowserModel) viewDirectoryListing() string {
 		start = m.selectedIndex - availableHeight + 1
 	}
 	end := start + availableHeight
-	if end > len(m.entries) {
-		end = len(m.entries)
+	if end > len(m.visible) {
+		end = len(m.visible)
 	}
 
 	// Directory entries
 	for i := start; i < end; i++ {
-		entry := m.entries[i]
+		entry := m.entries[m.visible[i]]
 		prefix := "  "
 		if i == m.selectedIndex {
 			prefix = "> "
@@ -467,13 +893,11 @@ func (m FileBrowserModel) viewDirectoryListing() string {
 		}
 
 		// Truncate long names
-		maxNameLen := m.width - 30
+		maxNameLen := m.width - 44
 		if maxNameLen < 20 {
 			maxNameLen = 20
 		}
-		if len(name) > maxNameLen {
-			name = name[:maxNameLen-3] + "..."
-		}
+		name = Truncate(name, maxNameLen)
 
 		// Size (right-aligned)
 		sizeStr := ""
@@ -487,12 +911,12 @@ func (m FileBrowserModel) viewDirectoryListing() string {
 			perms = entry.Permissions
 		}
 
-		b.WriteString(fmt.Sprintf("%s%s%-*s  %6s  %s\n",
+		b.WriteString(fmt.Sprintf("%s%s%s  %s  %s  %s\n",
 			prefix,
 			icon,
-			maxNameLen,
-			name,
-			sizeStr,
+			PadRight(name, maxNameLen),
+			PadLeft(sizeStr, 6),
+			PadRight(entry.ModTime, 12),
 			perms,
 		))
 	}
@@ -506,6 +930,22 @@ func (m FileBrowserModel) viewDirectoryListing() string {
 	return b.String()
 }
 
+// viewBookmarks renders the bookmark picker
+func (m FileBrowserModel) viewBookmarks() string {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("Bookmarks (%s, %s, Esc: close)\n",
+		formatBindings(fileKeys.Open), formatBindings(fileKeys.RemoveBookmark)))
+	for i, bookmark := range m.bookmarks {
+		prefix := "  "
+		if i == m.bookmarkIndex {
+			prefix = "> "
+		}
+		b.WriteString(prefix + bookmark + "\n")
+	}
+	return b.String()
+}
+
 // viewFileContent renders the file content preview
 func (m FileBrowserModel) viewFileContent() string {
 	var b strings.Builder
@@ -533,13 +973,46 @@ func (m FileBrowserModel) viewFileContent() string {
 	b.WriteString(strings.Repeat("-", min(m.width, 80)))
 	b.WriteString("\n")
 	scrollPercent := int(m.previewViewport.ScrollPercent() * 100)
-	b.WriteString(fmt.Sprintf("[VIEWING] %d%% | j/k: scroll | Backspace/Esc: back to list", scrollPercent))
+	if m.styles.Verbose {
+		b.WriteString(fmt.Sprintf("Viewing file, scrolled %d%%%s, press e to edit, Backspace or Esc to return to the list",
+			scrollPercent, m.verboseRangeStatus()))
+	} else {
+		b.WriteString(fmt.Sprintf("[VIEWING] %d%%%s | j/k: scroll | e: edit | Backspace/Esc: back to list", scrollPercent, m.rangeStatus()))
+	}
 
 	return b.String()
 }
 
+// rangeStatus tells how much of a file larger than one chunk is loaded
+func (m FileBrowserModel) rangeStatus() string {
+	if !m.paged || m.fileSize <= maxFilePreviewBytes {
+		return ""
+	}
+	status := fmt.Sprintf(" | bytes 1–%d of %d", m.fileEnd, m.fileSize)
+	if m.loadingMore {
+		status += " | loading more..."
+	}
+	return status
+}
+
+// verboseRangeStatus is rangeStatus in words for screen readers
+func (m FileBrowserModel) verboseRangeStatus() string {
+	if !m.paged || m.fileSize <= maxFilePreviewBytes {
+		return ""
+	}
+	status := fmt.Sprintf(", showing bytes 1 to %d of %d", m.fileEnd, m.fileSize)
+	if m.loadingMore {
+		status += ", loading more"
+	}
+	return status
+}
+
 // buildStatusLine creates the status line at the bottom
 func (m FileBrowserModel) buildStatusLine() string {
+	if m.styles.Verbose {
+		return m.verboseStatusLine()
+	}
+
 	var stateIndicator string
 	switch m.state {
 	case FileBrowserStateLoading:
@@ -552,12 +1025,49 @@ func (m FileBrowserModel) buildStatusLine() string {
 		stateIndicator = "[IDLE]"
 	}
 
-	itemCount := fmt.Sprintf(" %d items", len(m.entries))
-	if m.selectedIndex >= 0 && m.selectedIndex < len(m.entries) {
-		itemCount = fmt.Sprintf(" %d/%d", m.selectedIndex+1, len(m.entries))
+	itemCount := fmt.Sprintf(" %d items", len(m.visible))
+	if m.selectedIndex >= 0 && m.selectedIndex < len(m.visible) {
+		itemCount = fmt.Sprintf(" %d/%d", m.selectedIndex+1, len(m.visible))
+	}
+
+	if m.filter.Value() != "" {
+		return fmt.Sprintf("%s%s | %d of %d match %q | %s",
+			stateIndicator, itemCount, len(m.visible), len(m.entries), m.filter.Value(),
+			formatBindings(fileKeys.Open, fileKeys.ClearFilter))
+	}
+	if m.filter.Focused() {
+		return fmt.Sprintf("%s%s | Type to filter | %s", stateIndicator, itemCount, formatBindings(fileKeys.ClearFilter))
+	}
+	return fmt.Sprintf("%s%s | %s | %s (%s) | %s | Esc: back",
+		stateIndicator, itemCount, formatBindings(fileKeys.Open, fileKeys.Filter),
+		formatBindings(fileKeys.Sort), m.sortMode, formatBindings(fileKeys.Parent))
+}
+
+// verboseStatusLine describes the browser state in words for screen readers
+func (m FileBrowserModel) verboseStatusLine() string {
+	var state string
+	switch m.state {
+	case FileBrowserStateLoading:
+		state = "Loading directory"
+	case FileBrowserStateReady:
+		state = "Directory loaded"
+	case FileBrowserStateError:
+		state = "Failed to load directory"
+	default:
+		state = "File browser idle"
+	}
+
+	position := pluralize(len(m.visible), "item")
+	if m.selectedIndex >= 0 && m.selectedIndex < len(m.visible) {
+		position = fmt.Sprintf("item %d of %d", m.selectedIndex+1, len(m.visible))
 	}
 
-	return fmt.Sprintf("%s%s | Enter: open | Backspace: parent | Esc: back", stateIndicator, itemCount)
+	if m.filter.Value() != "" || m.filter.Focused() {
+		return fmt.Sprintf("%s, %d of %d entries match the filter %q, %s, press Enter to open, Esc to clear the filter",
+			state, len(m.visible), len(m.entries), m.filter.Value(), position)
+	}
+	return fmt.Sprintf("%s, %s, sorted by %s, press Enter to open, / to filter, s to change the sort, Backspace for parent, Esc to go back",
+		state, position, m.sortMode)
 }
 
 // MaxFilePreviewBytes returns the maximum bytes to read for file preview
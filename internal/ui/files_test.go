@@ -1,10 +1,15 @@
 package ui
 
 import (
+	"fmt"
+	"slices"
 	"strings"
 	"testing"
+	"time"
+	"unicode/utf8"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-runewidth"
 
 	"github.com/maxime/k8s-tui/internal/k8s"
 )
@@ -36,8 +41,8 @@ func TestFileBrowserModel_SetPodInfo(t *testing.T) {
 	if m.pod != "my-pod" {
 		t.Errorf("pod = %q, want %q", m.pod, "my-pod")
 	}
-	if m.container != "main" {
-		t.Errorf("container = %q, want %q", m.container, "main")
+	if m.Container() != "main" {
+		t.Errorf("Container() = %q, want %q", m.Container(), "main")
 	}
 }
 
@@ -294,6 +299,90 @@ func TestFileBrowserModel_NavigateToEntry(t *testing.T) {
 	}
 }
 
+// sortTestEntries is a listing with a directory, a symlink and files of
+// differing sizes and ages, in ls order
+func sortTestEntries() []k8s.FileInfo {
+	day := func(d int) time.Time { return time.Date(2024, time.March, d, 12, 0, 0, 0, time.UTC) }
+	return []k8s.FileInfo{
+		{Name: ".", IsDir: true, Size: 4096, Modified: day(9)},
+		{Name: "..", IsDir: true, Size: 4096, Modified: day(1)},
+		{Name: "Zeta.txt", Size: 10, Modified: day(5)},
+		{Name: "app.log", Size: 5000, Modified: day(8)},
+		{Name: "current", IsSymlink: true, LinkTarget: "app.log", Size: 7, Modified: day(7)},
+		{Name: "etc", IsDir: true, Size: 4096, Modified: day(2)},
+		{Name: "big.bin", Size: 90000, Modified: day(3)},
+	}
+}
+
+func visibleNames(m FileBrowserModel) []string {
+	var names []string
+	for _, entry := range m.VisibleEntries() {
+		names = append(names, entry.Name)
+	}
+	return names
+}
+
+func TestFileBrowserModel_SortModes(t *testing.T) {
+	m := NewFileBrowserModel()
+	m.SetEntries(sortTestEntries())
+
+	tests := []struct {
+		mode FileSortMode
+		want []string
+	}{
+		{FileSortListing, []string{".", "..", "Zeta.txt", "app.log", "current", "etc", "big.bin"}},
+		{FileSortName, []string{".", "..", "etc", "app.log", "big.bin", "current", "Zeta.txt"}},
+		{FileSortSize, []string{".", "..", "etc", "big.bin", "app.log", "Zeta.txt", "current"}},
+		{FileSortModTime, []string{".", "..", "etc", "app.log", "current", "Zeta.txt", "big.bin"}},
+	}
+
+	for i, tt := range tests {
+		if i > 0 {
+			m.CycleSort()
+		}
+		if m.SortMode() != tt.mode {
+			t.Fatalf("sort mode = %v, want %v", m.SortMode(), tt.mode)
+		}
+		if got := visibleNames(m); !slices.Equal(got, tt.want) {
+			t.Errorf("sorted by %v = %v, want %v", tt.mode, got, tt.want)
+		}
+	}
+
+	// The modes wrap around to the listing order
+	m.CycleSort()
+	if m.SortMode() != FileSortListing {
+		t.Errorf("expected the sort to wrap to ls order, got %v", m.SortMode())
+	}
+}
+
+func TestFileBrowserModel_SortKeepsSelectionAndFilter(t *testing.T) {
+	m := NewFileBrowserModel()
+	m.SetSize(100, 24)
+	m.SetEntries(sortTestEntries())
+
+	// Select big.bin, then sort by name with the s key
+	m.GotoBottom()
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	if m.SortMode() != FileSortName {
+		t.Fatalf("s should switch to sorting by name, got %v", m.SortMode())
+	}
+	if entry := m.SelectedEntry(); entry == nil || entry.Name != "big.bin" {
+		t.Errorf("the selected entry should stay selected, got %+v", entry)
+	}
+	if !strings.Contains(m.View(), "s: sort (name)") {
+		t.Errorf("status should show the sort mode, got:\n%s", m.View())
+	}
+
+	// A new filter keeps the sort
+	m.StartFilter()
+	for _, r := range "a" {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if got, want := visibleNames(m), []string{"app.log", "Zeta.txt"}; !slices.Equal(got, want) {
+		t.Errorf("filtered entries = %v, want %v", got, want)
+	}
+}
+
 func TestFileBrowserModel_SetFileContent(t *testing.T) {
 	m := NewFileBrowserModel()
 	m.SetSize(80, 24) // Initialize viewport
@@ -312,6 +401,155 @@ func TestFileBrowserModel_SetFileContent(t *testing.T) {
 	}
 }
 
+func TestFileBrowserModel_SetFileContentHighlights(t *testing.T) {
+	content := `{"replicas": 3}`
+
+	m := NewFileBrowserModel()
+	m.SetStyles(Styles{Highlight: true})
+	m.SetSize(80, 24)
+	m.SetFileContent("deploy.json", content)
+	if !strings.Contains(m.View(), "\x1b[") {
+		t.Errorf("expected a highlighted .json preview, got:\n%s", m.View())
+	}
+
+	m = NewFileBrowserModel()
+	m.SetStyles(PlainStyles())
+	m.SetSize(80, 24)
+	m.SetFileContent("deploy.json", content)
+	if strings.Contains(m.View(), "\x1b[") || !strings.Contains(m.View(), content) {
+		t.Errorf("plain styles should show the file as is, got:\n%s", m.View())
+	}
+}
+
+func TestFileBrowserModel_SetFileContentBinary(t *testing.T) {
+	m := NewFileBrowserModel()
+	m.SetSize(80, 24)
+	m.SetEntries([]k8s.FileInfo{{Name: "app", Size: 4_200_000}})
+
+	// Only the preview cap was read; the listing knows the real size
+	m.SetFileContent("app", "\x7fELF\x02\x01\x01\x00\x00\x00\x1b[2J")
+
+	view := m.View()
+	if !strings.Contains(view, "[binary file, 4200000 bytes — not shown]") {
+		t.Errorf("expected a binary notice, got:\n%s", view)
+	}
+	if strings.Contains(view, "ELF") || strings.Contains(view, "\x1b[2J") {
+		t.Error("binary content should not be rendered")
+	}
+}
+
+func TestFileBrowserModel_SetFileContentBeforeSetSize(t *testing.T) {
+	m := NewFileBrowserModel()
+	m.SetFileContent("test.txt", "early-content")
+	m.SetSize(80, 24)
+
+	if !strings.Contains(m.View(), "early-content") {
+		t.Errorf("content set before SetSize should be shown, got:\n%s", m.View())
+	}
+}
+
+func TestFileBrowserModel_SetFileChunk(t *testing.T) {
+	m := NewFileBrowserModel()
+	m.SetSize(80, 24)
+	m.currentPath = "/var/log"
+
+	lines := func(from, to int) string {
+		var b strings.Builder
+		for i := from; i < to; i++ {
+			fmt.Fprintf(&b, "line %d\n", i)
+		}
+		return b.String()
+	}
+	first := lines(0, 100) + "partial "
+	size := int64(maxFilePreviewBytes * 3)
+	m.SetFileChunk("app.log", k8s.FileChunk{Content: first, Size: size})
+
+	if !m.IsViewingFile() || m.ViewingFile() != "app.log" {
+		t.Fatalf("the first chunk should open the file, got state %v", m.state)
+	}
+	if strings.Contains(m.previewContent, "partial") {
+		t.Error("a partial last line should wait for the rest of it")
+	}
+	if !strings.Contains(m.View(), fmt.Sprintf("bytes 1–%d of %d", len(first), size)) {
+		t.Errorf("expected the loaded range in the status, got:\n%s", m.View())
+	}
+
+	// Scrolling up doesn't load anything
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
+	if _, ok := m.MoreContentRequest(); ok {
+		t.Fatal("scrolling up shouldn't read more")
+	}
+
+	// Reaching the bottom asks for the next chunk, once
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'G'}})
+	offset, ok := m.MoreContentRequest()
+	if !ok || offset != int64(len(first)) {
+		t.Fatalf("expected the next chunk at %d, got %d (%v)", len(first), offset, ok)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	if _, ok := m.MoreContentRequest(); ok {
+		t.Error("a chunk being loaded shouldn't be asked for again")
+	}
+	if !strings.Contains(m.View(), "loading more...") {
+		t.Errorf("expected a loading hint, got:\n%s", m.View())
+	}
+
+	// A chunk for another file or offset is dropped
+	m.SetFileChunk("other.log", k8s.FileChunk{Content: "other\n", Offset: offset, Size: size})
+	m.SetFileChunk("app.log", k8s.FileChunk{Content: "stale\n", Offset: offset + 1, Size: size})
+	if strings.Contains(m.previewContent, "other") || strings.Contains(m.previewContent, "stale") {
+		t.Fatal("unexpected chunk appended")
+	}
+
+	// The file grew to more than expected and this chunk ends it
+	second := "line\n" + lines(100, 110)
+	m.SetFileChunk("app.log", k8s.FileChunk{Content: second, Offset: offset, Size: offset + int64(len(second))})
+	if !strings.Contains(m.previewContent, "partial line\nline 100\n") || !strings.HasSuffix(m.previewContent, "line 109\n") {
+		t.Errorf("expected the chunk appended after the held back line, got:\n%s", m.previewContent)
+	}
+
+	// At the end of the file scrolling doesn't read on, but G checks
+	// whether the file grew
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'G'}})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	if _, ok := m.MoreContentRequest(); ok {
+		t.Error("scrolling at the end of the file shouldn't read more")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'G'}})
+	if _, ok := m.MoreContentRequest(); !ok {
+		t.Error("G at the end should check for more")
+	}
+	m.FailMoreContent()
+	if strings.Contains(m.View(), "loading more") {
+		t.Error("a failed read should stop loading")
+	}
+
+	m.ExitFileView()
+	if m.paged || m.fileEnd != 0 {
+		t.Error("leaving the file should forget its chunks")
+	}
+}
+
+func TestFileBrowserModel_SetFileChunkSmallFile(t *testing.T) {
+	m := NewFileBrowserModel()
+	m.SetSize(80, 24)
+	m.SetFileChunk("app.conf", k8s.FileChunk{Content: "level: info", Size: 11})
+
+	view := m.View()
+	if !strings.Contains(view, "level: info") {
+		t.Errorf("expected the whole file shown, got:\n%s", view)
+	}
+	if strings.Contains(view, "bytes 1") {
+		t.Errorf("a file within one chunk needs no range, got:\n%s", view)
+	}
+
+	// Binary files aren't paged
+	m.SetFileChunk("app", k8s.FileChunk{Content: "\x7fELF\x02\x01\x00", Size: 4_200_000})
+	if !strings.Contains(m.View(), "binary file") || m.paged {
+		t.Errorf("expected a binary notice, got:\n%s", m.View())
+	}
+}
+
 func TestFileBrowserModel_ExitFileView(t *testing.T) {
 	m := NewFileBrowserModel()
 	m.SetSize(80, 24)
@@ -394,6 +632,95 @@ func TestFileBrowserModel_Update_Navigation(t *testing.T) {
 	}
 }
 
+func TestFileBrowserModel_Filter(t *testing.T) {
+	m := NewFileBrowserModel()
+	m.SetSize(80, 24)
+	m.SetEntries([]k8s.FileInfo{
+		{Name: ".."},
+		{Name: "app.log"},
+		{Name: "config", IsDir: true},
+		{Name: "Error.LOG"},
+		{Name: "readme.md"},
+	})
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	if !m.IsFiltering() {
+		t.Fatal("/ should start filtering")
+	}
+	for _, r := range "log" {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+
+	visible := m.VisibleEntries()
+	if len(visible) != 2 || visible[0].Name != "app.log" || visible[1].Name != "Error.LOG" {
+		t.Fatalf("expected the two log files, matched case-insensitively, got %+v", visible)
+	}
+	if len(m.Entries()) != 5 {
+		t.Error("filtering should keep the underlying entries")
+	}
+
+	// Arrows move within the matches; the selection resolves to the real entry
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if entry := m.SelectedEntry(); entry == nil || entry.Name != "Error.LOG" {
+		t.Errorf("expected Error.LOG selected, got %+v", entry)
+	}
+	path, isFile := m.NavigateToEntry()
+	if path != "/Error.LOG" || !isFile {
+		t.Errorf("NavigateToEntry() = %q, %v; want /Error.LOG, true", path, isFile)
+	}
+
+	view := m.View()
+	if !strings.Contains(view, "2 of 5 match") {
+		t.Errorf("status should show the match count, got:\n%s", view)
+	}
+	if strings.Contains(view, "readme.md") {
+		t.Error("non-matching entries should be hidden")
+	}
+
+	m.ClearFilter()
+	if m.IsFiltering() || m.Filter() != "" || len(m.VisibleEntries()) != 5 {
+		t.Errorf("clearing should show all entries again, got %d", len(m.VisibleEntries()))
+	}
+}
+
+func TestFileBrowserModel_FilterNoMatches(t *testing.T) {
+	m := NewFileBrowserModel()
+	m.SetSize(80, 24)
+	m.SetEntries([]k8s.FileInfo{{Name: "app.log"}})
+	m.StartFilter()
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("zzz")})
+
+	if m.SelectedEntry() != nil {
+		t.Error("expected no selection without matches")
+	}
+	if path, _ := m.NavigateToEntry(); path != "" {
+		t.Errorf("expected no navigation without matches, got %q", path)
+	}
+	if view := m.View(); !strings.Contains(view, "No matching files") {
+		t.Errorf("expected a no-match message, got:\n%s", view)
+	}
+}
+
+func TestFileBrowserModel_View_TruncatesMultibyteNames(t *testing.T) {
+	m := NewFileBrowserModel()
+	m.SetSize(50, 24) // Names are cut to 20 cells
+	m.SetEntries([]k8s.FileInfo{
+		{Name: "résumé-ébauche-définitive.txt", Size: 10},
+		{Name: "🚀🚀🚀🚀🚀🚀🚀🚀🚀🚀🚀🚀.log", Size: 10},
+	})
+
+	view := m.View()
+	if !utf8.ValidString(view) {
+		t.Fatalf("truncation split a rune:\n%q", view)
+	}
+	if !strings.Contains(view, "résumé-ébauche-dé...") {
+		t.Errorf("expected the accented name cut at 20 cells, got:\n%s", view)
+	}
+	if !strings.Contains(view, "🚀🚀🚀🚀🚀🚀🚀🚀...") {
+		t.Errorf("expected the emoji name cut at 20 cells, got:\n%s", view)
+	}
+}
+
 func TestFileBrowserModel_View_DirectoryListing(t *testing.T) {
 	m := NewFileBrowserModel()
 	m.SetSize(80, 24)
@@ -430,6 +757,27 @@ func TestFileBrowserModel_View_DirectoryListing(t *testing.T) {
 	}
 }
 
+func TestFileBrowserModel_View_WideNamesAligned(t *testing.T) {
+	m := NewFileBrowserModel()
+	m.SetSize(80, 24)
+	m.SetPodInfo("default", "my-pod", "main")
+	m.SetEntries([]k8s.FileInfo{
+		{Name: "notes.txt", Size: 100, ModTime: "2024-05-01", Permissions: "-rw-r--r--"},
+		{Name: "報告書.txt", Size: 100, ModTime: "2024-05-01", Permissions: "-rw-r--r--"},
+	})
+
+	// The permissions column starts at the same cell on both rows
+	columns := make(map[int]bool)
+	for _, line := range strings.Split(m.View(), "\n") {
+		if i := strings.Index(line, "-rw-r--r--"); i >= 0 {
+			columns[runewidth.StringWidth(line[:i])] = true
+		}
+	}
+	if len(columns) != 1 {
+		t.Errorf("expected aligned columns, got start cells %v in:\n%s", columns, m.View())
+	}
+}
+
 func TestFileBrowserModel_View_FileContent(t *testing.T) {
 	m := NewFileBrowserModel()
 	m.SetSize(80, 24)
@@ -502,3 +850,80 @@ func TestMaxFilePreviewBytes(t *testing.T) {
 		t.Errorf("MaxFilePreviewBytes() = %d, want %d", bytes, 100*1024)
 	}
 }
+
+func TestFileBrowserModel_VerboseStatus(t *testing.T) {
+	m := NewFileBrowserModel()
+	m.SetStyles(AccessibleStyles())
+	m.SetSize(80, 24)
+	m.SetEntries([]k8s.FileInfo{
+		{Name: "config", IsDir: true, Permissions: "drwxr-xr-x"},
+		{Name: "app.log", Permissions: "-rw-r--r--"},
+	})
+
+	view := m.View()
+	if !strings.Contains(view, "Directory loaded, item 1 of 2") {
+		t.Errorf("expected verbose status, got:\n%s", view)
+	}
+	if strings.Contains(view, "[READY]") {
+		t.Error("verbose mode should not use bracketed indicators")
+	}
+}
+
+func TestFileBrowserModel_Bookmarks(t *testing.T) {
+	m := NewFileBrowserModel()
+	m.SetSize(80, 24)
+	m.SetCurrentPath("/var/log")
+
+	if m.OpenBookmarks() {
+		t.Error("the picker should not open without bookmarks")
+	}
+
+	// Add, then remove by toggling again
+	if !m.ToggleBookmark() || !m.IsBookmarked() {
+		t.Fatal("expected /var/log bookmarked")
+	}
+	if m.ToggleBookmark() || m.IsBookmarked() {
+		t.Fatal("toggling again should remove the bookmark")
+	}
+
+	m.SetBookmarks([]string{"/var/log", "/etc/app"})
+	if !m.OpenBookmarks() || !m.ShowingBookmarks() {
+		t.Fatal("expected the picker open")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if path, ok := m.SelectedBookmark(); !ok || path != "/etc/app" {
+		t.Errorf("expected /etc/app highlighted, got %q", path)
+	}
+
+	// Jump keeps the old directory in the history
+	m.CloseBookmarks()
+	m.JumpTo("/etc/app")
+	if m.CurrentPath() != "/etc/app" {
+		t.Errorf("expected /etc/app, got %s", m.CurrentPath())
+	}
+	if !m.PopPath() || m.CurrentPath() != "/var/log" {
+		t.Errorf("expected backspace history to return to /var/log, got %s", m.CurrentPath())
+	}
+
+	// Removing the last bookmarks closes the picker
+	m.OpenBookmarks()
+	for _, want := range []string{"/var/log", "/etc/app"} {
+		if removed, ok := m.RemoveSelectedBookmark(); !ok || removed != want {
+			t.Errorf("expected %s removed, got %q", want, removed)
+		}
+	}
+	if m.ShowingBookmarks() || len(m.Bookmarks()) != 0 {
+		t.Errorf("expected an empty, closed picker, got %v", m.Bookmarks())
+	}
+}
+
+func TestFileBrowserModel_BookmarksKeptOnClear(t *testing.T) {
+	m := NewFileBrowserModel()
+	m.SetBookmarks([]string{"/var/log"})
+	m.OpenBookmarks()
+
+	m.Clear()
+	if m.ShowingBookmarks() || len(m.Bookmarks()) != 1 {
+		t.Errorf("clearing should close the picker but keep bookmarks, got %v", m.Bookmarks())
+	}
+}
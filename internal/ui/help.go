@@ -0,0 +1,269 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+
+	"github.com/maxime/k8s-tui/internal/model"
+)
+
+// ViewHelp lists the keys of one view. It implements help.KeyMap, so the
+// help overlay renders it directly, and Footer formats the most used keys
+// for the view's own hint line.
+type ViewHelp struct {
+	Title  string
+	Short  []key.Binding   // Most used keys, shown in the view's footer
+	Groups [][]key.Binding // Every key of the view, shown in the help overlay
+}
+
+// ShortHelp returns the most used keys of the view
+func (h ViewHelp) ShortHelp() []key.Binding {
+	return h.Short
+}
+
+// FullHelp returns every key of the view, grouped
+func (h ViewHelp) FullHelp() [][]key.Binding {
+	return h.Groups
+}
+
+// Footer formats the short help as "key: action | key: action"
+func (h ViewHelp) Footer() string {
+	return formatBindings(h.Short...)
+}
+
+// formatBindings joins the help of enabled bindings for a hint line
+func formatBindings(bindings ...key.Binding) string {
+	parts := make([]string, 0, len(bindings))
+	for _, b := range bindings {
+		if !b.Enabled() {
+			continue
+		}
+		help := b.Help()
+		parts = append(parts, help.Key+": "+help.Desc)
+	}
+	return strings.Join(parts, " | ")
+}
+
+// fixedBinding describes a key a component handles itself. These keys
+// can't be remapped: a focused input or viewport owns the keyboard.
+func fixedBinding(help, desc string, keys ...string) key.Binding {
+	return key.NewBinding(key.WithKeys(keys...), key.WithHelp(help, desc))
+}
+
+// scrollKeys move through the file browser and the text viewers
+var scrollKeys = struct {
+	Up, Down, Top, Bottom, PageUp, PageDown key.Binding
+}{
+	Up:       fixedBinding("k/up", "up", "k", "up"),
+	Down:     fixedBinding("j/down", "down", "j", "down"),
+	Top:      fixedBinding("g", "top", "g"),
+	Bottom:   fixedBinding("G", "bottom", "G"),
+	PageUp:   fixedBinding("pgup", "page up", "pgup"),
+	PageDown: fixedBinding("pgdn/space", "page down", "pgdown", " "),
+}
+
+// execKeys are handled by the exec view and its command line
+var execKeys = struct {
+	Run, History, Scroll, Focus, Presets, Debug, Interrupt, Clear, Copy key.Binding
+}{
+	Run:       fixedBinding("Enter", "run command", "enter"),
+	History:   fixedBinding("Up/Down", "history", "up", "down"),
+	Scroll:    fixedBinding("PgUp/PgDn", "scroll output", "pgup", "pgdown"),
+	Focus:     fixedBinding("Tab", "switch focus", "tab"),
+	Presets:   fixedBinding("Ctrl+P", "presets", "ctrl+p"),
+	Debug:     fixedBinding("Ctrl+T", "debug container", "ctrl+t"),
+	Interrupt: fixedBinding("Ctrl+C", "interrupt", "ctrl+c"),
+	Clear:     fixedBinding("Ctrl+L", "clear output", "ctrl+l"),
+	Copy:      fixedBinding("Ctrl+Y", "copy output", "ctrl+y"),
+}
+
+// fileKeys are handled by the file browser listing
+var fileKeys = struct {
+	Open, Filter, ClearFilter, Sort, Parent, GoTo, Bookmark, Bookmarks, RemoveBookmark key.Binding
+}{
+	Open:           fixedBinding("Enter", "open", "enter"),
+	Filter:         fixedBinding("/", "filter", "/"),
+	ClearFilter:    fixedBinding("Esc", "clear filter", "esc"),
+	Sort:           fixedBinding("s", "sort", "s"),
+	Parent:         fixedBinding("Backspace", "parent", "backspace"),
+	GoTo:           fixedBinding(":", "go to path", ":"),
+	Bookmark:       fixedBinding("b", "bookmark directory", "b"),
+	Bookmarks:      fixedBinding("B", "bookmarks", "B"),
+	RemoveBookmark: fixedBinding("d", "remove bookmark", "d"),
+}
+
+// topKeys are handled by the top view
+var topKeys = struct {
+	Sort key.Binding
+}{
+	Sort: fixedBinding("s", "sort by CPU/memory", "s"),
+}
+
+// envKeys are handled by the environment view
+var envKeys = struct {
+	Source key.Binding
+}{
+	Source: fixedBinding("d", "runtime/declared", "d"),
+}
+
+// confirmKeys answer the confirmation overlay
+var confirmKeys = struct {
+	Yes, No, Cancel key.Binding
+}{
+	Yes:    fixedBinding("y", "yes", "y", "Y"),
+	No:     fixedBinding("n", "no", "n", "N"),
+	Cancel: fixedBinding("esc", "cancel", "esc"),
+}
+
+// HelpForView returns the keys active in view v. Views without keys of
+// their own get the pod list's.
+func (k KeyMap) HelpForView(v model.ViewState) ViewHelp {
+	general := []key.Binding{k.Help, k.Back, k.Quit}
+
+	switch v {
+	case model.ViewLogs:
+		return ViewHelp{
+			Title: "Log view",
+			Short: []key.Binding{k.Up, k.Down, k.GotoTop, k.GotoEnd, k.Follow, k.Search, k.Back},
+			Groups: [][]key.Binding{
+				{k.Up, k.Down, k.PageUp, k.PageDown, k.GotoTop, k.GotoEnd, k.ScrollLeft, k.ScrollRight},
+				{k.Follow, k.Pause, k.Tail, k.Wrap, k.PrettyJSON, k.LevelColors, k.AllContainers},
+				{k.Search, k.NextMatch, k.PrevMatch, k.Copy, k.CopyKubectl, k.Pager},
+				{k.SplitPrevious, k.SplitNarrower, k.SplitWider},
+				general,
+			},
+		}
+
+	case model.ViewExec:
+		// The command line takes printable keys, so ? and q are typed
+		return ViewHelp{
+			Title: "Exec",
+			Short: []key.Binding{execKeys.Run, execKeys.History, execKeys.Focus, execKeys.Presets,
+				execKeys.Debug, k.Back},
+			Groups: [][]key.Binding{
+				{execKeys.Run, execKeys.History, execKeys.Scroll, execKeys.Focus},
+				{execKeys.Clear, execKeys.Copy},
+				{execKeys.Presets, execKeys.Debug, execKeys.Interrupt},
+				{k.Back},
+			},
+		}
+
+	case model.ViewFiles:
+		return ViewHelp{
+			Title: "File browser",
+			Short: []key.Binding{fileKeys.Open, fileKeys.Filter, fileKeys.Sort, fileKeys.Parent, k.Back},
+			Groups: [][]key.Binding{
+				{scrollKeys.Up, scrollKeys.Down, scrollKeys.Top, scrollKeys.Bottom, scrollKeys.PageUp, scrollKeys.PageDown},
+				{fileKeys.Open, fileKeys.Parent, fileKeys.GoTo, fileKeys.Filter, fileKeys.ClearFilter, fileKeys.Sort, k.Edit, k.CopyKubectl},
+				{fileKeys.Bookmark, fileKeys.Bookmarks, fileKeys.RemoveBookmark},
+				general,
+			},
+		}
+
+	case model.ViewDeployments:
+		return ViewHelp{
+			Title: "Deployments",
+			Short: []key.Binding{k.Logs, k.Scale, k.Restart, k.Deployments, k.Refresh},
+			Groups: [][]key.Binding{
+				{k.Up, k.Down},
+				{k.Logs, k.Scale, k.Restart, k.CopyKubectl, k.Refresh},
+				{k.Deployments, k.Namespace, k.Context},
+				general,
+			},
+		}
+
+	case model.ViewTop:
+		return ViewHelp{
+			Title: "Top",
+			Short: []key.Binding{topKeys.Sort, k.Refresh, k.Back},
+			Groups: [][]key.Binding{
+				{k.Up, k.Down},
+				{topKeys.Sort, k.Refresh, k.Top},
+				{k.Namespace},
+				general,
+			},
+		}
+
+	case model.ViewEnv:
+		return ViewHelp{
+			Title:  "Environment",
+			Short:  []key.Binding{envKeys.Source, k.Reveal, k.Back},
+			Groups: [][]key.Binding{{k.Up, k.Down}, {envKeys.Source, k.Reveal}, general},
+		}
+
+	case model.ViewConfirm:
+		keys := []key.Binding{confirmKeys.Yes, confirmKeys.No, confirmKeys.Cancel}
+		return ViewHelp{
+			Title:  "Confirm",
+			Short:  keys,
+			Groups: [][]key.Binding{keys},
+		}
+
+	case model.ViewServices:
+		return ViewHelp{
+			Title:  "Services",
+			Short:  []key.Binding{k.Enter, k.Refresh, k.Back},
+			Groups: [][]key.Binding{{k.Up, k.Down, k.Enter}, {k.Services, k.Refresh}, general},
+		}
+
+	case model.ViewEvents:
+		return ViewHelp{
+			Title:  "Events",
+			Short:  []key.Binding{k.AllEvents, k.Back},
+			Groups: [][]key.Binding{{k.Up, k.Down}, {k.AllEvents}, general},
+		}
+
+	case model.ViewConfigMaps, model.ViewSecrets:
+		return ViewHelp{
+			Title:  v.String(),
+			Short:  []key.Binding{k.Enter, k.Reveal, k.Back},
+			Groups: [][]key.Binding{{k.Up, k.Down, k.Enter}, {k.Reveal}, general},
+		}
+
+	case model.ViewContextSelector:
+		return ViewHelp{
+			Title:  "Context selector",
+			Short:  []key.Binding{k.Enter, k.Reload, k.Back},
+			Groups: [][]key.Binding{{k.Up, k.Down, k.Enter}, {k.Reload}, general},
+		}
+
+	case model.ViewContainerSelector:
+		return ViewHelp{
+			Title:  "Container selector",
+			Short:  []key.Binding{k.Enter, k.Back},
+			Groups: [][]key.Binding{{k.Up, k.Down, k.Enter}, general},
+		}
+
+	case model.ViewAbout:
+		return ViewHelp{
+			Title:  "Connection info",
+			Short:  []key.Binding{k.Back},
+			Groups: [][]key.Binding{general},
+		}
+
+	case model.ViewNamespaceSelector:
+		return ViewHelp{
+			Title:  "Namespace selector",
+			Short:  []key.Binding{k.Enter, k.Back},
+			Groups: [][]key.Binding{{k.Up, k.Down, k.Enter}, general},
+		}
+
+	case model.ViewYAML:
+		return ViewHelp{
+			Title: "YAML",
+			Short: []key.Binding{scrollKeys.Up, scrollKeys.Down, k.Back},
+			Groups: [][]key.Binding{
+				{scrollKeys.Up, scrollKeys.Down, scrollKeys.Top, scrollKeys.Bottom, scrollKeys.PageUp, scrollKeys.PageDown},
+				general,
+			},
+		}
+
+	default:
+		return ViewHelp{
+			Title:  "Pod list",
+			Short:  k.ShortHelp(),
+			Groups: k.FullHelp(),
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+
+	"github.com/maxime/k8s-tui/internal/model"
+)
+
+func TestHelpForView_EveryViewHasKeys(t *testing.T) {
+	km := DefaultKeyMap()
+	for v := model.ViewPodList; v <= model.ViewEnv; v++ {
+		h := km.HelpForView(v)
+		if h.Title == "" || len(h.ShortHelp()) == 0 || len(h.FullHelp()) == 0 {
+			t.Errorf("%v: expected a title, short and full help, got %+v", v, h)
+		}
+		for _, group := range h.FullHelp() {
+			for _, b := range group {
+				if b.Help().Key == "" || b.Help().Desc == "" {
+					t.Errorf("%v: binding %v has no help text", v, b.Keys())
+				}
+			}
+		}
+	}
+}
+
+func TestHelpForView_PodListUsesKeyMap(t *testing.T) {
+	km := DefaultKeyMap()
+	h := km.HelpForView(model.ViewPodList)
+	if len(h.FullHelp()) != len(km.FullHelp()) || len(h.ShortHelp()) != len(km.ShortHelp()) {
+		t.Error("the pod list help should be the keymap's own help")
+	}
+}
+
+func TestHelpForView_LogView(t *testing.T) {
+	km := DefaultKeyMap()
+	h := km.HelpForView(model.ViewLogs)
+
+	var keys []string
+	for _, group := range h.FullHelp() {
+		for _, b := range group {
+			keys = append(keys, b.Help().Desc)
+		}
+	}
+	all := strings.Join(keys, ",")
+	for _, want := range []string{"follow", "pause/resume", "search", "next match", "wrap lines", "scroll left"} {
+		if !strings.Contains(all, want) {
+			t.Errorf("log view help should include %q, got %s", want, all)
+		}
+	}
+	if strings.Contains(all, "files") {
+		t.Error("log view help should not list pod list keys")
+	}
+}
+
+func TestViewHelp_FooterFollowsRemappedKeys(t *testing.T) {
+	km, err := ApplyKeyOverrides(DefaultKeyMap(), map[string][]string{"follow": {"F"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	footer := km.HelpForView(model.ViewLogs).Footer()
+	if !strings.Contains(footer, "F: follow") {
+		t.Errorf("footer should show the remapped key, got %q", footer)
+	}
+	if strings.Contains(footer, "f: follow") {
+		t.Errorf("footer should not show the default key, got %q", footer)
+	}
+}
+
+func TestFormatBindings(t *testing.T) {
+	disabled := key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "hidden"))
+	disabled.SetEnabled(false)
+
+	got := formatBindings(execKeys.Focus, disabled, execKeys.Presets)
+	if want := "Tab: switch focus | Ctrl+P: presets"; got != want {
+		t.Errorf("formatBindings() = %q, want %q", got, want)
+	}
+}
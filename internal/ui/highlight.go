@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/formatters"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+)
+
+// highlightStyle is the chroma color scheme used for file previews
+const highlightStyle = "monokai"
+
+// Highlight colors content with ANSI escape codes using a lexer chosen by
+// the file name's extension (.yaml, .json, .go, .sh...). Unknown types and
+// content over the preview cap are returned unchanged.
+func Highlight(filename, content string) string {
+	if len(content) > maxFilePreviewBytes {
+		return content
+	}
+
+	lexer := lexers.Match(filename)
+	if lexer == nil {
+		return content
+	}
+
+	iterator, err := chroma.Coalesce(lexer).Tokenise(nil, content)
+	if err != nil {
+		return content
+	}
+
+	var b strings.Builder
+	if err := formatters.TTY256.Format(&b, styles.Get(highlightStyle), iterator); err != nil {
+		return content
+	}
+	return b.String()
+}
@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// ansiPattern matches SGR color escape sequences
+var ansiPattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+func TestHighlight(t *testing.T) {
+	content := `{"name": "web", "replicas": 3}`
+
+	got := Highlight("config.json", content)
+	if !strings.Contains(got, "\x1b[") {
+		t.Errorf("expected ANSI escapes for a .json file, got %q", got)
+	}
+	if stripped := ansiPattern.ReplaceAllString(got, ""); stripped != content {
+		t.Errorf("highlighting should only add color, got %q", stripped)
+	}
+}
+
+func TestHighlight_UnknownType(t *testing.T) {
+	content := "2024-01-01 started\n"
+
+	if got := Highlight("app.unknownext", content); got != content {
+		t.Errorf("unknown types should be returned unchanged, got %q", got)
+	}
+}
+
+func TestHighlight_OverPreviewCap(t *testing.T) {
+	content := strings.Repeat("a: b\n", maxFilePreviewBytes/5+1)
+
+	if got := Highlight("big.yaml", content); got != content {
+		t.Error("content over the preview cap should not be highlighted")
+	}
+}
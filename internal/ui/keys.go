@@ -1,30 +1,91 @@
 package ui
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+
+	"github.com/maxime/k8s-tui/internal/config"
+)
 
 // KeyMap defines all keybindings for the application
 type KeyMap struct {
 	// Navigation
-	Up    key.Binding
-	Down  key.Binding
-	Enter key.Binding
+	Up     key.Binding
+	Down   key.Binding
+	Enter  key.Binding
+	Find   key.Binding
+	JumpTo key.Binding
 
 	// Actions
-	Logs    key.Binding
-	Exec    key.Binding
-	Files   key.Binding
-	Refresh key.Binding
+	Logs        key.Binding
+	Exec        key.Binding
+	Files       key.Binding
+	YAML        key.Binding
+	Env         key.Binding
+	Events      key.Binding
+	Top         key.Binding
+	Bundle      key.Binding
+	Copy        key.Binding
+	CopyKubectl key.Binding
+	Refresh     key.Binding
+	Reload      key.Binding
+
+	// Filters
+	HideCompleted key.Binding
+	OwnerColumn   key.Binding
+	ImageColumn   key.Binding
+	GroupByOwner  key.Binding
+	NodeColumns   key.Binding
+	NodeFilter    key.Binding
+	LabelSelector key.Binding
+
+	// Deployments
+	Deployments key.Binding
+	Scale       key.Binding
+	Restart     key.Binding
 
 	// Selectors
-	Namespace key.Binding
-	Context   key.Binding
+	Namespace     key.Binding
+	AllNamespaces key.Binding
+	Context       key.Binding
+	About         key.Binding
+
+	// Services, ConfigMaps and Secrets
+	Services   key.Binding
+	ConfigMaps key.Binding
+	Secrets    key.Binding
+	Reveal     key.Binding
+
+	// Events view specific
+	AllEvents key.Binding
 
 	// Log view specific
-	Follow   key.Binding
-	GotoTop  key.Binding
-	GotoEnd  key.Binding
-	PageUp   key.Binding
-	PageDown key.Binding
+	Follow        key.Binding
+	Pause         key.Binding
+	Tail          key.Binding
+	GotoTop       key.Binding
+	GotoEnd       key.Binding
+	PageUp        key.Binding
+	PageDown      key.Binding
+	Search        key.Binding
+	NextMatch     key.Binding
+	PrevMatch     key.Binding
+	AllContainers key.Binding
+	Wrap          key.Binding
+	PrettyJSON    key.Binding
+	LevelColors   key.Binding
+	ScrollLeft    key.Binding
+	ScrollRight   key.Binding
+	Pager         key.Binding
+	SplitPrevious key.Binding
+	SplitNarrower key.Binding
+	SplitWider    key.Binding
+
+	// File browser specific
+	Edit key.Binding
 
 	// General
 	Help key.Binding
@@ -47,6 +108,14 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("enter"),
 			key.WithHelp("enter", "select"),
 		),
+		Find: key.NewBinding(
+			key.WithKeys("ctrl+f"),
+			key.WithHelp("ctrl+f", "search all"),
+		),
+		JumpTo: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "jump to pod"),
+		),
 		Logs: key.NewBinding(
 			key.WithKeys("l"),
 			key.WithHelp("l", "logs"),
@@ -59,22 +128,130 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("f"),
 			key.WithHelp("f", "files"),
 		),
+		YAML: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "yaml"),
+		),
+		Env: key.NewBinding(
+			key.WithKeys("E"),
+			key.WithHelp("E", "environment"),
+		),
+		Events: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "events"),
+		),
+		Top: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", "top (resource usage)"),
+		),
+		Bundle: key.NewBinding(
+			key.WithKeys("b"),
+			key.WithHelp("b", "bundle"),
+		),
+		Copy: key.NewBinding(
+			key.WithKeys("Y"),
+			key.WithHelp("Y", "copy"),
+		),
+		CopyKubectl: key.NewBinding(
+			key.WithKeys("K"),
+			key.WithHelp("K", "copy kubectl command"),
+		),
 		Refresh: key.NewBinding(
 			key.WithKeys("r"),
 			key.WithHelp("r", "refresh"),
 		),
+		Reload: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "reload all"),
+		),
+		HideCompleted: key.NewBinding(
+			key.WithKeys("h"),
+			key.WithHelp("h", "hide completed"),
+		),
+		OwnerColumn: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "owner column"),
+		),
+		ImageColumn: key.NewBinding(
+			key.WithKeys("I"),
+			key.WithHelp("I", "image column"),
+		),
+		GroupByOwner: key.NewBinding(
+			key.WithKeys("O"),
+			key.WithHelp("O", "group by owner"),
+		),
+		NodeColumns: key.NewBinding(
+			key.WithKeys("W"),
+			key.WithHelp("W", "node/IP columns"),
+		),
+		NodeFilter: key.NewBinding(
+			key.WithKeys("N"),
+			key.WithHelp("N", "filter by node"),
+		),
+		LabelSelector: key.NewBinding(
+			key.WithKeys("F"),
+			key.WithHelp("F", "label selector"),
+		),
+		Deployments: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", "pods/deployments"),
+		),
+		Scale: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "scale"),
+		),
+		Restart: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "restart"),
+		),
 		Namespace: key.NewBinding(
 			key.WithKeys("n"),
 			key.WithHelp("n", "namespace"),
 		),
+		AllNamespaces: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", "all namespaces"),
+		),
 		Context: key.NewBinding(
 			key.WithKeys("c"),
 			key.WithHelp("c", "context"),
 		),
+		About: key.NewBinding(
+			key.WithKeys("i"),
+			key.WithHelp("i", "connection info"),
+		),
+		Services: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "services"),
+		),
+		ConfigMaps: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", "configmaps"),
+		),
+		Secrets: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "secrets"),
+		),
+		Reveal: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "reveal secret"),
+		),
+		AllEvents: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "pod/namespace events"),
+		),
 		Follow: key.NewBinding(
 			key.WithKeys("f", "F"),
 			key.WithHelp("f", "follow"),
 		),
+		Pause: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "pause/resume"),
+		),
+		Tail: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "tail length"),
+		),
 		GotoTop: key.NewBinding(
 			key.WithKeys("g"),
 			key.WithHelp("g", "top"),
@@ -91,6 +268,62 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("pgdown", " "),
 			key.WithHelp("pgdn", "page down"),
 		),
+		Search: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "search"),
+		),
+		NextMatch: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "next match"),
+		),
+		PrevMatch: key.NewBinding(
+			key.WithKeys("N"),
+			key.WithHelp("N", "previous match"),
+		),
+		AllContainers: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "all containers"),
+		),
+		Wrap: key.NewBinding(
+			key.WithKeys("w"),
+			key.WithHelp("w", "wrap lines"),
+		),
+		PrettyJSON: key.NewBinding(
+			key.WithKeys("J"),
+			key.WithHelp("J", "pretty JSON"),
+		),
+		LevelColors: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("L", "level colors"),
+		),
+		ScrollLeft: key.NewBinding(
+			key.WithKeys("left", "h"),
+			key.WithHelp("←/h", "scroll left"),
+		),
+		ScrollRight: key.NewBinding(
+			key.WithKeys("right", "l"),
+			key.WithHelp("→/l", "scroll right"),
+		),
+		Pager: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "open in pager"),
+		),
+		SplitPrevious: key.NewBinding(
+			key.WithKeys("P"),
+			key.WithHelp("P", "split with previous logs"),
+		),
+		SplitNarrower: key.NewBinding(
+			key.WithKeys("<"),
+			key.WithHelp("<", "narrow left pane"),
+		),
+		SplitWider: key.NewBinding(
+			key.WithKeys(">"),
+			key.WithHelp(">", "widen left pane"),
+		),
+		Edit: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "edit file"),
+		),
 		Help: key.NewBinding(
 			key.WithKeys("?"),
 			key.WithHelp("?", "help"),
@@ -114,9 +347,189 @@ func (k KeyMap) ShortHelp() []key.Binding {
 // FullHelp returns keybindings for the expanded help view
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Up, k.Down, k.Enter},             // Navigation
-		{k.Logs, k.Exec, k.Files},           // Actions
-		{k.Namespace, k.Context, k.Refresh}, // Management
-		{k.Help, k.Back, k.Quit},            // General
+		{k.Up, k.Down, k.Enter, k.Find, k.JumpTo},                                                    // Navigation
+		{k.Logs, k.Exec, k.Files, k.YAML, k.Env, k.Events, k.Top, k.Bundle, k.Copy, k.CopyKubectl},   // Actions
+		{k.Namespace, k.AllNamespaces, k.Context, k.About, k.Refresh, k.Reload, k.HideCompleted},     // Management
+		{k.OwnerColumn, k.ImageColumn, k.GroupByOwner, k.NodeColumns, k.NodeFilter, k.LabelSelector}, // Display
+		{k.Deployments, k.Scale, k.Restart},                                                          // Deployments
+		{k.Services, k.ConfigMaps, k.Secrets, k.Reveal},                                              // Services, ConfigMaps and Secrets
+		{k.Help, k.Back, k.Quit},                                                                     // General
+	}
+}
+
+// ASCIIHelp returns a copy of the keymap whose help text spells out arrow
+// glyphs, for terminals and screen readers that don't render them
+func (k KeyMap) ASCIIHelp() KeyMap {
+	replacer := strings.NewReplacer("↑", "up", "↓", "down", "←", "left", "→", "right")
+	result := k
+	for _, binding := range result.bindings() {
+		help := binding.Help()
+		binding.SetHelp(replacer.Replace(help.Key), help.Desc)
+	}
+	return result
+}
+
+// ReadOnly returns a copy of the keymap with the keys that change the
+// cluster disabled, so they neither match nor show in help. Exec goes too,
+// since a shell can change anything in the container.
+func (k KeyMap) ReadOnly() KeyMap {
+	result := k
+	for _, binding := range []*key.Binding{&result.Exec, &result.Edit, &result.Scale, &result.Restart} {
+		binding.SetEnabled(false)
+	}
+	return result
+}
+
+// LoadKeyMap reads keybinding overrides from the config file at path and
+// applies them on top of DefaultKeyMap. A missing file yields the defaults.
+func LoadKeyMap(path string) (KeyMap, error) {
+	cfg, err := config.Load(path)
+	if err != nil {
+		return DefaultKeyMap(), err
+	}
+	return ApplyKeyOverrides(DefaultKeyMap(), cfg.Keys)
+}
+
+// ApplyKeyOverrides replaces the keys of the named actions and validates
+// the result. On error the unmodified keymap is returned.
+func ApplyKeyOverrides(k KeyMap, overrides map[string][]string) (KeyMap, error) {
+	result := k
+	bindings := result.bindings()
+
+	// Sorted for deterministic error messages
+	actions := make([]string, 0, len(overrides))
+	for action := range overrides {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+
+	for _, action := range actions {
+		keys := overrides[action]
+		binding, ok := bindings[action]
+		if !ok {
+			return k, fmt.Errorf("unknown key action %q", action)
+		}
+		if len(keys) == 0 {
+			return k, fmt.Errorf("no keys given for action %q", action)
+		}
+		binding.SetKeys(keys...)
+		binding.SetHelp(strings.Join(keys, "/"), binding.Help().Desc)
+	}
+
+	if err := result.Validate(); err != nil {
+		return k, err
+	}
+	return result, nil
+}
+
+// keyScopes lists the actions that are active at the same time. A key may
+// be reused across scopes, e.g. f opens files in the pod list and toggles
+// follow in the log view.
+var keyScopes = map[string][]string{
+	"pod list": {"up", "down", "enter", "find", "jumpTo", "logs", "exec", "files", "yaml", "env", "events", "top", "bundle", "copy", "copyKubectl",
+		"refresh", "reload", "hideCompleted", "ownerColumn", "imageColumn", "groupByOwner", "nodeColumns", "nodeFilter",
+		"labelSelector", "deployments", "services", "configMaps", "secrets", "namespace", "allNamespaces", "context", "about", "restart", "help", "back", "quit"},
+	"deployments view": {"up", "down", "logs", "deployments", "scale", "restart", "copyKubectl", "refresh", "namespace",
+		"context", "help", "back", "quit"},
+	"services view":    {"up", "down", "enter", "services", "refresh", "help", "back", "quit"},
+	"top view":         {"up", "down", "top", "refresh", "namespace", "help", "back", "quit"},
+	"context selector": {"up", "down", "enter", "reload", "help", "back", "quit"},
+	"events view":      {"up", "down", "allEvents", "help", "back", "quit"},
+	"env view":         {"up", "down", "reveal", "help", "back", "quit"},
+	"data browser":     {"up", "down", "enter", "reveal", "help", "back", "quit"},
+	"log view": {"up", "down", "follow", "pause", "tail", "gotoTop", "gotoEnd", "pageUp", "pageDown",
+		"search", "nextMatch", "prevMatch", "allContainers", "wrap", "prettyJSON", "levelColors", "scrollLeft", "scrollRight", "copy",
+		"copyKubectl", "pager", "splitPrevious", "splitNarrower", "splitWider", "help", "back", "quit"},
+	"file viewer": {"edit", "copyKubectl", "help", "back", "quit"},
+}
+
+// Validate reports an error if two actions in the same view share a key
+func (k KeyMap) Validate() error {
+	bindings := k.bindings()
+
+	scopes := make([]string, 0, len(keyScopes))
+	for scope := range keyScopes {
+		scopes = append(scopes, scope)
+	}
+	sort.Strings(scopes)
+
+	for _, scope := range scopes {
+		owners := make(map[string]string)
+		for _, action := range keyScopes[scope] {
+			for _, keyName := range bindings[action].Keys() {
+				if other, taken := owners[keyName]; taken {
+					return fmt.Errorf("key %q is bound to both %s and %s in the %s",
+						keyName, other, action, scope)
+				}
+				owners[keyName] = action
+			}
+		}
+	}
+	return nil
+}
+
+// bindings maps config action names to the keymap's bindings
+func (k *KeyMap) bindings() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"up":            &k.Up,
+		"down":          &k.Down,
+		"enter":         &k.Enter,
+		"find":          &k.Find,
+		"jumpTo":        &k.JumpTo,
+		"logs":          &k.Logs,
+		"exec":          &k.Exec,
+		"files":         &k.Files,
+		"yaml":          &k.YAML,
+		"env":           &k.Env,
+		"events":        &k.Events,
+		"top":           &k.Top,
+		"bundle":        &k.Bundle,
+		"copy":          &k.Copy,
+		"copyKubectl":   &k.CopyKubectl,
+		"refresh":       &k.Refresh,
+		"reload":        &k.Reload,
+		"hideCompleted": &k.HideCompleted,
+		"ownerColumn":   &k.OwnerColumn,
+		"imageColumn":   &k.ImageColumn,
+		"groupByOwner":  &k.GroupByOwner,
+		"nodeColumns":   &k.NodeColumns,
+		"nodeFilter":    &k.NodeFilter,
+		"labelSelector": &k.LabelSelector,
+		"deployments":   &k.Deployments,
+		"scale":         &k.Scale,
+		"restart":       &k.Restart,
+		"namespace":     &k.Namespace,
+		"allNamespaces": &k.AllNamespaces,
+		"context":       &k.Context,
+		"about":         &k.About,
+		"services":      &k.Services,
+		"configMaps":    &k.ConfigMaps,
+		"secrets":       &k.Secrets,
+		"reveal":        &k.Reveal,
+		"allEvents":     &k.AllEvents,
+		"follow":        &k.Follow,
+		"pause":         &k.Pause,
+		"tail":          &k.Tail,
+		"gotoTop":       &k.GotoTop,
+		"gotoEnd":       &k.GotoEnd,
+		"pageUp":        &k.PageUp,
+		"pageDown":      &k.PageDown,
+		"search":        &k.Search,
+		"nextMatch":     &k.NextMatch,
+		"prevMatch":     &k.PrevMatch,
+		"allContainers": &k.AllContainers,
+		"wrap":          &k.Wrap,
+		"prettyJSON":    &k.PrettyJSON,
+		"levelColors":   &k.LevelColors,
+		"scrollLeft":    &k.ScrollLeft,
+		"scrollRight":   &k.ScrollRight,
+		"pager":         &k.Pager,
+		"splitPrevious": &k.SplitPrevious,
+		"splitNarrower": &k.SplitNarrower,
+		"splitWider":    &k.SplitWider,
+		"edit":          &k.Edit,
+		"help":          &k.Help,
+		"back":          &k.Back,
+		"quit":          &k.Quit,
 	}
 }
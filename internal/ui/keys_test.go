@@ -1,7 +1,14 @@
 package ui
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+
+	"github.com/maxime/k8s-tui/internal/model"
 )
 
 func TestDefaultKeyMap(t *testing.T) {
@@ -16,12 +23,43 @@ func TestDefaultKeyMap(t *testing.T) {
 		{"Up", []string{"k", "up"}, func() []string { return km.Up.Keys() }},
 		{"Down", []string{"j", "down"}, func() []string { return km.Down.Keys() }},
 		{"Enter", []string{"enter"}, func() []string { return km.Enter.Keys() }},
+		{"JumpTo", []string{"/"}, func() []string { return km.JumpTo.Keys() }},
 		{"Logs", []string{"l"}, func() []string { return km.Logs.Keys() }},
 		{"Exec", []string{"e"}, func() []string { return km.Exec.Keys() }},
 		{"Files", []string{"f"}, func() []string { return km.Files.Keys() }},
+		{"YAML", []string{"y"}, func() []string { return km.YAML.Keys() }},
+		{"Env", []string{"E"}, func() []string { return km.Env.Keys() }},
+		{"Top", []string{"T"}, func() []string { return km.Top.Keys() }},
+		{"Bundle", []string{"b"}, func() []string { return km.Bundle.Keys() }},
+		{"Copy", []string{"Y"}, func() []string { return km.Copy.Keys() }},
+		{"CopyKubectl", []string{"K"}, func() []string { return km.CopyKubectl.Keys() }},
+		{"Pause", []string{"p"}, func() []string { return km.Pause.Keys() }},
+		{"Tail", []string{"t"}, func() []string { return km.Tail.Keys() }},
+		{"Wrap", []string{"w"}, func() []string { return km.Wrap.Keys() }},
+		{"PrettyJSON", []string{"J"}, func() []string { return km.PrettyJSON.Keys() }},
+		{"LevelColors", []string{"L"}, func() []string { return km.LevelColors.Keys() }},
+		{"Pager", []string{"v"}, func() []string { return km.Pager.Keys() }},
+		{"SplitPrevious", []string{"P"}, func() []string { return km.SplitPrevious.Keys() }},
+		{"SplitNarrower", []string{"<"}, func() []string { return km.SplitNarrower.Keys() }},
+		{"SplitWider", []string{">"}, func() []string { return km.SplitWider.Keys() }},
+		{"ScrollLeft", []string{"left", "h"}, func() []string { return km.ScrollLeft.Keys() }},
+		{"ScrollRight", []string{"right", "l"}, func() []string { return km.ScrollRight.Keys() }},
+		{"Edit", []string{"e"}, func() []string { return km.Edit.Keys() }},
+		{"NodeColumns", []string{"W"}, func() []string { return km.NodeColumns.Keys() }},
+		{"ImageColumn", []string{"I"}, func() []string { return km.ImageColumn.Keys() }},
+		{"NodeFilter", []string{"N"}, func() []string { return km.NodeFilter.Keys() }},
+		{"LabelSelector", []string{"F"}, func() []string { return km.LabelSelector.Keys() }},
+		{"Services", []string{"s"}, func() []string { return km.Services.Keys() }},
+		{"ConfigMaps", []string{"C"}, func() []string { return km.ConfigMaps.Keys() }},
+		{"Secrets", []string{"S"}, func() []string { return km.Secrets.Keys() }},
+		{"Reveal", []string{"v"}, func() []string { return km.Reveal.Keys() }},
 		{"Refresh", []string{"r"}, func() []string { return km.Refresh.Keys() }},
+		{"Reload", []string{"R"}, func() []string { return km.Reload.Keys() }},
+		{"HideCompleted", []string{"h"}, func() []string { return km.HideCompleted.Keys() }},
 		{"Namespace", []string{"n"}, func() []string { return km.Namespace.Keys() }},
+		{"AllNamespaces", []string{"A"}, func() []string { return km.AllNamespaces.Keys() }},
 		{"Context", []string{"c"}, func() []string { return km.Context.Keys() }},
+		{"About", []string{"i"}, func() []string { return km.About.Keys() }},
 		{"Help", []string{"?"}, func() []string { return km.Help.Keys() }},
 		{"Back", []string{"esc"}, func() []string { return km.Back.Keys() }},
 		{"Quit", []string{"q", "ctrl+c"}, func() []string { return km.Quit.Keys() }},
@@ -85,28 +123,32 @@ func TestKeyMap_FullHelp(t *testing.T) {
 	km := DefaultKeyMap()
 	fullHelp := km.FullHelp()
 
-	if len(fullHelp) != 4 {
-		t.Errorf("FullHelp should return 4 groups, got %d", len(fullHelp))
+	// Group 0: Navigation (Up, Down, Enter, Find, JumpTo)
+	// Group 1: Actions (Logs, Exec, Files, YAML, Env, Events, Top, Bundle, Copy, Copy kubectl command)
+	// Group 2: Management (Namespace, All namespaces, Context, About, Refresh, Reload, Hide completed)
+	// Group 3: Display (Owner column, Group by owner, Node/IP columns, Node filter)
+	// Group 4: Deployments (Toggle, Scale, Restart)
+	// Group 5: Services, ConfigMaps and Secrets (Services, ConfigMaps, Secrets, Reveal)
+	// Group 6: General (Help, Back, Quit)
+	expectedGroups := [][]string{
+		{"k", "j", "enter", "ctrl+f", "/"},
+		{"l", "e", "f", "y", "E", "v", "T", "b", "Y", "K"},
+		{"n", "A", "c", "i", "r", "R", "h"},
+		{"o", "I", "O", "W", "N", "F"},
+		{"d", "s", "x"},
+		{"s", "C", "S", "v"},
+		{"?", "esc", "q"},
 	}
 
-	// Verify each group has 3 bindings
-	for i, group := range fullHelp {
-		if len(group) != 3 {
-			t.Errorf("FullHelp group %d should have 3 bindings, got %d", i, len(group))
-		}
+	if len(fullHelp) != len(expectedGroups) {
+		t.Fatalf("FullHelp should return %d groups, got %d", len(expectedGroups), len(fullHelp))
 	}
 
-	// Verify group contents
-	// Group 0: Navigation (Up, Down, Enter)
-	// Group 1: Actions (Logs, Exec, Files)
-	// Group 2: Management (Namespace, Context, Refresh)
-	// Group 3: General (Help, Back, Quit)
-
-	expectedGroups := [][]string{
-		{"k", "j", "enter"},
-		{"l", "e", "f"},
-		{"n", "c", "r"},
-		{"?", "esc", "q"},
+	// Verify each group has the expected number of bindings
+	for i, group := range fullHelp {
+		if len(group) != len(expectedGroups[i]) {
+			t.Fatalf("FullHelp group %d should have %d bindings, got %d", i, len(expectedGroups[i]), len(group))
+		}
 	}
 
 	for i, group := range fullHelp {
@@ -154,3 +196,136 @@ func TestKeyMap_VimBindings(t *testing.T) {
 		})
 	}
 }
+
+func writeKeyConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	return path
+}
+
+func TestDefaultKeyMap_Valid(t *testing.T) {
+	if err := DefaultKeyMap().Validate(); err != nil {
+		t.Errorf("default keymap should not have collisions: %v", err)
+	}
+}
+
+func TestLoadKeyMap_Override(t *testing.T) {
+	path := writeKeyConfig(t, `
+keys:
+  logs: ["L"]
+  quit: ["Q", "ctrl+c"]
+`)
+
+	km, err := LoadKeyMap(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if keys := km.Logs.Keys(); len(keys) != 1 || keys[0] != "L" {
+		t.Errorf("expected logs bound to L, got %v", keys)
+	}
+	if km.Logs.Help().Key != "L" || km.Logs.Help().Desc != "logs" {
+		t.Errorf("help should show the new key and keep the description, got %+v", km.Logs.Help())
+	}
+	if km.Quit.Help().Key != "Q/ctrl+c" {
+		t.Errorf("expected quit help key Q/ctrl+c, got %q", km.Quit.Help().Key)
+	}
+
+	// Untouched bindings keep their defaults
+	if keys := km.Exec.Keys(); len(keys) != 1 || keys[0] != "e" {
+		t.Errorf("exec should keep default key, got %v", keys)
+	}
+}
+
+func TestLoadKeyMap_Collision(t *testing.T) {
+	path := writeKeyConfig(t, `
+keys:
+  logs: ["e"]
+`)
+
+	km, err := LoadKeyMap(path)
+	if err == nil {
+		t.Fatal("expected collision error")
+	}
+	if !strings.Contains(err.Error(), `"e"`) {
+		t.Errorf("error should name the colliding key, got %v", err)
+	}
+
+	// Defaults are returned on error
+	if keys := km.Logs.Keys(); len(keys) != 1 || keys[0] != "l" {
+		t.Errorf("expected default logs key on error, got %v", keys)
+	}
+}
+
+func TestLoadKeyMap_SameKeyInDifferentViews(t *testing.T) {
+	// Follow only applies in the log view, so it may share a key with exec
+	path := writeKeyConfig(t, `
+keys:
+  follow: ["e"]
+`)
+
+	if _, err := LoadKeyMap(path); err != nil {
+		t.Errorf("keys in different views should not collide: %v", err)
+	}
+}
+
+func TestLoadKeyMap_UnknownAction(t *testing.T) {
+	path := writeKeyConfig(t, `
+keys:
+  teleport: ["t"]
+`)
+
+	if _, err := LoadKeyMap(path); err == nil {
+		t.Error("expected error for unknown action")
+	}
+}
+
+func TestLoadKeyMap_MissingFile(t *testing.T) {
+	km, err := LoadKeyMap(filepath.Join(t.TempDir(), "missing.yaml"))
+	if err != nil {
+		t.Fatalf("missing file should not be an error, got %v", err)
+	}
+
+	if keys := km.Logs.Keys(); len(keys) != 1 || keys[0] != "l" {
+		t.Errorf("expected default keymap, got logs=%v", keys)
+	}
+}
+
+func TestKeyMap_ReadOnly(t *testing.T) {
+	km := DefaultKeyMap().ReadOnly()
+
+	for name, b := range map[string]key.Binding{"exec": km.Exec, "edit": km.Edit, "scale": km.Scale, "restart": km.Restart} {
+		if b.Enabled() {
+			t.Errorf("expected %s disabled in read-only mode", name)
+		}
+	}
+	if !km.Logs.Enabled() || !km.Deployments.Enabled() {
+		t.Error("expected read-only keys to stay enabled")
+	}
+	if footer := km.HelpForView(model.ViewDeployments).Footer(); strings.Contains(footer, "scale") {
+		t.Errorf("expected scale hidden from the footer, got %q", footer)
+	}
+
+	if !DefaultKeyMap().Scale.Enabled() {
+		t.Error("ReadOnly should not modify the default keymap")
+	}
+}
+
+func TestKeyMap_ASCIIHelp(t *testing.T) {
+	km := DefaultKeyMap().ASCIIHelp()
+
+	if km.Up.Help().Key != "up/k" || km.Down.Help().Key != "down/j" {
+		t.Errorf("expected arrows spelled out, got %q and %q", km.Up.Help().Key, km.Down.Help().Key)
+	}
+	if km.ScrollLeft.Help().Key != "left/h" || km.ScrollRight.Help().Key != "right/l" {
+		t.Errorf("expected arrows spelled out, got %q and %q", km.ScrollLeft.Help().Key, km.ScrollRight.Help().Key)
+	}
+
+	// The original keymap is not modified
+	if DefaultKeyMap().Up.Help().Key != "↑/k" {
+		t.Error("ASCIIHelp should not modify the default keymap")
+	}
+}
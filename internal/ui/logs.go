@@ -1,11 +1,22 @@
 package ui
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/maxime/k8s-tui/internal/config"
 )
 
 // LogViewState represents the state of the log streaming
@@ -37,6 +48,20 @@ func (s LogViewState) String() string {
 	}
 }
 
+// lagIndicatorTTL is how long the lag indicator stays up after the
+// buffer was last trimmed while following
+const lagIndicatorTTL = 5 * time.Second
+
+// previousBanner explains why the log view shows a previous instance
+const previousBanner = "Container is in CrashLoopBackOff; showing logs of its previous instance"
+
+// horizontalScrollStep is how many columns ScrollLeft and ScrollRight move
+const horizontalScrollStep = 10
+
+// lineRateAlpha weighs the last second in the line rate's moving average,
+// so the rate follows a log storm within a few seconds without jumping
+const lineRateAlpha = 0.4
+
 // LogViewModel represents the log viewing component
 type LogViewModel struct {
 	viewport viewport.Model
@@ -45,6 +70,33 @@ type LogViewModel struct {
 	lines        []string
 	maxLines     int
 	contentDirty bool
+	background   bool      // Buffer lines without rendering while another view is active
+	lastLagTrim  time.Time // When old lines were last trimmed while following
+
+	// rowStarts holds the viewport row each line starts on when wrapping
+	// or pretty-printing, since such a line spans several rows; nil means
+	// one row per line. lines always keeps the raw text.
+	wrap       bool
+	prettyJSON bool
+	rowStarts  []int
+
+	// hOffset is how many columns unwrapped lines are scrolled right by
+	hOffset int
+
+	// Lines arriving since rateSecond are counted in rateCount; each second
+	// that ends is folded into rate, a moving average in lines per second
+	rateSecond time.Time
+	rateCount  int
+	rate       float64
+
+	// While paused, lines keep buffering but the viewport stays put.
+	// pausedLines counts lines received since pausing, resumeFollow the
+	// follow mode to restore, and trimmedRows the rows trimmed from the
+	// top that the viewport offset must make up for.
+	paused       bool
+	pausedLines  int
+	resumeFollow bool
+	trimmedRows  int
 
 	// State
 	state     LogViewState
@@ -53,6 +105,30 @@ type LogViewModel struct {
 	container string
 	namespace string
 	errorMsg  string
+	tailLines int64 // How many existing lines the stream started with
+	previous  bool  // Showing the previous instance of a crash-looping container
+
+	// While split, the previous instance's logs show in a second pane to
+	// the right; splitRatio is the share of the width the live logs get
+	split      bool
+	splitRatio float64
+	splitPane  viewport.Model
+
+	// Search state; matches holds the indices of lines containing searchTerm
+	searchTerm string
+	matches    []int
+	matchIndex int
+
+	// Styling; in combined mode each container's prefix gets the next
+	// palette color the first time it shows up
+	styles          Styles
+	combined        bool
+	containerStyles map[string]lipgloss.Style
+
+	// While levelColors is on, each line takes the style of the first
+	// level rule matching it
+	levelColors bool
+	levelRules  []levelRule
 
 	// Dimensions
 	width  int
@@ -60,16 +136,29 @@ type LogViewModel struct {
 	ready  bool
 }
 
+// levelRule colors log lines matching pattern with the named level style
+type levelRule struct {
+	pattern *regexp.Regexp
+	style   string
+}
+
 // NewLogViewModel creates a new log view model
 func NewLogViewModel() LogViewModel {
 	return LogViewModel{
-		lines:    make([]string, 0),
-		maxLines: 10000, // Keep last 10k lines
-		follow:   true,  // Start with follow mode enabled
-		state:    LogViewStateIdle,
+		lines:      make([]string, 0),
+		maxLines:   10000, // Keep last 10k lines
+		follow:     true,  // Start with follow mode enabled
+		state:      LogViewStateIdle,
+		styles:     DefaultStyles(),
+		splitRatio: defaultSplitRatio,
 	}
 }
 
+// SetStyles sets the styles used for rendering
+func (m *LogViewModel) SetStyles(styles Styles) {
+	m.styles = styles
+}
+
 // SetSize updates the viewport size
 func (m *LogViewModel) SetSize(width, height int) {
 	m.width = width
@@ -81,12 +170,23 @@ func (m *LogViewModel) SetSize(width, height int) {
 		viewportHeight = 1
 	}
 
+	// A split gives the live logs the left pane
+	mainWidth := width
+	if m.split {
+		mainWidth, m.splitPane.Width = paneWidths(width, m.splitRatio)
+		m.splitPane.Height = viewportHeight
+	}
+
 	if !m.ready {
-		m.viewport = viewport.New(width, viewportHeight)
+		m.viewport = viewport.New(mainWidth, viewportHeight)
 		m.viewport.YPosition = 0
+		// Sideways scrolling goes through ScrollLeft and ScrollRight,
+		// which track the offset for the status bar
+		m.viewport.KeyMap.Left = key.NewBinding(key.WithDisabled())
+		m.viewport.KeyMap.Right = key.NewBinding(key.WithDisabled())
 		m.ready = true
 	} else {
-		m.viewport.Width = width
+		m.viewport.Width = mainWidth
 		m.viewport.Height = viewportHeight
 	}
 
@@ -95,22 +195,114 @@ func (m *LogViewModel) SetSize(width, height int) {
 
 // SetPodInfo sets the pod information for display
 func (m *LogViewModel) SetPodInfo(namespace, pod, container string) {
+	if namespace != m.namespace || pod != m.pod {
+		m.containerStyles = nil
+	}
 	m.namespace = namespace
 	m.pod = pod
 	m.container = container
 }
 
-// SetState sets the current streaming state
+// SetCombined marks the stream as merging several containers, whose lines
+// start with a "[container] " prefix to color
+func (m *LogViewModel) SetCombined(combined bool) {
+	m.combined = combined
+	m.contentDirty = true
+	m.updateViewportContent()
+}
+
+// containerStyle returns the style of a container's prefix, assigning the
+// next palette color to a container seen for the first time
+func (m *LogViewModel) containerStyle(container string) lipgloss.Style {
+	if len(m.styles.Containers) == 0 {
+		return lipgloss.NewStyle()
+	}
+	style, ok := m.containerStyles[container]
+	if !ok {
+		if m.containerStyles == nil {
+			m.containerStyles = make(map[string]lipgloss.Style)
+		}
+		style = m.styles.Containers[len(m.containerStyles)%len(m.styles.Containers)]
+		m.containerStyles[container] = style
+	}
+	return style
+}
+
+// SetPrevious marks the logs as coming from the previous instance of a
+// container in CrashLoopBackOff, which the header explains
+func (m *LogViewModel) SetPrevious(previous bool) {
+	m.previous = previous
+}
+
+// IsPrevious returns whether the logs come from the previous instance
+func (m *LogViewModel) IsPrevious() bool {
+	return m.previous
+}
+
+// SetTailLines sets the tail length shown in the header
+func (m *LogViewModel) SetTailLines(n int64) {
+	m.tailLines = n
+}
+
+// SetState sets the current streaming state. A stream that (re)starts
+// while paused stays paused.
 func (m *LogViewModel) SetState(state LogViewState) {
+	if m.paused && state == LogViewStateStreaming {
+		state = LogViewStatePaused
+	}
 	m.state = state
 }
 
+// IsPaused returns whether the view is frozen while lines keep buffering
+func (m *LogViewModel) IsPaused() bool {
+	return m.paused
+}
+
+// TogglePause freezes or unfreezes the view. The stream keeps running
+// while paused; resuming jumps to the newest line if following.
+func (m *LogViewModel) TogglePause() {
+	if !m.paused {
+		m.paused = true
+		m.pausedLines = 0
+		m.resumeFollow = m.follow
+		if m.state == LogViewStateStreaming {
+			m.state = LogViewStatePaused
+		}
+		return
+	}
+
+	m.paused = false
+	m.trimmedRows = 0
+	if m.state == LogViewStatePaused {
+		m.state = LogViewStateStreaming
+	}
+	if m.resumeFollow || m.follow {
+		m.follow = true
+		m.viewport.GotoBottom()
+	}
+}
+
 // SetError sets an error message
 func (m *LogViewModel) SetError(err string) {
 	m.errorMsg = err
 	m.state = LogViewStateError
 }
 
+// SetBackground marks the log view as hidden behind another view.
+// While in the background, new lines are buffered without re-rendering the
+// viewport; pending content is flushed once the view is brought to the front.
+func (m *LogViewModel) SetBackground(background bool) {
+	m.background = background
+	if !background && m.contentDirty {
+		m.updateViewportContent()
+	}
+}
+
+// IsBackground returns whether the log view is buffering in the background
+func (m *LogViewModel) IsBackground() bool {
+	return m.background
+}
+
 // IsFollow returns whether follow mode is enabled
 func (m *LogViewModel) IsFollow() bool {
 	return m.follow
@@ -125,22 +317,223 @@ func (m *LogViewModel) ToggleFollow() {
 	}
 }
 
+// IsWrap returns whether long lines are soft-wrapped
+func (m *LogViewModel) IsWrap() bool {
+	return m.wrap
+}
+
+// ToggleWrap switches between wrapping long lines to the viewport width
+// and cutting them at the edge with horizontal scrolling. The line at the
+// top of the viewport stays there.
+func (m *LogViewModel) ToggleWrap() {
+	top := m.lineAtRow(m.viewport.YOffset)
+	m.wrap = !m.wrap
+	if m.wrap {
+		m.setHOffset(0)
+	}
+	m.updateViewportContent()
+	if !m.follow {
+		m.viewport.SetYOffset(m.rowOfLine(top))
+	}
+}
+
+// SetLevelRules sets the patterns that color lines by log level. Rules
+// with an invalid pattern are skipped.
+func (m *LogViewModel) SetLevelRules(levels []config.LogLevel) {
+	m.levelRules = nil
+	for _, level := range levels {
+		pattern, err := regexp.Compile(level.Pattern)
+		if err != nil {
+			continue
+		}
+		m.levelRules = append(m.levelRules, levelRule{pattern: pattern, style: level.Style})
+	}
+	m.updateViewportContent()
+}
+
+// IsLevelColors returns whether lines are colored by log level
+func (m *LogViewModel) IsLevelColors() bool {
+	return m.levelColors
+}
+
+// ToggleLevelColors switches coloring lines by log level on or off
+func (m *LogViewModel) ToggleLevelColors() {
+	m.levelColors = !m.levelColors
+	m.updateViewportContent()
+}
+
+// levelStyle returns the style of the first level rule matching line
+func (m *LogViewModel) levelStyle(line string) (lipgloss.Style, bool) {
+	for _, rule := range m.levelRules {
+		if !rule.pattern.MatchString(line) {
+			continue
+		}
+		style, ok := m.styles.LogLevel(rule.style)
+		// Leave tabs alone so the styled line lines up like the raw one
+		return style.TabWidth(lipgloss.NoTabConversion), ok
+	}
+	return lipgloss.Style{}, false
+}
+
+// IsPrettyJSON returns whether JSON lines are pretty-printed
+func (m *LogViewModel) IsPrettyJSON() bool {
+	return m.prettyJSON
+}
+
+// TogglePrettyJSON switches between showing JSON lines indented over
+// several rows and showing them as received. The line at the top of the
+// viewport stays there.
+func (m *LogViewModel) TogglePrettyJSON() {
+	top := m.lineAtRow(m.viewport.YOffset)
+	m.prettyJSON = !m.prettyJSON
+	m.updateViewportContent()
+	if !m.follow {
+		m.viewport.SetYOffset(m.rowOfLine(top))
+	}
+}
+
+// ScrollLeft scrolls unwrapped lines left
+func (m *LogViewModel) ScrollLeft() {
+	if !m.wrap {
+		m.setHOffset(m.hOffset - horizontalScrollStep)
+	}
+}
+
+// ScrollRight scrolls unwrapped lines right, up to the end of the widest
+func (m *LogViewModel) ScrollRight() {
+	if !m.wrap {
+		m.setHOffset(m.hOffset + horizontalScrollStep)
+	}
+}
+
+// HOffset returns how many columns unwrapped lines are scrolled right by
+func (m *LogViewModel) HOffset() int {
+	return m.hOffset
+}
+
+// setHOffset scrolls sideways to column n, clamped to the widest line
+func (m *LogViewModel) setHOffset(n int) {
+	m.hOffset = max(min(n, m.widestLine()-m.viewport.Width), 0)
+	m.viewport.SetXOffset(m.hOffset)
+}
+
+// widestLine returns the width of the widest row as rendered
+func (m *LogViewModel) widestLine() int {
+	widest := 0
+	for _, line := range m.lines {
+		if m.prettyJSON {
+			if pretty, ok := prettyJSONLine(line); ok {
+				line = pretty
+			}
+		}
+		for _, row := range strings.Split(line, "\n") {
+			widest = max(widest, ansi.StringWidth(row))
+		}
+	}
+	return widest
+}
+
+// rowOfLine returns the viewport row the line at index starts on
+func (m *LogViewModel) rowOfLine(index int) int {
+	if m.rowStarts == nil || index < 0 {
+		return index
+	}
+	if index >= len(m.rowStarts) {
+		return m.viewport.TotalLineCount()
+	}
+	return m.rowStarts[index]
+}
+
+// lineAtRow returns the index of the line shown on a viewport row
+func (m *LogViewModel) lineAtRow(row int) int {
+	if m.rowStarts == nil {
+		return row
+	}
+	return max(sort.SearchInts(m.rowStarts, row+1)-1, 0)
+}
+
 // AddLine adds a new log line
 func (m *LogViewModel) AddLine(line string) {
+	m.countLine(time.Now())
 	m.lines = append(m.lines, line)
 
 	// Trim old lines if we exceed max
 	if len(m.lines) > m.maxLines {
 		// Remove oldest 10% of lines
 		trimCount := m.maxLines / 10
+		if m.paused {
+			m.trimmedRows += m.rowOfLine(trimCount)
+		}
 		m.lines = m.lines[trimCount:]
+		m.trimMatches(trimCount)
+		if m.follow && !m.paused {
+			m.lastLagTrim = time.Now()
+		}
+	} else if m.searchTerm != "" && strings.Contains(line, m.searchTerm) {
+		m.matches = append(m.matches, len(m.lines)-1)
+	}
+
+	if m.paused {
+		m.pausedLines++
+	} else if m.follow && m.hOffset > 0 {
+		// Following shows new lines from their start
+		m.setHOffset(0)
 	}
 
 	m.contentDirty = true
+	if m.background {
+		return
+	}
 	// Update viewport immediately so new content is visible
 	m.updateViewportContent()
 }
 
+// countLine records a line arriving at the given time for the line rate
+func (m *LogViewModel) countLine(at time.Time) {
+	if m.rateSecond.IsZero() {
+		m.rateSecond = at
+	}
+	m.foldRate(at)
+	m.rateCount++
+}
+
+// foldRate folds the seconds that ended before now into the moving
+// average; seconds without lines make it decay
+func (m *LogViewModel) foldRate(now time.Time) {
+	elapsed := int(now.Sub(m.rateSecond) / time.Second)
+	if m.rateSecond.IsZero() || elapsed <= 0 {
+		return
+	}
+	m.rate += lineRateAlpha * (float64(m.rateCount) - m.rate)
+	// Past a minute of silence the rate is zero for display anyway
+	for range min(elapsed-1, 60) {
+		m.rate *= 1 - lineRateAlpha
+	}
+	m.rateCount = 0
+	m.rateSecond = m.rateSecond.Add(time.Duration(elapsed) * time.Second)
+}
+
+// LineRate returns how many lines per second arrived recently, averaged
+// over the last few seconds
+func (m *LogViewModel) LineRate() float64 {
+	return m.lineRateAt(time.Now())
+}
+
+// lineRateAt returns the line rate as of now without changing the model
+func (m *LogViewModel) lineRateAt(now time.Time) float64 {
+	rate := *m
+	rate.foldRate(now)
+	return rate.rate
+}
+
+// formatLineRate formats a line rate for the status bar
+func formatLineRate(rate float64) string {
+	if rate < 10 {
+		return fmt.Sprintf("%.1f", rate)
+	}
+	return fmt.Sprintf("%.0f", rate)
+}
+
 // AddLines adds multiple log lines
 func (m *LogViewModel) AddLines(lines []string) {
 	for _, line := range lines {
@@ -148,11 +541,60 @@ func (m *LogViewModel) AddLines(lines []string) {
 	}
 }
 
-// Clear clears all log lines
+// Clear clears all log lines and the search
 func (m *LogViewModel) Clear() {
 	m.lines = make([]string, 0)
+	m.searchTerm = ""
+	m.matches = nil
+	m.lastLagTrim = time.Time{}
+	m.paused = false
+	m.pausedLines = 0
+	m.trimmedRows = 0
+	m.rateSecond = time.Time{}
+	m.rateCount = 0
+	m.rate = 0
+	m.setHOffset(0)
+	m.contentDirty = true
+	m.updateViewportContent()
+}
+
+// LogSnapshot holds the buffered lines and scroll position of the log
+// view, to put it back as it was when the same logs are reopened
+type LogSnapshot struct {
+	Lines   []string
+	Follow  bool
+	YOffset int
+	HOffset int
+}
+
+// Snapshot captures the buffered lines and scroll position
+func (m *LogViewModel) Snapshot() LogSnapshot {
+	return LogSnapshot{
+		Lines:   slices.Clone(m.lines),
+		Follow:  m.follow,
+		YOffset: m.viewport.YOffset,
+		HOffset: m.hOffset,
+	}
+}
+
+// Restore replaces the buffer with a snapshot's lines and scrolls back to
+// where it was. Like Clear, it drops the search and any pause.
+func (m *LogViewModel) Restore(s LogSnapshot) {
+	m.Clear()
+	m.lines = slices.Clone(s.Lines)
+	m.follow = s.Follow
 	m.contentDirty = true
 	m.updateViewportContent()
+	m.setHOffset(s.HOffset)
+	if !m.follow {
+		m.viewport.SetYOffset(s.YOffset)
+	}
+}
+
+// Lagging reports whether the stream outpaces the buffer: old lines were
+// trimmed while following, so lines may scroll past unseen
+func (m *LogViewModel) Lagging() bool {
+	return !m.lastLagTrim.IsZero() && time.Since(m.lastLagTrim) < lagIndicatorTTL
 }
 
 // LineCount returns the number of log lines
@@ -160,6 +602,21 @@ func (m *LogViewModel) LineCount() int {
 	return len(m.lines)
 }
 
+// Lines returns a copy of every raw log line in the buffer
+func (m *LogViewModel) Lines() []string {
+	return slices.Clone(m.lines)
+}
+
+// VisibleLines returns the raw log lines currently shown in the viewport
+func (m *LogViewModel) VisibleLines() []string {
+	if !m.ready {
+		return nil
+	}
+	start := min(m.lineAtRow(m.viewport.YOffset), len(m.lines))
+	end := min(m.lineAtRow(m.viewport.YOffset+m.viewport.Height-1)+1, len(m.lines))
+	return m.lines[start:end]
+}
+
 // State returns the current log view state
 func (m *LogViewModel) State() LogViewState {
 	return m.state
@@ -171,16 +628,102 @@ func (m *LogViewModel) updateViewportContent() {
 		return
 	}
 
-	content := strings.Join(m.lines, "\n")
+	content := m.renderLines()
+	offset := m.viewport.YOffset
 	m.viewport.SetContent(content)
 
-	if m.follow {
+	if m.paused {
+		// Keep the same lines in view when old ones were trimmed
+		m.viewport.SetYOffset(max(offset-m.trimmedRows, 0))
+		m.trimmedRows = 0
+	} else if m.follow {
 		m.viewport.GotoBottom()
 	}
 
 	m.contentDirty = false
 }
 
+// renderLines pretty-prints, highlights, colors and wraps each line as enabled,
+// and records the row each line starts on when a line can span several
+func (m *LogViewModel) renderLines() string {
+	m.rowStarts = nil
+	if m.wrap || m.prettyJSON {
+		m.rowStarts = make([]int, len(m.lines))
+	}
+
+	rendered := make([]string, len(m.lines))
+	row := 0
+	for i, line := range m.lines {
+		prefix := ""
+		if m.combined {
+			prefix, line = splitContainerPrefix(line)
+		}
+		var level lipgloss.Style
+		styled := false
+		if m.levelColors {
+			level, styled = m.levelStyle(line)
+		}
+		if m.prettyJSON {
+			if pretty, ok := prettyJSONLine(line); ok {
+				line = pretty
+			}
+		}
+		if m.searchTerm != "" {
+			line = strings.ReplaceAll(line, m.searchTerm, m.styles.SearchMatch.Render(m.searchTerm))
+		}
+		if styled {
+			// Row by row, as rendering several rows at once pads them
+			// to the same width
+			rows := strings.Split(line, "\n")
+			for r, text := range rows {
+				rows[r] = level.Render(text)
+			}
+			line = strings.Join(rows, "\n")
+		}
+		if prefix != "" {
+			container := strings.TrimSuffix(strings.TrimPrefix(prefix, "["), "] ")
+			line = m.containerStyle(container).Render(strings.TrimSuffix(prefix, " ")) + " " + line
+		}
+		if m.wrap {
+			// Keeps styling intact across the break
+			line = ansi.Hardwrap(line, m.viewport.Width, true)
+		}
+		if m.rowStarts != nil {
+			m.rowStarts[i] = row
+			row += strings.Count(line, "\n") + 1
+		}
+		rendered[i] = line
+	}
+	return strings.Join(rendered, "\n")
+}
+
+// prettyJSONLine indents a line holding a JSON object, keeping the
+// "[container] " prefix of multi-container streams on the first row. ok is
+// false when the line isn't a JSON object.
+func prettyJSONLine(line string) (pretty string, ok bool) {
+	prefix, body := splitContainerPrefix(line)
+	body = strings.TrimSpace(body)
+	if !strings.HasPrefix(body, "{") || !json.Valid([]byte(body)) {
+		return line, false
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(body), "", "  "); err != nil {
+		return line, false
+	}
+	return prefix + buf.String(), true
+}
+
+// splitContainerPrefix separates the "[container] " prefix of a line from a
+// multi-container stream; prefix is empty when there is none
+func splitContainerPrefix(line string) (prefix, body string) {
+	if strings.HasPrefix(line, "[") {
+		if end := strings.Index(line, "] "); end > 0 {
+			return line[:end+2], line[end+2:]
+		}
+	}
+	return "", line
+}
+
 // Update handles messages for the log view
 func (m LogViewModel) Update(msg tea.Msg) (LogViewModel, tea.Cmd) {
 	var cmd tea.Cmd
@@ -230,24 +773,50 @@ func (m LogViewModel) View() string {
 	if m.namespace != "" {
 		header = fmt.Sprintf("Logs: %s/%s/%s", m.namespace, m.pod, m.container)
 	}
-	b.WriteString(header)
+	if m.previous {
+		header += " (previous)"
+	}
+	if m.tailLines > 0 {
+		header += fmt.Sprintf(" (tail %d)", m.tailLines)
+	}
+	if m.split {
+		header += " | right: previous instance"
+	}
+	b.WriteString(m.styles.Header.Render(header))
 	b.WriteString("\n")
-	b.WriteString(strings.Repeat("-", min(len(header)+10, m.width)))
+	// The banner takes the separator's line so the viewport keeps its height
+	if m.previous {
+		b.WriteString(m.styles.Error.Render(Truncate(previousBanner, m.width)))
+	} else {
+		b.WriteString(strings.Repeat("-", min(len(header)+10, m.width)))
+	}
 	b.WriteString("\n")
 
 	// Viewport content
-	b.WriteString(m.viewport.View())
+	if m.split {
+		b.WriteString(m.splitView())
+	} else {
+		b.WriteString(m.viewport.View())
+	}
 	b.WriteString("\n")
 
 	// Status bar
 	statusLine := m.buildStatusLine()
-	b.WriteString(statusLine)
+	if m.state == LogViewStateError {
+		b.WriteString(m.styles.Error.Render(statusLine))
+	} else {
+		b.WriteString(m.styles.StatusBar.Render(statusLine))
+	}
 
 	return b.String()
 }
 
 // buildStatusLine creates the status line at the bottom
 func (m LogViewModel) buildStatusLine() string {
+	if m.styles.Verbose {
+		return m.verboseStatusLine()
+	}
+
 	// State indicator
 	var stateIndicator string
 	switch m.state {
@@ -268,15 +837,95 @@ func (m LogViewModel) buildStatusLine() string {
 	if m.follow {
 		followIndicator = " [FOLLOW]"
 	}
+	if m.paused {
+		if m.state != LogViewStatePaused {
+			// The stream ended or failed while paused
+			followIndicator += " [PAUSED]"
+		}
+		followIndicator += fmt.Sprintf(" [+%d new]", m.pausedLines)
+	}
+	if m.Lagging() {
+		followIndicator += " [lagging — trimming while following]"
+	}
+	if m.searchTerm != "" {
+		followIndicator += " [" + m.SearchStatus() + "]"
+	}
+	if m.wrap {
+		followIndicator += " [WRAP]"
+	}
+	if m.prettyJSON {
+		followIndicator += " [JSON]"
+	}
+	if m.levelColors {
+		followIndicator += " [LEVELS]"
+	}
+	if m.hOffset > 0 {
+		followIndicator += fmt.Sprintf(" [COL %d]", m.hOffset+1)
+	}
 
-	// Line count and scroll position
-	scrollInfo := fmt.Sprintf(" Lines: %d | %d%%",
+	// Line count, rate and scroll position
+	rateInfo := ""
+	if m.state == LogViewStateStreaming {
+		rateInfo = " | " + formatLineRate(m.LineRate()) + " lines/s"
+	}
+	scrollInfo := fmt.Sprintf(" Lines: %d%s | %d%%",
 		len(m.lines),
+		rateInfo,
 		int(m.viewport.ScrollPercent()*100))
 
 	return fmt.Sprintf("%s%s%s", stateIndicator, followIndicator, scrollInfo)
 }
 
+// verboseStatusLine describes the log state in words for screen readers
+func (m LogViewModel) verboseStatusLine() string {
+	var state string
+	switch m.state {
+	case LogViewStateStreaming:
+		state = "Streaming logs"
+	case LogViewStatePaused:
+		state = "Logs paused"
+	case LogViewStateError:
+		state = fmt.Sprintf("Log stream error: %s", m.errorMsg)
+	case LogViewStateEnded:
+		state = "Log stream ended"
+	default:
+		state = "Logs idle"
+	}
+
+	follow := "not following"
+	if m.follow {
+		follow = "following"
+	}
+
+	status := fmt.Sprintf("%s, %s, %s, scrolled %d%%",
+		state, pluralize(len(m.lines), "line"), follow, int(m.viewport.ScrollPercent()*100))
+	if m.state == LogViewStateStreaming {
+		status += ", receiving " + formatLineRate(m.LineRate()) + " lines per second"
+	}
+	if m.paused {
+		status += fmt.Sprintf(", paused with %s since pausing", pluralize(m.pausedLines, "new line"))
+	}
+	if m.Lagging() {
+		status += ", lagging, old lines are trimmed while following"
+	}
+	if m.searchTerm != "" {
+		status += ", " + m.SearchStatus()
+	}
+	if m.wrap {
+		status += ", wrapping long lines"
+	}
+	if m.prettyJSON {
+		status += ", pretty-printing JSON lines"
+	}
+	if m.levelColors {
+		status += ", coloring lines by level"
+	}
+	if m.hOffset > 0 {
+		status += fmt.Sprintf(", scrolled right to column %d", m.hOffset+1)
+	}
+	return status
+}
+
 // ScrollUp scrolls the viewport up
 func (m *LogViewModel) ScrollUp(lines int) {
 	m.follow = false
@@ -316,3 +965,127 @@ func (m *LogViewModel) GotoBottom() {
 	m.follow = true
 	m.viewport.GotoBottom()
 }
+
+// Search highlights the lines containing term and jumps to the first match
+// at or below the top of the viewport, wrapping to the first match. Follow
+// mode is turned off so new lines don't scroll the match away. An empty term
+// clears the search.
+func (m *LogViewModel) Search(term string) {
+	m.searchTerm = term
+	m.matches = nil
+	m.matchIndex = 0
+
+	if term == "" {
+		m.updateViewportContent()
+		return
+	}
+
+	for i, line := range m.lines {
+		if strings.Contains(line, term) {
+			m.matches = append(m.matches, i)
+		}
+	}
+
+	m.follow = false
+	m.updateViewportContent()
+
+	if len(m.matches) == 0 {
+		return
+	}
+	top := m.lineAtRow(m.viewport.YOffset)
+	for i, line := range m.matches {
+		if line >= top {
+			m.matchIndex = i
+			break
+		}
+	}
+	m.centerOnMatch()
+}
+
+// ClearSearch removes the search term and its highlighting
+func (m *LogViewModel) ClearSearch() {
+	m.Search("")
+}
+
+// SearchTerm returns the active search term, empty when not searching
+func (m *LogViewModel) SearchTerm() string {
+	return m.searchTerm
+}
+
+// MatchCount returns the number of lines matching the search
+func (m *LogViewModel) MatchCount() int {
+	return len(m.matches)
+}
+
+// CurrentMatchLine returns the line index of the current match, or -1
+func (m *LogViewModel) CurrentMatchLine() int {
+	if len(m.matches) == 0 {
+		return -1
+	}
+	return m.matches[m.matchIndex]
+}
+
+// NextMatch jumps to the next match, wrapping to the first
+func (m *LogViewModel) NextMatch() {
+	if len(m.matches) == 0 {
+		return
+	}
+	m.matchIndex = (m.matchIndex + 1) % len(m.matches)
+	m.centerOnMatch()
+}
+
+// PrevMatch jumps to the previous match, wrapping to the last
+func (m *LogViewModel) PrevMatch() {
+	if len(m.matches) == 0 {
+		return
+	}
+	m.matchIndex = (m.matchIndex - 1 + len(m.matches)) % len(m.matches)
+	m.centerOnMatch()
+}
+
+// SearchStatus describes the search position, e.g. "match 2 of 5"
+func (m *LogViewModel) SearchStatus() string {
+	if len(m.matches) == 0 {
+		return fmt.Sprintf("no matches for %q", m.searchTerm)
+	}
+	return fmt.Sprintf("match %d of %d", m.matchIndex+1, len(m.matches))
+}
+
+// centerOnMatch scrolls so the current match is in the middle of the viewport
+func (m *LogViewModel) centerOnMatch() {
+	m.follow = false
+	if m.contentDirty {
+		m.updateViewportContent()
+	}
+	m.viewport.SetYOffset(m.rowOfLine(m.matches[m.matchIndex]) - m.viewport.Height/2)
+}
+
+// trimMatches drops matches on the oldest count lines after they are
+// trimmed and shifts the rest to the new line indices
+func (m *LogViewModel) trimMatches(count int) {
+	if m.searchTerm == "" {
+		return
+	}
+
+	current := m.CurrentMatchLine()
+	kept := m.matches[:0]
+	for _, line := range m.matches {
+		if line >= count {
+			kept = append(kept, line-count)
+		}
+	}
+	m.matches = kept
+
+	// The newest line was appended before trimming
+	if last := len(m.lines) - 1; strings.Contains(m.lines[last], m.searchTerm) {
+		m.matches = append(m.matches, last)
+	}
+
+	m.matchIndex = 0
+	for i, line := range m.matches {
+		if line >= current-count {
+			m.matchIndex = i
+			break
+		}
+	}
+}
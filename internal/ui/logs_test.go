@@ -1,8 +1,15 @@
 package ui
 
 import (
+	"fmt"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/maxime/k8s-tui/internal/config"
 )
 
 func TestNewLogViewModel(t *testing.T) {
@@ -66,6 +73,53 @@ func TestLogViewModel_Clear(t *testing.T) {
 	}
 }
 
+func TestLogViewModel_SnapshotRestore(t *testing.T) {
+	m := NewLogViewModel()
+	m.SetSize(80, 24)
+	for i := 0; i < 100; i++ {
+		m.AddLine(fmt.Sprintf("line %d", i))
+	}
+	m.ScrollUp(30)
+	snapshot := m.Snapshot()
+	if snapshot.Follow || len(snapshot.Lines) != 100 {
+		t.Fatalf("expected 100 lines scrolled up, got %d lines follow=%v", len(snapshot.Lines), snapshot.Follow)
+	}
+
+	// Another pod's logs replace the buffer
+	m.Clear()
+	m.GotoBottom()
+	m.AddLine("other pod")
+
+	m.Restore(snapshot)
+	if m.LineCount() != 100 || m.IsFollow() {
+		t.Fatalf("expected the lines and follow state restored, got %d lines follow=%v", m.LineCount(), m.IsFollow())
+	}
+	if m.viewport.YOffset != snapshot.YOffset {
+		t.Errorf("expected offset %d restored, got %d", snapshot.YOffset, m.viewport.YOffset)
+	}
+
+	// New lines don't move a restored view that isn't following
+	m.AddLine("line 100")
+	if m.viewport.YOffset != snapshot.YOffset {
+		t.Errorf("expected the view to stay at %d, got %d", snapshot.YOffset, m.viewport.YOffset)
+	}
+
+	// The snapshot doesn't share the buffer
+	snapshot.Lines[0] = "changed"
+	if m.Lines()[0] != "line 0" {
+		t.Error("restoring should copy the snapshot's lines")
+	}
+
+	// A following snapshot restores at the bottom
+	m.GotoBottom()
+	following := m.Snapshot()
+	m.Clear()
+	m.Restore(following)
+	if !m.IsFollow() || !m.viewport.AtBottom() {
+		t.Error("expected a following view restored at the bottom")
+	}
+}
+
 func TestLogViewModel_MaxLines(t *testing.T) {
 	m := NewLogViewModel()
 	m.maxLines = 100 // Set smaller for testing
@@ -81,6 +135,141 @@ func TestLogViewModel_MaxLines(t *testing.T) {
 	}
 }
 
+func TestLogViewModel_LaggingWhenTrimmingWhileFollowing(t *testing.T) {
+	m := NewLogViewModel()
+	m.SetSize(120, 24)
+	m.maxLines = 10
+
+	for i := 0; i < 10; i++ {
+		m.AddLine("line")
+	}
+	if m.Lagging() {
+		t.Fatal("should not lag before any lines are trimmed")
+	}
+
+	m.AddLine("line")
+	if !m.Lagging() {
+		t.Fatal("trimming while following should set the lag indicator")
+	}
+	if !strings.Contains(m.View(), "[lagging — trimming while following]") {
+		t.Errorf("status bar should show the lag indicator, got:\n%s", m.View())
+	}
+
+	// The indicator expires once trimming stops
+	m.lastLagTrim = time.Now().Add(-lagIndicatorTTL)
+	if m.Lagging() {
+		t.Error("lag indicator should expire")
+	}
+
+	m.AddLine("line")
+	m.Clear()
+	if m.Lagging() {
+		t.Error("clearing the logs should reset the lag indicator")
+	}
+}
+
+func TestLogViewModel_Pause(t *testing.T) {
+	m := NewLogViewModel()
+	m.SetSize(120, 10)
+	m.SetState(LogViewStateStreaming)
+	for i := 0; i < 20; i++ {
+		m.AddLine(fmt.Sprintf("line %d", i))
+	}
+
+	m.TogglePause()
+	if !m.IsPaused() || m.State() != LogViewStatePaused {
+		t.Fatalf("TogglePause should pause, state %v", m.State())
+	}
+	offset := m.viewport.YOffset
+
+	for i := 20; i < 30; i++ {
+		m.AddLine(fmt.Sprintf("line %d", i))
+	}
+	if m.LineCount() != 30 {
+		t.Errorf("lines should keep buffering while paused, got %d", m.LineCount())
+	}
+	if m.viewport.YOffset != offset {
+		t.Errorf("paused view should not scroll, offset %d -> %d", offset, m.viewport.YOffset)
+	}
+	view := m.View()
+	if !strings.Contains(view, "[PAUSED]") || !strings.Contains(view, "[+10 new]") {
+		t.Errorf("status should show the pause and buffered count, got:\n%s", view)
+	}
+
+	// A stream restarting while paused stays paused
+	m.SetState(LogViewStateStreaming)
+	if m.State() != LogViewStatePaused {
+		t.Error("SetState should keep a paused view paused")
+	}
+
+	m.TogglePause()
+	if m.IsPaused() || m.State() != LogViewStateStreaming {
+		t.Fatal("TogglePause should resume streaming")
+	}
+	if !m.IsFollow() || !m.viewport.AtBottom() {
+		t.Error("resuming should catch up to the newest line when following")
+	}
+}
+
+func TestLogViewModel_PauseKeepsViewWhenTrimming(t *testing.T) {
+	m := NewLogViewModel()
+	m.SetSize(120, 10)
+	m.maxLines = 100
+	for i := 0; i < 100; i++ {
+		m.AddLine(fmt.Sprintf("line %d", i))
+	}
+
+	m.TogglePause()
+	top := m.VisibleLines()[0]
+
+	// Trims the oldest 10 lines
+	m.AddLine("line 100")
+	if got := m.VisibleLines()[0]; got != top {
+		t.Errorf("paused view should keep showing %q after trimming, got %q", top, got)
+	}
+	if m.Lagging() {
+		t.Error("trimming while paused should not report lag")
+	}
+}
+
+func TestLogViewModel_PauseEndedStream(t *testing.T) {
+	m := NewLogViewModel()
+	m.SetSize(120, 10)
+	m.SetState(LogViewStateStreaming)
+	m.TogglePause()
+
+	m.SetState(LogViewStateEnded)
+	view := m.View()
+	if !strings.Contains(view, "[STREAM ENDED]") || !strings.Contains(view, "[PAUSED]") {
+		t.Errorf("status should show both the end and the pause, got:\n%s", view)
+	}
+
+	m.TogglePause()
+	if m.State() != LogViewStateEnded {
+		t.Errorf("resuming should not revive an ended stream, got %v", m.State())
+	}
+
+	m.TogglePause()
+	m.Clear()
+	if m.IsPaused() {
+		t.Error("clearing for a new stream should unpause")
+	}
+}
+
+func TestLogViewModel_NotLaggingWhenTrimmingWithoutFollow(t *testing.T) {
+	m := NewLogViewModel()
+	m.SetSize(120, 24)
+	m.maxLines = 10
+	m.ToggleFollow()
+
+	for i := 0; i < 20; i++ {
+		m.AddLine("line")
+	}
+	if m.Lagging() {
+		t.Error("trimming without follow should not set the lag indicator")
+	}
+}
+
 func TestLogViewModel_ToggleFollow(t *testing.T) {
 	m := NewLogViewModel()
 	m.SetSize(80, 24)
@@ -186,6 +375,16 @@ func TestLogViewModel_SetSize(t *testing.T) {
 	}
 }
 
+func TestLogViewModel_AddLineBeforeSetSize(t *testing.T) {
+	m := NewLogViewModel()
+	m.AddLine("early-line")
+	m.SetSize(100, 30)
+
+	if !strings.Contains(m.View(), "early-line") {
+		t.Errorf("lines added before SetSize should be shown, got:\n%s", m.View())
+	}
+}
+
 func TestLogViewModel_View_NotReady(t *testing.T) {
 	m := NewLogViewModel()
 
@@ -294,3 +493,574 @@ func TestLogViewModel_ScrollMethods(t *testing.T) {
 		t.Error("expected follow to be disabled after ScrollUp")
 	}
 }
+
+func TestLogViewModel_VisibleLines(t *testing.T) {
+	m := NewLogViewModel()
+	if m.VisibleLines() != nil {
+		t.Error("expected no visible lines before sizing")
+	}
+
+	m.SetSize(80, 10) // Viewport height 6
+	for i := 0; i < 20; i++ {
+		m.AddLine(fmt.Sprintf("line %d", i))
+	}
+
+	m.GotoTop()
+	m.ScrollDown(2)
+	visible := m.VisibleLines()
+	if len(visible) != 6 || visible[0] != "line 2" || visible[5] != "line 7" {
+		t.Errorf("expected lines 2-7, got %v", visible)
+	}
+
+	m.GotoBottom()
+	if visible := m.VisibleLines(); visible[len(visible)-1] != "line 19" {
+		t.Errorf("expected the last line at the bottom, got %v", visible)
+	}
+}
+
+func TestLogViewModel_Wrap(t *testing.T) {
+	m := NewLogViewModel()
+	m.SetSize(20, 10) // Viewport height 6
+	long := strings.Repeat("x", 50)
+	m.AddLines([]string{"first", long, "last"})
+
+	m.ToggleWrap()
+	if !m.IsWrap() {
+		t.Fatal("expected wrapping on")
+	}
+
+	// The 50-column line takes three rows of 20
+	if rows := m.viewport.TotalLineCount(); rows != 5 {
+		t.Errorf("expected 5 rows after wrapping, got %d", rows)
+	}
+	if m.LineCount() != 3 {
+		t.Errorf("line count should stay at the raw 3 lines, got %d", m.LineCount())
+	}
+	if !m.viewport.AtBottom() || !m.IsFollow() {
+		t.Error("follow should still keep the bottom in view")
+	}
+	if visible := m.VisibleLines(); len(visible) != 3 || visible[1] != long {
+		t.Errorf("expected the raw lines back, got %q", visible)
+	}
+	if !strings.Contains(m.View(), "[WRAP]") {
+		t.Error("status should show the wrap state")
+	}
+
+	// Following continues to track new lines
+	m.AddLine(long)
+	if !m.viewport.AtBottom() {
+		t.Error("expected to stay at the bottom after a wrapped line arrives")
+	}
+
+	m.ToggleWrap()
+	if m.IsWrap() || m.viewport.TotalLineCount() != 4 {
+		t.Errorf("expected one row per line without wrapping, got %d", m.viewport.TotalLineCount())
+	}
+}
+
+func TestLogViewModel_WrapSearch(t *testing.T) {
+	m := NewLogViewModel()
+	m.SetSize(20, 10) // Viewport height 6
+	for i := 0; i < 30; i++ {
+		m.AddLine(fmt.Sprintf("%02d %s", i, strings.Repeat("y", 40)))
+	}
+	m.AddLine("29 needle")
+	m.ToggleWrap()
+
+	m.Search("needle")
+	if m.MatchCount() != 1 {
+		t.Fatalf("expected 1 match, got %d", m.MatchCount())
+	}
+	visible := m.VisibleLines()
+	found := false
+	for _, line := range visible {
+		if line == "29 needle" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("the match should be scrolled into view, got %q", visible)
+	}
+}
+
+func TestLogViewModel_HorizontalScroll(t *testing.T) {
+	m := NewLogViewModel()
+	m.SetSize(20, 10)
+	m.AddLine("a" + strings.Repeat("z", 99))
+
+	m.ScrollRight()
+	if m.viewport.HorizontalScrollPercent() == 0 {
+		t.Error("expected to scroll right")
+	}
+	m.ScrollLeft()
+	if m.viewport.HorizontalScrollPercent() != 0 {
+		t.Error("expected to scroll back left")
+	}
+
+	// Wrapped lines fit, so there is nothing to scroll
+	m.ScrollRight()
+	m.ToggleWrap()
+	m.ScrollRight()
+	if !strings.HasPrefix(m.viewport.View(), "a") {
+		t.Errorf("wrapping should reset and disable horizontal scrolling, got %q", m.viewport.View())
+	}
+}
+
+func TestLogViewModel_HorizontalOffset(t *testing.T) {
+	m := NewLogViewModel()
+	m.SetSize(20, 10)
+	m.AddLine("a" + strings.Repeat("z", 99))
+
+	for i := 0; i < 3; i++ {
+		m.ScrollRight()
+	}
+	if m.HOffset() != 30 {
+		t.Errorf("expected an offset of 30, got %d", m.HOffset())
+	}
+	if !strings.Contains(m.View(), "[COL 31]") {
+		t.Error("status should show the first visible column")
+	}
+
+	// The widest line's end stops the scroll
+	for i := 0; i < 20; i++ {
+		m.ScrollRight()
+	}
+	if m.HOffset() != 80 {
+		t.Errorf("expected the offset clamped to 80, got %d", m.HOffset())
+	}
+
+	// New lines reset the offset while following, but not otherwise
+	m.AddLine("next")
+	if m.HOffset() != 0 || strings.Contains(m.View(), "[COL") {
+		t.Errorf("following should reset the offset, got %d", m.HOffset())
+	}
+	m.ToggleFollow()
+	m.ScrollRight()
+	m.AddLine("another")
+	if m.HOffset() != 10 {
+		t.Errorf("expected the offset kept when not following, got %d", m.HOffset())
+	}
+}
+
+func TestPrettyJSONLine(t *testing.T) {
+	tests := []struct {
+		name   string
+		line   string
+		want   string
+		wantOK bool
+	}{
+		{"object", `{"level":"info","n":1}`, "{\n  \"level\": \"info\",\n  \"n\": 1\n}", true},
+		{"container prefix", `[app] {"a":true}`, "[app] {\n  \"a\": true\n}", true},
+		{"plain text", "starting server", "starting server", false},
+		{"truncated object", `{"level":"info"`, `{"level":"info"`, false},
+		{"array", `[1,2]`, `[1,2]`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := prettyJSONLine(tt.line)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("prettyJSONLine(%q) = %q, %v; want %q, %v", tt.line, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestLogViewModel_PrettyJSON(t *testing.T) {
+	m := NewLogViewModel()
+	m.SetSize(80, 10) // Viewport height 6
+	raw := `{"level":"info","msg":"ready"}`
+	m.AddLines([]string{"plain", raw, "last"})
+
+	m.TogglePrettyJSON()
+	if !m.IsPrettyJSON() {
+		t.Fatal("expected pretty-printing on")
+	}
+
+	// The object spans four rows: braces and one per field
+	if rows := m.viewport.TotalLineCount(); rows != 6 {
+		t.Errorf("expected 6 rows after pretty-printing, got %d", rows)
+	}
+	if !strings.Contains(m.viewport.View(), `  "msg": "ready"`) {
+		t.Errorf("expected indented fields, got %q", m.viewport.View())
+	}
+	if visible := m.VisibleLines(); len(visible) != 3 || visible[1] != raw {
+		t.Errorf("expected the raw lines back, got %q", visible)
+	}
+	if !strings.Contains(m.View(), "[JSON]") {
+		t.Error("status should show the pretty-print state")
+	}
+
+	// Following keeps the bottom in view as multi-row lines arrive
+	m.AddLine(raw)
+	if !m.viewport.AtBottom() {
+		t.Error("expected to stay at the bottom after a JSON line arrives")
+	}
+
+	m.TogglePrettyJSON()
+	if m.IsPrettyJSON() || m.viewport.TotalLineCount() != 4 {
+		t.Errorf("expected one row per line after toggling back, got %d", m.viewport.TotalLineCount())
+	}
+	if m.lines[1] != raw {
+		t.Errorf("raw line should be kept, got %q", m.lines[1])
+	}
+}
+
+func TestLogViewModel_PrettyJSONPausedTrim(t *testing.T) {
+	m := NewLogViewModel()
+	m.SetSize(80, 10)
+	m.maxLines = 20
+	for i := 0; i < 20; i++ {
+		m.AddLine(fmt.Sprintf(`{"n":%d}`, i))
+	}
+	m.TogglePrettyJSON()
+	m.GotoTop()
+	m.ScrollDown(9) // Row 9 is the first row of line 3
+	m.TogglePause()
+
+	// Trimming two lines drops six rows above the viewport
+	m.AddLine(`{"n":20}`)
+	if visible := m.VisibleLines(); len(visible) == 0 || visible[0] != `{"n":3}` {
+		t.Errorf("expected the same line to stay at the top, got %q", visible)
+	}
+}
+
+func TestLogViewModel_BackgroundBuffersWithoutRendering(t *testing.T) {
+	m := NewLogViewModel()
+	m.SetSize(80, 24)
+	m.AddLine("visible line")
+
+	m.SetBackground(true)
+	before := m.viewport.View()
+
+	m.AddLine("background line")
+
+	if m.LineCount() != 2 {
+		t.Errorf("expected background line to be buffered, got %d lines", m.LineCount())
+	}
+	if m.viewport.View() != before {
+		t.Error("viewport should not re-render while in the background")
+	}
+
+	// Bringing the view to the front flushes pending content
+	m.SetBackground(false)
+	if !strings.Contains(m.viewport.View(), "background line") {
+		t.Error("buffered line should appear after returning to the foreground")
+	}
+}
+
+func TestLogViewModel_LineRate(t *testing.T) {
+	m := NewLogViewModel()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// 20 lines a second for 10 seconds
+	for i := range 200 {
+		m.countLine(start.Add(time.Duration(i) * 50 * time.Millisecond))
+	}
+	if rate := m.lineRateAt(start.Add(10 * time.Second)); rate < 18 || rate > 20 {
+		t.Errorf("expected about 20 lines/s, got %.2f", rate)
+	}
+
+	// Reading the rate doesn't change it
+	if a, b := m.lineRateAt(start.Add(10*time.Second)), m.lineRateAt(start.Add(10*time.Second)); a != b {
+		t.Errorf("reading the rate should not fold it again, got %.2f then %.2f", a, b)
+	}
+
+	// The rate decays when lines stop
+	if rate := m.lineRateAt(start.Add(20 * time.Second)); rate > 0.5 {
+		t.Errorf("expected the rate to decay after 10 idle seconds, got %.2f", rate)
+	}
+
+	// A single burst doesn't make it jump to the burst size
+	m.Clear()
+	for range 100 {
+		m.countLine(start)
+	}
+	if rate := m.lineRateAt(start.Add(time.Second)); rate >= 100 || rate <= 0 {
+		t.Errorf("expected a smoothed rate below the burst of 100, got %.2f", rate)
+	}
+
+	m.Clear()
+	if rate := m.lineRateAt(start.Add(time.Minute)); rate != 0 {
+		t.Errorf("Clear should reset the rate, got %.2f", rate)
+	}
+}
+
+func TestLogViewModel_LineRateInStatus(t *testing.T) {
+	m := NewLogViewModel()
+	m.SetSize(100, 24)
+	if strings.Contains(m.View(), "lines/s") {
+		t.Error("the rate should only show while streaming")
+	}
+
+	m.SetState(LogViewStateStreaming)
+	m.AddLine("line")
+	if !strings.Contains(m.View(), "0.0 lines/s") {
+		t.Errorf("expected the rate in the status bar, got:\n%s", m.View())
+	}
+
+	if got := formatLineRate(123.4); got != "123" {
+		t.Errorf("formatLineRate(123.4) = %q, want 123", got)
+	}
+	if got := formatLineRate(2.5); got != "2.5" {
+		t.Errorf("formatLineRate(2.5) = %q, want 2.5", got)
+	}
+}
+
+func TestLogViewModel_VerboseStatus(t *testing.T) {
+	m := NewLogViewModel()
+	m.SetStyles(AccessibleStyles())
+	m.SetSize(80, 24)
+	m.SetState(LogViewStateStreaming)
+	for i := 0; i < 42; i++ {
+		m.AddLine("line")
+	}
+
+	view := m.View()
+	if !strings.Contains(view, "Streaming logs, 42 lines, following") {
+		t.Errorf("expected verbose status, got:\n%s", view)
+	}
+	if strings.Contains(view, "[STREAMING]") || strings.Contains(view, "[FOLLOW]") {
+		t.Error("verbose mode should not use bracketed indicators")
+	}
+	if strings.Contains(view, "\x1b[") {
+		t.Error("accessible styles should not emit ANSI escapes")
+	}
+
+	m.ToggleFollow()
+	if !strings.Contains(m.View(), "not following") {
+		t.Error("expected verbose status to report follow off")
+	}
+}
+
+func TestLogViewModel_LevelColors(t *testing.T) {
+	prev := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	t.Cleanup(func() { lipgloss.SetColorProfile(prev) })
+
+	styles := DefaultStyles()
+	styles.LogError = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	m := NewLogViewModel()
+	m.SetStyles(styles)
+	m.SetLevelRules(config.Default().LogLevels)
+	m.SetSize(80, 14)
+	m.AddLine("2024-01-01 ERROR connection refused")
+	m.AddLine("2024-01-01 plain line")
+
+	if strings.Contains(m.viewport.View(), "\x1b[") {
+		t.Fatal("lines should stay uncolored until level colors are toggled on")
+	}
+
+	m.ToggleLevelColors()
+	want := styles.LogError.Render("2024-01-01 ERROR connection refused")
+	if !strings.Contains(want, "\x1b[") || !strings.Contains(m.viewport.View(), want) {
+		t.Errorf("expected the ERROR line in the error style, got:\n%q", m.viewport.View())
+	}
+	if !strings.Contains(m.viewport.View(), "\n2024-01-01 plain line") {
+		t.Errorf("expected lines without a level left plain, got:\n%q", m.viewport.View())
+	}
+	if m.Lines()[0] != "2024-01-01 ERROR connection refused" {
+		t.Errorf("expected the raw line kept for saving and search, got %q", m.Lines()[0])
+	}
+	if !strings.Contains(m.View(), "[LEVELS]") {
+		t.Error("status bar should show that level colors are on")
+	}
+}
+
+func TestLogViewModel_Search(t *testing.T) {
+	m := NewLogViewModel()
+	m.SetSize(80, 14) // 10 line viewport
+	for i := 0; i < 100; i++ {
+		if i%25 == 0 {
+			m.AddLine(fmt.Sprintf("%d ERROR boom", i))
+		} else {
+			m.AddLine(fmt.Sprintf("%d ok", i))
+		}
+	}
+
+	m.GotoTop()
+	m.Search("ERROR")
+
+	if m.IsFollow() {
+		t.Error("search should disable follow mode")
+	}
+	if m.MatchCount() != 4 {
+		t.Fatalf("expected 4 matches, got %d", m.MatchCount())
+	}
+	if m.CurrentMatchLine() != 0 || m.SearchStatus() != "match 1 of 4" {
+		t.Errorf("expected first match, got line %d (%s)", m.CurrentMatchLine(), m.SearchStatus())
+	}
+
+	m.NextMatch()
+	if m.CurrentMatchLine() != 25 {
+		t.Errorf("expected match on line 25, got %d", m.CurrentMatchLine())
+	}
+	// The match is centered in the viewport
+	if got := m.viewport.YOffset; got != 25-m.viewport.Height/2 {
+		t.Errorf("expected match centered at offset %d, got %d", 25-m.viewport.Height/2, got)
+	}
+	if !strings.Contains(m.View(), "[match 2 of 4]") {
+		t.Error("status bar should show the match position")
+	}
+
+	m.PrevMatch()
+	m.PrevMatch()
+	if m.CurrentMatchLine() != 75 {
+		t.Errorf("PrevMatch should wrap to the last match, got line %d", m.CurrentMatchLine())
+	}
+
+	m.ClearSearch()
+	if m.SearchTerm() != "" || m.MatchCount() != 0 || m.CurrentMatchLine() != -1 {
+		t.Error("ClearSearch should reset the search")
+	}
+}
+
+func TestLogViewModel_SearchNoMatches(t *testing.T) {
+	m := NewLogViewModel()
+	m.SetSize(80, 24)
+	m.AddLine("all good")
+
+	m.Search("panic")
+	m.NextMatch() // Must not panic without matches
+
+	if m.SearchStatus() != `no matches for "panic"` {
+		t.Errorf("unexpected status %q", m.SearchStatus())
+	}
+}
+
+func TestLogViewModel_SearchTracksNewAndTrimmedLines(t *testing.T) {
+	m := NewLogViewModel()
+	m.maxLines = 10
+	m.SetSize(80, 24)
+	m.AddLine("hit 0")
+	m.Search("hit")
+
+	for i := 1; i <= 10; i++ {
+		m.AddLine(fmt.Sprintf("line %d", i))
+	}
+	m.AddLine("hit 11")
+
+	// One line was trimmed on overflow, taking the first match with it
+	if m.MatchCount() != 1 {
+		t.Fatalf("expected 1 match after trimming, got %d", m.MatchCount())
+	}
+	if line := m.CurrentMatchLine(); m.lines[line] != "hit 11" {
+		t.Errorf("match index should follow the trimmed buffer, got %q", m.lines[line])
+	}
+}
+
+func TestLogViewModel_SearchHighlight(t *testing.T) {
+	// Force color output so the highlight is rendered
+	prev := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	t.Cleanup(func() { lipgloss.SetColorProfile(prev) })
+
+	m := NewLogViewModel()
+	m.SetStyles(DefaultStyles())
+	m.SetSize(80, 24)
+	m.AddLine("request failed: timeout")
+
+	m.Search("failed")
+	if !strings.Contains(m.viewport.View(), m.styles.SearchMatch.Render("failed")) {
+		t.Error("matches should be highlighted when colors are enabled")
+	}
+
+	m.SetStyles(PlainStyles())
+	m.Search("failed")
+	if strings.Contains(m.viewport.View(), "\x1b[") {
+		t.Error("plain styles should not highlight with escape codes")
+	}
+}
+
+func TestLogViewModel_CombinedContainerColors(t *testing.T) {
+	prev := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	t.Cleanup(func() { lipgloss.SetColorProfile(prev) })
+
+	m := NewLogViewModel()
+	m.SetStyles(DefaultStyles())
+	m.SetSize(80, 24)
+	m.SetPodInfo("default", "web", "(all containers)")
+	m.SetCombined(true)
+	m.AddLines([]string{"[app] started", "[sidecar] proxy ready", "[app] serving", "[INFO] not a container"})
+
+	app, sidecar := m.containerStyle("app"), m.containerStyle("sidecar")
+	if app.GetForeground() == sidecar.GetForeground() {
+		t.Error("expected each container to get its own color")
+	}
+	view := m.viewport.View()
+	if strings.Count(view, app.Render("[app]")) != 2 || !strings.Contains(view, sidecar.Render("[sidecar]")) {
+		t.Errorf("expected colored container prefixes, got %q", view)
+	}
+	if visible := m.VisibleLines(); visible[0] != "[app] started" {
+		t.Errorf("expected the raw lines kept, got %q", visible[0])
+	}
+
+	// The same pod keeps its colors; another pod starts over
+	m.SetPodInfo("default", "web", "(all containers)")
+	if m.containerStyle("sidecar").GetForeground() != sidecar.GetForeground() {
+		t.Error("expected a container's color to stay the same")
+	}
+	m.SetPodInfo("default", "api", "(all containers)")
+	if len(m.containerStyles) != 0 {
+		t.Error("expected colors reset for another pod")
+	}
+
+	// A single container stream leaves brackets alone
+	m.SetCombined(false)
+	if strings.Contains(m.viewport.View(), "\x1b[") {
+		t.Errorf("expected no colors outside combined mode, got %q", m.viewport.View())
+	}
+}
+
+func TestLogViewModel_CombinedNoColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	prev := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	t.Cleanup(func() { lipgloss.SetColorProfile(prev) })
+
+	m := NewLogViewModel()
+	m.SetStyles(DefaultStyles())
+	m.SetSize(80, 24)
+	m.SetCombined(true)
+	m.AddLine("[app] started")
+
+	if view := m.viewport.View(); !strings.Contains(view, "[app] started") || strings.Contains(view, "\x1b[") {
+		t.Errorf("expected a plain prefix with NO_COLOR, got %q", view)
+	}
+}
+
+func TestLogViewModel_HeaderShowsTail(t *testing.T) {
+	m := NewLogViewModel()
+	m.SetSize(80, 24)
+	m.SetPodInfo("default", "web", "main")
+
+	if strings.Contains(m.View(), "(tail") {
+		t.Error("header should not show a tail length before one is set")
+	}
+
+	m.SetTailLines(500)
+	if !strings.Contains(m.View(), "Logs: default/web/main (tail 500)") {
+		t.Errorf("header should show the tail length, got:\n%s", m.View())
+	}
+}
+
+func TestLogViewModel_PreviousBanner(t *testing.T) {
+	m := NewLogViewModel()
+	m.SetSize(80, 24)
+	m.SetPodInfo("default", "web", "main")
+	before := strings.Count(m.View(), "\n")
+
+	m.SetPrevious(true)
+	view := m.View()
+	if !strings.Contains(view, "Logs: default/web/main (previous)") || !strings.Contains(view, "CrashLoopBackOff") {
+		t.Errorf("expected the header and banner to mention the previous instance, got:\n%s", view)
+	}
+	if strings.Count(view, "\n") != before {
+		t.Error("the banner should not change the view's height")
+	}
+
+	m.SetPrevious(false)
+	if strings.Contains(m.View(), "previous") {
+		t.Error("current logs should not mention the previous instance")
+	}
+}
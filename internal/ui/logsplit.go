@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Split pane sizing: the live logs start with half the width, and < / >
+// move the divider a tenth of the width at a time
+const (
+	defaultSplitRatio = 0.5
+	splitRatioSteps   = 10
+	minSplitRatio     = 0.2
+	maxSplitRatio     = 0.8
+
+	// minPaneWidth is the fewest columns either pane is narrowed to
+	minPaneWidth = 20
+)
+
+// paneWidths divides total columns between the left and right panes at
+// ratio, less one column for the divider. Each pane keeps at least
+// minPaneWidth columns; a terminal too narrow for that is split evenly.
+func paneWidths(total int, ratio float64) (left, right int) {
+	avail := max(total-1, 0)
+	if avail < 2*minPaneWidth {
+		left = avail / 2
+		return left, avail - left
+	}
+	left = int(math.Round(float64(avail) * ratio))
+	left = min(max(left, minPaneWidth), avail-minPaneWidth)
+	return left, avail - left
+}
+
+// OpenSplit shows a second pane to the right of the live logs, loading
+// until SetSplitLines or SetSplitError fills it
+func (m *LogViewModel) OpenSplit() {
+	m.split = true
+	m.splitPane = viewport.New(0, 0)
+	m.splitPane.SetContent("Loading previous logs...")
+	m.resize()
+}
+
+// CloseSplit gives the live logs the whole width again
+func (m *LogViewModel) CloseSplit() {
+	if !m.split {
+		return
+	}
+	m.split = false
+	m.resize()
+}
+
+// IsSplit reports whether the second pane is shown
+func (m *LogViewModel) IsSplit() bool {
+	return m.split
+}
+
+// SetSplitLines shows the previous instance's logs in the second pane,
+// scrolled to their end
+func (m *LogViewModel) SetSplitLines(lines []string) {
+	if len(lines) == 0 {
+		m.splitPane.SetContent("No logs from the previous instance")
+		return
+	}
+	m.splitPane.SetContent(strings.Join(lines, "\n"))
+	m.splitPane.GotoBottom()
+}
+
+// SetSplitError shows why the second pane couldn't be loaded
+func (m *LogViewModel) SetSplitError(err string) {
+	m.splitPane.SetContent(m.styles.Error.Render(err))
+}
+
+// SplitRatio returns the share of the width the live logs get when split
+func (m *LogViewModel) SplitRatio() float64 {
+	return m.splitRatio
+}
+
+// NarrowSplit moves the divider left, giving the previous logs more room
+func (m *LogViewModel) NarrowSplit() {
+	m.setSplitRatio(m.splitRatio - 1.0/splitRatioSteps)
+}
+
+// WidenSplit moves the divider right, giving the live logs more room
+func (m *LogViewModel) WidenSplit() {
+	m.setSplitRatio(m.splitRatio + 1.0/splitRatioSteps)
+}
+
+// setSplitRatio clamps and applies a new ratio, rounded to a step so
+// repeated presses land on the same widths
+func (m *LogViewModel) setSplitRatio(ratio float64) {
+	ratio = math.Round(ratio*splitRatioSteps) / splitRatioSteps
+	m.splitRatio = min(max(ratio, minSplitRatio), maxSplitRatio)
+	m.resize()
+}
+
+// resize recomputes the pane widths for the current size
+func (m *LogViewModel) resize() {
+	if m.ready {
+		m.SetSize(m.width, m.height)
+	}
+}
+
+// splitView renders both panes with a divider between them
+func (m LogViewModel) splitView() string {
+	divider := strings.TrimSuffix(strings.Repeat("│\n", m.viewport.Height), "\n")
+	return lipgloss.JoinHorizontal(lipgloss.Top, m.viewport.View(), divider, m.splitPane.View())
+}
@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPaneWidths(t *testing.T) {
+	tests := []struct {
+		name        string
+		total       int
+		ratio       float64
+		left, right int
+	}{
+		{"even", 101, 0.5, 50, 50},
+		{"wider left", 101, 0.7, 70, 30},
+		{"narrower left", 101, 0.3, 30, 70},
+		{"clamped to the minimum", 61, 0.8, 40, 20},
+		{"too narrow splits evenly", 30, 0.8, 14, 15},
+		{"no room", 0, 0.5, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			left, right := paneWidths(tt.total, tt.ratio)
+			if left != tt.left || right != tt.right {
+				t.Errorf("paneWidths(%d, %v) = %d, %d; want %d, %d", tt.total, tt.ratio, left, right, tt.left, tt.right)
+			}
+		})
+	}
+}
+
+func TestLogViewModel_Split(t *testing.T) {
+	m := NewLogViewModel()
+	m.SetSize(101, 24)
+	m.SetPodInfo("default", "web", "app")
+	m.AddLine("live line")
+
+	m.OpenSplit()
+	if !m.IsSplit() || m.viewport.Width != 50 || m.splitPane.Width != 50 {
+		t.Fatalf("expected two 50 column panes, got %d and %d", m.viewport.Width, m.splitPane.Width)
+	}
+	if !strings.Contains(m.View(), "Loading previous logs...") {
+		t.Errorf("expected the split loading, got:\n%s", m.View())
+	}
+
+	m.SetSplitLines([]string{"panic: boom"})
+	view := m.View()
+	for _, want := range []string{"live line", "│", "panic: boom", "right: previous instance"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in view:\n%s", want, view)
+		}
+	}
+
+	// Stops at the clamp, and a resize keeps the ratio
+	for range 5 {
+		m.WidenSplit()
+	}
+	if m.SplitRatio() != maxSplitRatio || m.viewport.Width != 80 || m.splitPane.Width != 20 {
+		t.Errorf("expected the clamped ratio, got %v with %d and %d", m.SplitRatio(), m.viewport.Width, m.splitPane.Width)
+	}
+	m.SetSize(61, 24)
+	if m.viewport.Width != 40 || m.splitPane.Width != 20 {
+		t.Errorf("expected the right pane kept at its minimum, got %d and %d", m.viewport.Width, m.splitPane.Width)
+	}
+	m.NarrowSplit()
+	if m.SplitRatio() != 0.7 {
+		t.Errorf("expected the ratio one step back, got %v", m.SplitRatio())
+	}
+
+	m.CloseSplit()
+	if m.IsSplit() || m.viewport.Width != 61 {
+		t.Errorf("closing should give the live logs the whole width, got %d", m.viewport.Width)
+	}
+
+	m.SetSize(101, 24)
+	m.OpenSplit()
+	m.SetSplitLines(nil)
+	if !strings.Contains(m.View(), "No logs from the previous") {
+		t.Errorf("expected an empty notice, got:\n%s", m.View())
+	}
+}
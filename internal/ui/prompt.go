@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// PromptModel is a single-line text input shown as an overlay, e.g. to ask
+// for a file path
+type PromptModel struct {
+	title string
+	input textinput.Model
+	width int
+
+	styles Styles
+}
+
+// NewPromptModel creates a new prompt model
+func NewPromptModel() PromptModel {
+	ti := textinput.New()
+	ti.CharLimit = 1024
+	ti.Width = 60
+
+	return PromptModel{
+		input:  ti,
+		styles: DefaultStyles(),
+	}
+}
+
+// SetStyles sets the styles used for rendering
+func (m *PromptModel) SetStyles(styles Styles) {
+	m.styles = styles
+}
+
+// SetWidth updates the input width
+func (m *PromptModel) SetWidth(width int) {
+	m.width = width
+	m.input.Width = max(width-4, 10)
+}
+
+// Open shows the prompt with a title and an initial value
+func (m *PromptModel) Open(title, value string) {
+	m.title = title
+	m.input.SetValue(value)
+	m.input.CursorEnd()
+	m.input.Focus()
+}
+
+// SetValue replaces the entered text, e.g. with a completion, and moves
+// the cursor to its end
+func (m *PromptModel) SetValue(value string) {
+	m.input.SetValue(value)
+	m.input.CursorEnd()
+}
+
+// Close blurs the input
+func (m *PromptModel) Close() {
+	m.input.Blur()
+}
+
+// Title returns the prompt title
+func (m *PromptModel) Title() string {
+	return m.title
+}
+
+// Value returns the entered text with surrounding whitespace removed
+func (m *PromptModel) Value() string {
+	return strings.TrimSpace(m.input.Value())
+}
+
+// Update handles messages for the prompt input
+func (m PromptModel) Update(msg tea.Msg) (PromptModel, tea.Cmd) {
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// InlineView renders the title and input on one line, for a prompt shown
+// below the view it acts on
+func (m PromptModel) InlineView() string {
+	return m.title + " " + m.input.View()
+}
+
+// View renders the prompt
+func (m PromptModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(m.styles.Header.Render(m.title))
+	b.WriteString("\n\n")
+	b.WriteString(m.input.View())
+	b.WriteString("\n\n")
+	b.WriteString(m.styles.StatusBar.Render("enter: confirm | esc: cancel"))
+
+	return b.String()
+}
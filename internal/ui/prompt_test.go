@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPromptModel_Open(t *testing.T) {
+	m := NewPromptModel()
+	m.Open("Save bundle to:", "web-1-bundle.txt")
+
+	if m.Title() != "Save bundle to:" {
+		t.Errorf("unexpected title %q", m.Title())
+	}
+	if m.Value() != "web-1-bundle.txt" {
+		t.Errorf("expected initial value, got %q", m.Value())
+	}
+
+	view := m.View()
+	if !strings.Contains(view, "Save bundle to:") || !strings.Contains(view, "enter: confirm") {
+		t.Errorf("unexpected view:\n%s", view)
+	}
+}
+
+func TestPromptModel_Typing(t *testing.T) {
+	m := NewPromptModel()
+	m.Open("Path:", "/tmp/")
+
+	for _, r := range "out.txt " {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+
+	if m.Value() != "/tmp/out.txt" {
+		t.Errorf("expected typed value appended and trimmed, got %q", m.Value())
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	if m.Value() != "/tmp/out.txt" {
+		t.Errorf("backspace should remove the trailing space, got %q", m.Value())
+	}
+}
+
+func TestPromptModel_InlineView(t *testing.T) {
+	m := NewPromptModel()
+	m.Open("Search logs:", "error")
+
+	view := m.InlineView()
+	if strings.Contains(view, "\n") || !strings.Contains(view, "Search logs:") || !strings.Contains(view, "error") {
+		t.Errorf("expected the title and value on one line, got %q", view)
+	}
+}
+
+func TestPromptModel_SetValue(t *testing.T) {
+	m := NewPromptModel()
+	m.Open("Go to path:", "/var/lo")
+
+	m.SetValue("/var/log/")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("app")})
+	if m.Value() != "/var/log/app" {
+		t.Errorf("expected typing to continue after the new value, got %q", m.Value())
+	}
+}
@@ -0,0 +1,236 @@
+package ui
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// SearchKind identifies what a search result refers to
+type SearchKind int
+
+// Search result kinds, in the order they rank on equally good matches
+const (
+	SearchNamespace SearchKind = iota
+	SearchContext
+	SearchPod
+)
+
+// String returns the label shown next to results of this kind
+func (k SearchKind) String() string {
+	switch k {
+	case SearchNamespace:
+		return "namespace"
+	case SearchContext:
+		return "context"
+	case SearchPod:
+		return "pod"
+	default:
+		return "unknown"
+	}
+}
+
+// SearchResult is an item that can be found by the cross-view search
+type SearchResult struct {
+	Kind   SearchKind
+	Name   string
+	Detail string // Extra context shown after the name, e.g. pod status
+}
+
+// Match quality, best first
+const (
+	matchExact = iota
+	matchPrefix
+	matchWordPrefix
+	matchSubstring
+	noMatch
+)
+
+// RankSearch returns the candidates matching query, best matches first.
+// Exact matches rank above prefixes, prefixes of a dash- or dot-separated
+// word above other substrings; ties are ordered by kind, then name.
+func RankSearch(query string, candidates []SearchResult) []SearchResult {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil
+	}
+
+	type ranked struct {
+		result  SearchResult
+		quality int
+	}
+	var matches []ranked
+	for _, c := range candidates {
+		if q := matchQuality(query, strings.ToLower(c.Name)); q != noMatch {
+			matches = append(matches, ranked{c, q})
+		}
+	}
+
+	slices.SortStableFunc(matches, func(a, b ranked) int {
+		return cmp.Or(
+			cmp.Compare(a.quality, b.quality),
+			cmp.Compare(a.result.Kind, b.result.Kind),
+			strings.Compare(a.result.Name, b.result.Name),
+		)
+	})
+
+	results := make([]SearchResult, len(matches))
+	for i, m := range matches {
+		results[i] = m.result
+	}
+	return results
+}
+
+// matchQuality grades how well a lowercase name matches a lowercase query
+func matchQuality(query, name string) int {
+	switch {
+	case name == query:
+		return matchExact
+	case strings.HasPrefix(name, query):
+		return matchPrefix
+	}
+
+	for i := 0; i < len(name); i++ {
+		if strings.IndexByte("-._/", name[i]) >= 0 && strings.HasPrefix(name[i+1:], query) {
+			return matchWordPrefix
+		}
+	}
+	if strings.Contains(name, query) {
+		return matchSubstring
+	}
+	return noMatch
+}
+
+// SearchModel is an overlay with a query input and ranked results
+type SearchModel struct {
+	input    textinput.Model
+	results  []SearchResult
+	selected int
+	height   int
+
+	styles Styles
+}
+
+// NewSearchModel creates a new search model
+func NewSearchModel() SearchModel {
+	ti := textinput.New()
+	ti.CharLimit = 253 // Longest Kubernetes object name
+	ti.Width = 60
+	ti.Placeholder = "pod, namespace or context"
+
+	return SearchModel{
+		input:  ti,
+		styles: DefaultStyles(),
+	}
+}
+
+// SetStyles sets the styles used for rendering
+func (m *SearchModel) SetStyles(styles Styles) {
+	m.styles = styles
+}
+
+// SetSize updates the input width and how many results fit
+func (m *SearchModel) SetSize(width, height int) {
+	m.input.Width = max(width-4, 10)
+	m.height = height
+}
+
+// Open clears the query and focuses the input
+func (m *SearchModel) Open() {
+	m.input.SetValue("")
+	m.input.Focus()
+	m.results = nil
+	m.selected = 0
+}
+
+// Close blurs the input
+func (m *SearchModel) Close() {
+	m.input.Blur()
+}
+
+// Query returns the typed search text
+func (m *SearchModel) Query() string {
+	return m.input.Value()
+}
+
+// SetCandidates ranks the candidates against the current query
+func (m *SearchModel) SetCandidates(candidates []SearchResult) {
+	m.results = RankSearch(m.input.Value(), candidates)
+	m.selected = min(m.selected, max(len(m.results)-1, 0))
+}
+
+// Results returns the ranked results
+func (m *SearchModel) Results() []SearchResult {
+	return m.results
+}
+
+// Selected returns the highlighted result
+func (m *SearchModel) Selected() (SearchResult, bool) {
+	if m.selected < 0 || m.selected >= len(m.results) {
+		return SearchResult{}, false
+	}
+	return m.results[m.selected], true
+}
+
+// Update moves the selection on up/down and passes other keys to the
+// input. Callers should re-rank with SetCandidates after the query changes.
+func (m SearchModel) Update(msg tea.Msg) (SearchModel, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.Type {
+		case tea.KeyUp, tea.KeyCtrlP:
+			if m.selected > 0 {
+				m.selected--
+			}
+			return m, nil
+		case tea.KeyDown, tea.KeyCtrlN:
+			if m.selected < len(m.results)-1 {
+				m.selected++
+			}
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// View renders the search overlay
+func (m SearchModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(m.styles.Header.Render("Search pods, namespaces and contexts"))
+	b.WriteString("\n\n")
+	b.WriteString(m.input.View())
+	b.WriteString("\n\n")
+
+	switch {
+	case m.input.Value() == "":
+	case len(m.results) == 0:
+		b.WriteString("No matches.\n")
+	default:
+		// Leave room for the title, input and status bar
+		rows := max(m.height-7, 1)
+		start := max(m.selected-rows+1, 0)
+		for i := start; i < len(m.results) && i < start+rows; i++ {
+			r := m.results[i]
+			prefix := "  "
+			if i == m.selected {
+				prefix = m.styles.Selected.Render("> ")
+			}
+			line := prefix + PadRight("["+r.Kind.String()+"]", 11) + " " + r.Name
+			if r.Detail != "" {
+				line += " " + m.styles.StatusBar.Render("("+r.Detail+")")
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.styles.StatusBar.Render("↑/↓: select | enter: go | esc: cancel"))
+
+	return b.String()
+}
@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func searchCandidates() []SearchResult {
+	return []SearchResult{
+		{Kind: SearchPod, Name: "prod-api-7d9f"},
+		{Kind: SearchPod, Name: "web-prod-1"},
+		{Kind: SearchPod, Name: "reproducer"},
+		{Kind: SearchNamespace, Name: "prod"},
+		{Kind: SearchNamespace, Name: "production"},
+		{Kind: SearchContext, Name: "prod"},
+		{Kind: SearchContext, Name: "staging"},
+	}
+}
+
+func TestRankSearch(t *testing.T) {
+	results := RankSearch("Prod", searchCandidates())
+
+	want := []string{
+		"namespace prod",       // exact, namespaces first
+		"context prod",         // exact
+		"namespace production", // prefix
+		"pod prod-api-7d9f",    // prefix
+		"pod web-prod-1",       // word prefix
+		"pod reproducer",       // substring
+	}
+	if len(results) != len(want) {
+		t.Fatalf("expected %d results, got %d: %+v", len(want), len(results), results)
+	}
+	for i, r := range results {
+		if got := r.Kind.String() + " " + r.Name; got != want[i] {
+			t.Errorf("result %d = %q, want %q", i, got, want[i])
+		}
+	}
+}
+
+func TestRankSearch_EmptyQuery(t *testing.T) {
+	if results := RankSearch("  ", searchCandidates()); len(results) != 0 {
+		t.Errorf("empty query should match nothing, got %+v", results)
+	}
+}
+
+func TestMatchQuality(t *testing.T) {
+	tests := []struct {
+		query, name string
+		want        int
+	}{
+		{"api", "api", matchExact},
+		{"api", "api-server", matchPrefix},
+		{"server", "api-server", matchWordPrefix},
+		{"svc", "kube.svc.local", matchWordPrefix},
+		{"erv", "api-server", matchSubstring},
+		{"db", "api-server", noMatch},
+	}
+
+	for _, tt := range tests {
+		if got := matchQuality(tt.query, tt.name); got != tt.want {
+			t.Errorf("matchQuality(%q, %q) = %d, want %d", tt.query, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSearchModel_TypingAndSelection(t *testing.T) {
+	m := NewSearchModel()
+	m.SetSize(80, 24)
+	m.Open()
+
+	for _, r := range "prod" {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	m.SetCandidates(searchCandidates())
+
+	if m.Query() != "prod" || len(m.Results()) != 6 {
+		t.Fatalf("expected 6 results for prod, got %d for %q", len(m.Results()), m.Query())
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if r, ok := m.Selected(); !ok || r.Kind != SearchContext {
+		t.Errorf("down should select the context, got %+v", r)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if r, _ := m.Selected(); r.Kind != SearchNamespace {
+		t.Errorf("up should stop at the first result, got %+v", r)
+	}
+
+	view := m.View()
+	if !strings.Contains(view, "[namespace] prod") || !strings.Contains(view, "[pod]") {
+		t.Errorf("results should be labeled by type, got:\n%s", view)
+	}
+
+	// Reopening starts a fresh search
+	m.Open()
+	if m.Query() != "" || len(m.Results()) != 0 {
+		t.Error("Open should clear the previous search")
+	}
+}
+
+func TestSearchModel_NoMatches(t *testing.T) {
+	m := NewSearchModel()
+	m.Open()
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("zzz")})
+	m.SetCandidates(searchCandidates())
+
+	if _, ok := m.Selected(); ok {
+		t.Error("nothing should be selected without results")
+	}
+	if !strings.Contains(m.View(), "No matches.") {
+		t.Errorf("expected no matches message, got:\n%s", m.View())
+	}
+}
@@ -0,0 +1,192 @@
+package ui
+
+import (
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/maxime/k8s-tui/internal/k8s"
+)
+
+// Status-aware colors (ANSI 256 palette)
+var (
+	colorGreen  = lipgloss.Color("42")
+	colorYellow = lipgloss.Color("214")
+	colorRed    = lipgloss.Color("196")
+	colorGray   = lipgloss.Color("245")
+	colorCyan   = lipgloss.Color("39")
+	colorPurple = lipgloss.Color("170")
+)
+
+// containerColors tell containers apart in combined logs. Red is left out
+// so a container never looks like an error.
+var containerColors = []lipgloss.Color{"39", "170", "42", "214", "81", "208", "141", "229"}
+
+// Styles holds the lipgloss styles used across views
+type Styles struct {
+	Header    lipgloss.Style // View titles and table headers
+	Selected  lipgloss.Style // Selected row prefix
+	StatusBar lipgloss.Style // Log/exec status bars
+	Error     lipgloss.Style // Error messages
+
+	// SearchMatch highlights search matches in the log view
+	SearchMatch lipgloss.Style
+
+	// EventWarning marks Warning events in the events view
+	EventWarning lipgloss.Style
+
+	// Log level colors, picked by name from the logLevels config
+	LogError lipgloss.Style
+	LogWarn  lipgloss.Style
+	LogDim   lipgloss.Style
+
+	// Pod status colors
+	StatusRunning     lipgloss.Style
+	StatusPending     lipgloss.Style
+	StatusFailed      lipgloss.Style
+	StatusTerminating lipgloss.Style
+	StatusSucceeded   lipgloss.Style
+
+	// Service type colors; ClusterIP services use the default color
+	ServiceNodePort     lipgloss.Style
+	ServiceLoadBalancer lipgloss.Style
+	ServiceExternalName lipgloss.Style
+
+	// Containers is the palette for container prefixes in combined logs;
+	// empty leaves the prefixes plain
+	Containers []lipgloss.Style
+
+	// Verbose spells out status indicators as sentences for screen readers
+	Verbose bool
+
+	// Highlight syntax-highlights file previews
+	Highlight bool
+}
+
+// DefaultStyles returns the colored styles, or plain styles when the
+// NO_COLOR environment variable is set (https://no-color.org)
+func DefaultStyles() Styles {
+	if _, noColor := os.LookupEnv("NO_COLOR"); noColor {
+		return PlainStyles()
+	}
+
+	return Styles{
+		Header:            lipgloss.NewStyle().Bold(true),
+		Selected:          lipgloss.NewStyle().Foreground(colorCyan).Bold(true),
+		StatusBar:         lipgloss.NewStyle().Foreground(colorGray),
+		Error:             lipgloss.NewStyle().Foreground(colorRed),
+		SearchMatch:       lipgloss.NewStyle().Background(colorYellow).Foreground(lipgloss.Color("0")),
+		EventWarning:      lipgloss.NewStyle().Foreground(colorYellow),
+		LogError:          lipgloss.NewStyle().Foreground(colorRed),
+		LogWarn:           lipgloss.NewStyle().Foreground(colorYellow),
+		LogDim:            lipgloss.NewStyle().Foreground(colorGray),
+		StatusRunning:     lipgloss.NewStyle().Foreground(colorGreen),
+		StatusPending:     lipgloss.NewStyle().Foreground(colorYellow),
+		StatusFailed:      lipgloss.NewStyle().Foreground(colorRed),
+		StatusTerminating: lipgloss.NewStyle().Foreground(colorGray),
+		StatusSucceeded:   lipgloss.NewStyle().Foreground(colorGray),
+
+		ServiceNodePort:     lipgloss.NewStyle().Foreground(colorCyan),
+		ServiceLoadBalancer: lipgloss.NewStyle().Foreground(colorPurple),
+		ServiceExternalName: lipgloss.NewStyle().Foreground(colorGray),
+
+		Containers: containerStyles(),
+
+		Highlight: true,
+	}
+}
+
+// containerStyles returns a bold style per container color
+func containerStyles() []lipgloss.Style {
+	styles := make([]lipgloss.Style, len(containerColors))
+	for i, color := range containerColors {
+		styles[i] = lipgloss.NewStyle().Foreground(color).Bold(true)
+	}
+	return styles
+}
+
+// PlainStyles returns styles that render text unchanged
+func PlainStyles() Styles {
+	plain := lipgloss.NewStyle()
+	return Styles{
+		Header:            plain,
+		Selected:          plain,
+		StatusBar:         plain,
+		Error:             plain,
+		SearchMatch:       plain,
+		EventWarning:      plain,
+		LogError:          plain,
+		LogWarn:           plain,
+		LogDim:            plain,
+		StatusRunning:     plain,
+		StatusPending:     plain,
+		StatusFailed:      plain,
+		StatusTerminating: plain,
+		StatusSucceeded:   plain,
+
+		ServiceNodePort:     plain,
+		ServiceLoadBalancer: plain,
+		ServiceExternalName: plain,
+	}
+}
+
+// AccessibleStyles returns plain styles with verbose status text, for
+// screen reader users
+func AccessibleStyles() Styles {
+	s := PlainStyles()
+	s.Verbose = true
+	return s
+}
+
+// LogLevel returns the log level style called name in the logLevels
+// config; ok is false for an unknown name
+func (s Styles) LogLevel(name string) (style lipgloss.Style, ok bool) {
+	switch name {
+	case "error":
+		return s.LogError, true
+	case "warn":
+		return s.LogWarn, true
+	case "dim":
+		return s.LogDim, true
+	}
+	return lipgloss.Style{}, false
+}
+
+// PodStatus returns the style for a pod status. A stuck container reason
+// such as CrashLoopBackOff is shown as failed even while the pod phase is
+// still Running.
+func (s Styles) PodStatus(status k8s.PodStatus, reason string) lipgloss.Style {
+	if k8s.IsStuckReason(reason) {
+		return s.StatusFailed
+	}
+
+	switch status {
+	case k8s.PodStatusRunning:
+		return s.StatusRunning
+	case k8s.PodStatusPending:
+		return s.StatusPending
+	case k8s.PodStatusFailed:
+		return s.StatusFailed
+	case k8s.PodStatusTerminating:
+		return s.StatusTerminating
+	case k8s.PodStatusSucceeded:
+		return s.StatusSucceeded
+	default:
+		return lipgloss.NewStyle()
+	}
+}
+
+// ServiceType returns the style for a service type, so services reachable
+// from outside the cluster stand out
+func (s Styles) ServiceType(serviceType string) lipgloss.Style {
+	switch serviceType {
+	case k8s.ServiceTypeNodePort:
+		return s.ServiceNodePort
+	case k8s.ServiceTypeLoadBalancer:
+		return s.ServiceLoadBalancer
+	case k8s.ServiceTypeExternalName:
+		return s.ServiceExternalName
+	default:
+		return lipgloss.NewStyle()
+	}
+}
@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"os"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/maxime/k8s-tui/internal/k8s"
+)
+
+func TestDefaultStyles_Colors(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	// t.Setenv can't unset, so clear explicitly for this test
+	unsetEnv(t, "NO_COLOR")
+
+	s := DefaultStyles()
+
+	tests := []struct {
+		name   string
+		status k8s.PodStatus
+		reason string
+		want   lipgloss.TerminalColor
+	}{
+		{"Running", k8s.PodStatusRunning, "", colorGreen},
+		{"Pending", k8s.PodStatusPending, "", colorYellow},
+		{"Failed", k8s.PodStatusFailed, "", colorRed},
+		{"CrashLoopBackOff", k8s.PodStatusRunning, "CrashLoopBackOff", colorRed},
+		{"Init CrashLoopBackOff", k8s.PodStatusPending, "Init:CrashLoopBackOff", colorRed},
+		{"ImagePullBackOff", k8s.PodStatusPending, "ImagePullBackOff", colorRed},
+		{"CreateContainerConfigError", k8s.PodStatusRunning, "CreateContainerConfigError", colorRed},
+		{"ContainerCreating", k8s.PodStatusPending, "ContainerCreating", colorYellow},
+		{"Terminating", k8s.PodStatusTerminating, "", colorGray},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.PodStatus(tt.status, tt.reason).GetForeground(); got != tt.want {
+				t.Errorf("PodStatus(%s, %q) foreground = %v, want %v", tt.status, tt.reason, got, tt.want)
+			}
+		})
+	}
+
+	serviceTypes := map[string]lipgloss.TerminalColor{
+		k8s.ServiceTypeNodePort:     colorCyan,
+		k8s.ServiceTypeLoadBalancer: colorPurple,
+		k8s.ServiceTypeExternalName: colorGray,
+	}
+	for serviceType, want := range serviceTypes {
+		if got := s.ServiceType(serviceType).GetForeground(); got != want {
+			t.Errorf("ServiceType(%s) foreground = %v, want %v", serviceType, got, want)
+		}
+	}
+	if _, ok := s.ServiceType(k8s.ServiceTypeClusterIP).GetForeground().(lipgloss.NoColor); !ok {
+		t.Error("ClusterIP services should not be colored")
+	}
+
+	if got := s.EventWarning.GetForeground(); got != colorYellow {
+		t.Errorf("EventWarning foreground = %v, want %v", got, colorYellow)
+	}
+	if !s.Highlight {
+		t.Error("colored styles should highlight file previews")
+	}
+
+	levels := map[string]lipgloss.TerminalColor{"error": colorRed, "warn": colorYellow, "dim": colorGray}
+	for name, want := range levels {
+		style, ok := s.LogLevel(name)
+		if got := style.GetForeground(); !ok || got != want {
+			t.Errorf("LogLevel(%s) foreground = %v, want %v", name, got, want)
+		}
+	}
+	if _, ok := s.LogLevel("loud"); ok {
+		t.Error("unknown log level style names should be rejected")
+	}
+}
+
+func TestDefaultStyles_NoColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	s := DefaultStyles()
+
+	if _, ok := s.PodStatus(k8s.PodStatusFailed, "").GetForeground().(lipgloss.NoColor); !ok {
+		t.Error("NO_COLOR should disable status colors")
+	}
+	if s.Header.GetBold() {
+		t.Error("NO_COLOR should disable bold headers")
+	}
+	if s.Highlight {
+		t.Error("NO_COLOR should disable syntax highlighting")
+	}
+}
+
+func TestPlainStyles_RenderUnchanged(t *testing.T) {
+	s := PlainStyles()
+
+	if got := s.StatusRunning.Render("Running"); got != "Running" {
+		t.Errorf("plain style should render text unchanged, got %q", got)
+	}
+}
+
+// unsetEnv removes an environment variable for the duration of a test
+func unsetEnv(t *testing.T, name string) {
+	t.Helper()
+	if err := os.Unsetenv(name); err != nil {
+		t.Fatalf("failed to unset %s: %v", name, err)
+	}
+}
+
+func TestAccessibleStyles(t *testing.T) {
+	s := AccessibleStyles()
+
+	if !s.Verbose {
+		t.Error("accessible styles should enable verbose status text")
+	}
+	if got := s.Header.Render("Pods"); got != "Pods" {
+		t.Errorf("accessible styles should render text unchanged, got %q", got)
+	}
+	if DefaultStyles().Verbose {
+		t.Error("default styles should not be verbose")
+	}
+}
@@ -0,0 +1,34 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// pluralize formats a count with a singular or plural noun ("1 line", "2 lines")
+func pluralize(n int, singular string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, singular)
+	}
+	return fmt.Sprintf("%d %ss", n, singular)
+}
+
+// Truncate shortens s to maxLen terminal cells, ending in "..." when cut.
+// Runes are never split, and wide characters such as CJK or emoji count
+// as two cells.
+func Truncate(s string, maxLen int) string {
+	return runewidth.Truncate(s, maxLen, "...")
+}
+
+// PadRight pads s with spaces to width terminal cells, like fmt's %-*s but
+// counting wide characters such as CJK or emoji as two cells so columns
+// line up. A string already that wide is returned unchanged.
+func PadRight(s string, width int) string {
+	return runewidth.FillRight(s, width)
+}
+
+// PadLeft is PadRight for right-aligned columns, like fmt's %*s
+func PadLeft(s string, width int) string {
+	return runewidth.FillLeft(s, width)
+}
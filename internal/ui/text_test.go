@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+)
+
+func TestPluralize(t *testing.T) {
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{0, "0 lines"},
+		{1, "1 line"},
+		{42, "42 lines"},
+	}
+
+	for _, tt := range tests {
+		if got := pluralize(tt.n, "line"); got != tt.want {
+			t.Errorf("pluralize(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		max  int
+		want string
+	}{
+		{"fits", "short", 10, "short"},
+		{"exact fit", "exactly-ten", 11, "exactly-ten"},
+		{"ascii", "much-too-long-name", 10, "much-to..."},
+		{"accented", "café-déjà-vu-crème", 10, "café-dé..."},
+		{"accented fits", "crème-brûlée", 12, "crème-brûlée"},
+		{"emoji", "🚀🚀🚀🚀🚀🚀", 9, "🚀🚀🚀..."},
+		{"emoji odd width", "🚀🚀🚀🚀🚀🚀", 8, "🚀🚀..."},
+		{"cjk", "日本語の名前", 9, "日本語..."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Truncate(tt.in, tt.max)
+			if got != tt.want {
+				t.Errorf("Truncate(%q, %d) = %q, want %q", tt.in, tt.max, got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("Truncate(%q, %d) split a rune: %q", tt.in, tt.max, got)
+			}
+			if w := runewidth.StringWidth(got); w > tt.max {
+				t.Errorf("Truncate(%q, %d) is %d cells wide", tt.in, tt.max, w)
+			}
+		})
+	}
+}
+
+func TestPadRight(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    string
+		width int
+		want  string
+	}{
+		{"ascii", "web", 6, "web   "},
+		{"cjk", "日本", 6, "日本  "},
+		{"emoji", "🚀x", 6, "🚀x   "},
+		{"already wide", "much-too-long", 6, "much-too-long"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PadRight(tt.in, tt.width); got != tt.want {
+				t.Errorf("PadRight(%q, %d) = %q, want %q", tt.in, tt.width, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPadLeft(t *testing.T) {
+	if got := PadLeft("12K", 6); got != "   12K" {
+		t.Errorf("PadLeft ascii = %q", got)
+	}
+	if got := PadLeft("日本", 6); got != "  日本" {
+		t.Errorf("PadLeft cjk = %q", got)
+	}
+}
+
+// Columns after a padded cell start at the same terminal column whether
+// the cell holds ASCII or wide characters
+func TestPadRight_AlignsWideCharacters(t *testing.T) {
+	ascii := PadRight("report", 12) + "|"
+	wide := PadRight("報告書", 12) + "|"
+
+	if runewidth.StringWidth(ascii) != runewidth.StringWidth(wide) {
+		t.Errorf("expected equal widths, got %d for %q and %d for %q",
+			runewidth.StringWidth(ascii), ascii, runewidth.StringWidth(wide), wide)
+	}
+}
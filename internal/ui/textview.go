@@ -0,0 +1,177 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TextViewModel is a read-only scrollable text viewer used by overlays
+// such as the pod YAML manifest
+type TextViewModel struct {
+	viewport viewport.Model
+
+	// Content
+	title    string
+	content  string
+	loading  bool
+	errorMsg string
+
+	// Dimensions
+	width  int
+	height int
+	ready  bool
+}
+
+// NewTextViewModel creates a new text view model
+func NewTextViewModel() TextViewModel {
+	return TextViewModel{}
+}
+
+// SetSize updates the viewport size
+func (m *TextViewModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+
+	// Reserve space for header (2 lines) and status bar (1 line)
+	viewportHeight := height - 4
+	if viewportHeight < 1 {
+		viewportHeight = 1
+	}
+
+	if !m.ready {
+		m.viewport = viewport.New(width, viewportHeight)
+		m.viewport.YPosition = 0
+		m.ready = true
+		m.viewport.SetContent(m.content)
+	} else {
+		m.viewport.Width = width
+		m.viewport.Height = viewportHeight
+	}
+}
+
+// SetTitle sets the header title
+func (m *TextViewModel) SetTitle(title string) {
+	m.title = title
+}
+
+// Title returns the header title
+func (m *TextViewModel) Title() string {
+	return m.title
+}
+
+// SetLoading clears the content and shows a loading indicator
+func (m *TextViewModel) SetLoading() {
+	m.SetContent("")
+	m.loading = true
+}
+
+// SetError sets an error message
+func (m *TextViewModel) SetError(err string) {
+	m.loading = false
+	m.errorMsg = err
+}
+
+// SetContent replaces the displayed text and scrolls to the top
+func (m *TextViewModel) SetContent(content string) {
+	m.loading = false
+	m.errorMsg = ""
+	m.content = content
+	if m.ready {
+		m.viewport.SetContent(content)
+		m.viewport.GotoTop()
+	}
+}
+
+// Content returns the displayed text
+func (m *TextViewModel) Content() string {
+	return m.content
+}
+
+// ScrollUp scrolls the viewport up
+func (m *TextViewModel) ScrollUp(lines int) {
+	m.viewport.ScrollUp(lines)
+}
+
+// ScrollDown scrolls the viewport down
+func (m *TextViewModel) ScrollDown(lines int) {
+	m.viewport.ScrollDown(lines)
+}
+
+// PageUp scrolls the viewport up one page
+func (m *TextViewModel) PageUp() {
+	m.viewport.PageUp()
+}
+
+// PageDown scrolls the viewport down one page
+func (m *TextViewModel) PageDown() {
+	m.viewport.PageDown()
+}
+
+// GotoTop scrolls to the top
+func (m *TextViewModel) GotoTop() {
+	m.viewport.GotoTop()
+}
+
+// GotoBottom scrolls to the bottom
+func (m *TextViewModel) GotoBottom() {
+	m.viewport.GotoBottom()
+}
+
+// Update handles messages for the text view
+func (m TextViewModel) Update(msg tea.Msg) (TextViewModel, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "j", "down":
+			m.ScrollDown(1)
+		case "k", "up":
+			m.ScrollUp(1)
+		case "g":
+			m.GotoTop()
+		case "G":
+			m.GotoBottom()
+		case "pgdown", " ":
+			m.PageDown()
+		case "pgup":
+			m.PageUp()
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// View renders the text view
+func (m TextViewModel) View() string {
+	if !m.ready {
+		return "Initializing..."
+	}
+
+	var b strings.Builder
+
+	// Header
+	b.WriteString(m.title)
+	b.WriteString("\n")
+	b.WriteString(strings.Repeat("-", min(len(m.title)+10, m.width)))
+	b.WriteString("\n")
+
+	switch {
+	case m.loading:
+		b.WriteString("Loading...")
+	case m.errorMsg != "":
+		b.WriteString(fmt.Sprintf("Error: %s", m.errorMsg))
+	default:
+		b.WriteString(m.viewport.View())
+	}
+	b.WriteString("\n")
+
+	// Status bar
+	scrollPercent := int(m.viewport.ScrollPercent() * 100)
+	b.WriteString(fmt.Sprintf("%d%% | j/k: scroll | g/G: top/bottom | esc: close", scrollPercent))
+
+	return b.String()
+}
@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestTextViewModel_NotReady(t *testing.T) {
+	m := NewTextViewModel()
+
+	if m.View() != "Initializing..." {
+		t.Errorf("expected initializing message before SetSize, got %q", m.View())
+	}
+}
+
+func TestTextViewModel_ContentBeforeSize(t *testing.T) {
+	m := NewTextViewModel()
+	m.SetTitle("YAML: default/my-pod")
+	m.SetContent("apiVersion: v1\nkind: Pod")
+
+	m.SetSize(80, 24)
+
+	view := m.View()
+	if !strings.Contains(view, "YAML: default/my-pod") {
+		t.Error("view should contain the title")
+	}
+	if !strings.Contains(view, "kind: Pod") {
+		t.Error("content set before SetSize should appear after sizing")
+	}
+}
+
+func TestTextViewModel_LoadingAndError(t *testing.T) {
+	m := NewTextViewModel()
+	m.SetSize(80, 24)
+
+	m.SetLoading()
+	if !strings.Contains(m.View(), "Loading...") {
+		t.Error("view should show loading indicator")
+	}
+
+	m.SetError("pod not found")
+	if !strings.Contains(m.View(), "Error: pod not found") {
+		t.Error("view should show the error")
+	}
+
+	m.SetContent("ok")
+	if strings.Contains(m.View(), "Error:") {
+		t.Error("setting content should clear the error display")
+	}
+}
+
+func TestTextViewModel_Scrolling(t *testing.T) {
+	m := NewTextViewModel()
+	m.SetSize(80, 10)
+
+	lines := make([]string, 0, 100)
+	for i := 0; i < 100; i++ {
+		lines = append(lines, fmt.Sprintf("line %d", i))
+	}
+	m.SetContent(strings.Join(lines, "\n"))
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'G'}})
+	if !m.viewport.AtBottom() {
+		t.Error("G should scroll to the bottom")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
+	if m.viewport.AtBottom() {
+		t.Error("k should scroll up")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	if !m.viewport.AtTop() {
+		t.Error("g should scroll to the top")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	if m.viewport.AtTop() {
+		t.Error("j should scroll down")
+	}
+}
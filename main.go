@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 
@@ -10,9 +11,34 @@ import (
 )
 
 func main() {
-	p := tea.NewProgram(app.New(), tea.WithAltScreen())
+	var opts app.Options
+	flag.BoolVar(&opts.Plain, "plain", false, "disable colors and use verbose status text for screen readers")
+	flag.StringVar(&opts.Kubeconfig, "kubeconfig", "", "path to the kubeconfig file (default $KUBECONFIG or ~/.kube/config)")
+	flag.StringVar(&opts.Context, "context", "", "kubeconfig context to start in (default the current context)")
+	flag.StringVar(&opts.Namespace, "namespace", "", "namespace to start in, or all (default the context's namespace)")
+	flag.BoolVar(&opts.AllNamespaces, "all-namespaces", false, "start with pods listed across all namespaces")
+	flag.BoolVar(&opts.AllNamespaces, "A", false, "shorthand for --all-namespaces")
+	flag.BoolVar(&opts.ReadOnly, "read-only", false, "refuse every change to the cluster and the kubeconfig")
+	flag.Parse()
+
+	if flag.NArg() > 0 {
+		usageError(fmt.Errorf("unexpected arguments: %v", flag.Args()))
+	}
+	if err := opts.Validate(); err != nil {
+		usageError(err)
+	}
+
+	m := app.NewWithOptions(opts)
+	p := tea.NewProgram(m, tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("Error running program: %v\n", err)
 		os.Exit(1)
 	}
 }
+
+// usageError reports an invalid command line and exits
+func usageError(err error) {
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	flag.Usage()
+	os.Exit(2)
+}